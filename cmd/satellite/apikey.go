@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/macaroon"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+var (
+	apiKeyCmd = &cobra.Command{
+		Use:   "api-key [project name]",
+		Short: "Create a project with an api key",
+		Long:  "Create a project with the given name and print a new api key of it, for development and testing without the console",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdAPIKey,
+	}
+
+	apiKeyCfg struct {
+		Database string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
+	}
+)
+
+func cmdAPIKey(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	db, err := satellitedb.New(apiKeyCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	err = db.CreateTables()
+	if err != nil {
+		return errs.New("error creating tables for master database on satellite: %+v", err)
+	}
+
+	project, err := db.Console().Projects().Insert(ctx, &console.Project{Name: args[0]})
+	if err != nil {
+		return err
+	}
+
+	secret, err := console.CreateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	info, err := db.Console().APIKeys().Create(ctx, *secret, console.APIKeyInfo{
+		Name:      args[0],
+		ProjectID: project.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	key, err := macaroon.NewAPIKey(info.ID[:], secret[:]).Serialize()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+// generateUsageCSV generates a usage report for all buckets of all projects for a given period
+func generateUsageCSV(ctx context.Context, start time.Time, end time.Time, output io.Writer) (err error) {
+	db, err := satellitedb.New(usageCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	usages, err := db.Accounting().QueryBucketUsage(ctx, start, end)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(output)
+	headers := []string{
+		"projectID",
+		"bucketName",
+		"byte-hours:AtRest",
+		"bytes:BWGet",
+	}
+	if err := w.Write(headers); err != nil {
+		return err
+	}
+
+	for _, usage := range usages {
+		if err := w.Write(usageToStringSlice(usage)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	if output != os.Stdout {
+		fmt.Println("Generated project usage report")
+	}
+	return nil
+}
+
+func usageToStringSlice(usage *accounting.BucketUsage) []string {
+	return []string{
+		usage.ProjectID.String(),
+		usage.BucketName,
+		strconv.FormatFloat(usage.AtRestTotal, 'f', 5, 64),
+		strconv.FormatInt(usage.GetTotal, 10),
+	}
+}
@@ -110,6 +110,12 @@ func dashCmd(cmd *cobra.Command, args []string) (err error) {
 				return err
 			}
 
+			if satellites := stats.GetSatellites(); len(satellites) > 0 {
+				if err = printSatelliteStats(satellites); err != nil {
+					return err
+				}
+			}
+
 		} else {
 			color.Yellow("Loading...\n")
 		}
@@ -123,11 +129,35 @@ func dashCmd(cmd *cobra.Command, args []string) (err error) {
 		if err = w.Flush(); err != nil {
 			return err
 		}
+
+		if exits := data.GetExits(); len(exits) > 0 {
+			_, _ = heading.Printf("\nGraceful Exit\n")
+			if err = printExits(exits); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// printSatelliteStats prints the usage and the remaining allocation of each satellite
+func printSatelliteStats(satellites []*pb.SatelliteStats) error {
+	size := func(value int64) string { return color.WhiteString(memory.Size(value).String()) }
+
+	w := tabwriter.NewWriter(color.Output, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t%s\n", color.GreenString("Satellite"),
+		color.GreenString("Available Bandwidth"), color.GreenString("Used Bandwidth"),
+		color.GreenString("Available Disk"), color.GreenString("Used Disk"))
+
+	for _, satellite := range satellites {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", satellite.SatelliteId,
+			size(satellite.GetAvailableBandwidth()), size(satellite.GetUsedBandwidth()),
+			size(satellite.GetAvailableSpace()), size(satellite.GetUsedSpace()))
+	}
+	return w.Flush()
+}
+
 func whiteInt(value int64) string {
 	return color.WhiteString(fmt.Sprintf("%+v", value))
 }
@@ -0,0 +1,81 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+func cmdExit(cmd *cobra.Command, args []string) (err error) {
+	ctx := context.Background()
+
+	var satellites []storj.NodeID
+	for _, arg := range args {
+		satellite, err := storj.NodeIDFromString(arg)
+		if err != nil {
+			return err
+		}
+		satellites = append(satellites, satellite)
+	}
+
+	// the exit can only be requested with the identity of the node
+	ident, err := runCfg.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	lc, err := psclient.NewLiteClient(ctx, transport.NewClient(ident), &pb.Node{
+		Address: &pb.NodeAddress{
+			Address:   exitCfg.Address,
+			Transport: 0,
+		},
+		Type: pb.NodeType_STORAGE,
+	})
+	if err != nil {
+		return err
+	}
+
+	exits, err := lc.Exit(ctx, satellites)
+	if err != nil {
+		return err
+	}
+	if len(exits) == 0 {
+		fmt.Println("No satellites to exit")
+		return nil
+	}
+
+	fmt.Println("The pieces are transferred while the node keeps running. Follow the progress with the dashboard.")
+	return printExits(exits)
+}
+
+// printExits prints the progress of the graceful exits
+func printExits(exits []*pb.ExitStatus) error {
+	w := tabwriter.NewWriter(color.Output, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t%s\t%s\n", color.GreenString("Satellite"), color.GreenString("Status"),
+		color.GreenString("Transferred"), color.GreenString("Failed"), color.GreenString("Remaining"), color.GreenString("Size"))
+
+	for _, exit := range exits {
+		status := color.YellowString("EXITING since %s", time.Unix(exit.GetStartedUnixSec(), 0).Format(time.RFC822))
+		if exit.GetFinishedUnixSec() != 0 {
+			status = color.GreenString("EXITED at %s", time.Unix(exit.GetFinishedUnixSec(), 0).Format(time.RFC822))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", exit.SatelliteId, status,
+			whiteInt(exit.GetPiecesTransferred()), whiteInt(exit.GetPiecesFailed()), whiteInt(exit.GetPiecesRemaining()),
+			color.WhiteString(memory.Size(exit.GetBytesTransferred()).String()))
+	}
+	return w.Flush()
+}
@@ -4,11 +4,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -68,6 +70,11 @@ var (
 		Short: "Display a dashbaord",
 		RunE:  dashCmd,
 	}
+	exitCmd = &cobra.Command{
+		Use:   "exit [satellite-id...]",
+		Short: "Gracefully exit the satellites, or all of them when none is given",
+		RunE:  cmdExit,
+	}
 	runCfg   StorageNodeFlags
 	setupCfg StorageNodeFlags
 
@@ -77,6 +84,10 @@ var (
 		BootstrapAddr   string `default:"bootstrap.storj.io:8888" help:"address of server the storage node was bootstrapped against"`
 	}
 
+	exitCfg struct {
+		Address string `default:":28967" help:"address of the storage node"`
+	}
+
 	defaultConfDir = fpath.ApplicationDir("storj", "storagenode")
 	// TODO: this path should be defined somewhere else
 	defaultIdentityDir = fpath.ApplicationDir("storj", "identity", "storagenode")
@@ -116,16 +127,19 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(exitCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir), cfgstruct.IdentityDir(defaultIdentityDir))
 	cfgstruct.BindSetup(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir), cfgstruct.IdentityDir(defaultIdentityDir))
 	cfgstruct.BindSetup(configCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir), cfgstruct.IdentityDir(defaultIdentityDir))
 	cfgstruct.Bind(diagCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultDiagDir), cfgstruct.IdentityDir(defaultIdentityDir))
 	cfgstruct.Bind(dashboardCmd.Flags(), &dashboardCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(exitCmd.Flags(), &exitCfg, cfgstruct.ConfDir(defaultDiagDir))
 }
 
 func databaseConfig(config storagenode.Config) storagenodedb.Config {
 	return storagenodedb.Config{
 		Storage:  config.Storage.Path,
+		Pieces:   filepath.Join(config.Storage.Path, "blobs"),
 		Info:     filepath.Join(config.Storage.Path, "piecestore.db"),
 		Kademlia: config.Kademlia.DBPath,
 	}
@@ -320,7 +334,29 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 
 	// display the data
 	err = w.Flush()
-	return err
+	if err != nil {
+		return err
+	}
+
+	// the agreements rejected by satellites are kept for review
+	rejected, err := db.PSDB().GetRejectedBandwidthAllocations(context.Background())
+	if err != nil {
+		fmt.Printf("storage node 'rejected_agreements' table read error: %v\n", err)
+		return err
+	}
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n%d rejected agreements\n\n", len(rejected))
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.Debug)
+	fmt.Fprintln(w, "SatelliteID\tSerial Number\tTotal\tRejected\tReason\t")
+	for _, agreement := range rejected {
+		rba := agreement.Agreement.Agreement
+		fmt.Fprint(w, rba.PayerAllocation.SatelliteId, "\t", rba.PayerAllocation.SerialNumber, "\t", rba.Total, "\t",
+			agreement.Rejected.Format(time.RFC822), "\t", agreement.Reason, "\t\n")
+	}
+	return w.Flush()
 }
 
 func main() {
@@ -161,6 +161,11 @@ func newNetwork(flags *Flags) (*Processes, error) {
 				"--kademlia.bootstrap-addr", bootstrap.Address,
 				"--repairer.overlay-addr", process.Address,
 				"--repairer.pointer-db-addr", process.Address,
+
+				// the gateways use as many pieces as there are storage nodes
+				"--pointer-db.min-required", "1",
+				"--pointer-db.min-repair-threshold", "1",
+				"--pointer-db.min-total", "1",
 			},
 			"run": {},
 		})
@@ -168,6 +173,7 @@ func newNetwork(flags *Flags) (*Processes, error) {
 
 	// Create gateways for each satellite
 	for i, satellite := range satellites {
+		satellite := satellite
 		process := processes.New(Info{
 			Name:       fmt.Sprintf("gateway/%d", i),
 			Executable: "gateway",
@@ -209,6 +215,19 @@ func newNetwork(flags *Flags) (*Processes, error) {
 			accessKey := vip.GetString("minio.access-key")
 			secretKey := vip.GetString("minio.secret-key")
 
+			// the gateway uses a project of its own on the satellite
+			if vip.GetString("client.api-key") == "" {
+				output, err := exec.Command(satellite.Executable, "--config-dir", satellite.Directory, "api-key", process.Name).Output()
+				if err != nil {
+					return fmt.Errorf("creating api key: %v", err)
+				}
+
+				vip.Set("client.api-key", strings.TrimSpace(string(output)))
+				if err := vip.WriteConfig(); err != nil {
+					return err
+				}
+			}
+
 			process.Extra = append(process.Extra,
 				"ACCESS_KEY="+accessKey,
 				"SECRET_KEY="+secretKey,
@@ -0,0 +1,156 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	progressbar "github.com/cheggaaa/pb"
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/storj"
+)
+
+// batchFlags are the flags of the commands which work on many objects
+type batchFlags struct {
+	recursive   *bool
+	parallelism *int
+	include     *[]string
+	exclude     *[]string
+}
+
+// addBatchFlags adds the flags for working on many objects to cmd
+func addBatchFlags(cmd *cobra.Command) batchFlags {
+	return batchFlags{
+		recursive:   cmd.Flags().Bool("recursive", false, "if true, work on all the objects inside of the path"),
+		parallelism: cmd.Flags().Int("parallelism", 1, "number of objects to work on in parallel"),
+		include:     cmd.Flags().StringArray("include", nil, "only work on the objects matching the glob pattern, can be repeated"),
+		exclude:     cmd.Flags().StringArray("exclude", nil, "skip the objects matching the glob pattern, can be repeated"),
+	}
+}
+
+// validate checks the flags for consistency
+func (flags batchFlags) validate() error {
+	if *flags.parallelism < 1 {
+		return fmt.Errorf("Parallelism must be at least 1")
+	}
+
+	for _, pattern := range append(append([]string{}, *flags.include...), *flags.exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Invalid glob pattern %q: %v", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// matches returns whether the object at the relative path name passes the
+// include and exclude filters. Patterns are matched against both the whole
+// relative path and its last element.
+func (flags batchFlags) matches(name string) bool {
+	if len(*flags.include) > 0 && !matchesAny(*flags.include, name) {
+		return false
+	}
+	return !matchesAny(*flags.exclude, name)
+}
+
+// matchesAny returns whether name or its last element matches any of the patterns
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// joinName joins the slash separated relative path name to p
+func joinName(p fpath.FPath, name string) fpath.FPath {
+	if p.IsLocal() {
+		return p.Join(filepath.FromSlash(name))
+	}
+	return p.Join(name)
+}
+
+// listFPath returns the files inside of the local directory or remote
+// prefix p, by their slash separated path relative to p
+func listFPath(ctx context.Context, metainfo storj.Metainfo, p fpath.FPath) (map[string]syncFile, error) {
+	if p.IsLocal() {
+		return listLocal(p)
+	}
+
+	files, err := listRemote(ctx, metainfo, p)
+	if err != nil {
+		return nil, convertError(err, p)
+	}
+	return files, nil
+}
+
+// batchTask is the work on a single object of a batch
+type batchTask struct {
+	name string
+	run  func() error
+}
+
+// runBatch runs the tasks with up to parallelism of them at the same time.
+// It doesn't stop at the first failure, but prints a summary of the failed
+// tasks and returns an error if any failed. When showProgress is true, the
+// number of finished tasks is displayed on standard error.
+func runBatch(ctx context.Context, tasks []batchTask, parallelism int, showProgress bool) error {
+	var bar *progressbar.ProgressBar
+	if showProgress {
+		bar = progressbar.New(len(tasks))
+		bar.Output = os.Stderr
+		bar.Start()
+	}
+
+	var mu sync.Mutex
+	var failures []string
+
+	limiter := sync2.NewLimiter(parallelism)
+	for _, task := range tasks {
+		task := task
+		started := limiter.Go(ctx, func() {
+			err := task.run()
+			if bar != nil {
+				bar.Increment()
+			}
+			if err != nil {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %v", task.name, err))
+				mu.Unlock()
+			}
+		})
+		if !started {
+			mu.Lock()
+			failures = append(failures, fmt.Sprintf("%s: %v", task.name, ctx.Err()))
+			mu.Unlock()
+		}
+	}
+	limiter.Wait()
+
+	if bar != nil {
+		bar.Finish()
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%d of %d objects failed:\n", len(failures), len(tasks))
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "  %s\n", failure)
+	}
+	return fmt.Errorf("%d of %d objects failed", len(failures), len(tasks))
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/storj"
+)
+
+// objectMetainfo is a metainfo with a single page of objects, which also
+// gets the objects at the given paths
+type objectMetainfo struct {
+	listMetainfo
+	objects map[storj.Path]bool
+}
+
+func (metainfo *objectMetainfo) GetObject(ctx context.Context, bucket string, path storj.Path) (storj.Object, error) {
+	if !metainfo.objects[path] {
+		return storj.Object{}, storj.ErrObjectNotFound.New(path)
+	}
+	return storj.Object{Path: path}, nil
+}
+
+func newBatchFlags(include, exclude []string) batchFlags {
+	recursive, parallelism := true, 1
+	return batchFlags{
+		recursive:   &recursive,
+		parallelism: &parallelism,
+		include:     &include,
+		exclude:     &exclude,
+	}
+}
+
+func TestBatchFlags(t *testing.T) {
+	flags := newBatchFlags([]string{"*.txt"}, []string{"secret*"})
+	require.NoError(t, flags.validate())
+
+	assert.True(t, flags.matches("a.txt"))
+	assert.True(t, flags.matches("dir/a.txt"))
+	assert.False(t, flags.matches("a.jpg"))
+	assert.False(t, flags.matches("dir/secret.txt"))
+
+	// all objects match without includes
+	assert.True(t, newBatchFlags(nil, nil).matches("a.jpg"))
+
+	assert.Error(t, newBatchFlags([]string{"["}, nil).validate())
+
+	parallelism := 0
+	flags.parallelism = &parallelism
+	assert.Error(t, flags.validate())
+}
+
+func TestRunBatch(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var ran int32
+	tasks := []batchTask{
+		{name: "a", run: func() error { atomic.AddInt32(&ran, 1); return nil }},
+		{name: "b", run: func() error { atomic.AddInt32(&ran, 1); return errors.New("failed") }},
+		{name: "c", run: func() error { atomic.AddInt32(&ran, 1); return nil }},
+	}
+
+	// a failure doesn't stop the other tasks
+	err := runBatch(ctx, tasks, 2, false)
+	assert.EqualError(t, err, "1 of 3 objects failed")
+	assert.EqualValues(t, 3, atomic.LoadInt32(&ran))
+
+	assert.NoError(t, runBatch(ctx, tasks[:1], 1, false))
+}
+
+func TestListDeletes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	newMetainfo := func() *objectMetainfo {
+		return &objectMetainfo{
+			listMetainfo: listMetainfo{pages: []storj.ObjectList{{
+				Items: []storj.Object{{Path: "a.txt"}, {Path: "b.jpg"}},
+			}}},
+			objects: map[storj.Path]bool{"dir": true, "dir/a.txt": true, "dir/b.jpg": true},
+		}
+	}
+
+	paths := func(objects []fpath.FPath) (paths []string) {
+		for _, object := range objects {
+			paths = append(paths, object.Path())
+		}
+		return paths
+	}
+
+	dir, err := fpath.New("sj://bucket/dir")
+	require.NoError(t, err)
+
+	// the object at exactly the path is deleted with the ones inside of it
+	objects, err := listDeletes(ctx, newMetainfo(), dir, newBatchFlags(nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dir", "dir/a.txt", "dir/b.jpg"}, paths(objects))
+
+	objects, err = listDeletes(ctx, newMetainfo(), dir, newBatchFlags([]string{"*.txt"}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dir/a.txt"}, paths(objects))
+
+	// a prefix doesn't name an object
+	prefix, err := fpath.New("sj://bucket/dir/")
+	require.NoError(t, err)
+	objects, err = listDeletes(ctx, newMetainfo(), prefix, newBatchFlags(nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dir/a.txt", "dir/b.jpg"}, paths(objects))
+
+	// an object without objects inside of it
+	file, err := fpath.New("sj://bucket/file")
+	require.NoError(t, err)
+	metainfo := &objectMetainfo{
+		listMetainfo: listMetainfo{pages: []storj.ObjectList{{}}},
+		objects:      map[storj.Path]bool{"file": true},
+	}
+	objects, err = listDeletes(ctx, metainfo, file, newBatchFlags(nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file"}, paths(objects))
+}
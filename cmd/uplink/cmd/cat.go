@@ -42,5 +42,10 @@ func catMain(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return download(ctx, src, dst, false)
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return download(ctx, metainfo, streams, src, dst, false)
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	progressbar "github.com/cheggaaa/pb"
@@ -25,6 +26,7 @@ import (
 
 var (
 	progress *bool
+	cpFlags  batchFlags
 )
 
 func init() {
@@ -34,10 +36,12 @@ func init() {
 		RunE:  copyMain,
 	}, CLICmd)
 	progress = cpCmd.Flags().Bool("progress", true, "if true, show progress")
+	cpFlags = addBatchFlags(cpCmd)
 }
 
-// upload transfers src from local machine to s3 compatible object dst
-func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool) (err error) {
+// upload transfers src from local machine to s3 compatible object dst,
+// setting its metadata if not nil
+func upload(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, metadata map[string]string, showProgress bool) (err error) {
 	if !src.IsLocal() {
 		return fmt.Errorf("source must be local path: %s", src)
 	}
@@ -71,14 +75,8 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		return fmt.Errorf("source cannot be a directory: %s", src)
 	}
 
-	metainfo, streams, err := cfg.Metainfo(ctx)
-	if err != nil {
-		return err
-	}
-
 	createInfo := storj.CreateObject{
-		RedundancyScheme: cfg.GetRedundancyScheme(),
-		EncryptionScheme: cfg.GetEncryptionScheme(),
+		Metadata: metadata,
 	}
 	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
 	if err != nil {
@@ -121,7 +119,7 @@ func uploadStream(ctx context.Context, streams streams.Store, mutableObject stor
 }
 
 // download transfers s3 compatible object src to dst on local machine
-func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool) (err error) {
+func download(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, showProgress bool) (err error) {
 	if src.IsLocal() {
 		return fmt.Errorf("source must be Storj URL: %s", src)
 	}
@@ -130,11 +128,6 @@ func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgres
 		return fmt.Errorf("destination must be local path: %s", dst)
 	}
 
-	metainfo, streams, err := cfg.Metainfo(ctx)
-	if err != nil {
-		return err
-	}
-
 	readOnlyStream, err := metainfo.GetObjectStream(ctx, src.Bucket(), src.Path())
 	if err != nil {
 		return convertError(err, src)
@@ -185,7 +178,7 @@ func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgres
 }
 
 // copy copies s3 compatible object src to s3 compatible object dst
-func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) (err error) {
+func copy(ctx context.Context, metainfo storj.Metainfo, src fpath.FPath, dst fpath.FPath) (err error) {
 	if src.IsLocal() {
 		return fmt.Errorf("source must be Storj URL: %s", src)
 	}
@@ -194,55 +187,71 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) (err error) {
 		return fmt.Errorf("destination must be Storj URL: %s", dst)
 	}
 
-	metainfo, streams, err := cfg.Metainfo(ctx)
-	if err != nil {
-		return err
+	// if destination object name not specified, default to source object name
+	if strings.HasSuffix(dst.Path(), "/") {
+		dst = dst.Join(src.Base())
 	}
 
-	readOnlyStream, err := metainfo.GetObjectStream(ctx, src.Bucket(), src.Path())
+	// the satellite copies the object without transferring its data
+	_, err = metainfo.CopyObject(ctx, src.Bucket(), src.Path(), dst.Bucket(), dst.Path())
 	if err != nil {
 		return convertError(err, src)
 	}
 
-	download := stream.NewDownload(ctx, readOnlyStream, streams)
-	defer func() { err = errs.Combine(err, download.Close()) }()
+	fmt.Printf("%s copied to %s\n", src.String(), dst.String())
 
-	var bar *progressbar.ProgressBar
-	var reader io.Reader
-	if *progress {
-		bar = progressbar.New(int(readOnlyStream.Info().Size)).SetUnits(progressbar.U_BYTES)
-		bar.Start()
-		reader = bar.NewProxyReader(download)
-	} else {
-		reader = download
-	}
+	return nil
+}
 
-	// if destination object name not specified, default to source object name
-	if strings.HasSuffix(dst.Path(), "/") {
-		dst = dst.Join(src.Base())
+// copyRecursive copies all the files inside of the local directory or
+// remote prefix src to dst, keeping their relative paths
+func copyRecursive(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
+	if err := cpFlags.validate(); err != nil {
+		return err
 	}
 
-	createInfo := storj.CreateObject{
-		RedundancyScheme: cfg.GetRedundancyScheme(),
-		EncryptionScheme: cfg.GetEncryptionScheme(),
-	}
-	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
+	metainfo, streams, err := cfg.Metainfo(ctx)
 	if err != nil {
-		return convertError(err, dst)
+		return err
 	}
 
-	err = uploadStream(ctx, streams, obj, reader)
+	files, err := listFPath(ctx, metainfo, src)
 	if err != nil {
 		return err
 	}
-
-	if bar != nil {
-		bar.Finish()
+	if len(files) == 0 {
+		return fmt.Errorf("No objects found in %s", src)
 	}
 
-	fmt.Printf("%s copied to %s\n", src.String(), dst.String())
+	var tasks []batchTask
+	for _, name := range sortedNames(files) {
+		if !cpFlags.matches(name) {
+			continue
+		}
 
-	return nil
+		srcFile, dstFile := joinName(src, name), joinName(dst, name)
+		task := batchTask{name: srcFile.String()}
+		switch {
+		case src.IsLocal():
+			task.run = func() error {
+				return upload(ctx, metainfo, streams, srcFile, dstFile, nil, false)
+			}
+		case dst.IsLocal():
+			task.run = func() error {
+				if err := os.MkdirAll(filepath.Dir(dstFile.Path()), 0755); err != nil {
+					return err
+				}
+				return download(ctx, metainfo, streams, srcFile, dstFile, false)
+			}
+		default:
+			task.run = func() error {
+				return copy(ctx, metainfo, srcFile, dstFile)
+			}
+		}
+		tasks = append(tasks, task)
+	}
+
+	return runBatch(ctx, tasks, *cpFlags.parallelism, *progress)
 }
 
 // copyMain is the function executed when cpCmd is called
@@ -271,16 +280,25 @@ func copyMain(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("At least one of the source or the desination must be a Storj URL")
 	}
 
+	if *cpFlags.recursive {
+		return copyRecursive(ctx, src, dst)
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
 	// if uploading
 	if src.IsLocal() {
-		return upload(ctx, src, dst, *progress)
+		return upload(ctx, metainfo, streams, src, dst, nil, *progress)
 	}
 
 	// if downloading
 	if dst.IsLocal() {
-		return download(ctx, src, dst, *progress)
+		return download(ctx, metainfo, streams, src, dst, *progress)
 	}
 
 	// if copying from one remote location to another
-	return copy(ctx, src, dst)
+	return copy(ctx, metainfo, src, dst)
 }
@@ -0,0 +1,59 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/linksharing"
+	"storj.io/storj/pkg/process"
+)
+
+var linksharingCfg struct {
+	Linksharing linksharing.Config
+}
+
+func init() {
+	linksharingCmd := addCmd(&cobra.Command{
+		Use:   "linksharing",
+		Short: "Serve the objects shared with uplink share over HTTP",
+		RunE:  cmdLinksharing,
+	}, GWCmd)
+	cfgstruct.Bind(linksharingCmd.Flags(), &linksharingCfg)
+}
+
+func cmdLinksharing(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	identity, err := cfg.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := process.InitMetricsWithCertPath(ctx, nil, cfg.Identity.CertPath); err != nil {
+		zap.S().Error("Failed to initialize telemetry batcher: ", err)
+	}
+
+	// the objects of the satellite of the uplink are served by default
+	satellites := []string{cfg.Client.OverlayAddr, cfg.Client.PointerDBAddr}
+	if linksharingCfg.Linksharing.Satellites != "" {
+		satellites = strings.Split(linksharingCfg.Linksharing.Satellites, ",")
+	}
+
+	handler, err := linksharing.NewHandler(zap.L(), identity, satellites, linksharingCfg.Linksharing.MaxBufferMem.Int())
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, handler.Close()) }()
+
+	fmt.Printf("Serving shared objects at %s\n", linksharingCfg.Linksharing.Address)
+	return http.ListenAndServe(linksharingCfg.Linksharing.Address, handler)
+}
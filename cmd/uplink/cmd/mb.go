@@ -53,7 +53,11 @@ func makeBucket(cmd *cobra.Command, args []string) error {
 	if !storj.ErrBucketNotFound.Has(err) {
 		return err
 	}
-	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &storj.Bucket{PathCipher: storj.Cipher(cfg.Enc.PathType)})
+	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &storj.Bucket{
+		PathCipher:       storj.Cipher(cfg.Enc.PathType),
+		RedundancyScheme: cfg.GetRedundancyScheme(),
+		EncryptionScheme: cfg.GetEncryptionScheme(),
+	})
 	if err != nil {
 		return err
 	}
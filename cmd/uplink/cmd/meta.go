@@ -0,0 +1,101 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	metaContentType *string
+)
+
+func init() {
+	metaCmd := &cobra.Command{
+		Use:   "meta",
+		Short: "Metadata related commands",
+	}
+	CLICmd.AddCommand(metaCmd)
+
+	setCmd := addCmd(&cobra.Command{
+		Use:   "set sj://BUCKET/OBJECT [KEY=VALUE]...",
+		Short: "Set metadata of an object without uploading it again, an empty value removes the key",
+		RunE:  setMetadata,
+	}, metaCmd)
+	metaContentType = setCmd.Flags().String("content-type", "", "content type of the object, unchanged if empty")
+}
+
+// setMetadata is the function executed when meta set is called
+func setMetadata(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified")
+	}
+
+	dst, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if dst.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	updates := make(map[string]string, len(args)-1)
+	for _, arg := range args[1:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Invalid metadata %q, use format key=value", arg)
+		}
+		updates[parts[0]] = parts[1]
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	object, err := metainfo.GetObject(ctx, dst.Bucket(), dst.Path())
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	metadata := make(map[string]string, len(object.Metadata)+len(updates))
+	for key, value := range object.Metadata {
+		metadata[key] = value
+	}
+	for key, value := range updates {
+		if value == "" {
+			delete(metadata, key)
+		} else {
+			metadata[key] = value
+		}
+	}
+
+	contentType := object.ContentType
+	if *metaContentType != "" {
+		contentType = *metaContentType
+	}
+
+	_, err = metainfo.UpdateObjectMetadata(ctx, dst.Bucket(), dst.Path(), &storj.UpdateObject{
+		Metadata:    metadata,
+		ContentType: contentType,
+		Expires:     object.Expires,
+	})
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	fmt.Printf("Updated metadata of %s\n", dst)
+
+	return nil
+}
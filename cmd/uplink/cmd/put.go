@@ -42,5 +42,10 @@ func putMain(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return upload(ctx, src, dst, false)
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return upload(ctx, metainfo, streams, src, dst, nil, false)
 }
@@ -4,20 +4,30 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"storj.io/storj/internal/fpath"
 	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	rmProgress *bool
+	rmFlags    batchFlags
 )
 
 func init() {
-	addCmd(&cobra.Command{
+	rmCmd := addCmd(&cobra.Command{
 		Use:   "rm",
 		Short: "Delete an object",
 		RunE:  deleteObject,
 	}, CLICmd)
+	rmProgress = rmCmd.Flags().Bool("progress", false, "if true, show progress of recursive deletes")
+	rmFlags = addBatchFlags(rmCmd)
 }
 
 func deleteObject(cmd *cobra.Command, args []string) error {
@@ -41,6 +51,10 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if *rmFlags.recursive {
+		return deleteRecursive(ctx, metainfo, dst)
+	}
+
 	err = metainfo.DeleteObject(ctx, dst.Bucket(), dst.Path())
 	if err != nil {
 		return convertError(err, dst)
@@ -50,3 +64,64 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// deleteRecursive deletes all the objects inside of the prefix dst and the
+// object at dst itself
+func deleteRecursive(ctx context.Context, metainfo storj.Metainfo, dst fpath.FPath) error {
+	if err := rmFlags.validate(); err != nil {
+		return err
+	}
+
+	objects, err := listDeletes(ctx, metainfo, dst, rmFlags)
+	if err != nil {
+		return convertError(err, dst)
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("No objects found in %s", dst)
+	}
+
+	var tasks []batchTask
+	for _, object := range objects {
+		object := object
+		tasks = append(tasks, batchTask{name: object.String(), run: func() error {
+			if err := metainfo.DeleteObject(ctx, object.Bucket(), object.Path()); err != nil {
+				return convertError(err, object)
+			}
+			fmt.Printf("Deleted %s\n", object)
+			return nil
+		}})
+	}
+
+	return runBatch(ctx, tasks, *rmFlags.parallelism, *rmProgress)
+}
+
+// listDeletes returns the objects to delete recursively at dst which pass
+// the filters of flags: the objects inside of the prefix dst, and the object
+// at exactly dst if there is one.
+func listDeletes(ctx context.Context, metainfo storj.Metainfo, dst fpath.FPath, flags batchFlags) ([]fpath.FPath, error) {
+	var objects []fpath.FPath
+
+	if dst.Path() != "" && !strings.HasSuffix(dst.String(), "/") {
+		_, err := metainfo.GetObject(ctx, dst.Bucket(), dst.Path())
+		switch {
+		case err == nil:
+			if flags.matches(dst.Base()) {
+				objects = append(objects, dst)
+			}
+		case !storj.ErrObjectNotFound.Has(err):
+			return nil, err
+		}
+	}
+
+	files, err := listRemote(ctx, metainfo, dst)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range sortedNames(files) {
+		if flags.matches(name) {
+			objects = append(objects, joinName(dst, name))
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/linksharing"
+	"storj.io/storj/pkg/macaroon"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	shareExpires *time.Duration
+	shareBaseURL *string
+)
+
+func init() {
+	shareCmd := addCmd(&cobra.Command{
+		Use:   "share",
+		Short: "Creates a read-only link to a Storj object",
+		RunE:  shareMain,
+	}, CLICmd)
+	shareExpires = shareCmd.Flags().Duration("expires", 24*time.Hour, "how long the link is valid for, 0 for no expiration")
+	shareBaseURL = shareCmd.Flags().String("base-url", "http://localhost:8080", "url of the link sharing server")
+}
+
+// shareMain is the function executed when shareCmd is called
+func shareMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified for sharing")
+	}
+
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	if src.Path() == "" || strings.HasSuffix(src.Path(), "/") {
+		return fmt.Errorf("No object specified for sharing")
+	}
+
+	if *shareExpires < 0 {
+		return fmt.Errorf("Expiration can't be negative")
+	}
+
+	apiKey, err := macaroon.ParseAPIKey(cfg.Client.APIKey)
+	if err != nil {
+		return err
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, src.Bucket())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	if _, err := metainfo.GetObject(ctx, src.Bucket(), src.Path()); err != nil {
+		return convertError(err, src)
+	}
+
+	var expires time.Time
+	if *shareExpires > 0 {
+		expires = time.Now().Add(*shareExpires)
+	}
+
+	satellite := linksharing.Satellite{
+		OverlayAddr:   cfg.Client.OverlayAddr,
+		PointerDBAddr: cfg.Client.PointerDBAddr,
+	}
+
+	token, err := linksharing.NewToken(satellite, apiKey, storj.JoinPaths(src.Bucket(), src.Path()), bucket.PathCipher, cfg.GetRootKey(), expires)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := linksharing.SerializeToken(token)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s/%s\n", strings.TrimSuffix(*shareBaseURL, "/"), serialized)
+	return nil
+}
@@ -0,0 +1,372 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// syncChecksumKey is the object metadata key of the hex encoded sha256
+	// checksum of the content of a synced file
+	syncChecksumKey = "sync-sha256"
+	// syncModifiedKey is the object metadata key of the modification time of
+	// the local file at the time it was synced
+	syncModifiedKey = "sync-mtime"
+)
+
+var (
+	syncDelete      *bool
+	syncDryRun      *bool
+	syncParallelism *int
+)
+
+func init() {
+	syncCmd := addCmd(&cobra.Command{
+		Use:   "sync",
+		Short: "Mirrors a local directory to a Storj prefix or a Storj prefix to a local directory",
+		RunE:  syncMain,
+	}, CLICmd)
+	syncDelete = syncCmd.Flags().Bool("delete", false, "if true, delete the files of the destination which aren't in the source")
+	syncDryRun = syncCmd.Flags().Bool("dry-run", false, "if true, only print the changes without making them")
+	syncParallelism = syncCmd.Flags().Int("parallelism", 4, "number of files to transfer in parallel")
+}
+
+// syncFile is a file of the source or the destination of a sync
+type syncFile struct {
+	size     int64
+	modified time.Time
+	// checksum is the checksum stored in the metadata of remote files
+	checksum string
+}
+
+// syncMain is the function executed when syncCmd is called
+func syncMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("No source specified for sync")
+	}
+	if len(args) == 1 {
+		return fmt.Errorf("No destination specified")
+	}
+	if *syncParallelism < 1 {
+		return fmt.Errorf("Parallelism must be at least 1")
+	}
+
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	dst, err := fpath.New(args[1])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() == dst.IsLocal() {
+		return fmt.Errorf("Exactly one of the source or the destination must be a Storj URL")
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return syncUp(ctx, metainfo, streams, src, dst)
+	}
+	return syncDown(ctx, metainfo, streams, src, dst)
+}
+
+// syncUp mirrors the local directory src to the remote prefix dst
+func syncUp(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath) error {
+	local, err := listLocal(src)
+	if err != nil {
+		return err
+	}
+
+	remote, err := listRemote(ctx, metainfo, dst)
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	var tasks []batchTask
+	for _, name := range sortedNames(local) {
+		name, file := name, local[name]
+		srcFile, dstFile := joinName(src, name), joinName(dst, name)
+
+		if remoteFile, ok := remote[name]; ok {
+			changed, err := syncChanged(srcFile.Path(), file, remoteFile)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+		}
+
+		if *syncDryRun {
+			fmt.Printf("Would upload %s to %s\n", srcFile, dstFile)
+			continue
+		}
+
+		tasks = append(tasks, batchTask{name: srcFile.String(), run: func() error {
+			checksum, err := fileChecksum(srcFile.Path())
+			if err != nil {
+				return err
+			}
+
+			return upload(ctx, metainfo, streams, srcFile, dstFile, map[string]string{
+				syncChecksumKey: checksum,
+				syncModifiedKey: file.modified.Format(time.RFC3339Nano),
+			}, false)
+		}})
+	}
+
+	if *syncDelete {
+		for _, name := range sortedNames(remote) {
+			if _, ok := local[name]; ok {
+				continue
+			}
+
+			dstFile := joinName(dst, name)
+			if *syncDryRun {
+				fmt.Printf("Would delete %s\n", dstFile)
+				continue
+			}
+
+			tasks = append(tasks, batchTask{name: dstFile.String(), run: func() error {
+				if err := metainfo.DeleteObject(ctx, dstFile.Bucket(), dstFile.Path()); err != nil {
+					return convertError(err, dstFile)
+				}
+				fmt.Printf("Deleted %s\n", dstFile)
+				return nil
+			}})
+		}
+	}
+
+	return runBatch(ctx, tasks, *syncParallelism, false)
+}
+
+// syncDown mirrors the remote prefix src to the local directory dst
+func syncDown(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath) error {
+	remote, err := listRemote(ctx, metainfo, src)
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	local, err := listLocal(dst)
+	if err != nil {
+		return err
+	}
+
+	var tasks []batchTask
+	for _, name := range sortedNames(remote) {
+		name, file := name, remote[name]
+		srcFile, dstFile := joinName(src, name), joinName(dst, name)
+
+		if localFile, ok := local[name]; ok {
+			changed, err := syncChanged(dstFile.Path(), localFile, file)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+		}
+
+		if *syncDryRun {
+			fmt.Printf("Would download %s to %s\n", srcFile, dstFile)
+			continue
+		}
+
+		tasks = append(tasks, batchTask{name: srcFile.String(), run: func() error {
+			if err := os.MkdirAll(filepath.Dir(dstFile.Path()), 0755); err != nil {
+				return err
+			}
+
+			if err := download(ctx, metainfo, streams, srcFile, dstFile, false); err != nil {
+				return err
+			}
+
+			// keep the modification time of the source, so that the next
+			// sync doesn't need to compare the checksums
+			return os.Chtimes(dstFile.Path(), file.modified, file.modified)
+		}})
+	}
+
+	if *syncDelete {
+		for _, name := range sortedNames(local) {
+			if _, ok := remote[name]; ok {
+				continue
+			}
+
+			dstFile := joinName(dst, name)
+			if *syncDryRun {
+				fmt.Printf("Would delete %s\n", dstFile)
+				continue
+			}
+
+			tasks = append(tasks, batchTask{name: dstFile.String(), run: func() error {
+				if err := os.Remove(dstFile.Path()); err != nil {
+					return err
+				}
+				fmt.Printf("Deleted %s\n", dstFile)
+				return nil
+			}})
+		}
+	}
+
+	return runBatch(ctx, tasks, *syncParallelism, false)
+}
+
+// syncChanged returns whether the local file at localPath differs from the
+// remote file. Files with different sizes differ, files with the same size
+// and modification time don't, otherwise their checksums are compared.
+func syncChanged(localPath string, local, remote syncFile) (bool, error) {
+	if local.size != remote.size {
+		return true, nil
+	}
+	if local.modified.Equal(remote.modified) {
+		return false, nil
+	}
+	if remote.checksum == "" {
+		return true, nil
+	}
+
+	checksum, err := fileChecksum(localPath)
+	if err != nil {
+		return false, err
+	}
+	return checksum != remote.checksum, nil
+}
+
+// listLocal returns the regular files inside of the local directory dir, by
+// their slash separated path relative to dir
+func listLocal(dir fpath.FPath) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+
+	info, err := os.Stat(dir.Path())
+	if os.IsNotExist(err) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	err = filepath.Walk(dir.Path(), func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir.Path(), filePath)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(name)] = syncFile{
+			size:     info.Size(),
+			modified: info.ModTime(),
+		}
+		return nil
+	})
+	return files, err
+}
+
+// listRemote returns the objects inside of the remote prefix, by their path
+// relative to prefix
+func listRemote(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+
+	prefixPath := prefix.Path()
+	if prefixPath != "" && !strings.HasSuffix(prefixPath, "/") {
+		prefixPath += "/"
+	}
+
+	startAfter := ""
+	for {
+		list, err := metainfo.ListObjects(ctx, prefix.Bucket(), storj.ListOptions{
+			Direction: storj.After,
+			Cursor:    startAfter,
+			Prefix:    prefixPath,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range list.Items {
+			if object.IsPrefix {
+				continue
+			}
+
+			file := syncFile{
+				size:     object.Size,
+				modified: object.Modified,
+				checksum: object.Metadata[syncChecksumKey],
+			}
+			if modified, err := time.Parse(time.RFC3339Nano, object.Metadata[syncModifiedKey]); err == nil {
+				file.modified = modified
+			}
+			files[path.Clean(object.Path)] = file
+		}
+
+		if !list.More {
+			break
+		}
+		startAfter = list.Items[len(list.Items)-1].Path
+	}
+
+	return files, nil
+}
+
+// fileChecksum returns the hex encoded sha256 checksum of the file at filePath
+func fileChecksum(filePath string) (_ string, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sortedNames returns the names of files in order
+func sortedNames(files map[string]syncFile) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/storj"
+)
+
+// listMetainfo is a metainfo returning pages of objects in order
+type listMetainfo struct {
+	storj.Metainfo
+	pages   []storj.ObjectList
+	options []storj.ListOptions
+}
+
+func (metainfo *listMetainfo) ListObjects(ctx context.Context, bucket string, options storj.ListOptions) (storj.ObjectList, error) {
+	metainfo.options = append(metainfo.options, options)
+	page := metainfo.pages[0]
+	metainfo.pages = metainfo.pages[1:]
+	return page, nil
+}
+
+func TestSyncChanged(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	localPath := ctx.File("file")
+	require.NoError(t, ioutil.WriteFile(localPath, []byte("content"), 0644))
+	checksum, err := fileChecksum(localPath)
+	require.NoError(t, err)
+
+	modified := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := syncFile{size: 7, modified: modified}
+
+	for i, tt := range []struct {
+		remote  syncFile
+		changed bool
+	}{
+		// different sizes
+		{remote: syncFile{size: 8, modified: modified, checksum: checksum}, changed: true},
+		// same size and modification time
+		{remote: syncFile{size: 7, modified: modified, checksum: "other"}, changed: false},
+		// different modification time without checksum
+		{remote: syncFile{size: 7, modified: modified.Add(time.Second)}, changed: true},
+		// different modification time with the same checksum
+		{remote: syncFile{size: 7, modified: modified.Add(time.Second), checksum: checksum}, changed: false},
+		// different modification time with another checksum
+		{remote: syncFile{size: 7, modified: modified.Add(time.Second), checksum: "other"}, changed: true},
+	} {
+		changed, err := syncChanged(localPath, local, tt.remote)
+		require.NoError(t, err, i)
+		assert.Equal(t, tt.changed, changed, i)
+	}
+
+	// the checksum is computed only when needed
+	_, err = syncChanged(ctx.File("missing"), local, syncFile{size: 7, modified: modified.Add(time.Second), checksum: checksum})
+	assert.Error(t, err)
+}
+
+func TestListRemote(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	modified := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	synced := modified.Add(-time.Hour)
+
+	metainfo := &listMetainfo{pages: []storj.ObjectList{
+		{
+			More: true,
+			Items: []storj.Object{
+				{Path: "a", Size: 1, Modified: modified},
+				{Path: "sub/", IsPrefix: true},
+			},
+		},
+		{
+			Items: []storj.Object{
+				{Path: "sub/b", Size: 2, Modified: modified, Metadata: map[string]string{
+					syncChecksumKey: "checksum",
+					syncModifiedKey: synced.Format(time.RFC3339Nano),
+				}},
+			},
+		},
+	}}
+
+	prefix, err := fpath.New("sj://bucket/dir")
+	require.NoError(t, err)
+
+	files, err := listRemote(ctx, metainfo, prefix)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]syncFile{
+		"a":     {size: 1, modified: modified},
+		"sub/b": {size: 2, modified: synced, checksum: "checksum"},
+	}, files)
+
+	if assert.Len(t, metainfo.options, 2) {
+		assert.Equal(t, "dir/", metainfo.options[0].Prefix)
+		assert.True(t, metainfo.options[0].Recursive)
+		assert.Equal(t, "", metainfo.options[0].Cursor)
+		// the next page starts after the last item of the previous one
+		assert.Equal(t, "sub/", metainfo.options[1].Cursor)
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	for i, tt := range []struct {
+		patterns []string
+		name     string
+		matches  bool
+	}{
+		{patterns: nil, name: "a.txt", matches: false},
+		{patterns: []string{"*.txt"}, name: "a.txt", matches: true},
+		// the last element matches
+		{patterns: []string{"*.txt"}, name: "dir/a.txt", matches: true},
+		// the whole relative path matches
+		{patterns: []string{"dir/*"}, name: "dir/a.txt", matches: true},
+		{patterns: []string{"dir/*"}, name: "other/a.txt", matches: false},
+		{patterns: []string{"*.jpg", "*.txt"}, name: "a.txt", matches: true},
+		{patterns: []string{"*.jpg"}, name: "a.txt", matches: false},
+		// invalid patterns match nothing
+		{patterns: []string{"["}, name: "[", matches: false},
+	} {
+		assert.Equal(t, tt.matches, matchesAny(tt.patterns, tt.name), i)
+	}
+}
@@ -10,11 +10,14 @@ import (
 	"google.golang.org/grpc"
 
 	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/macaroon"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
 )
 
 // Node is a general purpose
@@ -23,6 +26,9 @@ type Node struct {
 	Info      pb.Node
 	Identity  *identity.FullIdentity
 	Transport transport.Client
+
+	// APIKey is the api key of the project of the uplink on every satellite
+	APIKey map[storj.NodeID]string
 }
 
 // newUplink creates a new uplink
@@ -35,6 +41,7 @@ func (planet *Planet) newUplink(name string) (*Node, error) {
 	node := &Node{
 		Log:      planet.log.Named(name),
 		Identity: identity,
+		APIKey:   map[storj.NodeID]string{},
 	}
 
 	node.Log.Debug("id=" + identity.ID.String())
@@ -55,6 +62,29 @@ func (planet *Planet) newUplink(name string) (*Node, error) {
 	return node, nil
 }
 
+// NewAPIKey creates a project on satellite and returns a new api key of it
+func (planet *Planet) NewAPIKey(ctx context.Context, satellite *satellite.Peer, projectName string) (string, error) {
+	project, err := satellite.DB.Console().Projects().Insert(ctx, &console.Project{Name: projectName})
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := console.CreateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	info, err := satellite.DB.Console().APIKeys().Create(ctx, *secret, console.APIKeyInfo{
+		Name:      projectName,
+		ProjectID: project.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return macaroon.NewAPIKey(info.ID[:], secret[:]).Serialize()
+}
+
 // ID returns node id
 func (node *Node) ID() storj.NodeID { return node.Info.Id }
 
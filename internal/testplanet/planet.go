@@ -31,8 +31,10 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/gc"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/lifecycle"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
@@ -125,6 +127,16 @@ func NewWithLogger(log *zap.Logger, satelliteCount, storageNodeCount, uplinkCoun
 		return nil, errs.Combine(err, planet.Shutdown())
 	}
 
+	// every uplink has a project on every satellite
+	for i, uplink := range planet.Uplinks {
+		for _, satellite := range planet.Satellites {
+			uplink.APIKey[satellite.ID()], err = planet.NewAPIKey(context.TODO(), satellite, "uplink"+strconv.Itoa(i))
+			if err != nil {
+				return nil, errs.Combine(err, planet.Shutdown())
+			}
+		}
+	}
+
 	// init Satellites
 	for _, satellite := range planet.Satellites {
 		satellite.Kademlia.Service.SetBootstrapNodes([]pb.Node{planet.Bootstrap.Local()})
@@ -281,6 +293,7 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 				MaxInlineSegmentSize: 8000,
 				Overlay:              true,
 				BwExpiration:         45,
+				Auth:                 true,
 			},
 			BwAgreement: bwagreement.Config{},
 			Checker: checker.Config{
@@ -298,6 +311,16 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 				MaxRetriesStatDB: 0,
 				Interval:         30 * time.Second,
 			},
+			Lifecycle: lifecycle.Config{
+				Interval: time.Hour,
+			},
+			GC: gc.Config{
+				Interval:          time.Hour,
+				GracePeriod:       time.Hour,
+				InitialPieces:     1000,
+				FalsePositiveRate: 0.1,
+				NodesPerWalk:      10,
+			},
 			Tally: tally.Config{
 				Interval: 30 * time.Second,
 			},
@@ -383,7 +406,13 @@ func (planet *Planet) newStorageNodes(count int) ([]*storagenode.Peer, error) {
 				KBucketRefreshInterval: time.Hour,
 
 				AgreementSenderCheckInterval: time.Hour,
+				AgreementSenderBatchSize:     1000,
+				AgreementSenderRetryDelay:    time.Second,
+				AgreementSenderMaxRetryDelay: time.Second,
 				CollectorInterval:            time.Hour,
+				TrashExpiration:              time.Hour,
+				ExitInterval:                 time.Hour,
+				MigrationInterval:            time.Hour,
 			},
 		}
 
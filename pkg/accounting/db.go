@@ -7,6 +7,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
+
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 )
@@ -40,6 +42,33 @@ type Rollup struct {
 	AtRestTotal    float64
 }
 
+// ProjectUsage is the usage of a project as of the last tally
+type ProjectUsage struct {
+	ProjectID uuid.UUID
+	// AtRestTotal is the number of bytes stored by the project
+	AtRestTotal int64
+	// EgressTotal is the number of bytes downloaded from the project since EgressStart
+	EgressTotal int64
+	EgressStart time.Time
+	TalliedAt   time.Time
+}
+
+// BucketID identifies a bucket of a project
+type BucketID struct {
+	ProjectID  uuid.UUID
+	BucketName string
+}
+
+// BucketUsage is the usage of a bucket of a project in a period
+type BucketUsage struct {
+	ProjectID  uuid.UUID
+	BucketName string
+	// AtRestTotal is the number of byte-hours stored in the bucket
+	AtRestTotal float64
+	// GetTotal is the number of bytes downloaded from the bucket
+	GetTotal int64
+}
+
 // DB stores information about bandwidth usage
 type DB interface {
 	// LastRawTime records the latest last tallied time.
@@ -56,4 +85,16 @@ type DB interface {
 	SaveRollup(ctx context.Context, latestTally time.Time, isNew bool, stats RollupStats) error
 	// QueryPaymentInfo queries StatDB, Accounting Rollup on nodeID
 	QueryPaymentInfo(ctx context.Context, start time.Time, end time.Time) ([]*CSVRow, error)
+	// GetProjectUsage retrieves the last tallied usage of a project, which is zero for projects that weren't tallied yet
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*ProjectUsage, error)
+	// GetProjectUsages retrieves the last tallied usage of all projects
+	GetProjectUsages(ctx context.Context) ([]*ProjectUsage, error)
+	// SaveProjectUsage records the tallied usage of a project
+	SaveProjectUsage(ctx context.Context, usage *ProjectUsage) error
+	// SaveBucketRaw records raw tallies of the data of dataType of the buckets of the projects
+	SaveBucketRaw(ctx context.Context, intervalEnd time.Time, dataType int, bucketData map[BucketID]float64) error
+	// QueryBucketUsage sums the raw tallies of the buckets of all projects in the [start, end) period
+	QueryBucketUsage(ctx context.Context, start time.Time, end time.Time) ([]*BucketUsage, error)
+	// QueryProjectUsage sums the raw tallies of the buckets of a project in the [start, end) period
+	QueryProjectUsage(ctx context.Context, projectID uuid.UUID, start time.Time, end time.Time) ([]*BucketUsage, error)
 }
@@ -5,9 +5,11 @@ package tally
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/accounting"
@@ -80,6 +82,8 @@ func (t *Tally) calculateAtRestData(ctx context.Context) (err error) {
 	}
 
 	var nodeData = make(map[storj.NodeID]float64)
+	var projectData = make(map[uuid.UUID]int64)
+	var bucketData = make(map[accounting.BucketID]float64)
 	err = t.pointerdb.Iterate("", "", true, false,
 		func(it storage.Iterator) error {
 			var item storage.ListItem
@@ -89,10 +93,19 @@ func (t *Tally) calculateAtRestData(ctx context.Context) (err error) {
 				if err != nil {
 					return Error.Wrap(err)
 				}
+				if bucket, ok := pathBucket(item.Key.String()); ok {
+					projectData[bucket.ProjectID] += pointer.GetSegmentSize()
+					bucketData[bucket] += float64(pointer.GetSegmentSize())
+				}
 				remote := pointer.GetRemote()
 				if remote == nil {
 					continue
 				}
+				// the pieces of a deduplicated segment are accounted once
+				// with its entry, not with the pointers referencing it
+				if len(remote.GetDedupId()) > 0 {
+					continue
+				}
 				pieces := remote.GetRemotePieces()
 				if pieces == nil {
 					t.logger.Debug("no pieces on remote segment")
@@ -118,9 +131,6 @@ func (t *Tally) calculateAtRestData(ctx context.Context) (err error) {
 			return nil
 		},
 	)
-	if len(nodeData) == 0 {
-		return nil
-	}
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -132,6 +142,19 @@ func (t *Tally) calculateAtRestData(ctx context.Context) (err error) {
 
 	latestTally = time.Now().UTC()
 
+	if err = t.saveProjectsAtRest(ctx, projectData); err != nil {
+		return err
+	}
+	for k := range bucketData {
+		bucketData[k] *= numHours
+	}
+	if err = t.accountingDB.SaveBucketRaw(ctx, latestTally, accounting.AtRest, bucketData); err != nil {
+		return Error.Wrap(err)
+	}
+	if len(nodeData) == 0 {
+		return nil
+	}
+
 	for k := range nodeData {
 		nodeData[k] *= numHours
 	}
@@ -166,6 +189,8 @@ func (t *Tally) QueryBW(ctx context.Context) error {
 	for i := range bwTotals {
 		bwTotals[i] = make(map[storj.NodeID]int64)
 	}
+	var projectEgress = make(map[uuid.UUID]int64)
+	var bucketEgress = make(map[accounting.BucketID]float64)
 	var latestBwa time.Time
 	for _, baRow := range bwAgreements {
 		rba := baRow.Agreement
@@ -173,6 +198,105 @@ func (t *Tally) QueryBW(ctx context.Context) error {
 			latestBwa = baRow.CreatedAt
 		}
 		bwTotals[rba.PayerAllocation.Action][rba.StorageNodeId] += rba.Total
+
+		projectID := rba.PayerAllocation.ProjectId
+		if rba.PayerAllocation.Action == pb.BandwidthAction_GET && len(projectID) == len(uuid.UUID{}) {
+			var project uuid.UUID
+			copy(project[:], projectID)
+			projectEgress[project] += rba.Total
+			if bucket := rba.PayerAllocation.Bucket; bucket != "" {
+				bucketEgress[accounting.BucketID{ProjectID: project, BucketName: bucket}] += float64(rba.Total)
+			}
+		}
+	}
+	if err = t.saveProjectsEgress(ctx, projectEgress); err != nil {
+		return err
+	}
+	if err = t.accountingDB.SaveBucketRaw(ctx, latestBwa, accounting.BandwidthGet, bucketEgress); err != nil {
+		return Error.Wrap(err)
 	}
 	return Error.Wrap(t.accountingDB.SaveBWRaw(ctx, latestBwa, isNil, bwTotals))
 }
+
+// pathBucket returns the bucket of a pointerdb path, which is in the form
+// of <segment>/<project id>/<bucket>/<encrypted path>. Paths which aren't
+// namespaced by a project, like the ones of the lifecycle rules, are ignored.
+// So are the entries of the deduplicated segments, whose size is accounted
+// to each pointer referencing them.
+func pathBucket(path string) (bucket accounting.BucketID, ok bool) {
+	if strings.HasPrefix(path, pointerdb.LifecyclePrefix) || strings.HasPrefix(path, pointerdb.DedupPrefix) {
+		return bucket, false
+	}
+
+	parts := strings.SplitN(path, "/", 4)
+	if len(parts) < 3 {
+		return bucket, false
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return bucket, false
+	}
+	return accounting.BucketID{ProjectID: *id, BucketName: parts[2]}, true
+}
+
+// saveProjectsAtRest records the data stored by each project, projects
+// that don't store any data anymore are reset to zero
+func (t *Tally) saveProjectsAtRest(ctx context.Context, projectData map[uuid.UUID]int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	usages, err := t.accountingDB.GetProjectUsages(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	for _, usage := range usages {
+		if _, ok := projectData[usage.ProjectID]; ok || usage.AtRestTotal == 0 {
+			continue
+		}
+		usage.AtRestTotal = 0
+		usage.TalliedAt = now
+		if err = t.accountingDB.SaveProjectUsage(ctx, usage); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	for project, total := range projectData {
+		usage, err := t.accountingDB.GetProjectUsage(ctx, project)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		usage.AtRestTotal = total
+		usage.TalliedAt = now
+		if err = t.accountingDB.SaveProjectUsage(ctx, usage); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// saveProjectsEgress adds the new egress of each project to its egress of
+// the current month
+func (t *Tally) saveProjectsEgress(ctx context.Context, projectEgress map[uuid.UUID]int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for project, total := range projectEgress {
+		usage, err := t.accountingDB.GetProjectUsage(ctx, project)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if usage.EgressStart.Before(monthStart) {
+			usage.EgressTotal = 0
+			usage.EgressStart = monthStart
+		}
+		usage.EgressTotal += total
+		usage.TalliedAt = now
+		if err = t.accountingDB.SaveProjectUsage(ctx, usage); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
@@ -80,7 +80,7 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 		return nil, err
 	}
 	peerIdentity := &identity.PeerIdentity{ID: cursor.identity.ID, Leaf: cursor.identity.Leaf}
-	pba, err := cursor.allocation.PayerBandwidthAllocation(ctx, peerIdentity, pb.BandwidthAction_GET_AUDIT)
+	pba, err := cursor.allocation.PayerBandwidthAllocation(ctx, peerIdentity, nil, "", pb.BandwidthAction_GET_AUDIT)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +99,13 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 		return nil, nil
 	}
 
+	// the pointers referencing a deduplicated or copied segment have neither
+	// pieces nor redundancy, the pieces are audited with the entry of the
+	// segment instead
+	if len(pointer.GetRemote().GetRemotePieces()) == 0 {
+		return nil, nil
+	}
+
 	// create the erasure scheme so we can get the stripe size
 	es, err := makeErasureScheme(pointer.GetRemote().GetRedundancy())
 	if err != nil {
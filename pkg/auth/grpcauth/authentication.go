@@ -39,3 +39,16 @@ func NewAPIKeyInjector(APIKey string, callOpts ...grpc.CallOption) grpc.UnaryCli
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// NewContextAPIKeyInjector injects the api key of each request context, which
+// is set with auth.WithAPIKey, to grpc connection context. It allows sharing a
+// connection between requests with different api keys.
+func NewContextAPIKeyInjector(callOpts ...grpc.CallOption) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		opts = append(opts, callOpts...)
+		if apiKey, ok := auth.GetAPIKey(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, "apikey", string(apiKey))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -75,3 +75,31 @@ func TestAPIKeyInjector(t *testing.T) {
 		assert.Equal(t, tt.APIKey, strings.Join(md["apikey"], ""))
 	}
 }
+
+func TestContextAPIKeyInjector(t *testing.T) {
+	injector := NewContextAPIKeyInjector()
+
+	// mock for method invoker
+	var outputCtx context.Context
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outputCtx = ctx
+		return nil
+	}
+
+	for _, apiKey := range []string{"abc123", "def456"} {
+		ctx := auth.WithAPIKey(context.Background(), []byte(apiKey))
+		err := injector(ctx, "/test.method", nil, nil, nil, invoker)
+		assert.NoError(t, err)
+
+		md, ok := metadata.FromOutgoingContext(outputCtx)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, apiKey, strings.Join(md["apikey"], ""))
+	}
+
+	// requests without api keys are sent as they are
+	err := injector(context.Background(), "/test.method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+
+	_, ok := metadata.FromOutgoingContext(outputCtx)
+	assert.Equal(t, false, ok)
+}
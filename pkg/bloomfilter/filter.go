@@ -0,0 +1,111 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default error class for bloom filters
+var Error = errs.Class("bloom filter error")
+
+const version = 1
+
+// Filter is a bloom filter of ids. It may report that it contains an id
+// which was never added, but never the contrary.
+type Filter struct {
+	hashCount int
+	table     []byte
+}
+
+// NewOptimal returns a filter sized for expectedElements ids with the given
+// rate of false positives
+func NewOptimal(expectedElements int, falsePositiveRate float64) *Filter {
+	if expectedElements < 1 {
+		expectedElements = 1
+	}
+
+	bits := -float64(expectedElements) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)
+	hashCount := int(math.Ceil(bits / float64(expectedElements) * math.Ln2))
+	if hashCount < 1 {
+		hashCount = 1
+	}
+	if hashCount > math.MaxUint8 {
+		hashCount = math.MaxUint8
+	}
+
+	return &Filter{
+		hashCount: hashCount,
+		table:     make([]byte, int(math.Ceil(bits/8))+1),
+	}
+}
+
+// NewFromBytes decodes a filter encoded with Bytes
+func NewFromBytes(data []byte) (*Filter, error) {
+	if len(data) < 3 {
+		return nil, Error.New("not enough data")
+	}
+	if data[0] != version {
+		return nil, Error.New("unsupported version %d", data[0])
+	}
+	if data[1] == 0 {
+		return nil, Error.New("invalid hash count")
+	}
+
+	table := make([]byte, len(data)-2)
+	copy(table, data[2:])
+
+	return &Filter{
+		hashCount: int(data[1]),
+		table:     table,
+	}, nil
+}
+
+// Add adds id to the filter
+func (filter *Filter) Add(id []byte) {
+	for _, bit := range filter.bits(id) {
+		filter.table[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Contains returns whether the filter may contain id
+func (filter *Filter) Contains(id []byte) bool {
+	for _, bit := range filter.bits(id) {
+		if filter.table[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the size of the encoded filter in bytes
+func (filter *Filter) Size() int {
+	return len(filter.table) + 2
+}
+
+// Bytes encodes the filter
+func (filter *Filter) Bytes() []byte {
+	data := make([]byte, 0, filter.Size())
+	data = append(data, version, byte(filter.hashCount))
+	return append(data, filter.table...)
+}
+
+// bits returns the positions in the table which are set for id, derived from
+// two hashes of id with double hashing
+func (filter *Filter) bits(id []byte) []uint64 {
+	sum := sha256.Sum256(id)
+	a := binary.LittleEndian.Uint64(sum[0:8])
+	b := binary.LittleEndian.Uint64(sum[8:16])
+
+	size := uint64(len(filter.table)) * 8
+	bits := make([]uint64, filter.hashCount)
+	for i := range bits {
+		bits[i] = (a + uint64(i)*b) % size
+	}
+	return bits
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomIDs(t *testing.T, count int) [][]byte {
+	ids := make([][]byte, count)
+	for i := range ids {
+		ids[i] = make([]byte, 32)
+		_, err := rand.Read(ids[i])
+		require.NoError(t, err)
+	}
+	return ids
+}
+
+func TestFilter(t *testing.T) {
+	const count = 10000
+	const falsePositiveRate = 0.05
+
+	added := randomIDs(t, count)
+	other := randomIDs(t, count)
+
+	filter := NewOptimal(count, falsePositiveRate)
+	for _, id := range added {
+		filter.Add(id)
+	}
+
+	decoded, err := NewFromBytes(filter.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, filter.Size(), len(filter.Bytes()))
+
+	for _, f := range []*Filter{filter, decoded} {
+		for _, id := range added {
+			require.True(t, f.Contains(id))
+		}
+
+		positives := 0
+		for _, id := range other {
+			if f.Contains(id) {
+				positives++
+			}
+		}
+		assert.True(t, float64(positives)/count < 2*falsePositiveRate, "%d false positives", positives)
+	}
+}
+
+func TestNewFromBytes(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{version, 1},
+		{version + 1, 1, 0},
+		{version, 0, 0},
+	} {
+		_, err := NewFromBytes(data)
+		assert.True(t, Error.Has(err))
+	}
+}
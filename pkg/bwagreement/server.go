@@ -4,9 +4,12 @@
 package bwagreement
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
@@ -20,7 +23,9 @@ import (
 var (
 	// Error the default bwagreement errs class
 	Error = errs.Class("bwagreement error")
-	mon   = monkit.Package()
+	// ErrDuplicate is the errs class of agreements which were already stored
+	ErrDuplicate = errs.Class("duplicate bandwidth agreement")
+	mon          = monkit.Package()
 )
 
 // Config is a configuration struct that is everything you need to start an
@@ -30,8 +35,12 @@ type Config struct {
 
 // DB stores bandwidth agreements.
 type DB interface {
-	// CreateAgreement adds a new bandwidth agreement.
+	// CreateAgreement adds a new bandwidth agreement, failing with ErrDuplicate
+	// when it was already added.
 	CreateAgreement(context.Context, *pb.RenterBandwidthAllocation) error
+	// GetAgreement gets the bandwidth agreement of the storage node with the
+	// serial number.
+	GetAgreement(ctx context.Context, serialNumber string, storageNodeID storj.NodeID) (*Agreement, error)
 	// GetAgreements gets all bandwidth agreements.
 	GetAgreements(context.Context) ([]Agreement, error)
 	// GetAgreementsSince gets all bandwidth agreements since specific time.
@@ -67,35 +76,126 @@ func (s *Server) BandwidthAgreements(ctx context.Context, rba *pb.RenterBandwidt
 	reply = &pb.AgreementsSummary{
 		Status: pb.AgreementsSummary_REJECTED,
 	}
+	pi, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return reply, auth.ErrBadID.Wrap(err)
+	}
+	if err := s.verifyAgreement(pi.ID, rba); err != nil {
+		return reply, err
+	}
+
+	//save and return rersults
+	err = s.db.CreateAgreement(ctx, rba)
+	if err != nil {
+		if ErrDuplicate.Has(err) {
+			return reply, pb.ErrPayer.Wrap(auth.ErrSerial.Wrap(err))
+		}
+		// the agreement wasn't rejected, it can be sent again
+		reply.Status = pb.AgreementsSummary_FAIL
+		return reply, Error.Wrap(err)
+	}
+	reply.Status = pb.AgreementsSummary_OK
+	s.logger.Debug("Stored Agreement...")
+	return reply, nil
+}
+
+// Settlement receives batches of bandwidth agreements from a storage node,
+// and replies to each batch with the result of each agreement
+func (s *Server) Settlement(stream pb.Bandwidth_SettlementServer) (err error) {
+	ctx := stream.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	pi, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return auth.ErrBadID.Wrap(err)
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		resp := &pb.SettlementResponse{}
+		for _, rba := range req.GetAllocations() {
+			resp.Results = append(resp.Results, s.settle(ctx, pi.ID, rba))
+		}
+		s.logger.Debug("Settled agreements", zap.String("Node ID", pi.ID.String()), zap.Int("agreements", len(resp.Results)))
+
+		if err := stream.Send(resp); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+}
+
+// settle stores an agreement sent by storage node nodeID and returns whether
+// it was accepted, rejected or should be sent again
+func (s *Server) settle(ctx context.Context, nodeID storj.NodeID, rba *pb.RenterBandwidthAllocation) *pb.SettlementResult {
+	if err := s.verifyAgreement(nodeID, rba); err != nil {
+		return &pb.SettlementResult{Status: pb.SettlementResult_REJECTED, Reason: err.Error()}
+	}
+
+	err := s.db.CreateAgreement(ctx, rba)
+	if ErrDuplicate.Has(err) {
+		// the storage node sends an agreement again when it didn't get the
+		// result, only other agreements with the same serial are rejected
+		duplicate := err
+		var identical bool
+		identical, err = s.identical(ctx, rba)
+		if err == nil && !identical {
+			return &pb.SettlementResult{Status: pb.SettlementResult_REJECTED, Reason: auth.ErrSerial.Wrap(duplicate).Error()}
+		}
+	}
+	if err != nil {
+		s.logger.Error("Failed to store agreement", zap.Error(err))
+		return &pb.SettlementResult{Status: pb.SettlementResult_RETRY, Reason: err.Error()}
+	}
+	return &pb.SettlementResult{Status: pb.SettlementResult_ACCEPTED}
+}
+
+// identical returns whether rba is the agreement stored already with its
+// serial number
+func (s *Server) identical(ctx context.Context, rba *pb.RenterBandwidthAllocation) (bool, error) {
+	stored, err := s.db.GetAgreement(ctx, rba.PayerAllocation.SerialNumber, rba.StorageNodeId)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	storedBytes, err := proto.Marshal(&stored.Agreement)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	rbaBytes, err := proto.Marshal(rba)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	return bytes.Equal(storedBytes, rbaBytes), nil
+}
+
+// verifyAgreement verifies an agreement sent by storage node nodeID
+func (s *Server) verifyAgreement(nodeID storj.NodeID, rba *pb.RenterBandwidthAllocation) error {
 	pba := rba.PayerAllocation
 	//verify message content
-	pi, err := identity.PeerIdentityFromContext(ctx)
-	if err != nil || rba.StorageNodeId != pi.ID {
-		return reply, auth.ErrBadID.New("Storage Node ID: %s vs %s", rba.StorageNodeId, pi.ID)
+	if rba.StorageNodeId != nodeID {
+		return auth.ErrBadID.New("Storage Node ID: %s vs %s", rba.StorageNodeId, nodeID)
 	}
 	//todo:  use whitelist for uplinks?
 	if pba.SatelliteId != s.NodeID {
-		return reply, pb.ErrPayer.New("Satellite ID: %s vs %s", pba.SatelliteId, s.NodeID)
+		return pb.ErrPayer.New("Satellite ID: %s vs %s", pba.SatelliteId, s.NodeID)
 	}
 	exp := time.Unix(pba.GetExpirationUnixSec(), 0).UTC()
 	if exp.Before(time.Now().UTC()) {
-		return reply, pb.ErrPayer.Wrap(auth.ErrExpired.New("%v vs %v", exp, time.Now().UTC()))
+		return pb.ErrPayer.Wrap(auth.ErrExpired.New("%v vs %v", exp, time.Now().UTC()))
 	}
 	//verify message crypto
 	if err := auth.VerifyMsg(rba, pba.UplinkId); err != nil {
-		return reply, pb.ErrRenter.Wrap(err)
+		return pb.ErrRenter.Wrap(err)
 	}
 	if err := auth.VerifyMsg(&pba, pba.SatelliteId); err != nil {
-		return reply, pb.ErrPayer.Wrap(err)
-	}
-
-	//save and return rersults
-	err = s.db.CreateAgreement(ctx, rba)
-	if err != nil {
-		//todo:  better classify transport errors (AgreementsSummary_FAIL) vs logical (AgreementsSummary_REJECTED)
-		return reply, pb.ErrPayer.Wrap(auth.ErrSerial.Wrap(err))
+		return pb.ErrPayer.Wrap(err)
 	}
-	reply.Status = pb.AgreementsSummary_OK
-	s.logger.Debug("Stored Agreement...")
-	return reply, nil
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/tls"
 	"crypto/x509"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 
@@ -306,6 +308,69 @@ func testDatabase(ctx context.Context, t *testing.T, bwdb bwagreement.DB) {
 			assert.Equal(t, pb.AgreementsSummary_REJECTED, reply.Status)
 		}
 	}
+
+	{ // TestSettlement
+		ctxSN1, storageNode1 := getPeerContext(ctx, t)
+
+		pba, err := testbwagreement.GeneratePayerBandwidthAllocation(pb.BandwidthAction_GET, satID, upID, time.Hour)
+		assert.NoError(t, err)
+		rba, err := testbwagreement.GenerateRenterBandwidthAllocation(pba, storageNode1, upID, 666)
+		assert.NoError(t, err)
+
+		pbaInvalid, err := testbwagreement.GeneratePayerBandwidthAllocation(pb.BandwidthAction_GET, satID, upID, time.Hour)
+		assert.NoError(t, err)
+		rbaInvalid, err := testbwagreement.GenerateRenterBandwidthAllocation(pbaInvalid, storageNode1, upID, 666)
+		assert.NoError(t, err)
+		rbaInvalid.Signature = []byte("invalid")
+
+		rbaConflicting, err := testbwagreement.GenerateRenterBandwidthAllocation(pba, storageNode1, upID, 777)
+		assert.NoError(t, err)
+
+		stream := &settlementStream{ctx: ctxSN1, requests: []*pb.SettlementRequest{
+			{Allocations: []*pb.RenterBandwidthAllocation{rba, rbaInvalid}},
+			{Allocations: []*pb.RenterBandwidthAllocation{rba, rbaConflicting}},
+		}}
+		require.NoError(t, satellite.Settlement(stream))
+		require.Len(t, stream.responses, 2)
+
+		results := stream.responses[0].GetResults()
+		require.Len(t, results, 2)
+		assert.Equal(t, pb.SettlementResult_ACCEPTED, results[0].GetStatus())
+		assert.Equal(t, pb.SettlementResult_REJECTED, results[1].GetStatus())
+		assert.NotEmpty(t, results[1].GetReason())
+
+		// an agreement sent again is accepted, but another one with the same
+		// serial number isn't
+		results = stream.responses[1].GetResults()
+		require.Len(t, results, 2)
+		assert.Equal(t, pb.SettlementResult_ACCEPTED, results[0].GetStatus())
+		assert.Equal(t, pb.SettlementResult_REJECTED, results[1].GetStatus())
+		assert.NotEmpty(t, results[1].GetReason())
+	}
+}
+
+// settlementStream sends the requests to Settlement and collects its responses
+type settlementStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*pb.SettlementRequest
+	responses []*pb.SettlementResponse
+}
+
+func (stream *settlementStream) Context() context.Context { return stream.ctx }
+
+func (stream *settlementStream) Recv() (*pb.SettlementRequest, error) {
+	if len(stream.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := stream.requests[0]
+	stream.requests = stream.requests[1:]
+	return req, nil
+}
+
+func (stream *settlementStream) Send(resp *pb.SettlementResponse) error {
+	stream.responses = append(stream.responses, resp)
+	return nil
 }
 
 func callBWA(ctx context.Context, t *testing.T, sat *bwagreement.Server, signature []byte, rba *pb.RenterBandwidthAllocation, certs [][]byte) (*pb.AgreementsSummary, error) {
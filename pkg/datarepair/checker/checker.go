@@ -113,8 +113,11 @@ func (c *checker) IdentifyInjuredSegments(ctx context.Context) (err error) {
 					continue
 				}
 
+				// the pointers referencing a deduplicated or copied segment
+				// have no pieces, which are checked with the entry of the
+				// segment instead
 				pieces := remote.GetRemotePieces()
-				if pieces == nil {
+				if len(pieces) == 0 {
 					c.logger.Debug("no pieces on remote segment")
 					continue
 				}
@@ -12,13 +12,11 @@ import (
 	"storj.io/storj/internal/readcloser"
 	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/ranger"
-	"storj.io/storj/pkg/utils"
 )
 
 type decodedReader struct {
 	ctx             context.Context
 	cancel          context.CancelFunc
-	readers         map[int]io.ReadCloser
 	scheme          ErasureScheme
 	stripeReader    *StripeReader
 	outbuf          []byte
@@ -50,7 +48,6 @@ func DecodeReaders(ctx context.Context, rs map[int]io.ReadCloser,
 		return readcloser.FatalReadCloser(err)
 	}
 	dr := &decodedReader{
-		readers:         rs,
 		scheme:          es,
 		stripeReader:    NewStripeReader(rs, es, mbm),
 		outbuf:          make([]byte, 0, es.StripeSize()),
@@ -99,20 +96,8 @@ func (dr *decodedReader) Close() error {
 	dr.cancel()
 	// avoid double close of readers
 	dr.close.Do(func() {
-		var errs []error
-		// close the readers
-		for _, r := range dr.readers {
-			err := r.Close()
-			if err != nil {
-				errs = append(errs, err)
-			}
-		}
-		// close the stripe reader
-		err := dr.stripeReader.Close()
-		if err != nil {
-			errs = append(errs, err)
-		}
-		dr.closeErr = utils.CombineErrors(errs...)
+		// close the stripe reader, which closes the readers too
+		dr.closeErr = dr.stripeReader.Close()
 	})
 	return dr.closeErr
 }
@@ -12,6 +12,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -424,6 +425,61 @@ func TestRSSlow(t *testing.T) {
 	}
 }
 
+// Pieces slower than the ones providing enough shares will be closed
+func TestRSCancelSlowReaders(t *testing.T) {
+	ctx := context.Background()
+	data := randData(4 * 1024)
+	fc, err := infectious.NewFEC(2, 4)
+	if !assert.NoError(t, err) {
+		return
+	}
+	es := NewRSScheme(fc, 1024)
+	rs, err := NewRedundancyStrategy(es, 0, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	readers, err := EncodeReader(ctx, bytes.NewReader(data), rs, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pieces, err := readAll(readers)
+	if !assert.NoError(t, err) {
+		return
+	}
+	stalled := &stalledReadCloser{closed: make(chan struct{})}
+	readerMap := map[int]io.ReadCloser{0: stalled}
+	for i := 1; i < len(pieces); i++ {
+		readerMap[i] = ioutil.NopCloser(bytes.NewReader(pieces[i]))
+	}
+	decoder := DecodeReaders(ctx, readerMap, rs, int64(len(data)), 0)
+	data2, err := ioutil.ReadAll(decoder)
+	if assert.NoError(t, err) {
+		assert.Equal(t, data, data2)
+	}
+	select {
+	case <-stalled.closed:
+	case <-time.After(time.Second):
+		t.Fatal("slow reader was not closed")
+	}
+	assert.NoError(t, decoder.Close())
+}
+
+// stalledReadCloser blocks on Read until closed
+type stalledReadCloser struct {
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (s *stalledReadCloser) Read(p []byte) (n int, err error) {
+	<-s.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (s *stalledReadCloser) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
 type testCase struct {
 	dataSize    int
 	blockSize   int
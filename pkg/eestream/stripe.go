@@ -13,11 +13,17 @@ import (
 	"github.com/vivint/infectious"
 )
 
+// errSlowReader is set to the readers canceled for being slower than the
+// readers which already provided enough erasure shares
+var errSlowReader = Error.New("canceled slow reader")
+
 // StripeReader can read and decodes stripes from a set of readers
 type StripeReader struct {
 	scheme      ErasureScheme
 	cond        *sync.Cond
 	readerCount int
+	readers     map[int]io.ReadCloser
+	closed      map[int]bool
 	bufs        map[int]*PieceBuffer
 	inbufs      map[int][]byte
 	inmap       map[int][]byte
@@ -25,7 +31,9 @@ type StripeReader struct {
 }
 
 // NewStripeReader creates a new StripeReader from the given readers, erasure
-// scheme and max buffer memory.
+// scheme and max buffer memory. The StripeReader takes ownership of the
+// readers: the slowest of them are closed as soon as enough erasure shares
+// are available without them, and the rest are closed on Close.
 func NewStripeReader(rs map[int]io.ReadCloser, es ErasureScheme, mbm int) *StripeReader {
 	readerCount := len(rs)
 
@@ -33,6 +41,8 @@ func NewStripeReader(rs map[int]io.ReadCloser, es ErasureScheme, mbm int) *Strip
 		scheme:      es,
 		cond:        sync.NewCond(&sync.Mutex{}),
 		readerCount: readerCount,
+		readers:     rs,
+		closed:      make(map[int]bool, readerCount),
 		bufs:        make(map[int]*PieceBuffer, readerCount),
 		inbufs:      make(map[int][]byte, readerCount),
 		inmap:       make(map[int][]byte, readerCount),
@@ -62,16 +72,30 @@ func NewStripeReader(rs map[int]io.ReadCloser, es ErasureScheme, mbm int) *Strip
 	return r
 }
 
-// Close closes the StripeReader and all PieceBuffers.
+// Close closes the StripeReader, all readers which are not closed yet and all
+// PieceBuffers.
 func (r *StripeReader) Close() error {
-	errs := make(chan error, len(r.bufs))
+	r.cond.L.Lock()
+	closers := make([]io.Closer, 0, len(r.readers)+len(r.bufs))
+	for i, rc := range r.readers {
+		if !r.closed[i] {
+			r.closed[i] = true
+			closers = append(closers, rc)
+		}
+	}
 	for _, buf := range r.bufs {
+		closers = append(closers, buf)
+	}
+	r.cond.L.Unlock()
+
+	errs := make(chan error, len(closers))
+	for _, c := range closers {
 		go func(c io.Closer) {
 			errs <- c.Close()
-		}(buf)
+		}(c)
 	}
 	var first error
-	for range r.bufs {
+	for range closers {
 		err := <-errs
 		if err != nil && first == nil {
 			first = Error.Wrap(err)
@@ -102,6 +126,7 @@ func (r *StripeReader) ReadStripe(num int64, p []byte) ([]byte, error) {
 				}
 				return nil, err
 			}
+			r.cancelSlowReaders()
 			return out, nil
 		}
 	}
@@ -130,6 +155,26 @@ func (r *StripeReader) readAvailableShares(num int64) (n int) {
 	return n
 }
 
+// cancelSlowReaders closes the readers which did not provide their erasure
+// share for the last decoded stripe, as long as the readers which did are
+// enough to attempt an error correction without them.
+func (r *StripeReader) cancelSlowReaders() {
+	if len(r.inmap) < r.scheme.RequiredCount()+1 {
+		return
+	}
+	for i, rc := range r.readers {
+		if r.inmap[i] != nil || r.errmap[i] != nil {
+			continue
+		}
+		r.errmap[i] = errSlowReader
+		r.closed[i] = true
+		go func(rc io.Closer, buf *PieceBuffer) {
+			_ = rc.Close()
+			_ = buf.Close()
+		}(rc, r.bufs[i])
+	}
+}
+
 // pendingReaders checks if there are any pending readers to get a share from.
 func (r *StripeReader) pendingReaders() bool {
 	goodReaders := r.readerCount - len(r.errmap)
@@ -0,0 +1,208 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/bloomfilter"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+)
+
+var (
+	// Error is the default error class for the garbage collection service
+	Error = errs.Class("garbage collection error")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the garbage collection service
+type Config struct {
+	Interval          time.Duration `help:"how frequently storage nodes should be sent the pieces to retain" default:"120h"`
+	GracePeriod       time.Duration `help:"how long before the walk of pointerdb pieces are kept regardless, as their segments may not be committed yet" default:"24h"`
+	InitialPieces     int           `help:"the number of pieces per storage node the filters are sized for" default:"400000"`
+	FalsePositiveRate float64       `help:"the rate of garbage pieces kept by the filters" default:"0.1"`
+	NodesPerWalk      int           `help:"the number of storage nodes the filters are built for in one walk of pointerdb, which bounds the filters held in memory" default:"100"`
+}
+
+// Service periodically sends every storage node a bloom filter of the pieces
+// it should keep for this satellite. The storage nodes move the pieces which
+// aren't in the filter to the trash.
+type Service struct {
+	log         *zap.Logger
+	config      Config
+	satelliteID storj.NodeID
+	pointerdb   *pointerdb.Service
+	overlay     *overlay.Cache
+	transport   transport.Client
+	ticker      *time.Ticker
+}
+
+// NewService creates a new garbage collection service
+func NewService(log *zap.Logger, config Config, satelliteID storj.NodeID, pointerdb *pointerdb.Service, overlay *overlay.Cache, transport transport.Client) *Service {
+	return &Service{
+		log:         log,
+		config:      config,
+		satelliteID: satelliteID,
+		pointerdb:   pointerdb,
+		overlay:     overlay,
+		transport:   transport,
+		ticker:      time.NewTicker(config.Interval),
+	}
+}
+
+// Run the garbage collection loop
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		select {
+		case <-service.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the service is canceled via context
+			return ctx.Err()
+		}
+
+		err = service.Collect(ctx, time.Now())
+		if err != nil {
+			service.log.Error("collecting garbage failed", zap.Error(err))
+		}
+	}
+}
+
+// Close closes resources
+func (service *Service) Close() error {
+	service.ticker.Stop()
+	return nil
+}
+
+// Collect sends every storage node of the overlay holding pieces the filter
+// of the pieces to retain. Only the pieces stored before now, minus the grace
+// period, may be collected. The filters are built and sent for NodesPerWalk
+// nodes at a time, walking pointerdb once for each of them.
+func (service *Service) Collect(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	created := now.Add(-service.config.GracePeriod)
+
+	var errlist errs.Group
+	for offset, more := int64(0), true; more; {
+		var nodes []*pb.Node
+		nodes, more, err = service.overlay.Paginate(ctx, offset, service.config.NodesPerWalk)
+		if err != nil {
+			errlist.Add(err)
+			return Error.Wrap(errlist.Err())
+		}
+		offset += int64(len(nodes))
+
+		byID := make(map[storj.NodeID]*pb.Node, len(nodes))
+		for _, node := range nodes {
+			byID[node.Id] = node
+		}
+		if len(byID) == 0 {
+			continue
+		}
+
+		filters, err := service.BuildFilters(ctx, func(nodeID storj.NodeID) bool {
+			return byID[nodeID] != nil
+		})
+		if err != nil {
+			errlist.Add(err)
+			return Error.Wrap(errlist.Err())
+		}
+
+		for nodeID, filter := range filters {
+			errlist.Add(service.retain(ctx, byID[nodeID], created, filter))
+		}
+	}
+	return Error.Wrap(errlist.Err())
+}
+
+// BuildFilters walks pointerdb and returns, for every storage node selected by
+// include, the filter of the ids of the pieces it stores for this satellite
+func (service *Service) BuildFilters(ctx context.Context, include func(storj.NodeID) bool) (filters map[storj.NodeID]*bloomfilter.Filter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	filters = make(map[storj.NodeID]*bloomfilter.Filter)
+	err = service.pointerdb.Iterate("", "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				err := proto.Unmarshal(item.Value, pointer)
+				if err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+
+				remote := pointer.GetRemote()
+				if remote == nil {
+					continue
+				}
+
+				for _, piece := range remote.GetRemotePieces() {
+					if !include(piece.NodeId) {
+						continue
+					}
+
+					id, err := service.pieceID(psclient.PieceID(remote.GetPieceId()), piece.NodeId)
+					if err != nil {
+						return err
+					}
+
+					filter, ok := filters[piece.NodeId]
+					if !ok {
+						filter = bloomfilter.NewOptimal(service.config.InitialPieces, service.config.FalsePositiveRate)
+						filters[piece.NodeId] = filter
+					}
+					filter.Add([]byte(id))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// pieceID returns the id under which the storage node stores the piece
+// derived from root for this satellite
+func (service *Service) pieceID(root psclient.PieceID, nodeID storj.NodeID) (psclient.PieceID, error) {
+	derived, err := root.Derive(nodeID.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return derived.Namespace(service.satelliteID.Bytes())
+}
+
+// retain sends the filter to the storage node
+func (service *Service) retain(ctx context.Context, node *pb.Node, created time.Time, filter *bloomfilter.Filter) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ps, err := psclient.NewPSClient(ctx, service.transport, node, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, ps.Close()) }()
+
+	trashed, err := ps.Retain(ctx, created, filter.Bytes())
+	if err != nil {
+		return err
+	}
+
+	service.log.Debug("sent retain filter",
+		zap.String("node", node.Id.String()), zap.Int("size", filter.Size()), zap.Int64("trashed", trashed))
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/gc"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestBuildFilters(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	service := pointerdb.NewService(zap.NewNop(), teststore.New())
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	node1 := teststorj.NodeIDFromString("node1")
+	node2 := teststorj.NodeIDFromString("node2")
+
+	put := func(path string, root psclient.PieceID, nodes ...storj.NodeID) {
+		var pieces []*pb.RemotePiece
+		for i, node := range nodes {
+			pieces = append(pieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: node})
+		}
+		require.NoError(t, service.Put(path, &pb.Pointer{
+			Type: pb.Pointer_REMOTE,
+			Remote: &pb.RemoteSegment{
+				PieceId:      root.String(),
+				RemotePieces: pieces,
+			},
+		}))
+	}
+
+	shared := psclient.NewPieceID()
+	only1 := psclient.NewPieceID()
+	deduplicated := psclient.NewPieceID()
+	deleted := psclient.NewPieceID()
+
+	put("s0/project/bucket/a", shared, node1, node2)
+	put("s0/project/bucket/b", only1, node1)
+	put(pointerdb.DedupPrefix+"project/010203", deduplicated, node2)
+	require.NoError(t, service.Put("l/project/bucket/c", &pb.Pointer{Type: pb.Pointer_INLINE}))
+
+	collector := gc.NewService(zap.NewNop(), gc.Config{
+		Interval:          time.Hour,
+		InitialPieces:     100,
+		FalsePositiveRate: 0.01,
+	}, satellite, service, nil, nil)
+	defer ctx.Check(collector.Close)
+
+	filters, err := collector.BuildFilters(ctx, func(storj.NodeID) bool { return true })
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+
+	contains := func(node storj.NodeID, root psclient.PieceID) bool {
+		derived, err := root.Derive(node.Bytes())
+		require.NoError(t, err)
+		id, err := derived.Namespace(satellite.Bytes())
+		require.NoError(t, err)
+		return filters[node].Contains([]byte(id))
+	}
+
+	assert.True(t, contains(node1, shared))
+	assert.True(t, contains(node2, shared))
+	assert.True(t, contains(node1, only1))
+	assert.True(t, contains(node2, deduplicated))
+	assert.False(t, contains(node1, deleted))
+	assert.False(t, contains(node2, deleted))
+
+	// the filters are built only for the selected nodes
+	filters, err = collector.BuildFilters(ctx, func(node storj.NodeID) bool { return node == node2 })
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	assert.True(t, contains(node2, shared))
+	assert.True(t, contains(node2, deduplicated))
+}
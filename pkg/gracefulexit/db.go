@@ -0,0 +1,79 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"context"
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// DB stores the progress of the storage nodes gracefully exiting the network.
+type DB interface {
+	// Start starts the exit of the node, keeping the progress of an exit
+	// which was already started.
+	Start(ctx context.Context, nodeID storj.NodeID) (*Progress, error)
+	// Get returns the progress of the exit of the node.
+	Get(ctx context.Context, nodeID storj.NodeID) (*Progress, error)
+	// Increment adds to the counters of the exit of the node.
+	Increment(ctx context.Context, nodeID storj.NodeID, piecesTransferred, piecesFailed, bytesTransferred int64) error
+	// Finish marks the exit of the node as finished.
+	Finish(ctx context.Context, nodeID storj.NodeID, finishedAt time.Time) (*Progress, error)
+	// UpdateScan stores the path of the last segment scanned for the pieces of
+	// the node, and whether every segment was scanned.
+	UpdateScan(ctx context.Context, nodeID storj.NodeID, cursor string, scanned bool) error
+
+	// PutTransfer stores the transfer of a piece, replacing the previous
+	// transfer of the piece.
+	PutTransfer(ctx context.Context, transfer *Transfer) error
+	// GetTransfer returns the transfer of piece pieceNum of the segment at
+	// path from the node.
+	GetTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) (*Transfer, error)
+	// DeleteTransfer deletes the transfer of piece pieceNum of the segment at
+	// path from the node.
+	DeleteTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) error
+	// GetOpenTransfers returns up to limit transfers from the node which
+	// didn't fail and either have no receiver or were given one before
+	// assignedBefore, in the order of their paths.
+	GetOpenTransfers(ctx context.Context, nodeID storj.NodeID, assignedBefore time.Time, limit int) ([]*Transfer, error)
+	// CountOpenTransfers returns the number of transfers from the node which
+	// didn't fail.
+	CountOpenTransfers(ctx context.Context, nodeID storj.NodeID) (int64, error)
+}
+
+// Progress is the progress of the exit of a storage node.
+type Progress struct {
+	NodeID            storj.NodeID
+	PiecesTransferred int64
+	PiecesFailed      int64
+	BytesTransferred  int64
+	StartedAt         time.Time
+	FinishedAt        time.Time // zero until the exit is finished
+	ScanCursor        string    // path of the last segment scanned for the pieces of the node
+	ScannedAt         time.Time // zero until every segment was scanned
+}
+
+// Finished returns whether the exit is finished.
+func (progress *Progress) Finished() bool {
+	return !progress.FinishedAt.IsZero()
+}
+
+// Scanned returns whether every segment was scanned for the pieces of the
+// node.
+func (progress *Progress) Scanned() bool {
+	return !progress.ScannedAt.IsZero()
+}
+
+// Transfer is a piece an exiting node has to transfer, which is given a
+// receiver once one is found.
+type Transfer struct {
+	NodeID       storj.NodeID
+	Path         string
+	PieceNum     int32
+	ReceiverID   storj.NodeID // zero until a node is found to receive the piece
+	SerialNumber string       // serial number of the payer allocation of the transfer
+	Failed       bool
+	CreatedAt    time.Time
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/gracefulexit"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestDB(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		exits := db.GracefulExit()
+		nodeID := teststorj.NodeIDFromString("exiting")
+
+		_, err := exits.Get(ctx, nodeID)
+		assert.True(t, gracefulexit.ErrNotStarted.Has(err))
+
+		err = exits.Increment(ctx, nodeID, 1, 0, 10)
+		assert.True(t, gracefulexit.ErrNotStarted.Has(err))
+
+		started, err := exits.Start(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, nodeID, started.NodeID)
+		assert.False(t, started.StartedAt.IsZero())
+		assert.False(t, started.Finished())
+
+		require.NoError(t, exits.Increment(ctx, nodeID, 1, 0, 10))
+		require.NoError(t, exits.Increment(ctx, nodeID, 1, 1, 20))
+
+		// starting again keeps the progress
+		progress, err := exits.Start(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), progress.PiecesTransferred)
+		assert.Equal(t, int64(1), progress.PiecesFailed)
+		assert.Equal(t, int64(30), progress.BytesTransferred)
+		assert.True(t, started.StartedAt.Equal(progress.StartedAt))
+		assert.False(t, progress.Scanned())
+
+		// the scan of the segments is resumed from its cursor
+		require.NoError(t, exits.UpdateScan(ctx, nodeID, "a/b", false))
+		progress, err = exits.Get(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, "a/b", progress.ScanCursor)
+		assert.False(t, progress.Scanned())
+
+		require.NoError(t, exits.UpdateScan(ctx, nodeID, "c/d", true))
+		progress, err = exits.Get(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, "c/d", progress.ScanCursor)
+		assert.True(t, progress.Scanned())
+
+		finishedAt := time.Now().Truncate(time.Second)
+		progress, err = exits.Finish(ctx, nodeID, finishedAt)
+		require.NoError(t, err)
+		assert.True(t, progress.Finished())
+		assert.True(t, finishedAt.Equal(progress.FinishedAt))
+
+		progress, err = exits.Get(ctx, nodeID)
+		require.NoError(t, err)
+		assert.True(t, progress.Finished())
+		assert.Equal(t, int64(2), progress.PiecesTransferred)
+	})
+}
+
+func TestTransfers(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		exits := db.GracefulExit()
+		nodeID := teststorj.NodeIDFromString("exiting")
+		receiverID := teststorj.NodeIDFromString("receiver")
+
+		_, err := exits.GetTransfer(ctx, nodeID, "path", 1)
+		assert.True(t, gracefulexit.ErrTransferNotFound.Has(err))
+
+		require.NoError(t, exits.PutTransfer(ctx, &gracefulexit.Transfer{
+			NodeID:       nodeID,
+			Path:         "path",
+			PieceNum:     1,
+			ReceiverID:   receiverID,
+			SerialNumber: "serial",
+		}))
+
+		transfer, err := exits.GetTransfer(ctx, nodeID, "path", 1)
+		require.NoError(t, err)
+		assert.Equal(t, receiverID, transfer.ReceiverID)
+		assert.Equal(t, "serial", transfer.SerialNumber)
+		assert.False(t, transfer.Failed)
+		assert.False(t, transfer.CreatedAt.IsZero())
+
+		// the transfers of the other pieces are separate
+		_, err = exits.GetTransfer(ctx, nodeID, "path", 2)
+		assert.True(t, gracefulexit.ErrTransferNotFound.Has(err))
+
+		// a failed transfer replaces the previous one
+		require.NoError(t, exits.PutTransfer(ctx, &gracefulexit.Transfer{
+			NodeID:   nodeID,
+			Path:     "path",
+			PieceNum: 1,
+			Failed:   true,
+		}))
+
+		transfer, err = exits.GetTransfer(ctx, nodeID, "path", 1)
+		require.NoError(t, err)
+		assert.True(t, transfer.ReceiverID.IsZero())
+		assert.Equal(t, "", transfer.SerialNumber)
+		assert.True(t, transfer.Failed)
+
+		require.NoError(t, exits.DeleteTransfer(ctx, nodeID, "path", 1))
+		_, err = exits.GetTransfer(ctx, nodeID, "path", 1)
+		assert.True(t, gracefulexit.ErrTransferNotFound.Has(err))
+	})
+}
+
+func TestOpenTransfers(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		exits := db.GracefulExit()
+		nodeID := teststorj.NodeIDFromString("exiting")
+		receiverID := teststorj.NodeIDFromString("receiver")
+
+		for _, transfer := range []*gracefulexit.Transfer{
+			{NodeID: nodeID, Path: "d", PieceNum: 1},
+			{NodeID: nodeID, Path: "a", PieceNum: 1},
+			{NodeID: nodeID, Path: "b", PieceNum: 1, ReceiverID: receiverID, SerialNumber: "serial"},
+			{NodeID: nodeID, Path: "c", PieceNum: 1, Failed: true},
+			{NodeID: receiverID, Path: "a", PieceNum: 2},
+		} {
+			require.NoError(t, exits.PutTransfer(ctx, transfer))
+		}
+
+		paths := func(transfers []*gracefulexit.Transfer) (paths []string) {
+			for _, transfer := range transfers {
+				paths = append(paths, transfer.Path)
+			}
+			return paths
+		}
+
+		// the transfers without receiver are open
+		open, err := exits.GetOpenTransfers(ctx, nodeID, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "d"}, paths(open))
+
+		// and the transfers given a receiver before the time
+		open, err = exits.GetOpenTransfers(ctx, nodeID, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "d"}, paths(open))
+		assert.Equal(t, receiverID, open[1].ReceiverID)
+		assert.Equal(t, "serial", open[1].SerialNumber)
+
+		open, err = exits.GetOpenTransfers(ctx, nodeID, time.Now().Add(time.Hour), 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, paths(open))
+
+		count, err := exits.CountOpenTransfers(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
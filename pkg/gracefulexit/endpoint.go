@@ -0,0 +1,530 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+var (
+	// Error is the default graceful exit errs class
+	Error = errs.Class("graceful exit error")
+	// ErrNotStarted is the errs class of nodes which didn't start to exit
+	ErrNotStarted = errs.Class("graceful exit not started")
+	// ErrTransferNotFound is the errs class of pieces the nodes weren't told
+	// to transfer
+	ErrTransferNotFound = errs.Class("graceful exit transfer not found")
+	mon                 = monkit.Package()
+)
+
+const (
+	// scanLimit is how many segments are scanned for the pieces of an exiting
+	// node per request for transfers
+	scanLimit = storage.LookupLimit
+	// transferTimeout is how long an exiting node has to report the transfer
+	// of a piece before the piece is given another receiver
+	transferTimeout = time.Hour
+)
+
+// Endpoint lets storage nodes leave the network by transferring their pieces
+// to nodes chosen by the overlay. Nodes which started to exit don't get new
+// pieces, and the pieces left on them are repaired once they finished. The
+// receiver and the allocation of every transfer are stored, so that a piece
+// is only replaced by the transfer the node was told to do.
+type Endpoint struct {
+	log        *zap.Logger
+	db         DB
+	pointerdb  *pointerdb.Service
+	allocation *pointerdb.AllocationSigner
+	overlay    pb.OverlayServer
+	pieces     pointerdb.PieceVerifier
+	identity   *identity.FullIdentity
+}
+
+// NewEndpoint creates a new graceful exit endpoint
+func NewEndpoint(log *zap.Logger, db DB, pointerdb *pointerdb.Service, allocation *pointerdb.AllocationSigner, overlay pb.OverlayServer, pieces pointerdb.PieceVerifier, identity *identity.FullIdentity) *Endpoint {
+	return &Endpoint{
+		log:        log,
+		db:         db,
+		pointerdb:  pointerdb,
+		allocation: allocation,
+		overlay:    overlay,
+		pieces:     pieces,
+		identity:   identity,
+	}
+}
+
+// Close closes resources
+func (endpoint *Endpoint) Close() error { return nil }
+
+// Initiate starts the exit of the calling node, or returns its progress
+func (endpoint *Endpoint) Initiate(ctx context.Context, req *pb.InitiateRequest) (_ *pb.ExitProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	// only the nodes known to the overlay store pieces
+	if _, err := endpoint.overlay.Lookup(ctx, &pb.LookupRequest{NodeId: peer.ID}); err != nil {
+		return nil, Error.New("node %s not found in the overlay: %v", peer.ID, err)
+	}
+
+	progress, err := endpoint.db.Start(ctx, peer.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	endpoint.log.Info("Node is exiting", zap.String("Node ID", peer.ID.String()))
+	return exitProgress(progress), nil
+}
+
+// GetTransfers returns the next pieces the calling node has to transfer. Every
+// request scans the next page of segments for the pieces of the node, which
+// are recorded as transfers without receiver, and gives a receiver to the
+// recorded pieces which have none, or whose transfer wasn't reported in time.
+// So the pieces no node can receive for now are retried by later requests
+// without scanning the segments again.
+func (endpoint *Endpoint) GetTransfers(ctx context.Context, req *pb.GetTransfersRequest) (_ *pb.GetTransfersResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, progress, err := endpoint.exiting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	scanned := progress.Scanned()
+	if !scanned {
+		scanned, err = endpoint.scan(ctx, peer.ID, progress.ScanCursor)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	open, err := endpoint.db.GetOpenTransfers(ctx, peer.ID, time.Now().Add(-transferTimeout), limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	resp := &pb.GetTransfersResponse{More: !scanned || len(open) == limit}
+	for _, recorded := range open {
+		pointer, err := endpoint.pointerdb.Get(recorded.Path)
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return nil, Error.Wrap(err)
+		}
+		if pointer == nil || findPiece(pointer, peer.ID, recorded.PieceNum) == nil {
+			// the segment was deleted or repaired since it was scanned
+			if err := endpoint.db.DeleteTransfer(ctx, peer.ID, recorded.Path, recorded.PieceNum); err != nil {
+				return nil, Error.Wrap(err)
+			}
+			continue
+		}
+
+		transfer, err := endpoint.transfer(ctx, peer, recorded.Path, pointer, recorded.PieceNum)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if transfer == nil {
+			// the pieces left are given a receiver by later requests
+			resp.More = !scanned
+			break
+		}
+
+		err = endpoint.db.PutTransfer(ctx, &Transfer{
+			NodeID:       peer.ID,
+			Path:         recorded.Path,
+			PieceNum:     recorded.PieceNum,
+			ReceiverID:   transfer.Receiver.Id,
+			SerialNumber: transfer.PayerAllocation.GetSerialNumber(),
+		})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		resp.Transfers = append(resp.Transfers, transfer)
+	}
+	return resp, nil
+}
+
+// scan scans the page of segments after cursor for the pieces of the node,
+// records them as transfers and returns whether every segment was scanned.
+// The pieces which have no hash to verify their transfer with are counted as
+// failed.
+func (endpoint *Endpoint) scan(ctx context.Context, nodeID storj.NodeID, cursor string) (scanned bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var failed int64
+	scanned = true
+	err = endpoint.pointerdb.Iterate("", cursor, true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			count := 0
+			for it.Next(&item) {
+				path := item.Key.String()
+				if path == cursor {
+					continue
+				}
+				if count >= scanLimit {
+					scanned = false
+					return nil
+				}
+				count++
+				cursor = path
+
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return err
+				}
+
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					if piece.NodeId != nodeID {
+						continue
+					}
+
+					// the pieces of a page scanned again, when storing the
+					// cursor failed, are recorded once
+					_, err := endpoint.db.GetTransfer(ctx, nodeID, path, piece.GetPieceNum())
+					if err == nil {
+						continue
+					}
+					if !ErrTransferNotFound.Has(err) {
+						return err
+					}
+
+					hashless := pieceHash(piece) == nil
+					if hashless {
+						endpoint.log.Warn("Piece can't be transferred", zap.String("Node ID", nodeID.String()),
+							zap.String("path", path), zap.Int32("piece", piece.GetPieceNum()))
+						failed++
+					}
+
+					err = endpoint.db.PutTransfer(ctx, &Transfer{
+						NodeID:   nodeID,
+						Path:     path,
+						PieceNum: piece.GetPieceNum(),
+						Failed:   hashless,
+					})
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if failed > 0 {
+		if err := endpoint.db.Increment(ctx, nodeID, 0, failed, 0); err != nil {
+			return false, err
+		}
+	}
+	return scanned, endpoint.db.UpdateScan(ctx, nodeID, cursor, scanned)
+}
+
+// transfer creates the transfer of piece pieceNum of the segment at path from
+// the exiting node, or returns nil when no node can receive the piece
+func (endpoint *Endpoint) transfer(ctx context.Context, peer *identity.PeerIdentity, path string, pointer *pb.Pointer, pieceNum int32) (_ *pb.Transfer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var excluded storj.NodeIDList
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		excluded = append(excluded, piece.NodeId)
+	}
+
+	found, err := endpoint.overlay.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{
+			Amount:        1,
+			ExcludedNodes: excluded,
+		},
+	})
+	if err != nil {
+		// not finding enough nodes only means that the piece can't be
+		// transferred now
+		if stat, _ := status.FromError(err); stat.Code() != codes.ResourceExhausted {
+			return nil, err
+		}
+		endpoint.log.Debug("Not enough nodes to transfer to", zap.Error(err))
+	}
+	if len(found.GetNodes()) == 0 {
+		return nil, nil
+	}
+
+	// every transfer gets its own allocation, as the receiver might get more
+	// than one piece
+	pba, err := endpoint.allocation.PayerBandwidthAllocation(ctx, peer, nil, "", pb.BandwidthAction_PUT_REPAIR)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := auth.GenerateSignature(endpoint.identity.ID.Bytes(), endpoint.identity)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := auth.NewSignedMessage(signature, endpoint.identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Transfer{
+		Path:            path,
+		PieceNum:        pieceNum,
+		PieceId:         pointer.GetRemote().GetPieceId(),
+		Receiver:        found.GetNodes()[0],
+		PayerAllocation: pba,
+		Authorization:   authorization,
+	}, nil
+}
+
+// Transferred replaces the calling node with the receiver of a piece, once
+// the transfer is verified to be the one the node was told to do and the
+// hash signed by the receiver is verified to match the piece
+func (endpoint *Endpoint) Transferred(ctx context.Context, req *pb.TransferredRequest) (_ *pb.TransferredResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, _, err := endpoint.exiting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := endpoint.db.GetTransfer(ctx, peer.ID, req.GetPath(), req.GetPieceNum())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if transfer.Failed {
+		return nil, Error.New("transfer of piece %d of %q failed", req.GetPieceNum(), req.GetPath())
+	}
+	if transfer.ReceiverID != req.ReceiverId || transfer.SerialNumber != req.GetSerialNumber() {
+		return nil, Error.New("piece %d of %q was to be transferred to node %s with allocation %s",
+			req.GetPieceNum(), req.GetPath(), transfer.ReceiverID, transfer.SerialNumber)
+	}
+
+	pointer, err := endpoint.pointerdb.Get(req.GetPath())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var transferred *pb.RemotePiece
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.NodeId == req.ReceiverId {
+			return nil, Error.New("node %s already stores a piece of %q", req.ReceiverId, req.GetPath())
+		}
+		if piece.NodeId == peer.ID && piece.GetPieceNum() == req.GetPieceNum() {
+			transferred = piece
+		}
+	}
+	if transferred == nil {
+		return nil, Error.New("no piece %d of %q on node %s", req.GetPieceNum(), req.GetPath(), peer.ID)
+	}
+
+	hash := req.GetHash()
+	if hash == nil {
+		return nil, Error.New("no hash of piece %d of %q", req.GetPieceNum(), req.GetPath())
+	}
+	if err := auth.VerifyMsg(hash, req.ReceiverId); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	derivedID, err := psclient.PieceID(pointer.GetRemote().GetPieceId()).Derive(req.ReceiverId.Bytes())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if hash.GetId() != derivedID.String() {
+		return nil, Error.New("hash of piece %s instead of %s", hash.GetId(), derivedID)
+	}
+	matches, err := endpoint.verify(ctx, pointer, transferred, req.ReceiverId, hash)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !matches {
+		return nil, Error.New("hash of piece %d of %q differs from the transferred content", req.GetPieceNum(), req.GetPath())
+	}
+
+	err = endpoint.pointerdb.ReplacePiece(req.GetPath(), req.GetPieceNum(), peer.ID, &pb.RemotePiece{
+		PieceNum:   req.GetPieceNum(),
+		NodeId:     req.ReceiverId,
+		Hash:       hash.GetHash(),
+		SignedHash: hash,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := endpoint.db.Increment(ctx, peer.ID, 1, 0, req.GetSize()); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := endpoint.db.DeleteTransfer(ctx, peer.ID, req.GetPath(), req.GetPieceNum()); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &pb.TransferredResponse{}, nil
+}
+
+// verify returns whether the piece transferred to the receiver matches the
+// piece of the exiting node. The hashes are compared when both nodes hashed
+// the piece in blocks of the same size, otherwise the transferred piece is
+// downloaded to compare it with the hash of the piece of the exiting node.
+func (endpoint *Endpoint) verify(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece, receiverID storj.NodeID, hash *pb.PieceHash) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	expected := pieceHash(piece)
+	if expected == nil {
+		return false, Error.New("no hash to verify piece %d with", piece.GetPieceNum())
+	}
+	if expected.GetBlockSize() == hash.GetBlockSize() {
+		return bytes.Equal(expected.GetHash(), hash.GetHash()), nil
+	}
+	return endpoint.pieces.Matches(ctx, pointer, receiverID, expected)
+}
+
+// TransferFailed counts a piece the calling node couldn't transfer, which is
+// left for repair
+func (endpoint *Endpoint) TransferFailed(ctx context.Context, req *pb.TransferFailedRequest) (_ *pb.TransferFailedResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, _, err := endpoint.exiting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := endpoint.db.GetTransfer(ctx, peer.ID, req.GetPath(), req.GetPieceNum())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if transfer.Failed {
+		return &pb.TransferFailedResponse{}, nil
+	}
+
+	endpoint.log.Warn("Node failed to transfer a piece", zap.String("Node ID", peer.ID.String()),
+		zap.String("path", req.GetPath()), zap.Int32("piece", req.GetPieceNum()), zap.String("error", req.GetError()))
+
+	transfer.Failed = true
+	if err := endpoint.db.PutTransfer(ctx, transfer); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := endpoint.db.Increment(ctx, peer.ID, 0, 1, 0); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &pb.TransferFailedResponse{}, nil
+}
+
+// Complete finishes the exit of the calling node, once every segment was
+// scanned for its pieces and every piece it still stores failed to be
+// transferred. The pieces it didn't transfer are repaired from
+// then on.
+func (endpoint *Endpoint) Complete(ctx context.Context, req *pb.CompleteRequest) (_ *pb.ExitProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	progress, err := endpoint.db.Get(ctx, peer.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if progress.Finished() {
+		return exitProgress(progress), nil
+	}
+
+	if !progress.Scanned() {
+		return nil, Error.New("node %s didn't get all of its transfers yet", peer.ID)
+	}
+	remaining, err := endpoint.db.CountOpenTransfers(ctx, peer.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if remaining > 0 {
+		return nil, Error.New("node %s has %d pieces left to transfer", peer.ID, remaining)
+	}
+
+	progress, err = endpoint.db.Finish(ctx, peer.ID, time.Now())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	endpoint.log.Info("Node exited", zap.String("Node ID", peer.ID.String()),
+		zap.Int64("transferred", progress.PiecesTransferred), zap.Int64("failed", progress.PiecesFailed))
+	return exitProgress(progress), nil
+}
+
+// exiting returns the calling node and the progress of its exit, as long as
+// it started to exit and didn't finish yet
+func (endpoint *Endpoint) exiting(ctx context.Context) (*identity.PeerIdentity, *Progress, error) {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+
+	progress, err := endpoint.db.Get(ctx, peer.ID)
+	if err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+	if progress.Finished() {
+		return nil, nil, Error.New("node %s already exited", peer.ID)
+	}
+	return peer, progress, nil
+}
+
+// exitProgress converts the progress of an exit to its protobuf
+func exitProgress(progress *Progress) *pb.ExitProgress {
+	out := &pb.ExitProgress{
+		PiecesTransferred: progress.PiecesTransferred,
+		PiecesFailed:      progress.PiecesFailed,
+		BytesTransferred:  progress.BytesTransferred,
+		StartedUnixSec:    progress.StartedAt.Unix(),
+	}
+	if progress.Finished() {
+		out.FinishedUnixSec = progress.FinishedAt.Unix()
+	}
+	return out
+}
+
+// findPiece returns piece pieceNum of the segment of pointer stored on the
+// node, if any
+func findPiece(pointer *pb.Pointer, nodeID storj.NodeID, pieceNum int32) *pb.RemotePiece {
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.NodeId == nodeID && piece.GetPieceNum() == pieceNum {
+			return piece
+		}
+	}
+	return nil
+}
+
+// pieceHash returns the hash of piece signed by the node storing it, or the
+// hash of the whole piece for the pieces stored before the hashes were
+// signed, if any
+func pieceHash(piece *pb.RemotePiece) *pb.PieceHash {
+	if piece.GetSignedHash() != nil {
+		return piece.GetSignedHash()
+	}
+	if len(piece.GetHash()) > 0 {
+		return &pb.PieceHash{Hash: piece.GetHash()}
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/gracefulexit"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+	"storj.io/storj/storagenode"
+)
+
+type testOverlay struct {
+	known     bool
+	exhausted bool
+	receiver  *pb.Node
+}
+
+func (overlay *testOverlay) Lookup(ctx context.Context, req *pb.LookupRequest) (*pb.LookupResponse, error) {
+	if !overlay.known {
+		return nil, gracefulexit.Error.New("node not found")
+	}
+	return &pb.LookupResponse{Node: &pb.Node{Id: req.NodeId}}, nil
+}
+
+func (overlay *testOverlay) BulkLookup(ctx context.Context, req *pb.LookupRequests) (*pb.LookupResponses, error) {
+	return nil, gracefulexit.Error.New("not implemented")
+}
+
+func (overlay *testOverlay) FindStorageNodes(ctx context.Context, req *pb.FindStorageNodesRequest) (*pb.FindStorageNodesResponse, error) {
+	if overlay.exhausted {
+		return nil, status.Errorf(codes.ResourceExhausted, "not enough nodes")
+	}
+	return &pb.FindStorageNodesResponse{Nodes: []*pb.Node{overlay.receiver}}, nil
+}
+
+type testPieceVerifier struct {
+	matches  bool
+	verified int
+}
+
+func (verifier *testPieceVerifier) Corrupted(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece) (bool, error) {
+	verifier.verified++
+	return !verifier.matches, nil
+}
+
+func (verifier *testPieceVerifier) Matches(ctx context.Context, pointer *pb.Pointer, nodeID storj.NodeID, hash *pb.PieceHash) (bool, error) {
+	verifier.verified++
+	return verifier.matches, nil
+}
+
+func peerContext(ctx context.Context, ident *identity.FullIdentity) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 5},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{ident.Leaf, ident.CA},
+			},
+		},
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		satelliteIdent, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		exitingIdent, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		receiverIdent, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+
+		service := pointerdb.NewService(zap.NewNop(), teststore.New())
+		overlay := &testOverlay{receiver: &pb.Node{Id: receiverIdent.ID}}
+		verifier := &testPieceVerifier{}
+		endpoint := gracefulexit.NewEndpoint(zap.NewNop(), db.GracefulExit(), service,
+			pointerdb.NewAllocationSigner(satelliteIdent, 45), overlay, verifier, satelliteIdent)
+
+		exitingCtx := peerContext(ctx, exitingIdent)
+		other := teststorj.NodeIDFromString("other")
+
+		// a: hashed in blocks, b: without hash, c: hashed as a whole, d: hashed in blocks
+		hashes := map[string]*pb.RemotePiece{
+			"a": {SignedHash: &pb.PieceHash{Hash: []byte("a"), BlockSize: psclient.HashBlockSize}},
+			"b": {},
+			"c": {Hash: []byte("c")},
+			"d": {SignedHash: &pb.PieceHash{Hash: []byte("d"), BlockSize: psclient.HashBlockSize}},
+		}
+		pointers := map[string]*pb.Pointer{}
+		for path, piece := range hashes {
+			piece.PieceNum = 1
+			piece.NodeId = exitingIdent.ID
+			pointers[path] = &pb.Pointer{
+				Type: pb.Pointer_REMOTE,
+				Remote: &pb.RemoteSegment{
+					PieceId:      psclient.NewPieceID().String(),
+					RemotePieces: []*pb.RemotePiece{{PieceNum: 0, NodeId: other}, piece},
+				},
+			}
+			require.NoError(t, service.Put(path, pointers[path]))
+		}
+
+		signedHash := func(path string, hash []byte, blockSize int64) *pb.PieceHash {
+			derivedID, err := psclient.PieceID(pointers[path].GetRemote().GetPieceId()).Derive(receiverIdent.ID.Bytes())
+			require.NoError(t, err)
+			signed := &pb.PieceHash{Id: derivedID.String(), Hash: hash, BlockSize: blockSize}
+			require.NoError(t, auth.SignMessage(signed, *receiverIdent))
+			return signed
+		}
+
+		_, err = endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		assert.True(t, gracefulexit.ErrNotStarted.Has(err))
+
+		// only the nodes known to the overlay can exit
+		_, err = endpoint.Initiate(exitingCtx, &pb.InitiateRequest{})
+		assert.Error(t, err)
+
+		overlay.known = true
+		progress, err := endpoint.Initiate(exitingCtx, &pb.InitiateRequest{})
+		require.NoError(t, err)
+		assert.Zero(t, progress.GetFinishedUnixSec())
+
+		// the pieces have to be transferred first
+		_, err = endpoint.Complete(exitingCtx, &pb.CompleteRequest{})
+		assert.Error(t, err)
+
+		// the pieces are left to transfer while no node can receive them, but
+		// the piece without hash can't be verified, so it fails
+		overlay.exhausted = true
+		resp, err := endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetTransfers())
+		assert.False(t, resp.GetMore())
+
+		progress, err = endpoint.Initiate(exitingCtx, &pb.InitiateRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), progress.GetPiecesFailed())
+
+		_, err = endpoint.Complete(exitingCtx, &pb.CompleteRequest{})
+		assert.Error(t, err)
+
+		overlay.exhausted = false
+		resp, err = endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetTransfers(), 3)
+		transfers := map[string]*pb.Transfer{}
+		for _, transfer := range resp.GetTransfers() {
+			assert.Equal(t, receiverIdent.ID, transfer.GetReceiver().Id)
+			transfers[transfer.GetPath()] = transfer
+		}
+		require.Contains(t, transfers, "a")
+		require.Contains(t, transfers, "c")
+		require.Contains(t, transfers, "d")
+
+		// the pieces being transferred don't get another receiver
+		resp, err = endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetTransfers())
+
+		transferred := func(path string, receiverID storj.NodeID, serialNumber string, hash *pb.PieceHash) error {
+			_, err := endpoint.Transferred(exitingCtx, &pb.TransferredRequest{
+				Path:         path,
+				PieceNum:     1,
+				ReceiverId:   receiverID,
+				Hash:         hash,
+				Size:         10,
+				SerialNumber: serialNumber,
+			})
+			return err
+		}
+		serialA := transfers["a"].GetPayerAllocation().GetSerialNumber()
+
+		// only the transfer the node was told to do is accepted
+		assert.Error(t, transferred("a", other, serialA, signedHash("a", []byte("a"), psclient.HashBlockSize)))
+		assert.Error(t, transferred("a", receiverIdent.ID, "serial", signedHash("a", []byte("a"), psclient.HashBlockSize)))
+		assert.Error(t, transferred("a", receiverIdent.ID, serialA, nil))
+		assert.Error(t, transferred("a", receiverIdent.ID, serialA, signedHash("a", []byte("other"), psclient.HashBlockSize)))
+		assert.Zero(t, verifier.verified)
+
+		hash := signedHash("a", []byte("a"), psclient.HashBlockSize)
+		require.NoError(t, transferred("a", receiverIdent.ID, serialA, hash))
+
+		pointer, err := service.Get("a")
+		require.NoError(t, err)
+		piece := pointer.GetRemote().GetRemotePieces()[1]
+		assert.Equal(t, receiverIdent.ID, piece.NodeId)
+		assert.Equal(t, hash.GetHash(), piece.GetSignedHash().GetHash())
+		assert.Equal(t, hash.GetSignature(), piece.GetSignedHash().GetSignature())
+
+		// the piece hashed as a whole is verified by downloading it
+		serialC := transfers["c"].GetPayerAllocation().GetSerialNumber()
+		assert.Error(t, transferred("c", receiverIdent.ID, serialC, signedHash("c", []byte("blocks"), psclient.HashBlockSize)))
+		assert.Equal(t, 1, verifier.verified)
+
+		verifier.matches = true
+		require.NoError(t, transferred("c", receiverIdent.ID, serialC, signedHash("c", []byte("blocks"), psclient.HashBlockSize)))
+		assert.Equal(t, 2, verifier.verified)
+
+		_, err = endpoint.Complete(exitingCtx, &pb.CompleteRequest{})
+		assert.Error(t, err)
+
+		// the failed pieces are counted once
+		for i := 0; i < 2; i++ {
+			_, err = endpoint.TransferFailed(exitingCtx, &pb.TransferFailedRequest{Path: "d", PieceNum: 1, Error: "failed"})
+			require.NoError(t, err)
+		}
+		assert.Error(t, transferred("d", receiverIdent.ID, transfers["d"].GetPayerAllocation().GetSerialNumber(),
+			signedHash("d", []byte("d"), psclient.HashBlockSize)))
+
+		resp, err = endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetTransfers())
+
+		progress, err = endpoint.Complete(exitingCtx, &pb.CompleteRequest{})
+		require.NoError(t, err)
+		assert.NotZero(t, progress.GetFinishedUnixSec())
+		assert.Equal(t, int64(2), progress.GetPiecesTransferred())
+		assert.Equal(t, int64(2), progress.GetPiecesFailed())
+		assert.Equal(t, int64(20), progress.GetBytesTransferred())
+
+		_, err = endpoint.GetTransfers(exitingCtx, &pb.GetTransfersRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestExit(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 6, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	// we wait a second for all the nodes to complete bootstrapping off the satellite
+	time.Sleep(2 * time.Second)
+
+	satellite, uplink := planet.Satellites[0], planet.Uplinks[0]
+
+	oc, err := uplink.DialOverlay(satellite)
+	require.NoError(t, err)
+	pdb, err := uplink.DialPointerDB(satellite, uplink.APIKey[satellite.ID()])
+	require.NoError(t, err)
+
+	fc, err := infectious.NewFEC(2, 4)
+	require.NoError(t, err)
+	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, int(1*memory.KB)), 3, 4)
+	require.NoError(t, err)
+
+	// the object is large enough to be stored on the storage nodes
+	segmentStore := segments.NewSegmentStore(oc, ecclient.NewClient(uplink.Identity, 0), pdb, rs, int(1*memory.KB))
+	streamStore, err := streams.NewStreamStore(segmentStore, int64(64*memory.MB), &storj.Key{1, 2, 3}, int(1*memory.KB), storj.AESGCM, streams.Options{MaxBufferMem: int(4 * memory.MB)})
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte("exiting "), 1000)
+	_, err = streamStore.Put(ctx, "bucket/file", storj.AESGCM, bytes.NewReader(data), nil, time.Time{})
+	require.NoError(t, err)
+
+	// the pieces of the node storing the first piece are counted
+	piecesOn := func(nodeID storj.NodeID) (count int64) {
+		err := satellite.Metainfo.Service.Iterate("", "", true, false,
+			func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) {
+					pointer := &pb.Pointer{}
+					if err := proto.Unmarshal(item.Value, pointer); err != nil {
+						return err
+					}
+					for _, piece := range pointer.GetRemote().GetRemotePieces() {
+						if piece.NodeId == nodeID {
+							count++
+						}
+					}
+				}
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		return count
+	}
+
+	var exiting *storagenode.Peer
+	for _, node := range planet.StorageNodes {
+		if piecesOn(node.ID()) > 0 {
+			exiting = node
+			break
+		}
+	}
+	require.NotNil(t, exiting)
+	pieces := piecesOn(exiting.ID())
+
+	require.NoError(t, exiting.DB.PSDB().AddExit(satellite.ID()))
+	require.NoError(t, exiting.Storage.Exiter.Exit(ctx))
+
+	progress, err := satellite.DB.GracefulExit().Get(ctx, exiting.ID())
+	require.NoError(t, err)
+	assert.True(t, progress.Finished())
+	assert.Equal(t, pieces, progress.PiecesTransferred)
+	assert.Zero(t, progress.PiecesFailed)
+	assert.Zero(t, piecesOn(exiting.ID()))
+
+	// the object is downloaded from the receivers
+	rr, _, err := streamStore.Get(ctx, "bucket/file", storj.AESGCM)
+	require.NoError(t, err)
+	reader, err := rr.Range(ctx, 0, rr.Size())
+	require.NoError(t, err)
+	downloaded, err := ioutil.ReadAll(reader)
+	require.NoError(t, errs.Combine(err, reader.Close()))
+	assert.Equal(t, data, downloaded)
+}
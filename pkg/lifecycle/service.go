@@ -0,0 +1,270 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/storage"
+)
+
+var (
+	// Error is the default error class for the lifecycle service
+	Error = errs.Class("lifecycle error")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the lifecycle service
+type Config struct {
+	Interval time.Duration `help:"how frequently bucket lifecycle rules should be applied" default:"1h"`
+}
+
+// Service deletes the objects, the versions of objects and the pending uploads
+// expired by the lifecycle rules of their buckets.
+//
+// The segments are deleted through the segment store of the satellite, which
+// deletes the pieces no other pointer references from the storage nodes.
+type Service struct {
+	log       *zap.Logger
+	pointerdb *pointerdb.Service
+	segments  segments.Store
+	ticker    *time.Ticker
+}
+
+// NewService creates a new lifecycle service
+func NewService(log *zap.Logger, pointerdb *pointerdb.Service, segments segments.Store, interval time.Duration) *Service {
+	return &Service{
+		log:       log,
+		pointerdb: pointerdb,
+		segments:  segments,
+		ticker:    time.NewTicker(interval),
+	}
+}
+
+// Run the lifecycle loop
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		err = service.ApplyRules(ctx, time.Now())
+		if err != nil {
+			service.log.Error("applying lifecycle rules failed", zap.Error(err))
+		}
+
+		select {
+		case <-service.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the service is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Close closes resources
+func (service *Service) Close() error {
+	service.ticker.Stop()
+	return nil
+}
+
+// ApplyRules deletes everything expired at the given time by the lifecycle
+// rules of all buckets
+func (service *Service) ApplyRules(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	lifecycles, err := service.lifecycles(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for bucket, lifecycle := range lifecycles {
+		for _, rule := range lifecycle.GetRules() {
+			if !rule.Enabled {
+				continue
+			}
+
+			if rule.ExpirationDays > 0 {
+				deadline := expiredBefore(now, rule.ExpirationDays)
+				errlist.Add(service.expire(ctx, "l", bucket, rule.Prefix, deadline))
+				errlist.Add(service.expire(ctx, "v", bucket, rule.Prefix, deadline))
+			}
+			if rule.AbortPendingDays > 0 {
+				errlist.Add(service.expire(ctx, "p", bucket, rule.Prefix, expiredBefore(now, rule.AbortPendingDays)))
+			}
+		}
+	}
+
+	return Error.Wrap(errlist.Err())
+}
+
+// lifecycles returns the lifecycle rules of all buckets, keyed by bucket
+func (service *Service) lifecycles(ctx context.Context) (lifecycles map[string]*pb.BucketLifecycle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	lifecycles = make(map[string]*pb.BucketLifecycle)
+	err = service.pointerdb.Iterate(pointerdb.LifecyclePrefix, "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				bucket := strings.TrimPrefix(item.Key.String(), pointerdb.LifecyclePrefix)
+
+				lifecycle, err := service.pointerdb.GetLifecycle(bucket)
+				if err != nil {
+					return err
+				}
+
+				lifecycles[bucket] = lifecycle
+			}
+			return nil
+		},
+	)
+	return lifecycles, err
+}
+
+// expire deletes the streams of bucket under prefix, whose pointers under
+// root were last written before deadline. The root is either l/ for
+// committed objects, v/ for the versions of objects or p/ for pending
+// uploads.
+func (service *Service) expire(ctx context.Context, root string, bucket string, prefix string, deadline time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the paths are collected first, as the database can't be modified
+	// while iterating over it
+	var expired []string
+	err = service.pointerdb.Iterate(root+"/"+bucket+"/"+prefix, "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				err := proto.Unmarshal(item.Value, pointer)
+				if err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+
+				created, err := ptypes.Timestamp(pointer.GetCreationDate())
+				if err != nil {
+					service.log.Warn("invalid pointer creation date", zap.String("path", item.Key.String()), zap.Error(err))
+					continue
+				}
+
+				if created.Before(deadline) {
+					expired = append(expired, strings.TrimPrefix(item.Key.String(), root+"/"))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range expired {
+		switch root {
+		case "p":
+			err = service.abortPending(ctx, path)
+		default:
+			err = service.deleteStream(ctx, root, path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(expired) > 0 {
+		service.log.Info("expired by lifecycle rules",
+			zap.String("bucket", bucket), zap.String("root", root), zap.Int("count", len(expired)))
+	}
+
+	return nil
+}
+
+// deleteStream deletes the segments of the committed object or the version
+// at path, with the last one last. The root is l/ for committed objects,
+// whose other segments are under s<index>/, or v/ for versions, whose other
+// segments are under v<index>/.
+func (service *Service) deleteStream(ctx context.Context, root string, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segmentRoot := "s"
+	if root == "v" {
+		segmentRoot = "v"
+	}
+
+	err = service.deleteSegments(ctx, segmentRoot, path)
+	if err != nil {
+		return err
+	}
+
+	return service.deleteSegment(ctx, root+"/"+path)
+}
+
+// abortPending deletes the pending upload marker at path and the segments
+// uploaded so far, unless the upload was committed after all
+func (service *Service) abortPending(ctx context.Context, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = service.pointerdb.Get("l/" + path)
+	if storage.ErrKeyNotFound.Has(err) {
+		err = service.deleteSegments(ctx, "s", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	return service.deleteSegment(ctx, "p/"+path)
+}
+
+// deleteSegments deletes all the segments of path under segmentRoot except
+// the last one. The satellite can't read the number of segments from the
+// encrypted stream info, so segments are deleted until one is missing.
+func (service *Service) deleteSegments(ctx context.Context, segmentRoot string, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for i := 0; ; i++ {
+		segmentPath := fmt.Sprintf("%s%d/%s", segmentRoot, i, path)
+
+		// not every database reports deleting a missing key
+		_, err := service.pointerdb.Get(segmentPath)
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = service.segments.Delete(ctx, segmentPath)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// deleteSegment deletes the segment at path through the segment store, so
+// that its pieces are deleted from the storage nodes unless they are still
+// referenced by other pointers
+func (service *Service) deleteSegment(ctx context.Context, path string) error {
+	return ignoreNotFound(service.segments.Delete(ctx, path))
+}
+
+func expiredBefore(now time.Time, days int32) time.Time {
+	return now.Add(-time.Duration(days) * 24 * time.Hour)
+}
+
+func ignoreNotFound(err error) error {
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil
+	}
+	return err
+}
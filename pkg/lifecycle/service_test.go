@@ -0,0 +1,116 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/lifecycle"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+// segmentStore deletes the segments from pointerdb and records the paths of
+// the segments whose pieces were released
+type segmentStore struct {
+	segments.Store
+	pointerdb *pointerdb.Service
+	released  map[string]bool
+}
+
+func (store *segmentStore) Delete(ctx context.Context, path storj.Path) error {
+	released, err := store.pointerdb.Unlink(path)
+	if err != nil {
+		return err
+	}
+	if released != nil {
+		store.released[path] = true
+	}
+	return nil
+}
+
+func TestApplyRules(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+
+	db := teststore.New()
+	service := pointerdb.NewService(zap.NewNop(), db)
+
+	put := func(path string, created time.Time) {
+		timestamp, err := ptypes.TimestampProto(created)
+		require.NoError(t, err)
+
+		pointer, err := proto.Marshal(&pb.Pointer{
+			Type:         pb.Pointer_REMOTE,
+			CreationDate: timestamp,
+			Remote:       &pb.RemoteSegment{PieceId: path},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Put(storage.Key(path), pointer))
+	}
+
+	put("l/bucket/logs/old", old)
+	put("s0/bucket/logs/old", old)
+	put("l/bucket/logs/new", now)
+	put("l/bucket/data/old", old)
+	put("p/bucket/logs/pending", old)
+	put("s0/bucket/logs/pending", old)
+	put("l/other/logs/old", old)
+	put("v/bucket/logs/old/1", old)
+	put("v0/bucket/logs/old/1", old)
+	put("v/bucket/logs/old/2", now)
+
+	err := service.SetLifecycle("bucket", &pb.BucketLifecycle{
+		Rules: []*pb.LifecycleRule{
+			{Id: "logs", Prefix: "logs/", Enabled: true, ExpirationDays: 7, AbortPendingDays: 1},
+			{Id: "data", Prefix: "data/", Enabled: false, ExpirationDays: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	segmentStore := &segmentStore{pointerdb: service, released: map[string]bool{}}
+	lifecycles := lifecycle.NewService(zap.NewNop(), service, segmentStore, time.Hour)
+	defer ctx.Check(lifecycles.Close)
+
+	require.NoError(t, lifecycles.ApplyRules(ctx, now))
+
+	for path, exists := range map[string]bool{
+		"l/bucket/logs/old":      false,
+		"s0/bucket/logs/old":     false,
+		"l/bucket/logs/new":      true,
+		"l/bucket/data/old":      true,
+		"p/bucket/logs/pending":  false,
+		"s0/bucket/logs/pending": false,
+		"l/other/logs/old":       true,
+		"v/bucket/logs/old/1":    false,
+		"v0/bucket/logs/old/1":   false,
+		"v/bucket/logs/old/2":    true,
+	} {
+		_, err := service.Get(path)
+		if exists {
+			assert.NoError(t, err, path)
+		} else {
+			assert.True(t, storage.ErrKeyNotFound.Has(err), path)
+			// the pieces of the deleted segments are freed
+			assert.True(t, segmentStore.released[path], path)
+		}
+	}
+}
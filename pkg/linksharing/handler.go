@@ -0,0 +1,162 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/auth/grpcauth"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
+	"storj.io/storj/pkg/ranger"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+)
+
+var mon = monkit.Package()
+
+// Config is the configuration of the link sharing server
+type Config struct {
+	Address      string      `help:"address to serve shared objects over http" default:"localhost:8080"`
+	Satellites   string      `help:"comma separated addresses of the satellites whose shared objects are served" default:""`
+	MaxBufferMem memory.Size `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4M"`
+}
+
+// Handler serves read-only objects shared with tokens over HTTP. The token
+// is the path of the request, so links are in the form of
+// http://<address>/<token>.
+//
+// Only the objects of the configured satellites are served, through
+// connections shared by all requests.
+type Handler struct {
+	log   *zap.Logger
+	conns map[string]*grpc.ClientConn
+	ec    ecclient.Client
+}
+
+// NewHandler creates a Handler which connects to satellites with identity.
+// The connections are closed with Close.
+func NewHandler(log *zap.Logger, identity *identity.FullIdentity, satellites []string, maxBufferMem int) (*Handler, error) {
+	handler := &Handler{
+		log:   log,
+		conns: make(map[string]*grpc.ClientConn),
+		ec:    ecclient.NewClient(identity, maxBufferMem),
+	}
+
+	tc := transport.NewClient(identity)
+	for _, address := range satellites {
+		if _, ok := handler.conns[address]; ok {
+			continue
+		}
+
+		// the api keys of the tokens are passed with the context of each
+		// request
+		conn, err := tc.DialAddress(context.Background(), address,
+			grpc.WithUnaryInterceptor(grpcauth.NewContextAPIKeyInjector()))
+		if err != nil {
+			return nil, errs.Combine(Error.New("failed to connect to satellite %s: %v", address, err), handler.Close())
+		}
+		handler.conns[address] = conn
+	}
+
+	return handler, nil
+}
+
+// Close closes the connections to the satellites
+func (handler *Handler) Close() error {
+	var errlist errs.Group
+	for _, conn := range handler.conns {
+		errlist.Add(conn.Close())
+	}
+	return errlist.Err()
+}
+
+// ServeHTTP serves the object shared by the token of the request, with
+// support for range requests
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, err := ParseToken(strings.TrimPrefix(r.URL.Path, "/"))
+	if err != nil {
+		http.Error(w, "invalid share token", http.StatusBadRequest)
+		return
+	}
+
+	overlayConn, ok := handler.conns[token.OverlayAddr]
+	pointerdbConn, ok2 := handler.conns[token.PointerdbAddr]
+	if !ok || !ok2 {
+		http.Error(w, "satellite of the share link is not served", http.StatusForbidden)
+		return
+	}
+
+	// the ranger reads the segments with the api key of the token
+	ctx = auth.WithAPIKey(ctx, []byte(token.ApiKey))
+
+	rr, meta, err := handler.open(ctx, overlayConn, pointerdbConn, token)
+	if err != nil {
+		handler.serveError(w, err)
+		return
+	}
+
+	ranger.ServeContent(ctx, w, r, token.Name, meta.Modified, rr)
+}
+
+// open returns a ranger of the object shared by token, reading it through the
+// connections to the overlay and the pointerdb of its satellite
+func (handler *Handler) open(ctx context.Context, overlayConn, pointerdbConn *grpc.ClientConn, token *pb.ShareToken) (rr ranger.Ranger, meta streams.Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	oc := overlay.NewClientFrom(pb.NewOverlayClient(overlayConn))
+	pdb := pdbclient.New(pb.NewPointerDBClient(pointerdbConn))
+
+	// the redundancy strategy and inline size are only used for uploads
+	segmentStore := segments.NewSegmentStore(oc, handler.ec, pdb, eestream.RedundancyStrategy{}, 0)
+
+	var contentKey storj.Key
+	copy(contentKey[:], token.ContentKey)
+
+	return streams.GetWithKey(ctx, segmentStore, token.EncryptedPath, &contentKey)
+}
+
+// serveError responds with the status code matching err
+func (handler *Handler) serveError(w http.ResponseWriter, err error) {
+	if storage.ErrKeyNotFound.Has(err) {
+		http.Error(w, "object not found", http.StatusNotFound)
+		return
+	}
+
+	switch status.Code(errs.Unwrap(err)) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		http.Error(w, "share link is invalid or expired", http.StatusForbidden)
+		return
+	}
+
+	handler.log.Error("failed to serve shared object", zap.Error(err))
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
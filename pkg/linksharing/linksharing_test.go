@@ -0,0 +1,176 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/linksharing"
+	"storj.io/storj/pkg/macaroon"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestToken(t *testing.T) {
+	secret := []byte("secret")
+	apiKey := macaroon.NewAPIKey([]byte("head"), secret)
+	rootKey := &storj.Key{1, 2, 3}
+	satellite := linksharing.Satellite{OverlayAddr: "overlay:7777", PointerDBAddr: "pointerdb:7777"}
+	expires := time.Now().Add(time.Hour)
+
+	_, err := linksharing.NewToken(satellite, apiKey, "bucket", storj.AESGCM, rootKey, expires)
+	assert.Error(t, err)
+
+	token, err := linksharing.NewToken(satellite, apiKey, "bucket/dir/file", storj.AESGCM, rootKey, expires)
+	require.NoError(t, err)
+
+	serialized, err := linksharing.SerializeToken(token)
+	require.NoError(t, err)
+
+	parsed, err := linksharing.ParseToken(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, token, parsed)
+	assert.Equal(t, "file", parsed.Name)
+
+	encPath, err := streams.EncryptAfterBucket("bucket/dir/file", storj.AESGCM, rootKey)
+	require.NoError(t, err)
+	assert.Equal(t, encPath, parsed.EncryptedPath)
+
+	contentKey, err := encryption.DeriveContentKey("bucket/dir/file", rootKey)
+	require.NoError(t, err)
+	assert.Equal(t, contentKey[:], parsed.ContentKey)
+
+	restricted, err := macaroon.ParseAPIKey(parsed.ApiKey)
+	require.NoError(t, err)
+
+	read := macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        "bucket",
+		EncryptedPath: storj.JoinPaths(storj.SplitPath(encPath)[1:]...),
+		Time:          time.Now(),
+	}
+	assert.NoError(t, restricted.Check(secret, read))
+
+	write := read
+	write.Op = macaroon.ActionWrite
+	assert.Error(t, restricted.Check(secret, write))
+
+	otherBucket := read
+	otherBucket.Bucket = "other"
+	assert.Error(t, restricted.Check(secret, otherBucket))
+
+	expired := read
+	expired.Time = expires.Add(time.Second)
+	assert.Error(t, restricted.Check(secret, expired))
+
+	_, err = linksharing.ParseToken("not a token")
+	assert.True(t, linksharing.ErrToken.Has(err))
+}
+
+func TestHandlerInvalidRequests(t *testing.T) {
+	handler, err := linksharing.NewHandler(zap.NewNop(), nil, nil, 0)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodPost, "/token", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/", http.StatusBadRequest},
+		{http.MethodGet, "/not-a-token", http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+		assert.Equal(t, test.status, w.Code, test.method+" "+test.path)
+	}
+}
+
+func TestHandlerServesSharedObject(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite, uplink := planet.Satellites[0], planet.Uplinks[0]
+
+	oc, err := uplink.DialOverlay(satellite)
+	require.NoError(t, err)
+	pdb, err := uplink.DialPointerDB(satellite, uplink.APIKey[satellite.ID()])
+	require.NoError(t, err)
+
+	fc, err := infectious.NewFEC(2, 4)
+	require.NoError(t, err)
+	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, int(1*memory.KB)), 3, 4)
+	require.NoError(t, err)
+
+	// the object is large enough to be stored on the storage nodes
+	segmentStore := segments.NewSegmentStore(oc, ecclient.NewClient(uplink.Identity, 0), pdb, rs, int(1*memory.KB))
+	rootKey := &storj.Key{1, 2, 3}
+	streamStore, err := streams.NewStreamStore(segmentStore, int64(64*memory.MB), rootKey, int(1*memory.KB), storj.AESGCM, streams.Options{MaxBufferMem: int(4 * memory.MB)})
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte("shared object "), 500)
+	_, err = streamStore.Put(ctx, "bucket/dir/file", storj.AESGCM, bytes.NewReader(data), nil, time.Time{})
+	require.NoError(t, err)
+
+	apiKey, err := macaroon.ParseAPIKey(uplink.APIKey[satellite.ID()])
+	require.NoError(t, err)
+
+	share := func(address string) string {
+		token, err := linksharing.NewToken(linksharing.Satellite{OverlayAddr: address, PointerDBAddr: address},
+			apiKey, "bucket/dir/file", storj.AESGCM, rootKey, time.Time{})
+		require.NoError(t, err)
+
+		serialized, err := linksharing.SerializeToken(token)
+		require.NoError(t, err)
+		return "/" + serialized
+	}
+
+	handler, err := linksharing.NewHandler(zap.NewNop(), uplink.Identity, []string{satellite.Addr()}, 0)
+	require.NoError(t, err)
+	defer ctx.Check(handler.Close)
+
+	// the connections are reused by the requests
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, share(satellite.Addr()), nil))
+		if assert.Equal(t, http.StatusOK, w.Code) {
+			assert.Equal(t, data, w.Body.Bytes())
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, share(satellite.Addr()), nil)
+	r.Header.Set("Range", "bytes=14-19")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if assert.Equal(t, http.StatusPartialContent, w.Code) {
+		assert.Equal(t, "shared", w.Body.String())
+	}
+
+	// the objects of other satellites aren't served
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, share("127.0.0.1:1"), nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/macaroon"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	// Error is the general link sharing error class
+	Error = errs.Class("link sharing error")
+	// ErrToken is the error class of malformed share tokens
+	ErrToken = errs.Class("share token error")
+)
+
+// Satellite is the addresses of the satellite a shared object is stored on
+type Satellite struct {
+	OverlayAddr   string
+	PointerDBAddr string
+}
+
+// NewToken creates a token sharing read access to the object at path, the
+// unencrypted path of the object prefixed by its bucket. The api key of the
+// token is apiKey restricted to reading the object until expires, or with
+// no expiration if expires is zero.
+func NewToken(satellite Satellite, apiKey *macaroon.APIKey, path storj.Path, pathCipher storj.Cipher, rootKey *storj.Key, expires time.Time) (*pb.ShareToken, error) {
+	comps := storj.SplitPath(path)
+	if len(comps) < 2 {
+		return nil, Error.New("path %q is not an object", path)
+	}
+
+	encPath, err := streams.EncryptAfterBucket(path, pathCipher, rootKey)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	contentKey, err := encryption.DeriveContentKey(path, rootKey)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	caveat := pb.Caveat{
+		ReadOnly:            true,
+		Bucket:              comps[0],
+		EncryptedPathPrefix: storj.JoinPaths(storj.SplitPath(encPath)[1:]...),
+	}
+	if !expires.IsZero() {
+		caveat.NotAfter, err = ptypes.TimestampProto(expires)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	restricted, err := apiKey.Restrict(caveat)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	serialized, err := restricted.Serialize()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &pb.ShareToken{
+		OverlayAddr:   satellite.OverlayAddr,
+		PointerdbAddr: satellite.PointerDBAddr,
+		ApiKey:        serialized,
+		EncryptedPath: encPath,
+		ContentKey:    contentKey[:],
+		Name:          comps[len(comps)-1],
+	}, nil
+}
+
+// SerializeToken serializes token to a string which can be used in urls
+func SerializeToken(token *pb.ShareToken) (string, error) {
+	data, err := proto.Marshal(token)
+	if err != nil {
+		return "", ErrToken.Wrap(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ParseToken parses a token serialized with SerializeToken
+func ParseToken(serialized string) (*pb.ShareToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(serialized)
+	if err != nil {
+		return nil, ErrToken.Wrap(err)
+	}
+
+	token := &pb.ShareToken{}
+	if err := proto.Unmarshal(data, token); err != nil {
+		return nil, ErrToken.Wrap(err)
+	}
+
+	switch {
+	case token.OverlayAddr == "" || token.PointerdbAddr == "":
+		return nil, ErrToken.New("missing satellite address")
+	case token.ApiKey == "":
+		return nil, ErrToken.New("missing api key")
+	case len(storj.SplitPath(token.EncryptedPath)) < 2:
+		return nil, ErrToken.New("missing object path")
+	case len(token.ContentKey) != len(storj.Key{}):
+		return nil, ErrToken.New("invalid content key")
+	}
+
+	return token, nil
+}
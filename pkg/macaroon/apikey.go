@@ -0,0 +1,205 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package macaroon
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/pb"
+)
+
+var (
+	// Error is a general api key error
+	Error = errs.Class("api key error")
+	// ErrFormat means that the structural formatting of the api key is invalid
+	ErrFormat = errs.Class("api key format error")
+	// ErrUnauthorized means that the api key does not grant the requested permission
+	ErrUnauthorized = errs.Class("api key unauthorized error")
+)
+
+// ActionType specifies the operation type being performed that the Macaroon will validate
+type ActionType int
+
+const (
+	// ActionRead specifies a read operation
+	ActionRead ActionType = iota + 1
+	// ActionWrite specifies a write operation
+	ActionWrite
+	// ActionList specifies a list operation
+	ActionList
+	// ActionDelete specifies a delete operation
+	ActionDelete
+)
+
+// Action specifies the specific operation being performed that the Macaroon will validate
+type Action struct {
+	Op ActionType
+	// Bucket is the bucket of the request, empty for requests not inside
+	// of a bucket, like listing the buckets
+	Bucket string
+	// EncryptedPath is the path inside of the bucket, as seen by the satellite
+	EncryptedPath string
+	Time          time.Time
+}
+
+// APIKey implements a Macaroon-backed Storj-v3 API key.
+type APIKey struct {
+	mac *Macaroon
+}
+
+// NewAPIKey creates an unrestricted api key for the root secret identified
+// by head
+func NewAPIKey(head, secret []byte) *APIKey {
+	return &APIKey{mac: NewUnrestricted(head, secret)}
+}
+
+// ParseAPIKey parses a given api key string and returns an APIKey if the
+// APIKey was correctly formatted. It does not validate the key.
+func ParseAPIKey(key string) (*APIKey, error) {
+	data, err := base64.URLEncoding.DecodeString(key)
+	if err != nil {
+		return nil, ErrFormat.Wrap(err)
+	}
+
+	var serialized pb.Macaroon
+	if err := proto.Unmarshal(data, &serialized); err != nil {
+		return nil, ErrFormat.Wrap(err)
+	}
+	if len(serialized.Head) == 0 || len(serialized.Tail) == 0 {
+		return nil, ErrFormat.New("missing head or tail")
+	}
+
+	return &APIKey{mac: &Macaroon{
+		head:    serialized.Head,
+		caveats: serialized.Caveats,
+		tail:    serialized.Tail,
+	}}, nil
+}
+
+// Head returns the identifier of the root secret of the key
+func (a *APIKey) Head() []byte { return a.mac.Head() }
+
+// Serialize serializes the api key to a string
+func (a *APIKey) Serialize() (string, error) {
+	data, err := proto.Marshal(&pb.Macaroon{
+		Head:    a.mac.head,
+		Caveats: a.mac.caveats,
+		Tail:    a.mac.tail,
+	})
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// Restrict generates a new api key with the provided caveat attached.
+// Anyone holding the key can restrict it further.
+func (a *APIKey) Restrict(caveat pb.Caveat) (*APIKey, error) {
+	if caveat.ReadOnly && caveat.WriteOnly {
+		return nil, Error.New("caveat can't be both read only and write only")
+	}
+	if caveat.EncryptedPathPrefix != "" && caveat.Bucket == "" {
+		return nil, Error.New("caveat path prefix requires a bucket")
+	}
+
+	data, err := proto.Marshal(&caveat)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &APIKey{mac: a.mac.AddFirstPartyCaveat(data)}, nil
+}
+
+// Caveats returns the caveats of the key, from the first one added
+func (a *APIKey) Caveats() (caveats []pb.Caveat, err error) {
+	for _, data := range a.mac.caveats {
+		var caveat pb.Caveat
+		if err := proto.Unmarshal(data, &caveat); err != nil {
+			return nil, ErrFormat.Wrap(err)
+		}
+		caveats = append(caveats, caveat)
+	}
+	return caveats, nil
+}
+
+// Check makes sure that the key was derived from secret and that all of
+// its caveats allow the action
+func (a *APIKey) Check(secret []byte, action Action) error {
+	return a.check(secret, action, true)
+}
+
+// CheckOperation makes sure that the key was derived from secret and that
+// all of its caveats allow the operation of the action, without checking
+// the bucket and path restrictions. It is meant for requests that don't
+// access any path by themselves, like bandwidth allocations.
+func (a *APIKey) CheckOperation(secret []byte, action Action) error {
+	return a.check(secret, action, false)
+}
+
+func (a *APIKey) check(secret []byte, action Action, checkPath bool) error {
+	if !a.mac.Validate(secret) {
+		return ErrUnauthorized.New("macaroon unauthorized")
+	}
+
+	caveats, err := a.Caveats()
+	if err != nil {
+		return err
+	}
+
+	for _, caveat := range caveats {
+		if !allowsOperation(caveat, action) || (checkPath && !allowsPath(caveat, action)) {
+			return ErrUnauthorized.New("action disallowed")
+		}
+	}
+
+	return nil
+}
+
+// allowsOperation returns whether caveat allows the operation of action at
+// the time of action
+func allowsOperation(caveat pb.Caveat, action Action) bool {
+	switch action.Op {
+	case ActionRead, ActionList:
+		if caveat.WriteOnly {
+			return false
+		}
+	case ActionWrite, ActionDelete:
+		if caveat.ReadOnly {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if caveat.NotAfter != nil {
+		notAfter, err := ptypes.Timestamp(caveat.NotAfter)
+		if err != nil || action.Time.After(notAfter) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allowsPath returns whether caveat allows accessing the path of action
+func allowsPath(caveat pb.Caveat, action Action) bool {
+	if caveat.Bucket != "" && caveat.Bucket != action.Bucket {
+		return false
+	}
+
+	if caveat.EncryptedPathPrefix != "" {
+		prefix := strings.TrimSuffix(caveat.EncryptedPathPrefix, "/")
+		if action.EncryptedPath != prefix && !strings.HasPrefix(action.EncryptedPath, prefix+"/") {
+			return false
+		}
+	}
+
+	return true
+}
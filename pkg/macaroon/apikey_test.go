@@ -0,0 +1,97 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package macaroon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/macaroon"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestSerializeParseAPIKey(t *testing.T) {
+	key := macaroon.NewAPIKey([]byte("head"), []byte("secret"))
+	key, err := key.Restrict(pb.Caveat{ReadOnly: true})
+	require.NoError(t, err)
+
+	serialized, err := key.Serialize()
+	require.NoError(t, err)
+
+	parsed, err := macaroon.ParseAPIKey(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("head"), parsed.Head())
+
+	caveats, err := parsed.Caveats()
+	require.NoError(t, err)
+	require.Len(t, caveats, 1)
+	assert.True(t, caveats[0].ReadOnly)
+
+	_, err = macaroon.ParseAPIKey("not a key")
+	assert.True(t, macaroon.ErrFormat.Has(err))
+}
+
+func TestAPIKeyCheck(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+
+	notAfter, err := ptypes.TimestampProto(now.Add(time.Hour))
+	require.NoError(t, err)
+
+	root := macaroon.NewAPIKey([]byte("head"), secret)
+	restricted, err := root.Restrict(pb.Caveat{
+		Bucket:              "bucket",
+		EncryptedPathPrefix: "enc1/",
+	})
+	require.NoError(t, err)
+	restricted, err = restricted.Restrict(pb.Caveat{ReadOnly: true, NotAfter: notAfter})
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		key     *macaroon.APIKey
+		secret  []byte
+		action  macaroon.Action
+		allowed bool
+	}{
+		{root, secret, macaroon.Action{Op: macaroon.ActionWrite, Bucket: "other", Time: now}, true},
+		{root, []byte("wrong secret"), macaroon.Action{Op: macaroon.ActionRead, Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: "bucket", EncryptedPath: "enc1/enc2", Time: now}, true},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionList, Bucket: "bucket", EncryptedPath: "enc1", Time: now}, true},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: "bucket", EncryptedPath: "enc12", Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: "other", EncryptedPath: "enc1/enc2", Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionList, Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionWrite, Bucket: "bucket", EncryptedPath: "enc1/enc2", Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionDelete, Bucket: "bucket", EncryptedPath: "enc1/enc2", Time: now}, false},
+		{restricted, secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: "bucket", EncryptedPath: "enc1/enc2", Time: now.Add(2 * time.Hour)}, false},
+	} {
+		err := tt.key.Check(tt.secret, tt.action)
+		if tt.allowed {
+			assert.NoError(t, err, i)
+		} else {
+			assert.True(t, macaroon.ErrUnauthorized.Has(err), i)
+		}
+	}
+
+	_, err = root.Restrict(pb.Caveat{ReadOnly: true, WriteOnly: true})
+	assert.Error(t, err)
+	_, err = root.Restrict(pb.Caveat{EncryptedPathPrefix: "enc1/"})
+	assert.Error(t, err)
+}
+
+func TestAPIKeyCheckOperation(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+
+	key, err := macaroon.NewAPIKey([]byte("head"), secret).Restrict(pb.Caveat{Bucket: "bucket", ReadOnly: true})
+	require.NoError(t, err)
+
+	assert.NoError(t, key.CheckOperation(secret, macaroon.Action{Op: macaroon.ActionRead, Time: now}))
+	assert.Error(t, key.CheckOperation(secret, macaroon.Action{Op: macaroon.ActionWrite, Time: now}))
+	assert.Error(t, key.CheckOperation([]byte("wrong secret"), macaroon.Action{Op: macaroon.ActionRead, Time: now}))
+	assert.Error(t, key.Check(secret, macaroon.Action{Op: macaroon.ActionRead, Time: now}))
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package macaroon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// Macaroon is a bearer token, which can be restricted by anyone holding it
+// by appending caveats, but can't be made less restrictive.
+//
+// The tail of a macaroon is an HMAC chained over the head and the caveats,
+// starting with the root secret. Only the holder of the root secret can
+// check that a macaroon wasn't forged or had caveats removed.
+type Macaroon struct {
+	head    []byte
+	caveats [][]byte
+	tail    []byte
+}
+
+// NewUnrestricted creates a macaroon without caveats for the root secret
+// identified by head
+func NewUnrestricted(head, secret []byte) *Macaroon {
+	return &Macaroon{
+		head: copyBytes(head),
+		tail: sign(secret, head),
+	}
+}
+
+// AddFirstPartyCaveat returns a copy of the macaroon restricted with caveat
+func (m *Macaroon) AddFirstPartyCaveat(caveat []byte) *Macaroon {
+	restricted := m.Copy()
+	restricted.caveats = append(restricted.caveats, copyBytes(caveat))
+	restricted.tail = sign(m.tail, caveat)
+	return restricted
+}
+
+// Validate checks that the macaroon was derived from secret
+func (m *Macaroon) Validate(secret []byte) bool {
+	tail := sign(secret, m.head)
+	for _, caveat := range m.caveats {
+		tail = sign(tail, caveat)
+	}
+	return hmac.Equal(tail, m.tail)
+}
+
+// Head returns the identifier of the root secret
+func (m *Macaroon) Head() []byte { return copyBytes(m.head) }
+
+// Caveats returns the caveats of the macaroon, from the first one added
+func (m *Macaroon) Caveats() [][]byte {
+	caveats := make([][]byte, 0, len(m.caveats))
+	for _, caveat := range m.caveats {
+		caveats = append(caveats, copyBytes(caveat))
+	}
+	return caveats
+}
+
+// Tail returns the signature of the macaroon
+func (m *Macaroon) Tail() []byte { return copyBytes(m.tail) }
+
+// Copy returns a deep copy of the macaroon
+func (m *Macaroon) Copy() *Macaroon {
+	return &Macaroon{
+		head:    copyBytes(m.head),
+		caveats: m.Caveats(),
+		tail:    copyBytes(m.tail),
+	}
+}
+
+func sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func copyBytes(data []byte) []byte {
+	return append([]byte(nil), data...)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package macaroon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMacaroon(t *testing.T) {
+	secret := []byte("secret")
+	unrestricted := NewUnrestricted([]byte("head"), secret)
+	assert.True(t, unrestricted.Validate(secret))
+	assert.False(t, unrestricted.Validate([]byte("other secret")))
+
+	restricted := unrestricted.AddFirstPartyCaveat([]byte("first"))
+	restricted = restricted.AddFirstPartyCaveat([]byte("second"))
+	assert.True(t, restricted.Validate(secret))
+	assert.Equal(t, [][]byte{[]byte("first"), []byte("second")}, restricted.Caveats())
+	assert.Equal(t, []byte("head"), restricted.Head())
+
+	// the original macaroon isn't modified
+	assert.Empty(t, unrestricted.Caveats())
+	assert.NotEqual(t, unrestricted.Tail(), restricted.Tail())
+
+	// removing a caveat invalidates the macaroon
+	removed := restricted.Copy()
+	removed.caveats = removed.caveats[:1]
+	assert.False(t, removed.Validate(secret))
+}
@@ -5,8 +5,11 @@ package kvmetainfo
 
 import (
 	"context"
+	"strings"
 
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/buckets"
+	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 )
 
@@ -17,8 +20,18 @@ func (db *DB) CreateBucket(ctx context.Context, bucket string, info *storj.Bucke
 	if bucket == "" {
 		return storj.Bucket{}, storj.ErrNoBucket.New("")
 	}
+	if storj.IsMultipartBucket(bucket) {
+		return storj.Bucket{}, storj.ErrBucketNameReserved.New("%q", bucket)
+	}
+
+	meta := buckets.Meta{PathEncryptionType: getPathCipher(info)}
+	if info != nil {
+		meta.Versioning = info.Versioning
+		meta.RedundancyScheme = info.RedundancyScheme
+		meta.EncryptionScheme = info.EncryptionScheme
+	}
 
-	meta, err := db.buckets.Put(ctx, bucket, getPathCipher(info))
+	meta, err = db.buckets.Put(ctx, bucket, meta)
 	if err != nil {
 		return storj.Bucket{}, err
 	}
@@ -97,6 +110,103 @@ func (db *DB) ListBuckets(ctx context.Context, options storj.BucketListOptions)
 	return list, nil
 }
 
+// SetBucketLifecycle replaces the lifecycle rules of a bucket. The prefixes
+// of the rules are encrypted, so that the satellite can match them against
+// the encrypted paths.
+func (db *DB) SetBucketLifecycle(ctx context.Context, bucket string, rules []storj.LifecycleRule) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	lifecycle := &pb.BucketLifecycle{}
+	for _, rule := range rules {
+		if rule.ExpirationDays < 0 || rule.AbortPendingDays < 0 {
+			return errClass.New("lifecycle rule %q has negative days", rule.ID)
+		}
+
+		prefix, err := db.encryptLifecyclePrefix(bucketInfo, rule.Prefix)
+		if err != nil {
+			return err
+		}
+
+		lifecycle.Rules = append(lifecycle.Rules, &pb.LifecycleRule{
+			Id:               rule.ID,
+			Prefix:           prefix,
+			Enabled:          rule.Enabled,
+			ExpirationDays:   int32(rule.ExpirationDays),
+			AbortPendingDays: int32(rule.AbortPendingDays),
+		})
+	}
+
+	return db.pointers.SetLifecycle(ctx, bucket, lifecycle)
+}
+
+// GetBucketLifecycle returns the lifecycle rules of a bucket
+func (db *DB) GetBucketLifecycle(ctx context.Context, bucket string) (rules []storj.LifecycleRule, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle, err := db.pointers.GetLifecycle(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range lifecycle.GetRules() {
+		prefix, err := db.decryptLifecyclePrefix(bucketInfo, rule.Prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, storj.LifecycleRule{
+			ID:               rule.Id,
+			Prefix:           prefix,
+			Enabled:          rule.Enabled,
+			ExpirationDays:   int(rule.ExpirationDays),
+			AbortPendingDays: int(rule.AbortPendingDays),
+		})
+	}
+
+	return rules, nil
+}
+
+// encryptLifecyclePrefix encrypts the directory prefix of a lifecycle rule,
+// the encrypted prefix ends with a slash unless it is empty
+func (db *DB) encryptLifecyclePrefix(bucket storj.Bucket, prefix storj.Path) (storj.Path, error) {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return "", nil
+	}
+
+	encrypted, err := streams.EncryptAfterBucket(storj.JoinPaths(bucket.Name, prefix), bucket.PathCipher, db.rootKey)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(encrypted, bucket.Name+"/") + "/", nil
+}
+
+// decryptLifecyclePrefix decrypts the directory prefix of a lifecycle rule
+func (db *DB) decryptLifecyclePrefix(bucket storj.Bucket, prefix storj.Path) (storj.Path, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return "", nil
+	}
+
+	decrypted, err := streams.DecryptAfterBucket(storj.JoinPaths(bucket.Name, prefix), bucket.PathCipher, db.rootKey)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(decrypted, bucket.Name+"/") + "/", nil
+}
+
 func getPathCipher(info *storj.Bucket) storj.Cipher {
 	if info == nil {
 		return storj.AESGCM
@@ -109,5 +219,9 @@ func bucketFromMeta(bucket string, meta buckets.Meta) storj.Bucket {
 		Name:       bucket,
 		Created:    meta.Created,
 		PathCipher: meta.PathEncryptionType,
+		Versioning: meta.Versioning,
+
+		RedundancyScheme: meta.RedundancyScheme,
+		EncryptionScheme: meta.EncryptionScheme,
 	}
 }
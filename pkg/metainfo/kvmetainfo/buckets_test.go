@@ -5,7 +5,6 @@ package kvmetainfo
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"testing"
 
@@ -24,7 +23,6 @@ import (
 )
 
 const (
-	TestAPIKey = "test-api-key"
 	TestEncKey = "test-encryption-key"
 	TestBucket = "test-bucket"
 )
@@ -72,7 +70,7 @@ func TestBucketsBasic(t *testing.T) {
 func TestBucketsReadNewWayWriteOldWay(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		// (Old API) Create new bucket
-		_, err := db.buckets.Put(ctx, TestBucket, storj.AESGCM)
+		_, err := db.buckets.Put(ctx, TestBucket, buckets.Meta{PathEncryptionType: storj.AESGCM})
 		assert.NoError(t, err)
 
 		// (New API) Check that bucket list include the new bucket
@@ -159,6 +157,21 @@ func TestErrNoBucket(t *testing.T) {
 	})
 }
 
+func TestErrBucketNameReserved(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		for _, bucket := range []string{storj.MultipartUploadsPrefix + TestBucket, storj.MultipartPartsPrefix + TestBucket} {
+			_, err := db.CreateBucket(ctx, bucket, nil)
+			assert.True(t, storj.ErrBucketNameReserved.Has(err))
+
+			_, err = db.buckets.Put(ctx, bucket, buckets.Meta{})
+			assert.True(t, storj.ErrBucketNameReserved.Has(err))
+
+			_, err = db.GetBucket(ctx, bucket)
+			assert.True(t, storj.ErrBucketNotFound.Has(err))
+		}
+	})
+}
+
 func TestBucketCreateCipher(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		forAllCiphers(func(cipher storj.Cipher) {
@@ -329,18 +342,12 @@ func runTest(t *testing.T, test func(context.Context, *DB)) {
 }
 
 func newDB(planet *testplanet.Planet) (*DB, error) {
-	// TODO(kaloyan): We should have a better way for configuring the Satellite's API Key
-	err := flag.Set("pointer-db.auth.api-key", TestAPIKey)
-	if err != nil {
-		return nil, err
-	}
-
 	oc, err := planet.Uplinks[0].DialOverlay(planet.Satellites[0])
 	if err != nil {
 		return nil, err
 	}
 
-	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], TestAPIKey)
+	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], planet.Uplinks[0].APIKey[planet.Satellites[0].ID()])
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +368,7 @@ func newDB(planet *testplanet.Planet) (*DB, error) {
 	key := new(storj.Key)
 	copy(key[:], TestEncKey)
 
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM, streams.Options{MaxBufferMem: int(4 * memory.MB)})
 	if err != nil {
 		return nil, err
 	}
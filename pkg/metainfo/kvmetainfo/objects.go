@@ -5,7 +5,6 @@ package kvmetainfo
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -24,20 +23,6 @@ import (
 	"storj.io/storj/storage"
 )
 
-const (
-	// commitedPrefix is prefix where completed object info is stored
-	committedPrefix = "l/"
-)
-
-var defaultRS = storj.RedundancyScheme{
-	Algorithm:      storj.ReedSolomon,
-	RequiredShares: 20,
-	RepairShares:   30,
-	OptimalShares:  40,
-	TotalShares:    50,
-	ShareSize:      1 * memory.KB.Int32(),
-}
-
 var defaultES = storj.EncryptionScheme{
 	Cipher:    storj.AESGCM,
 	BlockSize: 1 * memory.KB.Int32(),
@@ -47,7 +32,7 @@ var defaultES = storj.EncryptionScheme{
 func (db *DB) GetObject(ctx context.Context, bucket string, path storj.Path) (info storj.Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	_, info, err = db.getInfo(ctx, committedPrefix, bucket, path)
+	_, info, err = db.getInfo(ctx, bucket, path, "")
 
 	return info, err
 }
@@ -56,22 +41,52 @@ func (db *DB) GetObject(ctx context.Context, bucket string, path storj.Path) (in
 func (db *DB) GetObjectStream(ctx context.Context, bucket string, path storj.Path) (stream storj.ReadOnlyStream, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	meta, info, err := db.getInfo(ctx, committedPrefix, bucket, path)
+	meta, info, err := db.getInfo(ctx, bucket, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return db.readonlyStream(meta, info)
+}
+
+// GetObjectVersion returns information about a version of an object
+func (db *DB) GetObjectVersion(ctx context.Context, bucket string, path storj.Path, version string) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, info, err = db.getVersionInfo(ctx, bucket, path, version)
+
+	return info, err
+}
+
+// GetObjectVersionStream returns interface for reading the stream of a version of an object
+func (db *DB) GetObjectVersionStream(ctx context.Context, bucket string, path storj.Path, version string) (stream storj.ReadOnlyStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	meta, info, err := db.getVersionInfo(ctx, bucket, path, version)
 	if err != nil {
 		return nil, err
 	}
 
+	return db.readonlyStream(meta, info)
+}
+
+func (db *DB) readonlyStream(meta object, info storj.Object) (storj.ReadOnlyStream, error) {
 	streamKey, err := encryption.DeriveContentKey(meta.fullpath, db.rootKey)
 	if err != nil {
 		return nil, err
 	}
 
-	return &readonlyStream{
+	stream := &readonlyStream{
 		db:            db,
 		info:          info,
 		encryptedPath: meta.encryptedPath,
 		streamKey:     streamKey,
-	}, nil
+	}
+	if info.Archived {
+		stream.version = info.VersionID
+	}
+
+	return stream, nil
 }
 
 // CreateObject creates an uploading object and returns an interface for uploading Object information
@@ -103,11 +118,17 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 	// TODO: autodetect content type from the path extension
 	// if info.ContentType == "" {}
 
+	// the defaults of the bucket apply unless overridden, the zero schemes
+	// leave them to the configuration of the uplink
 	if info.RedundancyScheme.IsZero() {
-		info.RedundancyScheme = defaultRS
+		info.RedundancyScheme = bucketInfo.RedundancyScheme
 	}
 
 	if info.EncryptionScheme.IsZero() {
+		info.EncryptionScheme = bucketInfo.EncryptionScheme
+	}
+
+	if info.EncryptionScheme.IsZero() && !info.RedundancyScheme.IsZero() {
 		info.EncryptionScheme = storj.EncryptionScheme{
 			Cipher:    defaultES.Cipher,
 			BlockSize: info.RedundancyScheme.ShareSize,
@@ -123,13 +144,40 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 // ModifyObject modifies a committed object
 func (db *DB) ModifyObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil, errors.New("not implemented")
+
+	_, info, err := db.getInfo(ctx, bucket, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mutableObject{
+		db:   db,
+		info: info,
+	}, nil
 }
 
 // DeleteObject deletes an object from database
 func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	if bucketInfo.Versioning {
+		if path == "" {
+			return storj.ErrNoPath.New("")
+		}
+
+		// the object is kept as a prior version
+		_, err = db.streams.Archive(ctx, storj.JoinPaths(bucket, path), bucketInfo.PathCipher)
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return err
+	}
+
 	store, err := db.buckets.GetObjectStore(ctx, bucket)
 	if err != nil {
 		return err
@@ -138,16 +186,199 @@ func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path)
 	return store.Delete(ctx, path)
 }
 
+// DeleteObjectVersion permanently deletes a version of an object
+func (db *DB) DeleteObjectVersion(ctx context.Context, bucket string, path storj.Path, version string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, info, err := db.getVersionInfo(ctx, bucket, path, version)
+	if err != nil {
+		return err
+	}
+
+	fullpath := storj.JoinPaths(bucket, path)
+	if info.Archived {
+		err = db.streams.DeleteVersion(ctx, fullpath, info.Bucket.PathCipher, version)
+	} else {
+		err = db.streams.Delete(ctx, fullpath, info.Bucket.PathCipher)
+	}
+	if storage.ErrKeyNotFound.Has(err) {
+		err = storj.ErrObjectNotFound.Wrap(err)
+	}
+	return err
+}
+
+// ListObjectVersions lists the prior versions of an object. The cursor of
+// the options is a version and the prefix is ignored.
+func (db *DB) ListObjectVersions(ctx context.Context, bucket string, path storj.Path, options storj.ListOptions) (list storj.ObjectList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	if path == "" {
+		return storj.ObjectList{}, storj.ErrNoPath.New("")
+	}
+
+	startAfter, endBefore, err := listMarkers(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := db.streams.ListVersions(ctx, storj.JoinPaths(bucket, path), bucketInfo.PathCipher, startAfter, endBefore, options.Limit)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		info := objectFromStreamMeta(bucketInfo, path, false, item.Meta)
+		info.VersionID = item.Path
+		info.Archived = true
+		list.Items = append(list.Items, info)
+	}
+
+	return list, nil
+}
+
+// CopyObject copies an object on the satellite without transferring its data
+func (db *DB) CopyObject(ctx context.Context, srcBucket string, srcPath storj.Path, dstBucket string, dstPath storj.Path) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	srcBucketInfo, err := db.GetBucket(ctx, srcBucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	dstBucketInfo, err := db.GetBucket(ctx, dstBucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	if srcPath == "" || dstPath == "" {
+		return storj.Object{}, storj.ErrNoPath.New("")
+	}
+
+	if srcBucket == dstBucket && srcPath == dstPath {
+		return db.GetObject(ctx, srcBucket, srcPath)
+	}
+
+	_, err = db.streams.Copy(ctx,
+		storj.JoinPaths(srcBucket, srcPath), srcBucketInfo.PathCipher,
+		storj.JoinPaths(dstBucket, dstPath), dstBucketInfo.PathCipher)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return storj.Object{}, err
+	}
+
+	return db.GetObject(ctx, dstBucket, dstPath)
+}
+
+// UpdateObjectMetadata replaces the metadata, content type and expiration of
+// a committed object without transferring its data. The pieces already stored
+// expire as they were uploaded, so an expiration can't be extended or cleared.
+func (db *DB) UpdateObjectMetadata(ctx context.Context, bucket string, path storj.Path, updateInfo *storj.UpdateObject) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	if path == "" {
+		return storj.Object{}, storj.ErrNoPath.New("")
+	}
+
+	if updateInfo == nil {
+		updateInfo = &storj.UpdateObject{}
+	}
+
+	metadata, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: updateInfo.ContentType,
+		UserDefined: updateInfo.Metadata,
+	})
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	_, err = db.streams.UpdateMetadata(ctx, storj.JoinPaths(bucket, path), bucketInfo.PathCipher, metadata, updateInfo.Expires)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return storj.Object{}, err
+	}
+
+	return db.GetObject(ctx, bucket, path)
+}
+
 // ModifyPendingObject creates an interface for updating a partially uploaded object
 func (db *DB) ModifyPendingObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil, errors.New("not implemented")
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, storj.ErrNoPath.New("")
+	}
+
+	meta, err := db.streams.Pending(ctx, storj.JoinPaths(bucket, path), bucketInfo.PathCipher)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return nil, err
+	}
+
+	return &mutableObject{
+		db:      db,
+		info:    objectFromStreamMeta(bucketInfo, path, false, meta),
+		pending: true,
+	}, nil
 }
 
 // ListPendingObjects lists pending objects in bucket based on the ListOptions
 func (db *DB) ListPendingObjects(ctx context.Context, bucket string, options storj.ListOptions) (list storj.ObjectList, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return storj.ObjectList{}, errors.New("not implemented")
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	startAfter, endBefore, err := listMarkers(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := db.streams.ListPending(ctx, storj.JoinPaths(bucket, options.Prefix), startAfter, endBefore, bucketInfo.PathCipher, options.Recursive, options.Limit, meta.All)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, objectFromStreamMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
+	}
+
+	return list, nil
 }
 
 // ListObjects lists objects in bucket based on the ListOptions
@@ -164,7 +395,33 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		return storj.ObjectList{}, err
 	}
 
-	var startAfter, endBefore string
+	startAfter, endBefore, err := listMarkers(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
+	}
+
+	return list, nil
+}
+
+// listMarkers converts the cursor and direction of the list options to
+// startAfter and endBefore markers
+func listMarkers(options storj.ListOptions) (startAfter, endBefore string, err error) {
 	switch options.Direction {
 	case storj.Before:
 		// before lists backwards from cursor, without cursor
@@ -179,7 +436,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		// after lists forwards from cursor, without cursor
 		startAfter = options.Cursor
 	default:
-		return storj.ObjectList{}, errClass.New("invalid direction %d", options.Direction)
+		return "", "", errClass.New("invalid direction %d", options.Direction)
 	}
 
 	// TODO: remove this hack-fix of specifying the last key
@@ -187,23 +444,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		endBefore = "\x7f\x7f\x7f\x7f\x7f\x7f\x7f"
 	}
 
-	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
-	if err != nil {
-		return storj.ObjectList{}, err
-	}
-
-	list = storj.ObjectList{
-		Bucket: bucket,
-		Prefix: options.Prefix,
-		More:   more,
-		Items:  make([]storj.Object, 0, len(items)),
-	}
-
-	for _, item := range items {
-		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
-	}
-
-	return list, nil
+	return startAfter, endBefore, nil
 }
 
 type object struct {
@@ -214,7 +455,25 @@ type object struct {
 	streamMeta      pb.StreamMeta
 }
 
-func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path storj.Path) (obj object, info storj.Object, err error) {
+// getVersionInfo returns information about a prior version of an object, or
+// about the object itself if version is its current version
+func (db *DB) getVersionInfo(ctx context.Context, bucket string, path storj.Path, version string) (obj object, info storj.Object, err error) {
+	obj, info, err = db.getInfo(ctx, bucket, path, version)
+	if !storj.ErrObjectNotFound.Has(err) {
+		return obj, info, err
+	}
+
+	current, currentInfo, currentErr := db.getInfo(ctx, bucket, path, "")
+	if currentErr == nil && currentInfo.VersionID == version {
+		return current, currentInfo, nil
+	}
+
+	return object{}, storj.Object{}, err
+}
+
+// getInfo returns information about an object, or about its prior version
+// if version is not empty
+func (db *DB) getInfo(ctx context.Context, bucket string, path storj.Path, version string) (obj object, info storj.Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	bucketInfo, err := db.GetBucket(ctx, bucket)
@@ -233,7 +492,7 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
-	pointer, _, _, err := db.pointers.Get(ctx, prefix+encryptedPath)
+	pointer, _, _, err := db.pointers.Get(ctx, getLastSegmentPath(encryptedPath, version))
 	if err != nil {
 		if storage.ErrKeyNotFound.Has(err) {
 			err = storj.ErrObjectNotFound.Wrap(err)
@@ -280,10 +539,19 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
+	if version != "" {
+		// archived pointers are created when the version is archived
+		lastSegmentMeta.Modified, err = streams.VersionTime(version)
+		if err != nil {
+			return object{}, storj.Object{}, storj.ErrObjectNotFound.Wrap(err)
+		}
+	}
+
 	info, err = objectStreamFromMeta(bucketInfo, path, lastSegmentMeta, streamInfo, streamMeta, redundancyScheme)
 	if err != nil {
 		return object{}, storj.Object{}, err
 	}
+	info.Archived = version != ""
 
 	return object{
 		fullpath:        fullpath,
@@ -311,6 +579,35 @@ func objectFromMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta ob
 		Stream: storj.Stream{
 			Size:     meta.Size,
 			Checksum: []byte(meta.Checksum),
+			SHA256:   meta.SHA256,
+		},
+	}
+}
+
+func objectFromStreamMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta streams.Meta) storj.Object {
+	serMetaInfo := pb.SerializableMeta{}
+	err := proto.Unmarshal(meta.Data, &serMetaInfo)
+	if err != nil {
+		zap.S().Warnf("Failed deserializing metadata: %v", err)
+	}
+
+	return storj.Object{
+		Version:  0, // TODO:
+		Bucket:   bucket,
+		Path:     path,
+		IsPrefix: isPrefix,
+
+		Metadata: serMetaInfo.UserDefined,
+
+		ContentType: serMetaInfo.ContentType,
+		Created:     meta.Modified, // TODO: use correct field
+		Modified:    meta.Modified, // TODO: use correct field
+		Expires:     meta.Expiration,
+
+		Stream: storj.Stream{
+			Size:     meta.Size,
+			Checksum: meta.Checksum,
+			SHA256:   meta.SHA256,
 		},
 	}
 }
@@ -325,11 +622,18 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		return storj.Object{}, err
 	}
 
+	// the segments of concatenated streams don't have the same size
+	fixedSegmentSize := stream.SegmentsSize
+	if len(stream.SegmentSizes) > 0 {
+		fixedSegmentSize = -1
+	}
+
 	return storj.Object{
-		Version:  0, // TODO:
-		Bucket:   bucket,
-		Path:     path,
-		IsPrefix: false,
+		Version:   0, // TODO:
+		VersionID: streams.VersionID(lastSegment.Modified),
+		Bucket:    bucket,
+		Path:      path,
+		IsPrefix:  false,
 
 		Metadata: serMetaInfo.UserDefined,
 
@@ -339,11 +643,12 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		Expires:     lastSegment.Expiration, // TODO: use correct field
 
 		Stream: storj.Stream{
-			Size: stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
-			// Checksum: []byte(object.Checksum),
+			Size:     streams.StreamSize(&stream),
+			Checksum: stream.Checksum,
+			SHA256:   stream.Sha256Checksum,
 
 			SegmentCount:     stream.NumberOfSegments,
-			FixedSegmentSize: stream.SegmentsSize,
+			FixedSegmentSize: fixedSegmentSize,
 
 			RedundancyScheme: storj.RedundancyScheme{
 				Algorithm:      storj.ReedSolomon,
@@ -379,8 +684,9 @@ func convertTime(ts *timestamp.Timestamp) time.Time {
 }
 
 type mutableObject struct {
-	db   *DB
-	info storj.Object
+	db      *DB
+	info    storj.Object
+	pending bool
 }
 
 func (object *mutableObject) Info() storj.Object { return object.info }
@@ -393,15 +699,35 @@ func (object *mutableObject) CreateStream(ctx context.Context) (storj.MutableStr
 }
 
 func (object *mutableObject) ContinueStream(ctx context.Context) (storj.MutableStream, error) {
-	return nil, errors.New("not implemented")
+	if !object.pending {
+		return nil, errClass.New("object %q has no partially uploaded stream", object.info.Path)
+	}
+
+	return &mutableStream{
+		db:        object.db,
+		info:      object.info,
+		continued: true,
+	}, nil
 }
 
-func (object *mutableObject) DeleteStream(ctx context.Context) error {
-	return errors.New("not implemented")
+func (object *mutableObject) DeleteStream(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path := storj.JoinPaths(object.info.Bucket.Name, object.info.Path)
+	if object.pending {
+		err = object.db.streams.DeletePending(ctx, path, object.info.Bucket.PathCipher)
+	} else {
+		err = object.db.streams.Delete(ctx, path, object.info.Bucket.PathCipher)
+	}
+	if storage.ErrKeyNotFound.Has(err) {
+		err = storj.ErrObjectNotFound.Wrap(err)
+	}
+	return err
 }
 
 func (object *mutableObject) Commit(ctx context.Context) error {
-	_, info, err := object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
+	_, info, err := object.db.getInfo(ctx, object.info.Bucket.Name, object.info.Path, "")
 	object.info = info
+	object.pending = false
 	return err
 }
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -35,21 +36,43 @@ func TestCreateObject(t *testing.T) {
 		BlockSize: 1 * memory.KB.Int32(),
 	}
 
+	bucketRS := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		RequiredShares: 4,
+		RepairShares:   6,
+		OptimalShares:  8,
+		TotalShares:    10,
+		ShareSize:      1 * memory.KB.Int32(),
+	}
+
 	runTest(t, func(ctx context.Context, db *DB) {
 		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
 		if !assert.NoError(t, err) {
 			return
 		}
 
+		defaultsBucket, err := db.CreateBucket(ctx, TestBucket+"-defaults", &storj.Bucket{
+			PathCipher:       storj.AESGCM,
+			RedundancyScheme: bucketRS,
+			EncryptionScheme: customES,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, bucketRS, defaultsBucket.RedundancyScheme)
+		assert.Equal(t, customES, defaultsBucket.EncryptionScheme)
+
 		for i, tt := range []struct {
+			bucket     storj.Bucket
 			create     *storj.CreateObject
 			expectedRS storj.RedundancyScheme
 			expectedES storj.EncryptionScheme
 		}{
 			{
+				// the uplink decides without bucket defaults
 				create:     nil,
-				expectedRS: defaultRS,
-				expectedES: defaultES,
+				expectedRS: storj.RedundancyScheme{},
+				expectedES: storj.EncryptionScheme{},
 			}, {
 				create:     &storj.CreateObject{RedundancyScheme: customRS, EncryptionScheme: customES},
 				expectedRS: customRS,
@@ -60,20 +83,34 @@ func TestCreateObject(t *testing.T) {
 				expectedES: storj.EncryptionScheme{Cipher: defaultES.Cipher, BlockSize: customRS.ShareSize},
 			}, {
 				create:     &storj.CreateObject{EncryptionScheme: customES},
-				expectedRS: defaultRS,
+				expectedRS: storj.RedundancyScheme{},
+				expectedES: customES,
+			}, {
+				bucket:     defaultsBucket,
+				create:     nil,
+				expectedRS: bucketRS,
+				expectedES: customES,
+			}, {
+				bucket:     defaultsBucket,
+				create:     &storj.CreateObject{RedundancyScheme: customRS},
+				expectedRS: customRS,
 				expectedES: customES,
 			},
 		} {
 			errTag := fmt.Sprintf("%d. %+v", i, tt)
 
-			obj, err := db.CreateObject(ctx, bucket.Name, TestFile, tt.create)
+			if tt.bucket.Name == "" {
+				tt.bucket = bucket
+			}
+
+			obj, err := db.CreateObject(ctx, tt.bucket.Name, TestFile, tt.create)
 			if !assert.NoError(t, err) {
 				return
 			}
 
 			info := obj.Info()
 
-			assert.Equal(t, TestBucket, info.Bucket.Name, errTag)
+			assert.Equal(t, tt.bucket.Name, info.Bucket.Name, errTag)
 			assert.Equal(t, storj.AESGCM, info.Bucket.PathCipher, errTag)
 			assert.Equal(t, TestFile, info.Path, errTag)
 			assert.EqualValues(t, 0, info.Size, errTag)
@@ -278,6 +315,252 @@ func TestDeleteObject(t *testing.T) {
 	})
 }
 
+func TestCopyObject(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		data := make([]byte, 32*memory.KB)
+		_, err := rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "small-file", []byte("test"))
+		upload(ctx, t, db, bucket, "large-file", data)
+
+		_, err = db.CopyObject(ctx, bucket.Name, "", bucket.Name, "copy")
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.CopyObject(ctx, "non-existing-bucket", "small-file", bucket.Name, "copy")
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		_, err = db.CopyObject(ctx, bucket.Name, "non-existing-file", bucket.Name, "copy")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		// copying onto itself must leave the object intact
+		object, err := db.CopyObject(ctx, bucket.Name, "small-file", bucket.Name, "small-file")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "small-file", object.Path)
+		}
+		assertStream(ctx, t, db, bucket, "small-file", 4, []byte("test"))
+
+		object, err = db.CopyObject(ctx, bucket.Name, "small-file", bucket.Name, "small-copy")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "small-copy", object.Path)
+			assert.EqualValues(t, 4, object.Size)
+		}
+
+		object, err = db.CopyObject(ctx, bucket.Name, "large-file", bucket.Name, "large-copy")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "large-copy", object.Path)
+			assert.EqualValues(t, 32*memory.KB, object.Size)
+		}
+
+		assertStream(ctx, t, db, bucket, "small-copy", 4, []byte("test"))
+		assertStream(ctx, t, db, bucket, "large-copy", int64(32*memory.KB), data)
+
+		// the pieces are shared, so deleting the source must not affect the copy
+		err = db.DeleteObject(ctx, bucket.Name, "large-file")
+		assert.NoError(t, err)
+
+		assertStream(ctx, t, db, bucket, "large-copy", int64(32*memory.KB), data)
+	})
+}
+
+func TestUpdateObjectMetadata(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		data := make([]byte, 32*memory.KB)
+		_, err := rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "large-file", data)
+
+		_, err = db.UpdateObjectMetadata(ctx, bucket.Name, "", nil)
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.UpdateObjectMetadata(ctx, "non-existing-bucket", "large-file", nil)
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		_, err = db.UpdateObjectMetadata(ctx, bucket.Name, "non-existing-file", nil)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		expires := time.Now().Add(time.Hour).UTC().Round(time.Second)
+		updateInfo := &storj.UpdateObject{
+			Metadata:    map[string]string{"key": "value"},
+			ContentType: "text/plain",
+			Expires:     expires,
+		}
+
+		object, err := db.UpdateObjectMetadata(ctx, bucket.Name, "large-file", updateInfo)
+		if assert.NoError(t, err) {
+			assert.Equal(t, updateInfo.Metadata, object.Metadata)
+			assert.Equal(t, updateInfo.ContentType, object.ContentType)
+			assert.True(t, expires.Equal(object.Expires))
+			assert.EqualValues(t, 32*memory.KB, object.Size)
+		}
+
+		object, err = db.GetObject(ctx, bucket.Name, "large-file")
+		if assert.NoError(t, err) {
+			assert.Equal(t, updateInfo.Metadata, object.Metadata)
+			assert.Equal(t, updateInfo.ContentType, object.ContentType)
+		}
+
+		// the pieces expire with the expiration they were stored with
+		_, err = db.UpdateObjectMetadata(ctx, bucket.Name, "large-file", &storj.UpdateObject{Expires: expires.Add(time.Hour)})
+		assert.True(t, storj.ErrExpiration.Has(err))
+		_, err = db.UpdateObjectMetadata(ctx, bucket.Name, "large-file", &storj.UpdateObject{})
+		assert.True(t, storj.ErrExpiration.Has(err))
+
+		object, err = db.GetObject(ctx, bucket.Name, "large-file")
+		if assert.NoError(t, err) {
+			assert.True(t, expires.Equal(object.Expires))
+			assert.Equal(t, updateInfo.Metadata, object.Metadata)
+		}
+
+		// the data is left intact
+		assertStream(ctx, t, db, bucket, "large-file", int64(32*memory.KB), data)
+	})
+}
+
+func TestObjectVersions(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, bucket.Versioning)
+
+		upload(ctx, t, db, bucket, TestFile, []byte("first"))
+		first, err := db.GetObject(ctx, bucket.Name, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, TestFile, []byte("second"))
+		assertStream(ctx, t, db, bucket, TestFile, 6, []byte("second"))
+
+		// a failed overwrite neither replaces nor archives the object
+		obj, err := db.CreateObject(ctx, bucket.Name, TestFile, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		str, err := obj.CreateStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		failed := stream.NewUpload(canceled, str, db.streams)
+		_, _ = failed.Write([]byte("third"))
+		assert.Error(t, failed.Close())
+		assertStream(ctx, t, db, bucket, TestFile, 6, []byte("second"))
+
+		_, err = db.ListObjectVersions(ctx, bucket.Name, "", storj.ListOptions{Direction: storj.After})
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		list, err := db.ListObjectVersions(ctx, bucket.Name, TestFile, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Items)) {
+			assert.False(t, list.More)
+			assert.Equal(t, first.VersionID, list.Items[0].VersionID)
+			assert.True(t, list.Items[0].Archived)
+			assert.EqualValues(t, 5, list.Items[0].Size)
+		}
+
+		version, err := db.GetObjectVersion(ctx, bucket.Name, TestFile, first.VersionID)
+		if assert.NoError(t, err) {
+			assert.True(t, version.Archived)
+			assert.EqualValues(t, 5, version.Size)
+			assert.True(t, first.Modified.Equal(version.Modified))
+		}
+
+		readOnly, err := db.GetObjectVersionStream(ctx, bucket.Name, TestFile, first.VersionID)
+		if assert.NoError(t, err) {
+			download := stream.NewDownload(ctx, readOnly, db.streams)
+			data, err := ioutil.ReadAll(download)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("first"), data)
+			assert.NoError(t, download.Close())
+		}
+
+		_, err = db.GetObjectVersion(ctx, bucket.Name, TestFile, "non-existing-version")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		// deleting the object keeps it as a prior version
+		err = db.DeleteObject(ctx, bucket.Name, TestFile)
+		assert.NoError(t, err)
+
+		_, err = db.GetObject(ctx, bucket.Name, TestFile)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		list, err = db.ListObjectVersions(ctx, bucket.Name, TestFile, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, len(list.Items))
+		}
+
+		err = db.DeleteObjectVersion(ctx, bucket.Name, TestFile, first.VersionID)
+		assert.NoError(t, err)
+
+		_, err = db.GetObjectVersion(ctx, bucket.Name, TestFile, first.VersionID)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		list, err = db.ListObjectVersions(ctx, bucket.Name, TestFile, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, len(list.Items))
+		}
+	})
+}
+
+func TestPendingObjects(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, TestFile, []byte("test"))
+
+		_, err = db.ModifyPendingObject(ctx, bucket.Name, "")
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.ModifyPendingObject(ctx, "non-existing-bucket", TestFile)
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		// the upload completed, so there is nothing to continue
+		_, err = db.ModifyPendingObject(ctx, bucket.Name, TestFile)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		list, err := db.ListPendingObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Items)
+			assert.False(t, list.More)
+		}
+
+		object, err := db.ModifyObject(ctx, bucket.Name, TestFile)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 4, object.Info().Size)
+
+			_, err = object.ContinueStream(ctx)
+			assert.Error(t, err)
+		}
+	})
+}
+
 func TestListObjectsEmpty(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
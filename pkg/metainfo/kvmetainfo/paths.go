@@ -36,3 +36,21 @@ func keyAfter(cursor string) string {
 func getSegmentPath(encryptedPath storj.Path, segNum int64) storj.Path {
 	return storj.JoinPaths(fmt.Sprintf("s%d", segNum), encryptedPath)
 }
+
+// getVersionSegmentPath returns the unique path for a particular segment of
+// a prior version of an object, or of the object if version is empty
+func getVersionSegmentPath(encryptedPath storj.Path, version string, segNum int64) storj.Path {
+	if version == "" {
+		return getSegmentPath(encryptedPath, segNum)
+	}
+	return storj.JoinPaths(fmt.Sprintf("v%d", segNum), encryptedPath, version)
+}
+
+// getLastSegmentPath returns the path for the last segment of a prior
+// version of an object, or of the object if version is empty
+func getLastSegmentPath(encryptedPath storj.Path, version string) storj.Path {
+	if version == "" {
+		return storj.JoinPaths("l", encryptedPath)
+	}
+	return storj.JoinPaths("v", encryptedPath, version)
+}
@@ -21,6 +21,7 @@ type readonlyStream struct {
 
 	info          storj.Object
 	encryptedPath storj.Path
+	version       string     // set for prior versions of an object
 	streamKey     *storj.Key // lazySegmentReader derivedKey
 }
 
@@ -45,9 +46,10 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 	}
 
 	var segmentPath storj.Path
+	var contentNonce []byte // set for the segments of concatenated streams
 	isLastSegment := segment.Index+1 == stream.info.SegmentCount
 	if !isLastSegment {
-		segmentPath = getSegmentPath(stream.encryptedPath, index)
+		segmentPath = getVersionSegmentPath(stream.encryptedPath, stream.version, index)
 		_, meta, err := stream.db.segments.Get(ctx, segmentPath)
 		if err != nil {
 			return segment, err
@@ -62,8 +64,9 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 		segment.Size = stream.info.FixedSegmentSize
 		copy(segment.EncryptedKeyNonce[:], segmentMeta.KeyNonce)
 		segment.EncryptedKey = segmentMeta.EncryptedKey
+		contentNonce = segmentMeta.ContentNonce
 	} else {
-		segmentPath = storj.JoinPaths("l", stream.encryptedPath)
+		segmentPath = getLastSegmentPath(stream.encryptedPath, stream.version)
 		segment.Size = stream.info.LastSegment.Size
 		segment.EncryptedKeyNonce = stream.info.LastSegment.EncryptedKeyNonce
 		segment.EncryptedKey = stream.info.LastSegment.EncryptedKey
@@ -74,15 +77,28 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 		return segment, err
 	}
 
-	nonce := new(storj.Nonce)
-	_, err = encryption.Increment(nonce, index+1)
+	pointer, _, _, err := stream.db.pointers.Get(ctx, segmentPath)
 	if err != nil {
 		return segment, err
 	}
 
-	pointer, _, _, err := stream.db.pointers.Get(ctx, segmentPath)
-	if err != nil {
-		return segment, err
+	if isLastSegment {
+		streamMeta := pb.StreamMeta{}
+		err = proto.Unmarshal(pointer.Metadata, &streamMeta)
+		if err != nil {
+			return segment, err
+		}
+		contentNonce = streamMeta.GetLastSegmentMeta().GetContentNonce()
+	}
+
+	nonce := new(storj.Nonce)
+	if len(contentNonce) > 0 {
+		copy(nonce[:], contentNonce)
+	} else {
+		_, err = encryption.Increment(nonce, index+1)
+		if err != nil {
+			return segment, err
+		}
 	}
 
 	if pointer.GetType() == pb.Pointer_INLINE {
@@ -128,12 +144,15 @@ func (stream *readonlyStream) Segments(ctx context.Context, index int64, limit i
 }
 
 type mutableStream struct {
-	db   *DB
-	info storj.Object
+	db        *DB
+	info      storj.Object
+	continued bool
 }
 
 func (stream *mutableStream) Info() storj.Object { return stream.info }
 
+func (stream *mutableStream) Continued() bool { return stream.continued }
+
 func (stream *mutableStream) AddSegments(ctx context.Context, segments ...storj.Segment) error {
 	return errors.New("not implemented")
 }
@@ -0,0 +1,103 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3signer"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// backendRetryInterval is the interval of the checks of a backend that
+// doesn't accept connections yet
+const backendRetryInterval = 100 * time.Millisecond
+
+// backendCheck verifies that the local address minio is started on is served
+// by the gateway, and not by another server that took it in the meantime.
+// Minio can't be given a listener, so the address can only be reserved until
+// minio listens on it.
+//
+// The check requests a random bucket, which only the gateway layer of this
+// process can see.
+type backendCheck struct {
+	minio.Gateway
+	bucket string
+
+	once    sync.Once
+	checked chan struct{}
+}
+
+// newBackendCheck wraps gateway to recognize the requests of the check
+func newBackendCheck(gateway minio.Gateway) (*backendCheck, error) {
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &backendCheck{
+		Gateway: gateway,
+		bucket:  "backend-check-" + hex.EncodeToString(nonce[:]),
+		checked: make(chan struct{}),
+	}, nil
+}
+
+// NewGatewayLayer implements minio.Gateway
+func (check *backendCheck) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
+	layer, err := check.Gateway.NewGatewayLayer(creds)
+	return &backendCheckLayer{ObjectLayer: layer, check: check}, err
+}
+
+// Verify requests the bucket of the check from address until it's served,
+// and returns an error if it's served by another server
+func (check *backendCheck) Verify(ctx context.Context, address, accessKey, secretKey string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	client := &http.Client{Timeout: time.Minute}
+	for {
+		req, err := http.NewRequest(http.MethodHead, "http://"+address+"/"+check.bucket, nil)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		req = s3signer.SignV4(*req, accessKey, secretKey, "", "us-east-1")
+
+		resp, err := client.Do(req.WithContext(ctx))
+		if err == nil {
+			_ = resp.Body.Close()
+
+			select {
+			case <-check.checked:
+				return nil
+			default:
+				return Error.New("%s is served by another server than the gateway", address)
+			}
+		}
+
+		// minio doesn't listen yet
+		select {
+		case <-ctx.Done():
+			return Error.Wrap(ctx.Err())
+		case <-time.After(backendRetryInterval):
+		}
+	}
+}
+
+type backendCheckLayer struct {
+	minio.ObjectLayer
+	check *backendCheck
+}
+
+// GetBucketInfo implements minio.ObjectLayer
+func (layer *backendCheckLayer) GetBucketInfo(ctx context.Context, bucket string) (minio.BucketInfo, error) {
+	if bucket == layer.check.bucket {
+		layer.check.once.Do(func() { close(layer.check.checked) })
+		return minio.BucketInfo{}, minio.BucketNotFound{Bucket: bucket}
+	}
+	return layer.ObjectLayer.GetBucketInfo(ctx, bucket)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+)
+
+func TestBackendCheck(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	check, err := newBackendCheck(&nopGateway{})
+	require.NoError(t, err)
+
+	layer, err := check.NewGatewayLayer(auth.Credentials{})
+	require.NoError(t, err)
+
+	// minio passes the requested bucket to the gateway layer
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := layer.GetBucketInfo(r.Context(), strings.TrimPrefix(r.URL.Path, "/"))
+		assert.Equal(t, minio.BucketNotFound{Bucket: check.bucket}, err)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer gateway.Close()
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer other.Close()
+
+	err = check.Verify(ctx, strings.TrimPrefix(other.URL, "http://"), "access", "secret")
+	assert.True(t, Error.Has(err))
+
+	err = check.Verify(ctx, strings.TrimPrefix(gateway.URL, "http://"), "access", "secret")
+	assert.NoError(t, err)
+}
+
+// nopGateway is a minio.Gateway without gateway layer
+type nopGateway struct {
+	minio.Gateway
+}
+
+func (gateway *nopGateway) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
+	return nil, nil
+}
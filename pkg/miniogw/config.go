@@ -6,6 +6,10 @@ package miniogw
 import (
 	"context"
 	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
 	"os"
 
 	"github.com/minio/cli"
@@ -62,9 +66,13 @@ type ClientConfig struct {
 	OverlayAddr   string `help:"Address to contact overlay server through"`
 	PointerDBAddr string `help:"Address to contact pointerdb server through"`
 
-	APIKey        string      `help:"API Key (TODO: this needs to change to macaroons somehow)"`
-	MaxInlineSize memory.Size `help:"max inline segment size in bytes" default:"4K"`
-	SegmentSize   memory.Size `help:"the size of a segment in bytes" default:"64M"`
+	APIKey           string      `help:"API Key (TODO: this needs to change to macaroons somehow)"`
+	MaxInlineSize    memory.Size `help:"max inline segment size in bytes" default:"4K"`
+	SegmentSize      memory.Size `help:"the size of a segment in bytes" default:"64M"`
+	SegmentsInFlight int         `help:"number of segments of an object to upload or download in parallel, sharing the maximum buffer memory" default:"1"`
+	Compression      int         `help:"Type of compression to use for the content of new objects (0=None, 1=Gzip)" default:"0"`
+	Checksum         int         `help:"Type of checksum of the content of new objects, verified on downloads (0=None, 1=MD5, 2=SHA-256, 3=MD5 and SHA-256)" default:"3"`
+	Dedup            bool        `help:"deduplicate the identical segments of new objects within the project, encrypting them with keys derived from their content" default:"false"`
 }
 
 // ServerConfig determines how minio listens for requests
@@ -92,11 +100,23 @@ func (c Config) Run(ctx context.Context) (err error) {
 		return err
 	}
 
+	// minio listens on a local address behind the handler of the versioning
+	// requests, which listens on the configured address once the gateway is
+	// verified to serve the local address
+	minioAddress, err := freeLocalAddress()
+	if err != nil {
+		return err
+	}
+
 	err = minio.RegisterGatewayCommand(cli.Command{
 		Name:  "storj",
 		Usage: "Storj",
 		Action: func(cliCtx *cli.Context) error {
-			return c.action(ctx, cliCtx, identity)
+			err := c.action(ctx, cliCtx, identity, minioAddress)
+			if err != nil {
+				zap.L().Error("gateway stopped", zap.Error(err))
+			}
+			return err
 		},
 		HideHelpCommand: true,
 	})
@@ -115,11 +135,11 @@ func (c Config) Run(ctx context.Context) (err error) {
 	}
 
 	minio.Main([]string{"storj", "gateway", "storj",
-		"--address", c.Server.Address, "--config-dir", c.Minio.Dir, "--quiet"})
+		"--address", minioAddress, "--config-dir", c.Minio.Dir, "--quiet"})
 	return Error.New("unexpected minio exit")
 }
 
-func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *identity.FullIdentity) (err error) {
+func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *identity.FullIdentity, minioAddress string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	gw, err := c.NewGateway(ctx, identity)
@@ -127,8 +147,39 @@ func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *ident
 		return err
 	}
 
-	minio.StartGateway(cliCtx, Logging(gw, zap.L()))
-	return Error.New("unexpected minio exit")
+	check, err := newBackendCheck(Logging(gw, zap.L()))
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", c.Server.Address)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = listener.Close() }()
+
+	// minio exits the process if it fails to start
+	go minio.StartGateway(cliCtx, check)
+
+	err = check.Verify(ctx, minioAddress, c.Minio.AccessKey, c.Minio.SecretKey)
+	if err != nil {
+		return err
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: minioAddress})
+	handler := NewVersionsHandler(zap.L(), gw.(*Gateway), c.Minio.AccessKey, c.Minio.SecretKey, proxy)
+	return Error.Wrap(http.Serve(listener, handler))
+}
+
+// freeLocalAddress returns a local address with a free port, which another
+// server may take before minio listens on it
+func freeLocalAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	address := listener.Addr().String()
+	return address, listener.Close()
 }
 
 // GetMetainfo returns an implementation of storj.Metainfo
@@ -156,7 +207,12 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 		return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
 	}
 
-	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem.Int())
+	if c.Client.SegmentsInFlight <= 0 {
+		return nil, nil, Error.New("segments in flight must be larger than 0")
+	}
+
+	// every segment in flight has its own read buffers
+	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem.Int()/c.Client.SegmentsInFlight)
 	fc, err := infectious.NewFEC(c.RS.MinThreshold, c.RS.MaxThreshold)
 	if err != nil {
 		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
@@ -173,10 +229,16 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 		return nil, nil, err
 	}
 
-	key := new(storj.Key)
-	copy(key[:], c.Enc.Key)
+	key := c.GetRootKey()
 
-	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize.Int64(), key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType))
+	// the segments buffered by parallel uploads share the maximum buffer memory
+	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize.Int64(), key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType), streams.Options{
+		Compression:      storj.Compression(c.Client.Compression),
+		Checksum:         storj.ChecksumType(c.Client.Checksum),
+		Dedup:            c.Client.Dedup,
+		SegmentsInFlight: c.Client.SegmentsInFlight,
+		MaxBufferMem:     c.RS.MaxBufferMem.Int(),
+	})
 	if err != nil {
 		return nil, nil, Error.New("failed to create stream store: %v", err)
 	}
@@ -186,7 +248,7 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 	return kvmetainfo.New(buckets, streams, segments, pdb, key), streams, nil
 }
 
-// GetRedundancyScheme returns the configured redundancy scheme for new uploads
+// GetRedundancyScheme returns the configured redundancy scheme for new buckets
 func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 	return storj.RedundancyScheme{
 		Algorithm:      storj.ReedSolomon,
@@ -194,10 +256,18 @@ func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 		RepairShares:   int16(c.RS.RepairThreshold),
 		OptimalShares:  int16(c.RS.SuccessThreshold),
 		TotalShares:    int16(c.RS.MaxThreshold),
+		ShareSize:      c.RS.ErasureShareSize.Int32(),
 	}
 }
 
-// GetEncryptionScheme returns the configured encryption scheme for new uploads
+// GetRootKey returns the configured root key for encrypting the data
+func (c Config) GetRootKey() *storj.Key {
+	key := new(storj.Key)
+	copy(key[:], c.Enc.Key)
+	return key
+}
+
+// GetEncryptionScheme returns the configured encryption scheme for new buckets
 func (c Config) GetEncryptionScheme() storj.EncryptionScheme {
 	return storj.EncryptionScheme{
 		Cipher:    storj.Cipher(c.Enc.DataType),
@@ -36,7 +36,6 @@ func NewStorjGateway(metainfo storj.Metainfo, streams streams.Store, pathCipher
 		pathCipher: pathCipher,
 		encryption: encryption,
 		redundancy: redundancy,
-		multipart:  NewMultipartUploads(),
 	}
 }
 
@@ -47,7 +46,6 @@ type Gateway struct {
 	pathCipher storj.Cipher
 	encryption storj.EncryptionScheme
 	redundancy storj.RedundancyScheme
-	multipart  *MultipartUploads
 }
 
 // Name implements cmd.Gateway
@@ -115,6 +113,12 @@ func (layer *gatewayLayer) GetObject(ctx context.Context, bucket, object string,
 		return convertError(err, bucket, object)
 	}
 
+	return layer.getObject(ctx, readOnlyStream, startOffset, length, writer)
+}
+
+func (layer *gatewayLayer) getObject(ctx context.Context, readOnlyStream storj.ReadOnlyStream, startOffset int64, length int64, writer io.Writer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	if startOffset < 0 || length < -1 || startOffset+length > readOnlyStream.Info().Size {
 		return minio.InvalidRange{
 			OffsetBegin:  startOffset,
@@ -148,14 +152,16 @@ func (layer *gatewayLayer) GetObjectInfo(ctx context.Context, bucket, object str
 		return minio.ObjectInfo{}, convertError(err, bucket, object)
 	}
 
+	etag, userDefined := objectETag(obj.Checksum, obj.Metadata)
+
 	return minio.ObjectInfo{
 		Name:        object,
 		Bucket:      bucket,
 		ModTime:     obj.Modified,
 		Size:        obj.Size,
-		ETag:        hex.EncodeToString(obj.Checksum),
+		ETag:        etag,
 		ContentType: obj.ContentType,
-		UserDefined: obj.Metadata,
+		UserDefined: userDefined,
 	}, err
 }
 
@@ -218,15 +224,16 @@ func (layer *gatewayLayer) ListObjects(ctx context.Context, bucket, prefix, mark
 				prefixes = append(prefixes, path)
 				continue
 			}
+			etag, userDefined := objectETag(item.Checksum, item.Metadata)
 			objects = append(objects, minio.ObjectInfo{
 				Bucket:      bucket,
 				IsDir:       false,
 				Name:        path,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        etag,
 				ContentType: item.ContentType,
-				UserDefined: item.Metadata,
+				UserDefined: userDefined,
 			})
 		}
 		startAfter = list.Items[len(list.Items)-1].Path
@@ -287,15 +294,16 @@ func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucket, prefix, co
 				prefixes = append(prefixes, path)
 				continue
 			}
+			etag, userDefined := objectETag(item.Checksum, item.Metadata)
 			objects = append(objects, minio.ObjectInfo{
 				Bucket:      bucket,
 				IsDir:       false,
 				Name:        path,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        etag,
 				ContentType: item.ContentType,
-				UserDefined: item.Metadata,
+				UserDefined: userDefined,
 			})
 		}
 
@@ -333,7 +341,11 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucket st
 		return convertError(err, bucket, "")
 	}
 
-	_, err = layer.gateway.metainfo.CreateBucket(ctx, bucket, &storj.Bucket{PathCipher: layer.gateway.pathCipher})
+	_, err = layer.gateway.metainfo.CreateBucket(ctx, bucket, &storj.Bucket{
+		PathCipher:       layer.gateway.pathCipher,
+		RedundancyScheme: layer.gateway.redundancy,
+		EncryptionScheme: layer.gateway.encryption,
+	})
 
 	return err
 }
@@ -341,24 +353,57 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucket st
 func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo minio.ObjectInfo) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	readOnlyStream, err := layer.gateway.metainfo.GetObjectStream(ctx, srcBucket, srcObject)
+	var info storj.Object
+	if srcBucket == destBucket && srcObject == destObject {
+		// copying an object onto itself replaces its metadata
+		info, err = layer.replaceMetadata(ctx, srcBucket, srcObject, srcInfo.UserDefined)
+	} else {
+		info, err = layer.gateway.metainfo.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject)
+	}
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, srcBucket, srcObject)
 	}
 
-	download := stream.NewDownload(ctx, readOnlyStream, layer.gateway.streams)
-	defer func() { err = errs.Combine(err, download.Close()) }()
+	etag, userDefined := objectETag(info.Checksum, info.Metadata)
 
-	info := readOnlyStream.Info()
-	createInfo := storj.CreateObject{
-		ContentType:      info.ContentType,
-		Expires:          info.Expires,
-		Metadata:         info.Metadata,
-		RedundancyScheme: info.RedundancyScheme,
-		EncryptionScheme: info.EncryptionScheme,
+	return minio.ObjectInfo{
+		Name:        destObject,
+		Bucket:      destBucket,
+		ModTime:     info.Modified,
+		Size:        info.Size,
+		ETag:        etag,
+		ContentType: info.ContentType,
+		UserDefined: userDefined,
+	}, nil
+}
+
+// replaceMetadata replaces the metadata of an object with the user defined
+// metadata of a copy request, keeping the ETag of a multipart upload
+func (layer *gatewayLayer) replaceMetadata(ctx context.Context, bucket, object string, userDefined map[string]string) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	info, err = layer.gateway.metainfo.GetObject(ctx, bucket, object)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	metadata := make(map[string]string, len(userDefined)+1)
+	for key, value := range userDefined {
+		metadata[key] = value
+	}
+
+	contentType := metadata["content-type"]
+	delete(metadata, "content-type")
+
+	if etag, ok := info.Metadata[multipartETagKey]; ok {
+		metadata[multipartETagKey] = etag
 	}
 
-	return layer.putObject(ctx, destBucket, destObject, download, &createInfo)
+	return layer.gateway.metainfo.UpdateObjectMetadata(ctx, bucket, object, &storj.UpdateObject{
+		Metadata:    metadata,
+		ContentType: contentType,
+		Expires:     info.Expires,
+	})
 }
 
 func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string, reader io.Reader, createInfo *storj.CreateObject) (objInfo minio.ObjectInfo, err error) {
@@ -380,18 +425,37 @@ func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string,
 	}
 
 	info := mutableObject.Info()
+	etag, userDefined := objectETag(info.Checksum, info.Metadata)
 
 	return minio.ObjectInfo{
 		Name:        object,
 		Bucket:      bucket,
 		ModTime:     info.Modified,
 		Size:        info.Size,
-		ETag:        hex.EncodeToString(info.Checksum),
+		ETag:        etag,
 		ContentType: info.ContentType,
-		UserDefined: info.Metadata,
+		UserDefined: userDefined,
 	}, nil
 }
 
+// objectETag returns the ETag of an object with checksum and metadata, and
+// the metadata without the reserved ETag key. The ETag of an object completed
+// from a multipart upload is the one of the upload.
+func objectETag(checksum []byte, metadata map[string]string) (etag string, userDefined map[string]string) {
+	etag, ok := metadata[multipartETagKey]
+	if !ok {
+		return hex.EncodeToString(checksum), metadata
+	}
+
+	userDefined = make(map[string]string, len(metadata)-1)
+	for key, value := range metadata {
+		if key != multipartETagKey {
+			userDefined[key] = value
+		}
+	}
+	return etag, userDefined
+}
+
 func upload(ctx context.Context, streams streams.Store, mutableObject storj.MutableObject, reader io.Reader) error {
 	mutableStream, err := mutableObject.CreateStream(ctx)
 	if err != nil {
@@ -412,10 +476,8 @@ func (layer *gatewayLayer) PutObject(ctx context.Context, bucket, object string,
 	delete(metadata, "content-type")
 
 	createInfo := storj.CreateObject{
-		ContentType:      contentType,
-		Metadata:         metadata,
-		RedundancyScheme: layer.gateway.redundancy,
-		EncryptionScheme: layer.gateway.encryption,
+		ContentType: contentType,
+		Metadata:    metadata,
 	}
 
 	return layer.putObject(ctx, bucket, object, data, &createInfo)
@@ -431,7 +493,7 @@ func (layer *gatewayLayer) StorageInfo(context.Context) minio.StorageInfo {
 }
 
 func convertError(err error, bucket, object string) error {
-	if storj.ErrNoBucket.Has(err) {
+	if storj.ErrNoBucket.Has(err) || storj.ErrBucketNameReserved.Has(err) {
 		return minio.BucketNameInvalid{Bucket: bucket}
 	}
 
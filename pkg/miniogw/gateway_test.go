@@ -6,8 +6,8 @@ package miniogw
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/hex"
-	"flag"
 	"fmt"
 	"strings"
 	"testing"
@@ -33,7 +33,6 @@ import (
 )
 
 const (
-	TestAPIKey = "test-api-key"
 	TestEncKey = "test-encryption-key"
 	TestBucket = "test-bucket"
 	TestFile   = "test-file"
@@ -198,7 +197,7 @@ func TestPutObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, time.Since(info.ModTime) < 1*time.Second)
 			assert.Equal(t, data.Size(), info.Size)
-			// assert.Equal(t, data.SHA256HexString(), info.ETag) TODO: when we start calculating checksums
+			assert.Equal(t, data.MD5HexString(), info.ETag)
 			assert.Equal(t, serMetaInfo.ContentType, info.ContentType)
 			assert.Equal(t, serMetaInfo.UserDefined, info.UserDefined)
 		}
@@ -393,6 +392,24 @@ func TestCopyObject(t *testing.T) {
 			assert.Equal(t, info.ContentType, obj.ContentType)
 			assert.Equal(t, info.UserDefined, obj.Metadata)
 		}
+
+		// Copy the object onto itself to replace its metadata
+		srcInfo.UserDefined = map[string]string{"content-type": "text/html", "key3": "value3"}
+		info, err = layer.CopyObject(ctx, DestBucket, DestFile, DestBucket, DestFile, srcInfo)
+		if assert.NoError(t, err) {
+			assert.Equal(t, DestFile, info.Name)
+			assert.Equal(t, "text/html", info.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, info.UserDefined)
+			assert.Equal(t, hex.EncodeToString(obj.Checksum), info.ETag)
+		}
+
+		// Check that only the metadata is replaced using the Metainfo API
+		obj, err = metainfo.GetObject(ctx, DestBucket, DestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "text/html", obj.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, obj.Metadata)
+			assert.Equal(t, int64(4), obj.Size)
+		}
 	})
 }
 
@@ -452,6 +469,137 @@ func TestListObjectsV2(t *testing.T) {
 	})
 }
 
+func TestMultipartUpload(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Check the error when starting an upload to a non-existing bucket
+		_, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, nil)
+		assert.Equal(t, minio.BucketNotFound{Bucket: TestBucket}, err)
+
+		// Create the bucket using the Metainfo API
+		_, err = metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		metadata := map[string]string{"content-type": "text/plain", "key1": "value1"}
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, metadata)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Check the error when uploading a part of a non-existing upload
+		_, err = layer.PutObjectPart(ctx, TestBucket, TestFile, "non-existing", 1, newPartReader(t, "part"))
+		assert.Equal(t, minio.InvalidUploadID{UploadID: "non-existing"}, err)
+
+		// Upload the parts out of order
+		part2, err := layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 2, newPartReader(t, "second"))
+		assert.NoError(t, err)
+		part1, err := layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 1, newPartReader(t, "first"))
+		assert.NoError(t, err)
+
+		uploads, err := layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 10)
+		if assert.NoError(t, err) && assert.Len(t, uploads.Uploads, 1) {
+			assert.Equal(t, TestFile, uploads.Uploads[0].Object)
+			assert.Equal(t, uploadID, uploads.Uploads[0].UploadID)
+		}
+
+		// Check listing the uploads by prefix and with a limit
+		nestedID, err := layer.NewMultipartUpload(ctx, TestBucket, "dir/"+TestFile, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		uploads, err = layer.ListMultipartUploads(ctx, TestBucket, "dir/", "", "", "", 10)
+		if assert.NoError(t, err) && assert.Len(t, uploads.Uploads, 1) {
+			assert.Equal(t, "dir/"+TestFile, uploads.Uploads[0].Object)
+			assert.Equal(t, nestedID, uploads.Uploads[0].UploadID)
+		}
+
+		uploads, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "/", 10)
+		if assert.NoError(t, err) {
+			assert.Len(t, uploads.Uploads, 1)
+			assert.Equal(t, []string{"dir/"}, uploads.CommonPrefixes)
+		}
+
+		uploads, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 1)
+		if assert.NoError(t, err) {
+			assert.Len(t, uploads.Uploads, 1)
+			assert.True(t, uploads.IsTruncated)
+		}
+
+		err = layer.AbortMultipartUpload(ctx, TestBucket, "dir/"+TestFile, nestedID)
+		assert.NoError(t, err)
+
+		parts, err := layer.ListObjectParts(ctx, TestBucket, TestFile, uploadID, 0, 10)
+		if assert.NoError(t, err) && assert.Len(t, parts.Parts, 2) {
+			assert.Equal(t, 1, parts.Parts[0].PartNumber)
+			assert.EqualValues(t, len("first"), parts.Parts[0].Size)
+			assert.Equal(t, 2, parts.Parts[1].PartNumber)
+			assert.EqualValues(t, len("second"), parts.Parts[1].Size)
+			assert.Equal(t, map[string]string{"key1": "value1"}, parts.UserDefined)
+		}
+
+		// The ETags of the parts are their MD5
+		first, second := md5.Sum([]byte("first")), md5.Sum([]byte("second"))
+		assert.Equal(t, hex.EncodeToString(first[:]), part1.ETag)
+		assert.Equal(t, hex.EncodeToString(second[:]), part2.ETag)
+
+		info, err := layer.CompleteMultipartUpload(ctx, TestBucket, TestFile, uploadID, []minio.CompletePart{
+			{PartNumber: 1, ETag: part1.ETag},
+			{PartNumber: 2, ETag: part2.ETag},
+		})
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, len("firstsecond"), info.Size)
+			assert.Equal(t, "text/plain", info.ContentType)
+			assert.Equal(t, map[string]string{"key1": "value1"}, info.UserDefined)
+
+			etag := md5.Sum(append(first[:], second[:]...))
+			assert.Equal(t, hex.EncodeToString(etag[:])+"-2", info.ETag)
+		}
+
+		objInfo, err := layer.GetObjectInfo(ctx, TestBucket, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, info.ETag, objInfo.ETag)
+			assert.Equal(t, info.UserDefined, objInfo.UserDefined)
+		}
+
+		var buf bytes.Buffer
+		err = layer.GetObject(ctx, TestBucket, TestFile, 0, -1, &buf, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "firstsecond", buf.String())
+		}
+
+		// The completed upload is not pending anymore
+		uploads, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 10)
+		if assert.NoError(t, err) {
+			assert.Empty(t, uploads.Uploads)
+		}
+
+		// Abort another upload
+		uploadID, err = layer.NewMultipartUpload(ctx, TestBucket, DestFile, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = layer.PutObjectPart(ctx, TestBucket, DestFile, uploadID, 1, newPartReader(t, "part"))
+		assert.NoError(t, err)
+
+		err = layer.AbortMultipartUpload(ctx, TestBucket, DestFile, uploadID)
+		assert.NoError(t, err)
+
+		_, err = layer.ListObjectParts(ctx, TestBucket, DestFile, uploadID, 0, 10)
+		assert.Equal(t, minio.InvalidUploadID{UploadID: uploadID}, err)
+	})
+}
+
+// newPartReader returns a reader of an unsigned payload, without the MD5 and
+// SHA-256 of the data
+func newPartReader(t *testing.T, data string) *hash.Reader {
+	reader, err := hash.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
 func testListObjects(t *testing.T, listObjects func(context.Context, minio.ObjectLayer, string, string, string, string, int) ([]string, []minio.ObjectInfo, bool, error)) {
 	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
 		// Check the error when listing objects with unsupported delimiter
@@ -655,18 +803,12 @@ func runTest(t *testing.T, test func(context.Context, minio.ObjectLayer, storj.M
 }
 
 func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, streams.Store, error) {
-	// TODO(kaloyan): We should have a better way for configuring the Satellite's API Key
-	err := flag.Set("pointer-db.auth.api-key", TestAPIKey)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
 	oc, err := planet.Uplinks[0].DialOverlay(planet.Satellites[0])
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], TestAPIKey)
+	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], planet.Uplinks[0].APIKey[planet.Satellites[0].ID()])
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -687,7 +829,7 @@ func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, stre
 	key := new(storj.Key)
 	copy(key[:], TestEncKey)
 
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM, streams.Options{Checksum: storj.MD5, MaxBufferMem: int(4 * memory.MB)})
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -0,0 +1,336 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+	"go.uber.org/zap"
+)
+
+// VersionsHandler serves the versioning requests of S3, which the minio
+// release in use doesn't route to gateways, and passes all other requests to
+// minio:
+//
+//	GET /<bucket>?versions                       lists the versions of an object
+//	GET, HEAD /<bucket>/<object>?versionId=<id>  reads a version of an object
+//	DELETE /<bucket>/<object>?versionId=<id>     deletes a version of an object
+//
+// Only path-style requests authenticated with an AWS signature version 4 in
+// the authorization header are supported. The versions are listed for the
+// object named by the prefix of the request only.
+type VersionsHandler struct {
+	log       *zap.Logger
+	layer     *gatewayLayer
+	accessKey string
+	secretKey string
+	minio     http.Handler
+}
+
+// NewVersionsHandler creates a handler serving the versioning requests of
+// the objects of gateway, passing the other requests to minio
+func NewVersionsHandler(log *zap.Logger, gateway *Gateway, accessKey, secretKey string, minio http.Handler) *VersionsHandler {
+	return &VersionsHandler{
+		log:       log,
+		layer:     &gatewayLayer{gateway: gateway},
+		accessKey: accessKey,
+		secretKey: secretKey,
+		minio:     minio,
+	}
+}
+
+// ListVersionsResult is the S3 result of listing the versions of objects
+type ListVersionsResult struct {
+	XMLName             xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	Name                string          `xml:"Name"`
+	Prefix              string          `xml:"Prefix"`
+	KeyMarker           string          `xml:"KeyMarker"`
+	VersionIDMarker     string          `xml:"VersionIdMarker"`
+	NextKeyMarker       string          `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string          `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int             `xml:"MaxKeys"`
+	IsTruncated         bool            `xml:"IsTruncated"`
+	Versions            []ObjectVersion `xml:"Version"`
+}
+
+// ObjectVersion is a version of an object in the S3 result of listing versions
+type ObjectVersion struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+// apiError is an S3 error response
+type apiError struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+
+	status int
+}
+
+const maxVersionKeys = 1000
+
+// ServeHTTP implements http.Handler
+func (handler *VersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	bucket, object := splitBucketObject(r.URL.Path)
+	_, versions := query["versions"]
+	versionID := query.Get("versionId")
+
+	var serve func(w http.ResponseWriter, r *http.Request, bucket, object string) error
+	switch {
+	case versions && bucket != "" && object == "" && r.Method == http.MethodGet:
+		serve = handler.listVersions
+	case versionID != "" && object != "" && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		serve = handler.getVersion
+	case versionID != "" && object != "" && r.Method == http.MethodDelete:
+		serve = handler.deleteVersion
+	default:
+		handler.minio.ServeHTTP(w, r)
+		return
+	}
+
+	if err := verifySignature(r, handler.accessKey, handler.secretKey, time.Now()); err != nil {
+		handler.writeError(w, r, &apiError{Code: "AccessDenied", Message: err.Error(), status: http.StatusForbidden})
+		return
+	}
+
+	if err := serve(w, r, bucket, object); err != nil {
+		handler.writeError(w, r, convertAPIError(err))
+	}
+}
+
+func (handler *VersionsHandler) listVersions(w http.ResponseWriter, r *http.Request, bucket, _ string) (err error) {
+	ctx := r.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	query := r.URL.Query()
+	maxKeys := maxVersionKeys
+	if value := query.Get("max-keys"); value != "" {
+		maxKeys, err = strconv.Atoi(value)
+		if err != nil || maxKeys < 0 {
+			return &apiError{Code: "InvalidArgument", Message: "invalid max-keys", status: http.StatusBadRequest}
+		}
+		if maxKeys > maxVersionKeys {
+			maxKeys = maxVersionKeys
+		}
+	}
+
+	object := query.Get("prefix")
+	if object == "" || strings.HasSuffix(object, "/") {
+		return &apiError{Code: "NotImplemented", Message: "versions are listed for the object named by the prefix only", status: http.StatusNotImplemented}
+	}
+
+	result := ListVersionsResult{
+		Name:            bucket,
+		Prefix:          object,
+		KeyMarker:       query.Get("key-marker"),
+		VersionIDMarker: query.Get("version-id-marker"),
+		MaxKeys:         maxKeys,
+	}
+
+	// the versions of the object come after the key marker only if it's the
+	// object with a version marker
+	keyMarker := result.KeyMarker
+	if maxKeys > 0 && (keyMarker == "" || (keyMarker == object && result.VersionIDMarker != "") || keyMarker < object) {
+		versionIDMarker := ""
+		if keyMarker == object {
+			versionIDMarker = result.VersionIDMarker
+		}
+
+		list, err := handler.layer.ListObjectVersions(ctx, bucket, object, versionIDMarker, maxKeys)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range list.Versions {
+			result.Versions = append(result.Versions, ObjectVersion{
+				Key:          version.Name,
+				VersionID:    version.VersionID,
+				IsLatest:     version.IsLatest,
+				LastModified: version.ModTime.UTC().Format(time.RFC3339),
+				ETag:         `"` + version.ETag + `"`,
+				Size:         version.Size,
+				StorageClass: "STANDARD",
+			})
+		}
+
+		if list.IsTruncated {
+			result.IsTruncated = true
+			result.NextKeyMarker = object
+			result.NextVersionIDMarker = list.NextVersionIDMarker
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = w.Write([]byte(xml.Header))
+	return xml.NewEncoder(w).Encode(result)
+}
+
+func (handler *VersionsHandler) getVersion(w http.ResponseWriter, r *http.Request, bucket, object string) (err error) {
+	ctx := r.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	versionID := r.URL.Query().Get("versionId")
+	info, err := handler.layer.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	if err != nil {
+		return err
+	}
+
+	offset, length, err := parseRange(r.Header.Get("Range"), info.Size)
+	if err != nil {
+		return err
+	}
+
+	header := w.Header()
+	header.Set("x-amz-version-id", info.VersionID)
+	header.Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	header.Set("ETag", `"`+info.ETag+`"`)
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Content-Length", strconv.FormatInt(length, 10))
+	if info.ContentType != "" {
+		header.Set("Content-Type", info.ContentType)
+	}
+	for key, value := range info.UserDefined {
+		header.Set("x-amz-meta-"+key, value)
+	}
+
+	status := http.StatusOK
+	if length != info.Size {
+		status = http.StatusPartialContent
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, info.Size))
+	}
+	w.WriteHeader(status)
+
+	if r.Method == http.MethodHead || length == 0 {
+		return nil
+	}
+
+	err = handler.layer.GetObjectVersion(ctx, bucket, object, versionID, offset, length, w)
+	if err != nil {
+		// the status is sent already
+		handler.log.Error("failed to send object version", zap.Error(err))
+	}
+	return nil
+}
+
+func (handler *VersionsHandler) deleteVersion(w http.ResponseWriter, r *http.Request, bucket, object string) (err error) {
+	ctx := r.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	versionID := r.URL.Query().Get("versionId")
+	err = handler.layer.DeleteObjectVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("x-amz-version-id", versionID)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (handler *VersionsHandler) writeError(w http.ResponseWriter, r *http.Request, apiErr *apiError) {
+	if apiErr.status == http.StatusInternalServerError {
+		handler.log.Error("failed to serve versioning request", zap.String("message", apiErr.Message))
+	}
+
+	apiErr.Resource = r.URL.Path
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(apiErr.status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(apiErr)
+}
+
+// Error implements error
+func (apiErr *apiError) Error() string { return apiErr.Code + ": " + apiErr.Message }
+
+// convertAPIError converts the errors of the gateway layer to S3 errors
+func convertAPIError(err error) *apiError {
+	switch err := err.(type) {
+	case *apiError:
+		return err
+	case minio.BucketNotFound:
+		return &apiError{Code: "NoSuchBucket", Message: err.Error(), status: http.StatusNotFound}
+	case minio.ObjectNotFound:
+		return &apiError{Code: "NoSuchVersion", Message: err.Error(), status: http.StatusNotFound}
+	case minio.BucketNameInvalid:
+		return &apiError{Code: "InvalidBucketName", Message: err.Error(), status: http.StatusBadRequest}
+	case minio.ObjectNameInvalid:
+		return &apiError{Code: "InvalidObjectName", Message: err.Error(), status: http.StatusBadRequest}
+	default:
+		return &apiError{Code: "InternalError", Message: err.Error(), status: http.StatusInternalServerError}
+	}
+}
+
+// splitBucketObject splits the path of a path-style request into the bucket
+// and the object
+func splitBucketObject(path string) (bucket, object string) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// parseRange parses a single range of a Range header of an object of size,
+// returning the whole object if there is none
+func parseRange(header string, size int64) (offset, length int64, err error) {
+	if header == "" {
+		return 0, size, nil
+	}
+
+	invalid := &apiError{Code: "InvalidRange", Message: "the requested range is not satisfiable", status: http.StatusRequestedRangeNotSatisfiable}
+	if !strings.HasPrefix(header, "bytes=") || strings.Contains(header, ",") {
+		return 0, 0, invalid
+	}
+
+	bounds := strings.SplitN(strings.TrimPrefix(header, "bytes="), "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, invalid
+	}
+
+	switch {
+	case bounds[0] == "":
+		// the suffix of the given length
+		suffix, err := strconv.ParseInt(bounds[1], 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, invalid
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, nil
+	default:
+		first, err := strconv.ParseInt(bounds[0], 10, 64)
+		if err != nil || first < 0 || first >= size {
+			return 0, 0, invalid
+		}
+		last := size - 1
+		if bounds[1] != "" {
+			last, err = strconv.ParseInt(bounds[1], 10, 64)
+			if err != nil || last < first {
+				return 0, 0, invalid
+			}
+			if last >= size {
+				last = size - 1
+			}
+		}
+		return first, last - first + 1, nil
+	}
+}
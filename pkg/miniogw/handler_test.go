@@ -0,0 +1,96 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio-go/pkg/s3signer"
+	minio "github.com/minio/minio/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestVersionsHandler(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		_, err := metainfo.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+
+		first, err := createFile(ctx, metainfo, streams, TestBucket, TestFile, nil, []byte("first"))
+		require.NoError(t, err)
+		second, err := createFile(ctx, metainfo, streams, TestBucket, TestFile, nil, []byte("second"))
+		require.NoError(t, err)
+
+		passed := 0
+		minioHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { passed++ })
+		handler := NewVersionsHandler(zap.NewNop(), layer.(*gatewayLayer).gateway, "access", "secret", minioHandler)
+
+		serve := func(method, target string, sign bool) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(method, target, nil)
+			if sign {
+				r = s3signer.SignV4(*r, "access", "secret", "", "us-east-1")
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			return w
+		}
+
+		// other requests are passed to minio
+		serve(http.MethodGet, "/"+TestBucket+"/"+TestFile, false)
+		assert.Equal(t, 1, passed)
+
+		// versioning requests must be signed with the gateway credentials
+		w := serve(http.MethodGet, "/"+TestBucket+"?versions&prefix="+TestFile, false)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(http.MethodGet, "/"+TestBucket+"?versions&prefix="+TestFile, true)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result ListVersionsResult
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &result))
+		if assert.Len(t, result.Versions, 2) {
+			assert.Equal(t, second.VersionID, result.Versions[0].VersionID)
+			assert.True(t, result.Versions[0].IsLatest)
+			assert.Equal(t, first.VersionID, result.Versions[1].VersionID)
+			assert.False(t, result.Versions[1].IsLatest)
+			assert.EqualValues(t, len("first"), result.Versions[1].Size)
+		}
+
+		w = serve(http.MethodGet, "/"+TestBucket+"/"+TestFile+"?versionId="+first.VersionID, true)
+		if assert.Equal(t, http.StatusOK, w.Code) {
+			assert.Equal(t, "first", w.Body.String())
+			assert.Equal(t, first.VersionID, w.Header().Get("x-amz-version-id"))
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/"+TestBucket+"/"+TestFile+"?versionId="+first.VersionID, nil)
+		r.Header.Set("Range", "bytes=1-3")
+		r = s3signer.SignV4(*r, "access", "secret", "", "us-east-1")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if assert.Equal(t, http.StatusPartialContent, w.Code) {
+			assert.Equal(t, "irs", w.Body.String())
+		}
+
+		w = serve(http.MethodDelete, "/"+TestBucket+"/"+TestFile+"?versionId="+first.VersionID, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = serve(http.MethodGet, "/"+TestBucket+"/"+TestFile+"?versionId="+first.VersionID, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// the current object is kept
+		w = serve(http.MethodGet, "/"+TestBucket+"/"+TestFile+"?versionId="+second.VersionID, true)
+		if assert.Equal(t, http.StatusOK, w.Code) {
+			assert.Equal(t, "second", w.Body.String())
+		}
+		assert.Equal(t, 1, passed)
+	})
+}
@@ -38,9 +38,6 @@ func TestUploadDownload(t *testing.T) {
 
 	defer ctx.Check(planet.Shutdown)
 
-	err = flag.Set("pointer-db.auth.api-key", "apiKey")
-	assert.NoError(t, err)
-
 	// bind default values to config
 	var gwCfg miniogw.Config
 	cfgstruct.Bind(&pflag.FlagSet{}, &gwCfg)
@@ -54,7 +51,8 @@ func TestUploadDownload(t *testing.T) {
 	gwCfg.Client.PointerDBAddr = planet.Satellites[0].Addr()
 
 	// keys
-	gwCfg.Client.APIKey = "apiKey"
+	gwCfg.Client.APIKey, err = planet.NewAPIKey(ctx, planet.Satellites[0], "gateway")
+	assert.NoError(t, err)
 	gwCfg.Enc.Key = "encKey"
 
 	// redundancy
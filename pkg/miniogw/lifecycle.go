@@ -0,0 +1,108 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"encoding/xml"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Bucket lifecycle configuration (?lifecycle) is handled by minio itself in
+// the release in use and never reaches the gateway, these methods convert
+// the S3 configuration for when it does.
+
+// BucketLifecycle is the S3 lifecycle configuration of a bucket
+type BucketLifecycle struct {
+	XMLName xml.Name        `xml:"LifecycleConfiguration"`
+	Rules   []LifecycleRule `xml:"Rule"`
+}
+
+// LifecycleRule is a rule of the S3 lifecycle configuration
+type LifecycleRule struct {
+	ID     string `xml:"ID,omitempty"`
+	Status string `xml:"Status"`
+	Prefix string `xml:"Prefix,omitempty"`
+
+	Filter                         *LifecycleFilter      `xml:"Filter,omitempty"`
+	Expiration                     *LifecycleExpiration  `xml:"Expiration,omitempty"`
+	AbortIncompleteMultipartUpload *LifecycleAbortUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+// LifecycleFilter selects the objects a lifecycle rule applies to
+type LifecycleFilter struct {
+	Prefix string `xml:"Prefix,omitempty"`
+}
+
+// LifecycleExpiration is the number of days after which objects expire
+type LifecycleExpiration struct {
+	Days int `xml:"Days,omitempty"`
+}
+
+// LifecycleAbortUpload is the number of days after which incomplete
+// multipart uploads are aborted
+type LifecycleAbortUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation,omitempty"`
+}
+
+const lifecycleRuleEnabled = "Enabled"
+
+// PutBucketLifecycle sets the lifecycle configuration of a bucket
+func (layer *gatewayLayer) PutBucketLifecycle(ctx context.Context, bucket string, lifecycle *BucketLifecycle) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var rules []storj.LifecycleRule
+	for _, rule := range lifecycle.Rules {
+		converted := storj.LifecycleRule{
+			ID:      rule.ID,
+			Enabled: rule.Status == lifecycleRuleEnabled,
+			Prefix:  rule.Prefix,
+		}
+		if rule.Filter != nil && rule.Filter.Prefix != "" {
+			converted.Prefix = rule.Filter.Prefix
+		}
+		if rule.Expiration != nil {
+			converted.ExpirationDays = rule.Expiration.Days
+		}
+		if rule.AbortIncompleteMultipartUpload != nil {
+			converted.AbortPendingDays = rule.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		rules = append(rules, converted)
+	}
+
+	err = layer.gateway.metainfo.SetBucketLifecycle(ctx, bucket, rules)
+	return convertError(err, bucket, "")
+}
+
+// GetBucketLifecycle returns the lifecycle configuration of a bucket
+func (layer *gatewayLayer) GetBucketLifecycle(ctx context.Context, bucket string) (lifecycle *BucketLifecycle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rules, err := layer.gateway.metainfo.GetBucketLifecycle(ctx, bucket)
+	if err != nil {
+		return nil, convertError(err, bucket, "")
+	}
+
+	lifecycle = &BucketLifecycle{}
+	for _, rule := range rules {
+		converted := LifecycleRule{
+			ID:     rule.ID,
+			Status: "Disabled",
+			Prefix: rule.Prefix,
+		}
+		if rule.Enabled {
+			converted.Status = lifecycleRuleEnabled
+		}
+		if rule.ExpirationDays > 0 {
+			converted.Expiration = &LifecycleExpiration{Days: rule.ExpirationDays}
+		}
+		if rule.AbortPendingDays > 0 {
+			converted.AbortIncompleteMultipartUpload = &LifecycleAbortUpload{DaysAfterInitiation: rule.AbortPendingDays}
+		}
+		lifecycle.Rules = append(lifecycle.Rules, converted)
+	}
+
+	return lifecycle, nil
+}
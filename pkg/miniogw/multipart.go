@@ -4,454 +4,575 @@
 package miniogw
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
 	"io"
 	"sort"
 	"strconv"
-	"sync"
-	"sync/atomic"
+	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	minio "github.com/minio/minio/cmd"
 	"github.com/minio/minio/pkg/hash"
+	"github.com/zeebo/errs"
 
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
-func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, object string, metadata map[string]string) (uploadID string, err error) {
-	defer mon.Task()(&ctx)(&err)
+// multipartETagKey is the reserved metadata key for the ETag of a part, and
+// of an object completed from a multipart upload
+const multipartETagKey = "multipart-etag"
+
+// The state of multipart uploads is kept in pointerdb, so that uploads
+// survive gateway restarts and parts can be uploaded in parallel. It's kept
+// in pseudo-buckets of the bucket, which the api keys restrict like the
+// bucket itself:
+//
+//   .uploads.<bucket>/<object>/<upload id>              info about the upload
+//   .parts.<bucket>/<object>/<upload id>/<part number>  data of an uploaded part
+//
+// Every part is stored as its own stream. The segments of the parts are
+// concatenated into the final object when the upload is completed.
+
+func uploadsPath(bucket string) storj.Path {
+	return storj.MultipartUploadsPrefix + bucket
+}
 
-	// Check that the bucket exists
-	_, err = layer.gateway.metainfo.GetBucket(ctx, bucket)
+func uploadPath(bucket, object, uploadID string) storj.Path {
+	return storj.JoinPaths(uploadsPath(bucket), object, uploadID)
+}
+
+func partsPath(bucket, object, uploadID string) storj.Path {
+	return storj.JoinPaths(storj.MultipartPartsPrefix+bucket, object, uploadID)
+}
+
+func partPath(bucket, object, uploadID string, partID int) storj.Path {
+	return storj.JoinPaths(partsPath(bucket, object, uploadID), strconv.Itoa(partID))
+}
+
+// uploadIDSize is the number of random bytes of an upload id
+const uploadIDSize = 16
+
+// newUploadID generates a random upload id
+func newUploadID() (string, error) {
+	var id [uploadIDSize]byte
+	_, err := rand.Read(id[:])
 	if err != nil {
-		return "", convertError(err, bucket, "")
+		return "", Error.Wrap(err)
 	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// validUploadID checks whether uploadID is an upload id generated by
+// newUploadID, which is a single path component
+func validUploadID(uploadID string) bool {
+	id, err := hex.DecodeString(uploadID)
+	return err == nil && len(id) == uploadIDSize
+}
 
-	uploads := layer.gateway.multipart
+// multipartUpload is the info about a pending multipart upload
+type multipartUpload struct {
+	ID          string
+	Object      string
+	ContentType string
+	Metadata    map[string]string
+	Initiated   time.Time
+}
 
-	upload, err := uploads.Create(bucket, object, metadata)
+// uploadFromMeta decodes the info about an upload
+func uploadFromMeta(object, uploadID string, m streams.Meta) (multipartUpload, error) {
+	serMeta := pb.SerializableMeta{}
+	err := proto.Unmarshal(m.Data, &serMeta)
 	if err != nil {
-		return "", err
+		return multipartUpload{}, Error.Wrap(err)
 	}
 
-	go func() {
-		contentType := metadata["content-type"]
-		delete(metadata, "content-type")
+	metadata := serMeta.UserDefined
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
 
-		createInfo := storj.CreateObject{
-			ContentType:      contentType,
-			Metadata:         metadata,
-			RedundancyScheme: layer.gateway.redundancy,
-			EncryptionScheme: layer.gateway.encryption,
-		}
-		objInfo, err := layer.putObject(ctx, bucket, object, upload.Stream, &createInfo)
+	return multipartUpload{
+		ID:          uploadID,
+		Object:      object,
+		ContentType: serMeta.ContentType,
+		Metadata:    metadata,
+		Initiated:   m.Modified,
+	}, nil
+}
 
-		uploads.RemoveByID(upload.ID)
+// partFromItem decodes the info about an uploaded part. The ETag of a part
+// is the hex encoded MD5 of its content.
+func partFromItem(item streams.ListItem) (minio.PartInfo, error) {
+	partID, err := strconv.Atoi(item.Path)
+	if err != nil {
+		return minio.PartInfo{}, Error.New("invalid part %q", item.Path)
+	}
 
-		if err != nil {
-			upload.fail(err)
-		} else {
-			upload.complete(objInfo)
-		}
-	}()
+	serMeta := pb.SerializableMeta{}
+	err = proto.Unmarshal(item.Meta.Data, &serMeta)
+	if err != nil {
+		return minio.PartInfo{}, Error.Wrap(err)
+	}
 
-	return upload.ID, nil
+	return minio.PartInfo{
+		PartNumber:   partID,
+		LastModified: item.Meta.Modified,
+		ETag:         serMeta.UserDefined[multipartETagKey],
+		Size:         item.Meta.Size,
+	}, nil
 }
 
-func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
+// getUpload finds a pending upload
+func (layer *gatewayLayer) getUpload(ctx context.Context, bucket, object, uploadID string) (upload multipartUpload, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	if !validUploadID(uploadID) {
+		return multipartUpload{}, minio.InvalidUploadID{UploadID: uploadID}
+	}
 
-	upload, err := uploads.Get(bucket, object, uploadID)
+	m, err := layer.gateway.streams.Meta(ctx, uploadPath(bucket, object, uploadID), layer.gateway.pathCipher)
 	if err != nil {
-		return minio.PartInfo{}, err
+		if storage.ErrKeyNotFound.Has(err) {
+			return multipartUpload{}, minio.InvalidUploadID{UploadID: uploadID}
+		}
+		return multipartUpload{}, err
 	}
 
-	part, err := upload.Stream.AddPart(partID, data)
-	if err != nil {
-		return minio.PartInfo{}, err
+	return uploadFromMeta(object, uploadID, m)
+}
+
+// listAll lists all items under prefix
+func (layer *gatewayLayer) listAll(ctx context.Context, prefix storj.Path, pending bool) (items []streams.ListItem, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list := layer.gateway.streams.List
+	if pending {
+		list = layer.gateway.streams.ListPending
+	}
+
+	startAfter := ""
+	for {
+		page, more, err := list(ctx, prefix, startAfter, "", layer.gateway.pathCipher, true, 0, meta.All)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if !more || len(page) == 0 {
+			return items, nil
+		}
+
+		startAfter = page[len(page)-1].Path
 	}
+}
 
-	err = <-part.Done
+// listParts lists the uploaded parts of an upload sorted by the part number
+func (layer *gatewayLayer) listParts(ctx context.Context, bucket, object, uploadID string) (parts []minio.PartInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	items, err := layer.listAll(ctx, partsPath(bucket, object, uploadID), false)
 	if err != nil {
-		return minio.PartInfo{}, err
+		return nil, err
 	}
 
-	partInfo := minio.PartInfo{
-		PartNumber:   part.Number,
-		LastModified: time.Now(),
-		ETag:         data.SHA256HexString(),
-		Size:         atomic.LoadInt64(&part.Size),
+	parts = make([]minio.PartInfo, 0, len(items))
+	for _, item := range items {
+		part, err := partFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
 	}
 
-	upload.addCompletedPart(partInfo)
+	sort.Slice(parts, func(i, k int) bool {
+		return parts[i].PartNumber < parts[k].PartNumber
+	})
 
-	return partInfo, nil
+	return parts, nil
 }
 
-func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
+// deleteUpload deletes the uploaded parts, interrupted part uploads and the
+// info of an upload
+func (layer *gatewayLayer) deleteUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
-
-	upload, err := uploads.Remove(bucket, object, uploadID)
+	parts, err := layer.listAll(ctx, partsPath(bucket, object, uploadID), false)
 	if err != nil {
 		return err
 	}
 
-	errAbort := Error.New("abort")
-	upload.Stream.Abort(errAbort)
-	r := <-upload.Done
-	if r.Error != errAbort {
-		return r.Error
+	var group errs.Group
+	for _, part := range parts {
+		group.Add(layer.gateway.streams.Delete(ctx, storj.JoinPaths(partsPath(bucket, object, uploadID), part.Path), layer.gateway.pathCipher))
 	}
-	return nil
-}
 
-func (layer *gatewayLayer) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart) (objInfo minio.ObjectInfo, err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	uploads := layer.gateway.multipart
-	upload, err := uploads.Remove(bucket, object, uploadID)
+	pending, err := layer.listAll(ctx, partsPath(bucket, object, uploadID), true)
 	if err != nil {
-		return minio.ObjectInfo{}, err
+		return errs.Combine(group.Err(), err)
 	}
 
-	// notify stream that there aren't more parts coming
-	upload.Stream.Close()
-	// wait for completion
-	result := <-upload.Done
-	// return the final info
-	return result.Info, result.Error
+	for _, part := range pending {
+		group.Add(layer.gateway.streams.DeletePending(ctx, storj.JoinPaths(partsPath(bucket, object, uploadID), part.Path), layer.gateway.pathCipher))
+	}
+
+	if err := group.Err(); err != nil {
+		// keep the upload info, so that the abort can be retried
+		return err
+	}
+
+	return layer.gateway.streams.Delete(ctx, uploadPath(bucket, object, uploadID), layer.gateway.pathCipher)
 }
 
-func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int) (result minio.ListPartsInfo, err error) {
+func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, object string, metadata map[string]string) (uploadID string, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
-	upload, err := uploads.Get(bucket, object, uploadID)
+	// Check that the bucket exists
+	_, err = layer.gateway.metainfo.GetBucket(ctx, bucket)
 	if err != nil {
-		return minio.ListPartsInfo{}, err
+		return "", convertError(err, bucket, "")
 	}
 
-	list := minio.ListPartsInfo{}
+	uploadID, err = newUploadID()
+	if err != nil {
+		return "", err
+	}
 
-	list.Bucket = bucket
-	list.Object = object
-	list.UploadID = uploadID
-	list.PartNumberMarker = partNumberMarker
-	list.MaxParts = maxParts
-	list.UserDefined = upload.Metadata
-	list.Parts = upload.getCompletedParts()
+	userDefined := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		if key != "content-type" {
+			userDefined[key] = value
+		}
+	}
 
-	sort.Slice(list.Parts, func(i, k int) bool {
-		return list.Parts[i].PartNumber < list.Parts[k].PartNumber
+	serMeta, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: metadata["content-type"],
+		UserDefined: userDefined,
 	})
-
-	var first int
-	for i, p := range list.Parts {
-		first = i
-		if partNumberMarker <= p.PartNumber {
-			break
-		}
+	if err != nil {
+		return "", Error.Wrap(err)
 	}
 
-	list.Parts = list.Parts[first:]
-	if len(list.Parts) > maxParts {
-		list.NextPartNumberMarker = list.Parts[maxParts].PartNumber
-		list.Parts = list.Parts[:maxParts]
-		list.IsTruncated = true
+	_, err = layer.gateway.streams.Put(ctx, uploadPath(bucket, object, uploadID), layer.gateway.pathCipher, bytes.NewReader(nil), serMeta, time.Time{})
+	if err != nil {
+		return "", err
 	}
 
-	return list, nil
+	return uploadID, nil
 }
 
-// TODO: implement
-// func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
-// func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
+func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-// MultipartUploads manages pending multipart uploads
-type MultipartUploads struct {
-	mu      sync.RWMutex
-	lastID  int
-	pending map[string]*MultipartUpload
-}
+	_, err = layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
 
-// NewMultipartUploads creates new MultipartUploads
-func NewMultipartUploads() *MultipartUploads {
-	return &MultipartUploads{
-		pending: map[string]*MultipartUpload{},
+	// the parts are stored with the schemes of the object, as their segments
+	// become the segments of the object
+	mutableObject, err := layer.gateway.metainfo.CreateObject(ctx, bucket, object, nil)
+	if err != nil {
+		return minio.PartInfo{}, convertError(err, bucket, object)
 	}
-}
 
-// Create creates a new upload
-func (uploads *MultipartUploads) Create(bucket, object string, metadata map[string]string) (*MultipartUpload, error) {
-	uploads.mu.Lock()
-	defer uploads.mu.Unlock()
+	objectInfo := mutableObject.Info()
+	store, err := layer.gateway.streams.WithSchemes(objectInfo.RedundancyScheme, objectInfo.EncryptionScheme)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
 
-	for id, upload := range uploads.pending {
-		if upload.Bucket == bucket && upload.Object == object {
-			upload.Stream.Abort(Error.New("aborted by another upload to the same location"))
-			delete(uploads.pending, id)
-		}
+	// uploading a part with the same number again replaces the previous one
+	path := partPath(bucket, object, uploadID, partID)
+	partMD5 := md5.New()
+	_, err = store.Put(ctx, path, layer.gateway.pathCipher, io.TeeReader(data, partMD5), nil, time.Time{})
+	if err != nil {
+		return minio.PartInfo{}, err
 	}
 
-	uploads.lastID++
-	uploadID := "Upload" + strconv.Itoa(uploads.lastID)
+	// the ETag of a part is the MD5 of its content, like with S3, which is
+	// known only once the part is uploaded
+	etag := hex.EncodeToString(partMD5.Sum(nil))
+	serMeta, err := proto.Marshal(&pb.SerializableMeta{
+		UserDefined: map[string]string{multipartETagKey: etag},
+	})
+	if err != nil {
+		return minio.PartInfo{}, Error.Wrap(err)
+	}
 
-	upload := NewMultipartUpload(uploadID, bucket, object, metadata)
-	uploads.pending[uploadID] = upload
+	m, err := store.UpdateMetadata(ctx, path, layer.gateway.pathCipher, serMeta, time.Time{})
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
 
-	return upload, nil
+	return minio.PartInfo{
+		PartNumber:   partID,
+		LastModified: m.Modified,
+		ETag:         etag,
+		Size:         m.Size,
+	}, nil
 }
 
-// Get finds a pending upload
-func (uploads *MultipartUploads) Get(bucket, object, uploadID string) (*MultipartUpload, error) {
-	uploads.mu.Lock()
-	defer uploads.mu.Unlock()
+func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	upload, ok := uploads.pending[uploadID]
-	if !ok {
-		return nil, Error.New("pending upload %q missing", uploadID)
-	}
-	if upload.Bucket != bucket || upload.Object != object {
-		return nil, Error.New("pending upload %q bucket/object name mismatch", uploadID)
+	_, err = layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return err
 	}
 
-	return upload, nil
+	return layer.deleteUpload(ctx, bucket, object, uploadID)
 }
 
-// Remove returns and removes a pending upload
-func (uploads *MultipartUploads) Remove(bucket, object, uploadID string) (*MultipartUpload, error) {
-	uploads.mu.RLock()
-	defer uploads.mu.RUnlock()
+func (layer *gatewayLayer) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart) (objInfo minio.ObjectInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	upload, ok := uploads.pending[uploadID]
-	if !ok {
-		return nil, Error.New("pending upload %q missing", uploadID)
+	upload, err := layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.ObjectInfo{}, err
 	}
-	if upload.Bucket != bucket || upload.Object != object {
-		return nil, Error.New("pending upload %q bucket/object name mismatch", uploadID)
+
+	parts, err := layer.listParts(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.ObjectInfo{}, err
 	}
 
-	delete(uploads.pending, uploadID)
+	if len(uploadedParts) == 0 {
+		return minio.ObjectInfo{}, minio.InvalidPart{}
+	}
 
-	return upload, nil
-}
+	etags := make(map[int]string, len(parts))
+	for _, part := range parts {
+		etags[part.PartNumber] = part.ETag
+	}
 
-// RemoveByID removes pending upload by id
-func (uploads *MultipartUploads) RemoveByID(uploadID string) {
-	uploads.mu.RLock()
-	defer uploads.mu.RUnlock()
-	delete(uploads.pending, uploadID)
-}
+	// concatenate the parts in the order given by the client. The ETag of the
+	// object is the MD5 of the concatenated ETags of the parts and the number
+	// of parts, like with S3.
+	partPaths := make([]storj.Path, 0, len(uploadedParts))
+	partETags := md5.New()
+	for i, uploadedPart := range uploadedParts {
+		if i > 0 && uploadedPart.PartNumber <= uploadedParts[i-1].PartNumber {
+			return minio.ObjectInfo{}, minio.InvalidPart{}
+		}
 
-// MultipartUpload is partial info about a pending upload
-type MultipartUpload struct {
-	ID       string
-	Bucket   string
-	Object   string
-	Metadata map[string]string
-	Done     chan (*MultipartUploadResult)
-	Stream   *MultipartStream
-
-	mu        sync.Mutex
-	completed []minio.PartInfo
-}
+		etag, ok := etags[uploadedPart.PartNumber]
+		if !ok || etag != strings.Trim(uploadedPart.ETag, `"`) {
+			return minio.ObjectInfo{}, minio.InvalidPart{}
+		}
 
-// MultipartUploadResult contains either an Error or the uploaded ObjectInfo
-type MultipartUploadResult struct {
-	Error error
-	Info  minio.ObjectInfo
-}
+		etagBytes, err := hex.DecodeString(etag)
+		if err != nil {
+			return minio.ObjectInfo{}, minio.InvalidPart{}
+		}
+		_, _ = partETags.Write(etagBytes)
 
-// NewMultipartUpload creates a new MultipartUpload
-func NewMultipartUpload(uploadID string, bucket, object string, metadata map[string]string) *MultipartUpload {
-	upload := &MultipartUpload{
-		ID:       uploadID,
-		Bucket:   bucket,
-		Object:   object,
-		Metadata: metadata,
-		Done:     make(chan *MultipartUploadResult, 1),
-		Stream:   NewMultipartStream(),
-	}
-	return upload
-}
+		partPaths = append(partPaths, partPath(bucket, object, uploadID, uploadedPart.PartNumber))
+	}
 
-// addCompletedPart adds a completed part to the list
-func (upload *MultipartUpload) addCompletedPart(part minio.PartInfo) {
-	upload.mu.Lock()
-	defer upload.mu.Unlock()
+	metadata := make(map[string]string, len(upload.Metadata)+1)
+	for key, value := range upload.Metadata {
+		metadata[key] = value
+	}
+	metadata[multipartETagKey] = hex.EncodeToString(partETags.Sum(nil)) + "-" + strconv.Itoa(len(uploadedParts))
 
-	upload.completed = append(upload.completed, part)
-}
+	createInfo := storj.CreateObject{
+		ContentType: upload.ContentType,
+		Metadata:    metadata,
+	}
 
-func (upload *MultipartUpload) getCompletedParts() []minio.PartInfo {
-	upload.mu.Lock()
-	defer upload.mu.Unlock()
+	mutableObject, err := layer.gateway.metainfo.CreateObject(ctx, bucket, object, &createInfo)
+	if err != nil {
+		return minio.ObjectInfo{}, convertError(err, bucket, object)
+	}
 
-	return append([]minio.PartInfo{}, upload.completed...)
-}
+	info := mutableObject.Info()
+	path := storj.JoinPaths(bucket, object)
+	if info.Bucket.Versioning {
+		// keep the overwritten object as a prior version
+		_, err = layer.gateway.streams.Archive(ctx, path, info.Bucket.PathCipher)
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return minio.ObjectInfo{}, err
+		}
+	}
 
-// fail aborts the upload with an error
-func (upload *MultipartUpload) fail(err error) {
-	upload.Done <- &MultipartUploadResult{Error: err}
-	close(upload.Done)
-}
+	serMeta, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: info.ContentType,
+		UserDefined: info.Metadata,
+	})
+	if err != nil {
+		return minio.ObjectInfo{}, Error.Wrap(err)
+	}
 
-// complete completes the upload
-func (upload *MultipartUpload) complete(info minio.ObjectInfo) {
-	upload.Done <- &MultipartUploadResult{Info: info}
-	close(upload.Done)
-}
+	// the segments of the parts are moved to the object in pointerdb, the
+	// data isn't transferred again
+	_, err = layer.gateway.streams.Concat(ctx, path, info.Bucket.PathCipher, partPaths, layer.gateway.pathCipher, serMeta)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
 
-// MultipartStream serializes multiple readers into a single reader
-type MultipartStream struct {
-	mu          sync.Mutex
-	moreParts   sync.Cond
-	err         error
-	closed      bool
-	finished    bool
-	nextID      int
-	nextNumber  int
-	currentPart *StreamPart
-	parts       []*StreamPart
-}
+	err = mutableObject.Commit(ctx)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
 
-// StreamPart is a reader waiting in MultipartStream
-type StreamPart struct {
-	Number int
-	ID     int
-	Size   int64
-	Reader *hash.Reader
-	Done   chan error
-}
+	info = mutableObject.Info()
+	etag, userDefined := objectETag(info.Checksum, info.Metadata)
+
+	objInfo = minio.ObjectInfo{
+		Name:        object,
+		Bucket:      bucket,
+		ModTime:     info.Modified,
+		Size:        info.Size,
+		ETag:        etag,
+		ContentType: info.ContentType,
+		UserDefined: userDefined,
+	}
 
-// NewMultipartStream creates a new MultipartStream
-func NewMultipartStream() *MultipartStream {
-	stream := &MultipartStream{}
-	stream.moreParts.L = &stream.mu
-	stream.nextID = 1
-	return stream
+	return objInfo, layer.deleteUpload(ctx, bucket, object, uploadID)
 }
 
-// Abort aborts the stream reading
-func (stream *MultipartStream) Abort(err error) {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int) (result minio.ListPartsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	if stream.finished {
-		return
+	upload, err := layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.ListPartsInfo{}, err
 	}
 
-	if stream.err == nil {
-		stream.err = err
+	parts, err := layer.listParts(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.ListPartsInfo{}, err
 	}
-	stream.finished = true
-	stream.closed = true
 
-	for _, part := range stream.parts {
-		part.Done <- err
-		close(part.Done)
-	}
-	stream.parts = nil
+	list := minio.ListPartsInfo{}
 
-	stream.moreParts.Broadcast()
-}
+	list.Bucket = bucket
+	list.Object = object
+	list.UploadID = uploadID
+	list.PartNumberMarker = partNumberMarker
+	list.MaxParts = maxParts
+	list.UserDefined = upload.Metadata
 
-// Close closes the stream, but lets it complete
-func (stream *MultipartStream) Close() {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+	first := sort.Search(len(parts), func(i int) bool {
+		return parts[i].PartNumber > partNumberMarker
+	})
 
-	stream.closed = true
-	stream.moreParts.Broadcast()
+	list.Parts = parts[first:]
+	if len(list.Parts) > maxParts {
+		list.Parts = list.Parts[:maxParts]
+		list.NextPartNumberMarker = list.Parts[maxParts-1].PartNumber
+		list.IsTruncated = true
+	}
+
+	return list, nil
 }
 
-// Read implements io.Reader interface, blocking when there's no part
-func (stream *MultipartStream) Read(data []byte) (n int, err error) {
-	stream.mu.Lock()
-	for {
-		// has an error occurred?
-		if stream.err != nil {
-			stream.mu.Unlock()
-			return 0, Error.Wrap(err)
-		}
-		// still uploading the current part?
-		if stream.currentPart != nil {
-			break
-		}
-		// do we have the next part?
-		if len(stream.parts) > 0 && stream.nextID == stream.parts[0].ID {
-			stream.currentPart = stream.parts[0]
-			stream.parts = stream.parts[1:]
-			stream.nextID++
-			break
-		}
-		// we don't have the next part and are closed, hence we are complete
-		if stream.closed {
-			stream.finished = true
-			stream.mu.Unlock()
-			return 0, io.EOF
-		}
+func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-		stream.moreParts.Wait()
+	if delimiter != "" && delimiter != "/" {
+		return minio.ListMultipartsInfo{}, minio.UnsupportedDelimiter{Delimiter: delimiter}
 	}
-	stream.mu.Unlock()
-
-	// read as much as we can
-	n, err = stream.currentPart.Reader.Read(data)
-	atomic.AddInt64(&stream.currentPart.Size, int64(n))
 
-	if err == io.EOF {
-		// the part completed, hence advance to the next one
-		err = nil
-		close(stream.currentPart.Done)
-		stream.currentPart = nil
-	} else if err != nil {
-		// something bad happened, abort the whole thing
-		stream.Abort(err)
-		return n, Error.Wrap(err)
+	// Check that the bucket exists
+	_, err = layer.gateway.metainfo.GetBucket(ctx, bucket)
+	if err != nil {
+		return minio.ListMultipartsInfo{}, convertError(err, bucket, "")
 	}
 
-	return n, err
-}
-
-// AddPart adds a new part to the stream to wait
-func (stream *MultipartStream) AddPart(partID int, data *hash.Reader) (*StreamPart, error) {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+	result = minio.ListMultipartsInfo{
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		MaxUploads:     maxUploads,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
+	}
 
-	if partID < stream.nextID {
-		return nil, Error.New("part %d already uploaded, next part ID is %d", partID, stream.nextID)
+	if maxUploads <= 0 {
+		return result, nil
 	}
 
-	for _, p := range stream.parts {
-		if p.ID == partID {
-			// Replace the reader of this part with the new one.
-			// This could happen if the read timeout for this part has expired
-			// and the client tries to upload the part again.
-			p.Reader = data
-			return p, nil
-		}
+	// only the uploads under the directory of the prefix are listed. Like
+	// objects, the uploads are listed in the order of their encrypted paths,
+	// which the markers continue from.
+	dir := prefix[:strings.LastIndex(prefix, "/")+1]
+	listPrefix := uploadsPath(bucket)
+	if dir != "" {
+		listPrefix = storj.JoinPaths(listPrefix, strings.TrimSuffix(dir, "/"))
 	}
 
-	stream.nextNumber++
-	part := &StreamPart{
-		Number: stream.nextNumber - 1,
-		ID:     partID,
-		Size:   0,
-		Reader: data,
-		Done:   make(chan error, 1),
+	startAfter := ""
+	if strings.HasPrefix(keyMarker, dir) && keyMarker != dir {
+		startAfter = keyMarker[len(dir):]
+		if uploadIDMarker != "" {
+			startAfter = storj.JoinPaths(startAfter, uploadIDMarker)
+		}
 	}
 
-	stream.parts = append(stream.parts, part)
-	sort.Slice(stream.parts, func(i, k int) bool {
-		return stream.parts[i].ID < stream.parts[k].ID
-	})
+	prefixes := map[string]bool{}
+	for {
+		items, more, err := layer.gateway.streams.List(ctx, listPrefix, startAfter, "", layer.gateway.pathCipher, true, maxUploads, meta.All)
+		if err != nil {
+			return minio.ListMultipartsInfo{}, err
+		}
 
-	stream.moreParts.Broadcast()
+		for _, item := range items {
+			slash := strings.LastIndex(item.Path, "/")
+			if slash < 0 {
+				continue
+			}
+			object, uploadID := dir+item.Path[:slash], item.Path[slash+1:]
+			// without an upload id marker, all uploads of the marker object
+			// are skipped
+			if !strings.HasPrefix(object, prefix) || (object == keyMarker && uploadIDMarker == "") {
+				continue
+			}
+
+			if len(result.Uploads)+len(result.CommonPrefixes) >= maxUploads {
+				result.IsTruncated = true
+				return result, nil
+			}
+
+			if delimiter != "" {
+				if i := strings.Index(object[len(prefix):], delimiter); i >= 0 {
+					commonPrefix := object[:len(prefix)+i+len(delimiter)]
+					if !prefixes[commonPrefix] {
+						prefixes[commonPrefix] = true
+						result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix)
+					}
+					continue
+				}
+			}
+
+			upload, err := uploadFromMeta(object, uploadID, item.Meta)
+			if err != nil {
+				return minio.ListMultipartsInfo{}, err
+			}
+
+			result.Uploads = append(result.Uploads, minio.MultipartInfo{
+				Object:    upload.Object,
+				UploadID:  upload.ID,
+				Initiated: upload.Initiated,
+			})
+			result.NextKeyMarker = upload.Object
+			result.NextUploadIDMarker = upload.ID
+		}
 
-	return part, nil
+		if !more || len(items) == 0 {
+			return result, nil
+		}
+		startAfter = items[len(items)-1].Path
+	}
 }
+
+// TODO: implement
+// func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
@@ -0,0 +1,109 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3signer"
+	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/zeebo/errs"
+)
+
+const (
+	signV4Algorithm = "AWS4-HMAC-SHA256"
+	signV4Format    = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// maxClockSkew is the maximum difference between the time a request is
+	// signed at and the time it is received
+	maxClockSkew = 15 * time.Minute
+)
+
+// ErrSignature is the errs class of invalid request signatures
+var ErrSignature = errs.Class("signature error")
+
+// verifySignature verifies the AWS signature version 4 in the authorization
+// header of r, which must be signed with accessKey and secretKey. The
+// credential, the encoding of the request and the signature are computed by
+// the signer of minio, like for the requests it signs itself.
+func verifySignature(r *http.Request, accessKey, secretKey string, now time.Time) error {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, signV4Algorithm+" ") {
+		return ErrSignature.New("unsupported authorization")
+	}
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(authorization[len(signV4Algorithm)+1:], ",") {
+		keyValue := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(keyValue) != 2 {
+			return ErrSignature.New("malformed authorization")
+		}
+		fields[keyValue[0]] = keyValue[1]
+	}
+	if fields["SignedHeaders"] == "" || fields["Signature"] == "" {
+		return ErrSignature.New("malformed authorization")
+	}
+
+	signedAt, err := time.Parse(signV4Format, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return ErrSignature.New("malformed date")
+	}
+	if skew := now.Sub(signedAt); skew > maxClockSkew || skew < -maxClockSkew {
+		return ErrSignature.New("request signed at %s is too skewed", signedAt)
+	}
+
+	// the credential is <access key>/<date>/<region>/s3/aws4_request
+	credential := fields["Credential"]
+	scope := strings.Split(credential, "/")
+	if len(scope) != 5 {
+		return ErrSignature.New("malformed credential")
+	}
+	region := scope[2]
+	if credential != s3signer.GetCredential(accessKey, region, signedAt) {
+		return ErrSignature.New("invalid credential")
+	}
+
+	canonicalHeaders := ""
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := strings.Join(r.Header[http.CanonicalHeaderKey(name)], ",")
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders += name + ":" + strings.TrimSpace(value) + "\n"
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		s3utils.EncodePath(r.URL.Path),
+		strings.Replace(r.URL.Query().Encode(), "+", "%20", -1),
+		canonicalHeaders,
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signV4Algorithm,
+		signedAt.Format(signV4Format),
+		strings.TrimPrefix(credential, accessKey+"/"),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signature := s3signer.PostPresignSignatureV4(stringToSign, signedAt, secretKey, region)
+	if !hmac.Equal([]byte(signature), []byte(fields["Signature"])) {
+		return ErrSignature.New("signature doesn't match")
+	}
+	return nil
+}
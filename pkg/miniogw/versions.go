@@ -0,0 +1,131 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"io"
+
+	minio "github.com/minio/minio/cmd"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// The versioning requests of S3 (?versions and versionId) are not part of
+// the object layer of the minio release in use, so the methods below are
+// served by the VersionsHandler in front of minio.
+
+// ObjectVersionInfo is the information about a version of an object
+type ObjectVersionInfo struct {
+	minio.ObjectInfo
+
+	VersionID string
+	IsLatest  bool
+}
+
+// ListObjectVersionsInfo is the result of listing the versions of an object
+type ListObjectVersionsInfo struct {
+	IsTruncated bool
+
+	// NextVersionIDMarker is the marker for listing the next page
+	NextVersionIDMarker string
+
+	// Versions are sorted from the latest to the oldest
+	Versions []ObjectVersionInfo
+}
+
+// GetObjectVersion writes a version of an object to writer
+func (layer *gatewayLayer) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	readOnlyStream, err := layer.gateway.metainfo.GetObjectVersionStream(ctx, bucket, object, versionID)
+	if err != nil {
+		return convertError(err, bucket, object)
+	}
+
+	return layer.getObject(ctx, readOnlyStream, startOffset, length, writer)
+}
+
+// GetObjectVersionInfo returns information about a version of an object
+func (layer *gatewayLayer) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (info ObjectVersionInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	obj, err := layer.gateway.metainfo.GetObjectVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return ObjectVersionInfo{}, convertError(err, bucket, object)
+	}
+
+	return versionInfo(obj), nil
+}
+
+// DeleteObjectVersion permanently deletes a version of an object
+func (layer *gatewayLayer) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = layer.gateway.metainfo.DeleteObjectVersion(ctx, bucket, object, versionID)
+
+	return convertError(err, bucket, object)
+}
+
+// ListObjectVersions lists the versions of an object, starting with the
+// latest one. The object itself is included if versionIDMarker is empty.
+func (layer *gatewayLayer) ListObjectVersions(ctx context.Context, bucket, object, versionIDMarker string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if versionIDMarker == "" {
+		obj, err := layer.gateway.metainfo.GetObject(ctx, bucket, object)
+		if err != nil && !storj.ErrObjectNotFound.Has(err) {
+			return ListObjectVersionsInfo{}, convertError(err, bucket, object)
+		}
+		if err == nil {
+			result.Versions = append(result.Versions, versionInfo(obj))
+			maxKeys--
+		}
+	}
+
+	if maxKeys <= 0 && len(result.Versions) > 0 {
+		result.IsTruncated = true
+		result.NextVersionIDMarker = result.Versions[0].VersionID
+		return result, nil
+	}
+
+	list, err := layer.gateway.metainfo.ListObjectVersions(ctx, bucket, object, storj.ListOptions{
+		Cursor:    versionIDMarker,
+		Direction: storj.Before,
+		Limit:     maxKeys,
+	})
+	if err != nil {
+		return ListObjectVersionsInfo{}, convertError(err, bucket, object)
+	}
+
+	// versions are listed from the oldest to the latest
+	for i := len(list.Items) - 1; i >= 0; i-- {
+		result.Versions = append(result.Versions, versionInfo(list.Items[i]))
+	}
+
+	if list.More && len(list.Items) > 0 {
+		result.IsTruncated = true
+		result.NextVersionIDMarker = list.Items[0].VersionID
+	}
+
+	return result, nil
+}
+
+func versionInfo(obj storj.Object) ObjectVersionInfo {
+	etag, userDefined := objectETag(obj.Checksum, obj.Metadata)
+
+	return ObjectVersionInfo{
+		ObjectInfo: minio.ObjectInfo{
+			Name:        obj.Path,
+			Bucket:      obj.Bucket.Name,
+			ModTime:     obj.Modified,
+			Size:        obj.Size,
+			ETag:        etag,
+			ContentType: obj.ContentType,
+			UserDefined: userDefined,
+		},
+		VersionID: obj.VersionID,
+		IsLatest:  !obj.Archived,
+	}
+}
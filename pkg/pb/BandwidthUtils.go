@@ -58,3 +58,13 @@ func (m *RenterBandwidthAllocation) SetCerts(certs [][]byte) {
 func (m *RenterBandwidthAllocation) SetSignature(signature []byte) {
 	m.Signature = signature
 }
+
+//SetCerts updates the certs field, completing the auth.SignedMsg interface
+func (m *PieceHash) SetCerts(certs [][]byte) {
+	m.Certs = certs
+}
+
+//SetSignature updates the signature field, completing the auth.SignedMsg interface
+func (m *PieceHash) SetSignature(signature []byte) {
+	m.Signature = signature
+}
@@ -46,7 +46,33 @@ func (x AgreementsSummary_Status) String() string {
 	return proto.EnumName(AgreementsSummary_Status_name, int32(x))
 }
 func (AgreementsSummary_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_bandwidth_da05cbbeac812a9d, []int{0, 0}
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{0, 0}
+}
+
+type SettlementResult_Status int32
+
+const (
+	SettlementResult_RETRY    SettlementResult_Status = 0
+	SettlementResult_ACCEPTED SettlementResult_Status = 1
+	SettlementResult_REJECTED SettlementResult_Status = 2
+)
+
+var SettlementResult_Status_name = map[int32]string{
+	0: "RETRY",
+	1: "ACCEPTED",
+	2: "REJECTED",
+}
+var SettlementResult_Status_value = map[string]int32{
+	"RETRY":    0,
+	"ACCEPTED": 1,
+	"REJECTED": 2,
+}
+
+func (x SettlementResult_Status) String() string {
+	return proto.EnumName(SettlementResult_Status_name, int32(x))
+}
+func (SettlementResult_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{3, 0}
 }
 
 type AgreementsSummary struct {
@@ -60,7 +86,7 @@ func (m *AgreementsSummary) Reset()         { *m = AgreementsSummary{} }
 func (m *AgreementsSummary) String() string { return proto.CompactTextString(m) }
 func (*AgreementsSummary) ProtoMessage()    {}
 func (*AgreementsSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bandwidth_da05cbbeac812a9d, []int{0}
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{0}
 }
 func (m *AgreementsSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AgreementsSummary.Unmarshal(m, b)
@@ -87,9 +113,138 @@ func (m *AgreementsSummary) GetStatus() AgreementsSummary_Status {
 	return AgreementsSummary_FAIL
 }
 
+// SettlementRequest is a batch of bandwidth agreements to settle
+type SettlementRequest struct {
+	Allocations          []*RenterBandwidthAllocation `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *SettlementRequest) Reset()         { *m = SettlementRequest{} }
+func (m *SettlementRequest) String() string { return proto.CompactTextString(m) }
+func (*SettlementRequest) ProtoMessage()    {}
+func (*SettlementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{1}
+}
+func (m *SettlementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettlementRequest.Unmarshal(m, b)
+}
+func (m *SettlementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettlementRequest.Marshal(b, m, deterministic)
+}
+func (dst *SettlementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettlementRequest.Merge(dst, src)
+}
+func (m *SettlementRequest) XXX_Size() int {
+	return xxx_messageInfo_SettlementRequest.Size(m)
+}
+func (m *SettlementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettlementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettlementRequest proto.InternalMessageInfo
+
+func (m *SettlementRequest) GetAllocations() []*RenterBandwidthAllocation {
+	if m != nil {
+		return m.Allocations
+	}
+	return nil
+}
+
+// SettlementResponse has the result of each agreement of a batch, in the
+// order of the request
+type SettlementResponse struct {
+	Results              []*SettlementResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SettlementResponse) Reset()         { *m = SettlementResponse{} }
+func (m *SettlementResponse) String() string { return proto.CompactTextString(m) }
+func (*SettlementResponse) ProtoMessage()    {}
+func (*SettlementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{2}
+}
+func (m *SettlementResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettlementResponse.Unmarshal(m, b)
+}
+func (m *SettlementResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettlementResponse.Marshal(b, m, deterministic)
+}
+func (dst *SettlementResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettlementResponse.Merge(dst, src)
+}
+func (m *SettlementResponse) XXX_Size() int {
+	return xxx_messageInfo_SettlementResponse.Size(m)
+}
+func (m *SettlementResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettlementResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettlementResponse proto.InternalMessageInfo
+
+func (m *SettlementResponse) GetResults() []*SettlementResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type SettlementResult struct {
+	Status               SettlementResult_Status `protobuf:"varint,1,opt,name=status,proto3,enum=bandwidth.SettlementResult_Status" json:"status,omitempty"`
+	Reason               string                  `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SettlementResult) Reset()         { *m = SettlementResult{} }
+func (m *SettlementResult) String() string { return proto.CompactTextString(m) }
+func (*SettlementResult) ProtoMessage()    {}
+func (*SettlementResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_d2f45add4452a4db, []int{3}
+}
+func (m *SettlementResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettlementResult.Unmarshal(m, b)
+}
+func (m *SettlementResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettlementResult.Marshal(b, m, deterministic)
+}
+func (dst *SettlementResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettlementResult.Merge(dst, src)
+}
+func (m *SettlementResult) XXX_Size() int {
+	return xxx_messageInfo_SettlementResult.Size(m)
+}
+func (m *SettlementResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettlementResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettlementResult proto.InternalMessageInfo
+
+func (m *SettlementResult) GetStatus() SettlementResult_Status {
+	if m != nil {
+		return m.Status
+	}
+	return SettlementResult_RETRY
+}
+
+func (m *SettlementResult) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AgreementsSummary)(nil), "bandwidth.AgreementsSummary")
+	proto.RegisterType((*SettlementRequest)(nil), "bandwidth.SettlementRequest")
+	proto.RegisterType((*SettlementResponse)(nil), "bandwidth.SettlementResponse")
+	proto.RegisterType((*SettlementResult)(nil), "bandwidth.SettlementResult")
 	proto.RegisterEnum("bandwidth.AgreementsSummary_Status", AgreementsSummary_Status_name, AgreementsSummary_Status_value)
+	proto.RegisterEnum("bandwidth.SettlementResult_Status", SettlementResult_Status_name, SettlementResult_Status_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -105,6 +260,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type BandwidthClient interface {
 	BandwidthAgreements(ctx context.Context, in *RenterBandwidthAllocation, opts ...grpc.CallOption) (*AgreementsSummary, error)
+	Settlement(ctx context.Context, opts ...grpc.CallOption) (Bandwidth_SettlementClient, error)
 }
 
 type bandwidthClient struct {
@@ -124,9 +280,41 @@ func (c *bandwidthClient) BandwidthAgreements(ctx context.Context, in *RenterBan
 	return out, nil
 }
 
+func (c *bandwidthClient) Settlement(ctx context.Context, opts ...grpc.CallOption) (Bandwidth_SettlementClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Bandwidth_serviceDesc.Streams[0], "/bandwidth.Bandwidth/Settlement", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bandwidthSettlementClient{stream}
+	return x, nil
+}
+
+type Bandwidth_SettlementClient interface {
+	Send(*SettlementRequest) error
+	Recv() (*SettlementResponse, error)
+	grpc.ClientStream
+}
+
+type bandwidthSettlementClient struct {
+	grpc.ClientStream
+}
+
+func (x *bandwidthSettlementClient) Send(m *SettlementRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bandwidthSettlementClient) Recv() (*SettlementResponse, error) {
+	m := new(SettlementResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BandwidthServer is the server API for Bandwidth service.
 type BandwidthServer interface {
 	BandwidthAgreements(context.Context, *RenterBandwidthAllocation) (*AgreementsSummary, error)
+	Settlement(Bandwidth_SettlementServer) error
 }
 
 func RegisterBandwidthServer(s *grpc.Server, srv BandwidthServer) {
@@ -151,6 +339,32 @@ func _Bandwidth_BandwidthAgreements_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bandwidth_Settlement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BandwidthServer).Settlement(&bandwidthSettlementServer{stream})
+}
+
+type Bandwidth_SettlementServer interface {
+	Send(*SettlementResponse) error
+	Recv() (*SettlementRequest, error)
+	grpc.ServerStream
+}
+
+type bandwidthSettlementServer struct {
+	grpc.ServerStream
+}
+
+func (x *bandwidthSettlementServer) Send(m *SettlementResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bandwidthSettlementServer) Recv() (*SettlementRequest, error) {
+	m := new(SettlementRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Bandwidth_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "bandwidth.Bandwidth",
 	HandlerType: (*BandwidthServer)(nil),
@@ -160,26 +374,42 @@ var _Bandwidth_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Bandwidth_BandwidthAgreements_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Settlement",
+			Handler:       _Bandwidth_Settlement_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "bandwidth.proto",
 }
 
-func init() { proto.RegisterFile("bandwidth.proto", fileDescriptor_bandwidth_da05cbbeac812a9d) }
-
-var fileDescriptor_bandwidth_da05cbbeac812a9d = []byte{
-	// 210 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4f, 0x4a, 0xcc, 0x4b,
-	0x29, 0xcf, 0x4c, 0x29, 0xc9, 0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x0b, 0x48,
-	0x09, 0x14, 0x64, 0xa6, 0x26, 0xa7, 0x16, 0x97, 0xe4, 0x17, 0xa5, 0x42, 0x24, 0x95, 0xaa, 0xb8,
-	0x04, 0x1d, 0xd3, 0x8b, 0x52, 0x53, 0x73, 0x53, 0xf3, 0x4a, 0x8a, 0x83, 0x4b, 0x73, 0x73, 0x13,
-	0x8b, 0x2a, 0x85, 0xac, 0xb9, 0xd8, 0x8a, 0x4b, 0x12, 0x4b, 0x4a, 0x8b, 0x25, 0x18, 0x15, 0x18,
-	0x35, 0xf8, 0x8c, 0x94, 0xf5, 0x10, 0x66, 0x62, 0xa8, 0xd6, 0x0b, 0x06, 0x2b, 0x0d, 0x82, 0x6a,
-	0x51, 0xd2, 0xe0, 0x62, 0x83, 0x88, 0x08, 0x71, 0x70, 0xb1, 0xb8, 0x39, 0x7a, 0xfa, 0x08, 0x30,
-	0x08, 0xb1, 0x71, 0x31, 0xf9, 0x7b, 0x0b, 0x30, 0x0a, 0xf1, 0x70, 0x71, 0x04, 0xb9, 0x7a, 0xb9,
-	0x3a, 0x87, 0xb8, 0xba, 0x08, 0x30, 0x19, 0xe5, 0x73, 0x71, 0x3a, 0xc1, 0xcc, 0x15, 0x4a, 0xe2,
-	0x12, 0x86, 0x73, 0x10, 0x76, 0x08, 0x69, 0xeb, 0x21, 0x9c, 0x5c, 0x94, 0x5f, 0x5a, 0x92, 0x5a,
-	0xac, 0x17, 0x94, 0x9a, 0x57, 0x92, 0x5a, 0x84, 0x50, 0x9c, 0x93, 0x93, 0x9f, 0x9c, 0x58, 0x92,
-	0x99, 0x9f, 0x27, 0x25, 0x83, 0xcf, 0x9d, 0x4a, 0x0c, 0x4e, 0x2c, 0x51, 0x4c, 0x05, 0x49, 0x49,
-	0x6c, 0x60, 0x9f, 0x1b, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x2a, 0x6a, 0xd9, 0xfd, 0x29, 0x01,
-	0x00, 0x00,
+func init() { proto.RegisterFile("bandwidth.proto", fileDescriptor_bandwidth_d2f45add4452a4db) }
+
+var fileDescriptor_bandwidth_d2f45add4452a4db = []byte{
+	// 361 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xdf, 0x6a, 0xe2, 0x40,
+	0x14, 0xc6, 0x33, 0x59, 0x37, 0x6b, 0x8e, 0xcb, 0x6e, 0x9c, 0x85, 0x45, 0x5c, 0x17, 0x64, 0xf6,
+	0x26, 0xb0, 0x90, 0x5d, 0x2c, 0xbd, 0x69, 0xaf, 0xa2, 0x4d, 0xa1, 0xb5, 0xc5, 0x32, 0x7a, 0xd3,
+	0xde, 0x25, 0x7a, 0x68, 0x85, 0x98, 0x49, 0x67, 0x26, 0x94, 0xf6, 0x45, 0xfa, 0x3a, 0x7d, 0xb4,
+	0x62, 0xd4, 0xc4, 0xa6, 0x28, 0xf4, 0x72, 0xce, 0xf9, 0xce, 0xbf, 0xdf, 0x37, 0xf0, 0x3d, 0x0a,
+	0x93, 0xd9, 0xc3, 0x7c, 0xa6, 0xef, 0xbc, 0x54, 0x0a, 0x2d, 0xa8, 0x5d, 0x04, 0xda, 0x4e, 0x3a,
+	0xc7, 0x29, 0x2a, 0x2d, 0x24, 0xae, 0x92, 0xec, 0x09, 0x9a, 0xfe, 0xad, 0x44, 0x5c, 0x60, 0xa2,
+	0xd5, 0x38, 0x5b, 0x2c, 0x42, 0xf9, 0x48, 0x8f, 0xc1, 0x52, 0x3a, 0xd4, 0x99, 0x6a, 0x91, 0x2e,
+	0x71, 0xbf, 0xf5, 0xfe, 0x78, 0x65, 0xcf, 0x77, 0x6a, 0x6f, 0x9c, 0x4b, 0xf9, 0xba, 0x84, 0xb9,
+	0x60, 0xad, 0x22, 0xb4, 0x0e, 0xb5, 0x53, 0xff, 0xec, 0xc2, 0x31, 0xa8, 0x05, 0xe6, 0x68, 0xe8,
+	0x10, 0xfa, 0x15, 0xea, 0x3c, 0x38, 0x0f, 0x06, 0x93, 0xe0, 0xc4, 0x31, 0x59, 0x04, 0xcd, 0x31,
+	0x6a, 0x1d, 0xe7, 0xed, 0x38, 0xde, 0x67, 0xa8, 0x34, 0xbd, 0x84, 0x46, 0x18, 0xc7, 0x62, 0x1a,
+	0xea, 0xb9, 0x48, 0x96, 0x0b, 0x7c, 0x72, 0x1b, 0xbd, 0xbf, 0x5e, 0xb9, 0xb8, 0x14, 0x99, 0x46,
+	0xe5, 0x71, 0x4c, 0x34, 0xca, 0xfe, 0x66, 0x2f, 0xbf, 0xa8, 0xe1, 0xdb, 0xf5, 0x6c, 0x08, 0x74,
+	0x7b, 0x86, 0x4a, 0x45, 0xa2, 0x90, 0x1e, 0xc2, 0x17, 0x89, 0x2a, 0x8b, 0xf5, 0x66, 0xc0, 0xaf,
+	0xad, 0x0b, 0xdf, 0xe8, 0xb3, 0x58, 0xf3, 0x8d, 0x96, 0x3d, 0x13, 0x70, 0xaa, 0x59, 0x7a, 0x54,
+	0x81, 0xc5, 0xf6, 0xb4, 0xaa, 0xb0, 0xa2, 0x3f, 0xc1, 0x92, 0x18, 0x2a, 0x91, 0xb4, 0xcc, 0x2e,
+	0x71, 0x6d, 0xbe, 0x7e, 0xb1, 0x7f, 0x05, 0x43, 0x1b, 0x3e, 0xf3, 0x60, 0xc2, 0xaf, 0x1d, 0x63,
+	0x09, 0xcf, 0x1f, 0x0c, 0x82, 0xab, 0x25, 0xbc, 0x0a, 0xca, 0xde, 0x0b, 0x01, 0xbb, 0x60, 0x41,
+	0x23, 0xf8, 0x51, 0x82, 0x29, 0xfc, 0xa2, 0x1f, 0xa1, 0xd8, 0xee, 0xec, 0xf3, 0x9c, 0x19, 0x74,
+	0x04, 0x50, 0x5e, 0x47, 0x3b, 0x3b, 0x8e, 0xce, 0x3d, 0x6d, 0xff, 0xde, 0x91, 0x5d, 0xb9, 0xc1,
+	0x0c, 0x97, 0xfc, 0x27, 0xfd, 0xda, 0x8d, 0x99, 0x46, 0x91, 0x95, 0x7f, 0xcb, 0x83, 0xd7, 0x01,
+	0x00, 0x7a, 0x63, 0xa3, 0xd0, 0xc6, 0x02, 0x00, 0x00,
 }
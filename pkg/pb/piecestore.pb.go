@@ -54,7 +54,7 @@ func (x BandwidthAction) String() string {
 	return proto.EnumName(BandwidthAction_name, int32(x))
 }
 func (BandwidthAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{0}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{0}
 }
 
 type PayerBandwidthAllocation struct {
@@ -67,6 +67,8 @@ type PayerBandwidthAllocation struct {
 	CreatedUnixSec       int64           `protobuf:"varint,7,opt,name=created_unix_sec,json=createdUnixSec,proto3" json:"created_unix_sec,omitempty"`
 	Certs                [][]byte        `protobuf:"bytes,8,rep,name=certs,proto3" json:"certs,omitempty"`
 	Signature            []byte          `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	ProjectId            []byte          `protobuf:"bytes,10,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Bucket               string          `protobuf:"bytes,11,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -76,7 +78,7 @@ func (m *PayerBandwidthAllocation) Reset()         { *m = PayerBandwidthAllocati
 func (m *PayerBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation) ProtoMessage()    {}
 func (*PayerBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{0}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{0}
 }
 func (m *PayerBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation.Unmarshal(m, b)
@@ -145,6 +147,20 @@ func (m *PayerBandwidthAllocation) GetSignature() []byte {
 	return nil
 }
 
+func (m *PayerBandwidthAllocation) GetProjectId() []byte {
+	if m != nil {
+		return m.ProjectId
+	}
+	return nil
+}
+
+func (m *PayerBandwidthAllocation) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
 type RenterBandwidthAllocation struct {
 	PayerAllocation      PayerBandwidthAllocation `protobuf:"bytes,1,opt,name=payer_allocation,json=payerAllocation,proto3" json:"payer_allocation"`
 	Total                int64                    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
@@ -160,7 +176,7 @@ func (m *RenterBandwidthAllocation) Reset()         { *m = RenterBandwidthAlloca
 func (m *RenterBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation) ProtoMessage()    {}
 func (*RenterBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{1}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{1}
 }
 func (m *RenterBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation.Unmarshal(m, b)
@@ -221,7 +237,7 @@ func (m *PieceStore) Reset()         { *m = PieceStore{} }
 func (m *PieceStore) String() string { return proto.CompactTextString(m) }
 func (*PieceStore) ProtoMessage()    {}
 func (*PieceStore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{2}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{2}
 }
 func (m *PieceStore) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore.Unmarshal(m, b)
@@ -276,7 +292,7 @@ func (m *PieceStore_PieceData) Reset()         { *m = PieceStore_PieceData{} }
 func (m *PieceStore_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceStore_PieceData) ProtoMessage()    {}
 func (*PieceStore_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{2, 0}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{2, 0}
 }
 func (m *PieceStore_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore_PieceData.Unmarshal(m, b)
@@ -330,7 +346,7 @@ func (m *PieceId) Reset()         { *m = PieceId{} }
 func (m *PieceId) String() string { return proto.CompactTextString(m) }
 func (*PieceId) ProtoMessage()    {}
 func (*PieceId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{3}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{3}
 }
 func (m *PieceId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceId.Unmarshal(m, b)
@@ -368,6 +384,8 @@ type PieceSummary struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	PieceSize            int64    `protobuf:"varint,2,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
 	ExpirationUnixSec    int64    `protobuf:"varint,3,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	HashBlockSize        int64    `protobuf:"varint,4,opt,name=hash_block_size,json=hashBlockSize,proto3" json:"hash_block_size,omitempty"`
+	BlockHashes          [][]byte `protobuf:"bytes,5,rep,name=block_hashes,json=blockHashes,proto3" json:"block_hashes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -377,7 +395,7 @@ func (m *PieceSummary) Reset()         { *m = PieceSummary{} }
 func (m *PieceSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceSummary) ProtoMessage()    {}
 func (*PieceSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{4}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{4}
 }
 func (m *PieceSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceSummary.Unmarshal(m, b)
@@ -418,6 +436,20 @@ func (m *PieceSummary) GetExpirationUnixSec() int64 {
 	return 0
 }
 
+func (m *PieceSummary) GetHashBlockSize() int64 {
+	if m != nil {
+		return m.HashBlockSize
+	}
+	return 0
+}
+
+func (m *PieceSummary) GetBlockHashes() [][]byte {
+	if m != nil {
+		return m.BlockHashes
+	}
+	return nil
+}
+
 type PieceRetrieval struct {
 	BandwidthAllocation  *RenterBandwidthAllocation `protobuf:"bytes,1,opt,name=bandwidth_allocation,json=bandwidthAllocation,proto3" json:"bandwidth_allocation,omitempty"`
 	PieceData            *PieceRetrieval_PieceData  `protobuf:"bytes,2,opt,name=piece_data,json=pieceData,proto3" json:"piece_data,omitempty"`
@@ -431,7 +463,7 @@ func (m *PieceRetrieval) Reset()         { *m = PieceRetrieval{} }
 func (m *PieceRetrieval) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval) ProtoMessage()    {}
 func (*PieceRetrieval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{5}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{5}
 }
 func (m *PieceRetrieval) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval.Unmarshal(m, b)
@@ -486,7 +518,7 @@ func (m *PieceRetrieval_PieceData) Reset()         { *m = PieceRetrieval_PieceDa
 func (m *PieceRetrieval_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval_PieceData) ProtoMessage()    {}
 func (*PieceRetrieval_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{5, 0}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{5, 0}
 }
 func (m *PieceRetrieval_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval_PieceData.Unmarshal(m, b)
@@ -539,7 +571,7 @@ func (m *PieceRetrievalStream) Reset()         { *m = PieceRetrievalStream{} }
 func (m *PieceRetrievalStream) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrievalStream) ProtoMessage()    {}
 func (*PieceRetrievalStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{6}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{6}
 }
 func (m *PieceRetrievalStream) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrievalStream.Unmarshal(m, b)
@@ -586,7 +618,7 @@ func (m *PieceDelete) Reset()         { *m = PieceDelete{} }
 func (m *PieceDelete) String() string { return proto.CompactTextString(m) }
 func (*PieceDelete) ProtoMessage()    {}
 func (*PieceDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{7}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{7}
 }
 func (m *PieceDelete) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDelete.Unmarshal(m, b)
@@ -631,7 +663,7 @@ func (m *PieceDeleteSummary) Reset()         { *m = PieceDeleteSummary{} }
 func (m *PieceDeleteSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceDeleteSummary) ProtoMessage()    {}
 func (*PieceDeleteSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{8}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{8}
 }
 func (m *PieceDeleteSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDeleteSummary.Unmarshal(m, b)
@@ -658,19 +690,92 @@ func (m *PieceDeleteSummary) GetMessage() string {
 	return ""
 }
 
-type PieceStoreSummary struct {
-	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	TotalReceived        int64    `protobuf:"varint,2,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+// PieceHash is the hash of the content of a stored piece, signed by the
+// storage node
+type PieceHash struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockSize            int64    `protobuf:"varint,5,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	Certs                [][]byte `protobuf:"bytes,3,rep,name=certs,proto3" json:"certs,omitempty"`
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *PieceHash) Reset()         { *m = PieceHash{} }
+func (m *PieceHash) String() string { return proto.CompactTextString(m) }
+func (*PieceHash) ProtoMessage()    {}
+func (*PieceHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{9}
+}
+func (m *PieceHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceHash.Unmarshal(m, b)
+}
+func (m *PieceHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceHash.Marshal(b, m, deterministic)
+}
+func (dst *PieceHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceHash.Merge(dst, src)
+}
+func (m *PieceHash) XXX_Size() int {
+	return xxx_messageInfo_PieceHash.Size(m)
+}
+func (m *PieceHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceHash proto.InternalMessageInfo
+
+func (m *PieceHash) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PieceHash) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *PieceHash) GetBlockSize() int64 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
+
+func (m *PieceHash) GetCerts() [][]byte {
+	if m != nil {
+		return m.Certs
+	}
+	return nil
+}
+
+func (m *PieceHash) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type PieceStoreSummary struct {
+	Message              string     `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TotalReceived        int64      `protobuf:"varint,2,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+	Hash                 *PieceHash `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *PieceStoreSummary) Reset()         { *m = PieceStoreSummary{} }
 func (m *PieceStoreSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceStoreSummary) ProtoMessage()    {}
 func (*PieceStoreSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{9}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{10}
 }
 func (m *PieceStoreSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStoreSummary.Unmarshal(m, b)
@@ -704,6 +809,321 @@ func (m *PieceStoreSummary) GetTotalReceived() int64 {
 	return 0
 }
 
+func (m *PieceStoreSummary) GetHash() *PieceHash {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// RetainRequest asks a storage node to move to the trash the pieces of the
+// calling satellite which are not in the filter
+type RetainRequest struct {
+	CreationUnixSec      int64    `protobuf:"varint,1,opt,name=creation_unix_sec,json=creationUnixSec,proto3" json:"creation_unix_sec,omitempty"`
+	Filter               []byte   `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainRequest) Reset()         { *m = RetainRequest{} }
+func (m *RetainRequest) String() string { return proto.CompactTextString(m) }
+func (*RetainRequest) ProtoMessage()    {}
+func (*RetainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{11}
+}
+func (m *RetainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainRequest.Unmarshal(m, b)
+}
+func (m *RetainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainRequest.Marshal(b, m, deterministic)
+}
+func (dst *RetainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainRequest.Merge(dst, src)
+}
+func (m *RetainRequest) XXX_Size() int {
+	return xxx_messageInfo_RetainRequest.Size(m)
+}
+func (m *RetainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainRequest proto.InternalMessageInfo
+
+func (m *RetainRequest) GetCreationUnixSec() int64 {
+	if m != nil {
+		return m.CreationUnixSec
+	}
+	return 0
+}
+
+func (m *RetainRequest) GetFilter() []byte {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type RetainSummary struct {
+	Trashed              int64    `protobuf:"varint,1,opt,name=trashed,proto3" json:"trashed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainSummary) Reset()         { *m = RetainSummary{} }
+func (m *RetainSummary) String() string { return proto.CompactTextString(m) }
+func (*RetainSummary) ProtoMessage()    {}
+func (*RetainSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{12}
+}
+func (m *RetainSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainSummary.Unmarshal(m, b)
+}
+func (m *RetainSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainSummary.Marshal(b, m, deterministic)
+}
+func (dst *RetainSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainSummary.Merge(dst, src)
+}
+func (m *RetainSummary) XXX_Size() int {
+	return xxx_messageInfo_RetainSummary.Size(m)
+}
+func (m *RetainSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainSummary proto.InternalMessageInfo
+
+func (m *RetainSummary) GetTrashed() int64 {
+	if m != nil {
+		return m.Trashed
+	}
+	return 0
+}
+
+// RestoreTrashRequest asks a storage node to restore the pieces of the
+// calling satellite from the trash
+type RestoreTrashRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashRequest) Reset()         { *m = RestoreTrashRequest{} }
+func (m *RestoreTrashRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashRequest) ProtoMessage()    {}
+func (*RestoreTrashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{13}
+}
+func (m *RestoreTrashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashRequest.Unmarshal(m, b)
+}
+func (m *RestoreTrashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashRequest.Merge(dst, src)
+}
+func (m *RestoreTrashRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashRequest.Size(m)
+}
+func (m *RestoreTrashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashRequest proto.InternalMessageInfo
+
+type RestoreTrashSummary struct {
+	Restored             int64    `protobuf:"varint,1,opt,name=restored,proto3" json:"restored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashSummary) Reset()         { *m = RestoreTrashSummary{} }
+func (m *RestoreTrashSummary) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashSummary) ProtoMessage()    {}
+func (*RestoreTrashSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{14}
+}
+func (m *RestoreTrashSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashSummary.Unmarshal(m, b)
+}
+func (m *RestoreTrashSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashSummary.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashSummary.Merge(dst, src)
+}
+func (m *RestoreTrashSummary) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashSummary.Size(m)
+}
+func (m *RestoreTrashSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashSummary proto.InternalMessageInfo
+
+func (m *RestoreTrashSummary) GetRestored() int64 {
+	if m != nil {
+		return m.Restored
+	}
+	return 0
+}
+
+// ExitRequest asks a storage node to gracefully exit the satellites, or all
+// the satellites it stores pieces for when none is given. Only the operator
+// of the node can request it, using the identity of the node.
+type ExitRequest struct {
+	SatelliteIds         []NodeID `protobuf:"bytes,1,rep,name=satellite_ids,json=satelliteIds,proto3,customtype=NodeID" json:"satellite_ids"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExitRequest) Reset()         { *m = ExitRequest{} }
+func (m *ExitRequest) String() string { return proto.CompactTextString(m) }
+func (*ExitRequest) ProtoMessage()    {}
+func (*ExitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{15}
+}
+func (m *ExitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitRequest.Unmarshal(m, b)
+}
+func (m *ExitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitRequest.Merge(dst, src)
+}
+func (m *ExitRequest) XXX_Size() int {
+	return xxx_messageInfo_ExitRequest.Size(m)
+}
+func (m *ExitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitRequest proto.InternalMessageInfo
+
+type ExitSummary struct {
+	Exits                []*ExitStatus `protobuf:"bytes,1,rep,name=exits,proto3" json:"exits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExitSummary) Reset()         { *m = ExitSummary{} }
+func (m *ExitSummary) String() string { return proto.CompactTextString(m) }
+func (*ExitSummary) ProtoMessage()    {}
+func (*ExitSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{16}
+}
+func (m *ExitSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitSummary.Unmarshal(m, b)
+}
+func (m *ExitSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitSummary.Marshal(b, m, deterministic)
+}
+func (dst *ExitSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitSummary.Merge(dst, src)
+}
+func (m *ExitSummary) XXX_Size() int {
+	return xxx_messageInfo_ExitSummary.Size(m)
+}
+func (m *ExitSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitSummary proto.InternalMessageInfo
+
+func (m *ExitSummary) GetExits() []*ExitStatus {
+	if m != nil {
+		return m.Exits
+	}
+	return nil
+}
+
+// ExitStatus is the progress of the graceful exit from a satellite
+type ExitStatus struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	StartedUnixSec       int64    `protobuf:"varint,2,opt,name=started_unix_sec,json=startedUnixSec,proto3" json:"started_unix_sec,omitempty"`
+	FinishedUnixSec      int64    `protobuf:"varint,3,opt,name=finished_unix_sec,json=finishedUnixSec,proto3" json:"finished_unix_sec,omitempty"`
+	PiecesTransferred    int64    `protobuf:"varint,4,opt,name=pieces_transferred,json=piecesTransferred,proto3" json:"pieces_transferred,omitempty"`
+	PiecesFailed         int64    `protobuf:"varint,5,opt,name=pieces_failed,json=piecesFailed,proto3" json:"pieces_failed,omitempty"`
+	BytesTransferred     int64    `protobuf:"varint,6,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	PiecesRemaining      int64    `protobuf:"varint,7,opt,name=pieces_remaining,json=piecesRemaining,proto3" json:"pieces_remaining,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExitStatus) Reset()         { *m = ExitStatus{} }
+func (m *ExitStatus) String() string { return proto.CompactTextString(m) }
+func (*ExitStatus) ProtoMessage()    {}
+func (*ExitStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{17}
+}
+func (m *ExitStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitStatus.Unmarshal(m, b)
+}
+func (m *ExitStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitStatus.Marshal(b, m, deterministic)
+}
+func (dst *ExitStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitStatus.Merge(dst, src)
+}
+func (m *ExitStatus) XXX_Size() int {
+	return xxx_messageInfo_ExitStatus.Size(m)
+}
+func (m *ExitStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitStatus proto.InternalMessageInfo
+
+func (m *ExitStatus) GetStartedUnixSec() int64 {
+	if m != nil {
+		return m.StartedUnixSec
+	}
+	return 0
+}
+
+func (m *ExitStatus) GetFinishedUnixSec() int64 {
+	if m != nil {
+		return m.FinishedUnixSec
+	}
+	return 0
+}
+
+func (m *ExitStatus) GetPiecesTransferred() int64 {
+	if m != nil {
+		return m.PiecesTransferred
+	}
+	return 0
+}
+
+func (m *ExitStatus) GetPiecesFailed() int64 {
+	if m != nil {
+		return m.PiecesFailed
+	}
+	return 0
+}
+
+func (m *ExitStatus) GetBytesTransferred() int64 {
+	if m != nil {
+		return m.BytesTransferred
+	}
+	return 0
+}
+
+func (m *ExitStatus) GetPiecesRemaining() int64 {
+	if m != nil {
+		return m.PiecesRemaining
+	}
+	return 0
+}
+
 type StatsReq struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -714,7 +1134,7 @@ func (m *StatsReq) Reset()         { *m = StatsReq{} }
 func (m *StatsReq) String() string { return proto.CompactTextString(m) }
 func (*StatsReq) ProtoMessage()    {}
 func (*StatsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{10}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{18}
 }
 func (m *StatsReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatsReq.Unmarshal(m, b)
@@ -735,20 +1155,21 @@ func (m *StatsReq) XXX_DiscardUnknown() {
 var xxx_messageInfo_StatsReq proto.InternalMessageInfo
 
 type StatSummary struct {
-	UsedSpace            int64    `protobuf:"varint,1,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
-	AvailableSpace       int64    `protobuf:"varint,2,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
-	UsedBandwidth        int64    `protobuf:"varint,3,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
-	AvailableBandwidth   int64    `protobuf:"varint,4,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UsedSpace            int64             `protobuf:"varint,1,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	AvailableSpace       int64             `protobuf:"varint,2,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
+	UsedBandwidth        int64             `protobuf:"varint,3,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
+	AvailableBandwidth   int64             `protobuf:"varint,4,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
+	Satellites           []*SatelliteStats `protobuf:"bytes,5,rep,name=satellites,proto3" json:"satellites,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StatSummary) Reset()         { *m = StatSummary{} }
 func (m *StatSummary) String() string { return proto.CompactTextString(m) }
 func (*StatSummary) ProtoMessage()    {}
 func (*StatSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{11}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{19}
 }
 func (m *StatSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummary.Unmarshal(m, b)
@@ -796,6 +1217,77 @@ func (m *StatSummary) GetAvailableBandwidth() int64 {
 	return 0
 }
 
+func (m *StatSummary) GetSatellites() []*SatelliteStats {
+	if m != nil {
+		return m.Satellites
+	}
+	return nil
+}
+
+// SatelliteStats is the usage and the remaining allocation of a satellite
+type SatelliteStats struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	UsedSpace            int64    `protobuf:"varint,2,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	AvailableSpace       int64    `protobuf:"varint,3,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
+	UsedBandwidth        int64    `protobuf:"varint,4,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
+	AvailableBandwidth   int64    `protobuf:"varint,5,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SatelliteStats) Reset()         { *m = SatelliteStats{} }
+func (m *SatelliteStats) String() string { return proto.CompactTextString(m) }
+func (*SatelliteStats) ProtoMessage()    {}
+func (*SatelliteStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{20}
+}
+func (m *SatelliteStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteStats.Unmarshal(m, b)
+}
+func (m *SatelliteStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteStats.Marshal(b, m, deterministic)
+}
+func (dst *SatelliteStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteStats.Merge(dst, src)
+}
+func (m *SatelliteStats) XXX_Size() int {
+	return xxx_messageInfo_SatelliteStats.Size(m)
+}
+func (m *SatelliteStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteStats proto.InternalMessageInfo
+
+func (m *SatelliteStats) GetUsedSpace() int64 {
+	if m != nil {
+		return m.UsedSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAvailableSpace() int64 {
+	if m != nil {
+		return m.AvailableSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetUsedBandwidth() int64 {
+	if m != nil {
+		return m.UsedBandwidth
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAvailableBandwidth() int64 {
+	if m != nil {
+		return m.AvailableBandwidth
+	}
+	return 0
+}
+
 type SignedMessage struct {
 	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -809,7 +1301,7 @@ func (m *SignedMessage) Reset()         { *m = SignedMessage{} }
 func (m *SignedMessage) String() string { return proto.CompactTextString(m) }
 func (*SignedMessage) ProtoMessage()    {}
 func (*SignedMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{12}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{21}
 }
 func (m *SignedMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedMessage.Unmarshal(m, b)
@@ -860,7 +1352,7 @@ func (m *DashboardReq) Reset()         { *m = DashboardReq{} }
 func (m *DashboardReq) String() string { return proto.CompactTextString(m) }
 func (*DashboardReq) ProtoMessage()    {}
 func (*DashboardReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{13}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{22}
 }
 func (m *DashboardReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardReq.Unmarshal(m, b)
@@ -889,6 +1381,7 @@ type DashboardStats struct {
 	Stats                *StatSummary       `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
 	Connection           bool               `protobuf:"varint,7,opt,name=connection,proto3" json:"connection,omitempty"`
 	Uptime               *duration.Duration `protobuf:"bytes,8,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Exits                []*ExitStatus      `protobuf:"bytes,9,rep,name=exits,proto3" json:"exits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -898,7 +1391,7 @@ func (m *DashboardStats) Reset()         { *m = DashboardStats{} }
 func (m *DashboardStats) String() string { return proto.CompactTextString(m) }
 func (*DashboardStats) ProtoMessage()    {}
 func (*DashboardStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_ef88e5b6fd61ab5b, []int{14}
+	return fileDescriptor_piecestore_fd3f4d789d139767, []int{23}
 }
 func (m *DashboardStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardStats.Unmarshal(m, b)
@@ -974,6 +1467,13 @@ func (m *DashboardStats) GetUptime() *duration.Duration {
 	return nil
 }
 
+func (m *DashboardStats) GetExits() []*ExitStatus {
+	if m != nil {
+		return m.Exits
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PayerBandwidthAllocation)(nil), "piecestoreroutes.PayerBandwidthAllocation")
 	proto.RegisterType((*RenterBandwidthAllocation)(nil), "piecestoreroutes.RenterBandwidthAllocation")
@@ -986,9 +1486,18 @@ func init() {
 	proto.RegisterType((*PieceRetrievalStream)(nil), "piecestoreroutes.PieceRetrievalStream")
 	proto.RegisterType((*PieceDelete)(nil), "piecestoreroutes.PieceDelete")
 	proto.RegisterType((*PieceDeleteSummary)(nil), "piecestoreroutes.PieceDeleteSummary")
+	proto.RegisterType((*PieceHash)(nil), "piecestoreroutes.PieceHash")
 	proto.RegisterType((*PieceStoreSummary)(nil), "piecestoreroutes.PieceStoreSummary")
+	proto.RegisterType((*RetainRequest)(nil), "piecestoreroutes.RetainRequest")
+	proto.RegisterType((*RetainSummary)(nil), "piecestoreroutes.RetainSummary")
+	proto.RegisterType((*RestoreTrashRequest)(nil), "piecestoreroutes.RestoreTrashRequest")
+	proto.RegisterType((*RestoreTrashSummary)(nil), "piecestoreroutes.RestoreTrashSummary")
+	proto.RegisterType((*ExitRequest)(nil), "piecestoreroutes.ExitRequest")
+	proto.RegisterType((*ExitSummary)(nil), "piecestoreroutes.ExitSummary")
+	proto.RegisterType((*ExitStatus)(nil), "piecestoreroutes.ExitStatus")
 	proto.RegisterType((*StatsReq)(nil), "piecestoreroutes.StatsReq")
 	proto.RegisterType((*StatSummary)(nil), "piecestoreroutes.StatSummary")
+	proto.RegisterType((*SatelliteStats)(nil), "piecestoreroutes.SatelliteStats")
 	proto.RegisterType((*SignedMessage)(nil), "piecestoreroutes.SignedMessage")
 	proto.RegisterType((*DashboardReq)(nil), "piecestoreroutes.DashboardReq")
 	proto.RegisterType((*DashboardStats)(nil), "piecestoreroutes.DashboardStats")
@@ -1013,6 +1522,9 @@ type PieceStoreRoutesClient interface {
 	Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error)
 	Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error)
 	Dashboard(ctx context.Context, in *DashboardReq, opts ...grpc.CallOption) (PieceStoreRoutes_DashboardClient, error)
+	Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainSummary, error)
+	RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashSummary, error)
+	Exit(ctx context.Context, in *ExitRequest, opts ...grpc.CallOption) (*ExitSummary, error)
 }
 
 type pieceStoreRoutesClient struct {
@@ -1147,6 +1659,33 @@ func (x *pieceStoreRoutesDashboardClient) Recv() (*DashboardStats, error) {
 	return m, nil
 }
 
+func (c *pieceStoreRoutesClient) Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainSummary, error) {
+	out := new(RetainSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Retain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashSummary, error) {
+	out := new(RestoreTrashSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/RestoreTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Exit(ctx context.Context, in *ExitRequest, opts ...grpc.CallOption) (*ExitSummary, error) {
+	out := new(ExitSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Exit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PieceStoreRoutesServer is the server API for PieceStoreRoutes service.
 type PieceStoreRoutesServer interface {
 	Piece(context.Context, *PieceId) (*PieceSummary, error)
@@ -1155,6 +1694,9 @@ type PieceStoreRoutesServer interface {
 	Delete(context.Context, *PieceDelete) (*PieceDeleteSummary, error)
 	Stats(context.Context, *StatsReq) (*StatSummary, error)
 	Dashboard(*DashboardReq, PieceStoreRoutes_DashboardServer) error
+	Retain(context.Context, *RetainRequest) (*RetainSummary, error)
+	RestoreTrash(context.Context, *RestoreTrashRequest) (*RestoreTrashSummary, error)
+	Exit(context.Context, *ExitRequest) (*ExitSummary, error)
 }
 
 func RegisterPieceStoreRoutesServer(s *grpc.Server, srv PieceStoreRoutesServer) {
@@ -1288,6 +1830,60 @@ func (x *pieceStoreRoutesDashboardServer) Send(m *DashboardStats) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _PieceStoreRoutes_Retain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Retain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, req.(*RetainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_RestoreTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/RestoreTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, req.(*RestoreTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_Exit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Exit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Exit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Exit(ctx, req.(*ExitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "piecestoreroutes.PieceStoreRoutes",
 	HandlerType: (*PieceStoreRoutesServer)(nil),
@@ -1304,6 +1900,18 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stats",
 			Handler:    _PieceStoreRoutes_Stats_Handler,
 		},
+		{
+			MethodName: "Retain",
+			Handler:    _PieceStoreRoutes_Retain_Handler,
+		},
+		{
+			MethodName: "RestoreTrash",
+			Handler:    _PieceStoreRoutes_RestoreTrash_Handler,
+		},
+		{
+			MethodName: "Exit",
+			Handler:    _PieceStoreRoutes_Exit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1326,81 +1934,109 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	Metadata: "piecestore.proto",
 }
 
-func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_ef88e5b6fd61ab5b) }
-
-var fileDescriptor_piecestore_ef88e5b6fd61ab5b = []byte{
-	// 1159 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x16, 0xa9, 0xff, 0xd1, 0x6f, 0x36, 0x46, 0x2b, 0x0b, 0xb1, 0xad, 0x32, 0x4d, 0xaa, 0xda,
-	0x80, 0x9c, 0x38, 0x40, 0x81, 0x1e, 0xed, 0xca, 0x08, 0x84, 0xa2, 0x8e, 0xbb, 0xb2, 0x2f, 0x29,
-	0x50, 0x66, 0x45, 0x8e, 0x65, 0x22, 0x14, 0xa9, 0x92, 0x4b, 0x57, 0xf6, 0xb5, 0xcf, 0xd3, 0xf7,
-	0xe8, 0x13, 0xf4, 0xd0, 0x43, 0x8a, 0xbe, 0x40, 0x1f, 0xa0, 0xa7, 0x82, 0xbb, 0xfc, 0x91, 0xf5,
-	0x67, 0x20, 0x40, 0x6e, 0x9c, 0x6f, 0x3f, 0xce, 0xce, 0x7c, 0x33, 0x3b, 0xbb, 0xd0, 0x9c, 0x5a,
-	0x68, 0xa0, 0xcf, 0x5d, 0x0f, 0x7b, 0x53, 0xcf, 0xe5, 0x2e, 0x99, 0x43, 0x3c, 0x37, 0xe0, 0xe8,
-	0xb7, 0x61, 0xec, 0x8e, 0x5d, 0xb9, 0xda, 0xde, 0x1d, 0xbb, 0xee, 0xd8, 0xc6, 0x43, 0x61, 0x8d,
-	0x82, 0xab, 0x43, 0x33, 0xf0, 0x18, 0xb7, 0x5c, 0x47, 0xae, 0x6b, 0xbf, 0x65, 0xa1, 0x75, 0xce,
-	0x6e, 0xd1, 0x3b, 0x61, 0x8e, 0xf9, 0xab, 0x65, 0xf2, 0xeb, 0x63, 0xdb, 0x76, 0x0d, 0x41, 0x21,
-	0x2f, 0xa1, 0xea, 0x33, 0x8e, 0xb6, 0x6d, 0x71, 0xd4, 0x2d, 0xb3, 0xa5, 0x74, 0x94, 0x6e, 0xf5,
-	0xa4, 0xfe, 0xc7, 0x87, 0xbd, 0xcc, 0x5f, 0x1f, 0xf6, 0x0a, 0x67, 0xae, 0x89, 0x83, 0x3e, 0xad,
-	0x24, 0x9c, 0x81, 0x49, 0x0e, 0xa0, 0x1c, 0x4c, 0x6d, 0xcb, 0x79, 0x1f, 0xf2, 0xd5, 0x95, 0xfc,
-	0x92, 0x24, 0x0c, 0x4c, 0xb2, 0x0d, 0xa5, 0x09, 0x9b, 0xe9, 0xbe, 0x75, 0x87, 0xad, 0x6c, 0x47,
-	0xe9, 0x66, 0x69, 0x71, 0xc2, 0x66, 0x43, 0xeb, 0x0e, 0x49, 0x0f, 0x1e, 0xe3, 0x6c, 0x6a, 0xc9,
-	0x58, 0xf5, 0xc0, 0xb1, 0x66, 0xba, 0x8f, 0x46, 0x2b, 0x27, 0x58, 0x8f, 0xd2, 0xa5, 0x4b, 0xc7,
-	0x9a, 0x0d, 0xd1, 0x20, 0x4f, 0xa1, 0xe6, 0xa3, 0x67, 0x31, 0x5b, 0x77, 0x82, 0xc9, 0x08, 0xbd,
-	0x56, 0xbe, 0xa3, 0x74, 0xcb, 0xb4, 0x2a, 0xc1, 0x33, 0x81, 0x91, 0x6f, 0xa1, 0xc0, 0x8c, 0xf0,
-	0xaf, 0x56, 0xa1, 0xa3, 0x74, 0xeb, 0x47, 0x5f, 0xf4, 0x16, 0xb5, 0xeb, 0xa5, 0x32, 0x08, 0x22,
-	0x8d, 0x7e, 0x20, 0x5d, 0x68, 0x1a, 0x1e, 0x32, 0x8e, 0x66, 0x1a, 0x4c, 0x51, 0x04, 0x53, 0x8f,
-	0xf0, 0x38, 0x92, 0x2d, 0xc8, 0x1b, 0xe8, 0x71, 0xbf, 0x55, 0xea, 0x64, 0xbb, 0x55, 0x2a, 0x0d,
-	0xf2, 0x04, 0xca, 0xbe, 0x35, 0x76, 0x18, 0x0f, 0x3c, 0x6c, 0x95, 0x43, 0x5d, 0x68, 0x0a, 0x68,
-	0xff, 0x29, 0xb0, 0x4d, 0xd1, 0xe1, 0xab, 0xcb, 0xf0, 0x13, 0x34, 0xa7, 0x61, 0x89, 0x74, 0x96,
-	0x60, 0xa2, 0x14, 0x95, 0xa3, 0xfd, 0xe5, 0x04, 0xd6, 0x15, 0xf3, 0x24, 0x17, 0x96, 0x81, 0x36,
-	0x84, 0xa7, 0x39, 0xe7, 0x5b, 0x90, 0xe7, 0x2e, 0x67, 0xb6, 0x28, 0x56, 0x96, 0x4a, 0x83, 0x7c,
-	0x03, 0x8d, 0xd0, 0x29, 0x1b, 0xa3, 0xee, 0xb8, 0xa6, 0x28, 0x7e, 0x76, 0x65, 0x31, 0x6b, 0x11,
-	0x4d, 0x98, 0x66, 0x9a, 0x7c, 0x6e, 0x6d, 0xf2, 0xf9, 0xc5, 0xe4, 0xff, 0x51, 0x01, 0xce, 0xc3,
-	0x34, 0x86, 0x61, 0x1a, 0xe4, 0x67, 0xd8, 0x1a, 0xc5, 0xe1, 0x2f, 0x67, 0x7c, 0xb0, 0x9c, 0xf1,
-	0x5a, 0xe1, 0xe8, 0xe3, 0xd1, 0x0a, 0x35, 0x4f, 0x01, 0x84, 0x0b, 0xdd, 0x64, 0x9c, 0x89, 0xac,
-	0x2b, 0x47, 0xcf, 0x57, 0xe8, 0x98, 0x44, 0x24, 0x3f, 0xfb, 0x8c, 0x33, 0x5a, 0x9e, 0xc6, 0x9f,
-	0xe4, 0x14, 0x6a, 0x2c, 0xe0, 0xd7, 0xae, 0x67, 0xdd, 0xc9, 0xf8, 0xb2, 0xc2, 0xd3, 0xde, 0xb2,
-	0xa7, 0xa1, 0x35, 0x76, 0xd0, 0xfc, 0x01, 0x7d, 0x9f, 0x8d, 0x91, 0xde, 0xff, 0xab, 0x8d, 0x50,
-	0x4e, 0xdc, 0x93, 0x3a, 0xa8, 0xd1, 0x29, 0x2b, 0x53, 0xd5, 0x32, 0xd7, 0x1d, 0x02, 0x75, 0xdd,
-	0x21, 0x68, 0x41, 0xd1, 0x70, 0x1d, 0x8e, 0x0e, 0x97, 0xd5, 0xa2, 0xb1, 0xa9, 0xbd, 0x83, 0xa2,
-	0xd8, 0x66, 0x60, 0x2e, 0x6d, 0xb2, 0x94, 0x88, 0xfa, 0x31, 0x89, 0x68, 0x13, 0xa8, 0x4a, 0xc9,
-	0x82, 0xc9, 0x84, 0x79, 0xb7, 0x4b, 0xdb, 0xec, 0xc4, 0xb2, 0x8b, 0xd3, 0x2e, 0x53, 0x90, 0x72,
-	0x6e, 0x3a, 0xef, 0xd9, 0x35, 0xa9, 0x6a, 0x7f, 0xaa, 0x50, 0x17, 0xfb, 0x51, 0xe4, 0x9e, 0x85,
-	0x37, 0xcc, 0xfe, 0xe4, 0x8d, 0x33, 0x58, 0xd1, 0x38, 0xfb, 0x6b, 0x1a, 0x27, 0x89, 0xea, 0x93,
-	0x36, 0x0f, 0xdd, 0xd4, 0x3c, 0x0f, 0x08, 0xfe, 0x19, 0x14, 0xdc, 0xab, 0x2b, 0x1f, 0x79, 0xa4,
-	0x71, 0x64, 0x69, 0x6f, 0x60, 0xeb, 0x7e, 0x06, 0x43, 0xee, 0x21, 0x9b, 0x2c, 0xb8, 0x53, 0x16,
-	0xdd, 0xcd, 0xb5, 0x9e, 0x7a, 0xbf, 0xf5, 0x4c, 0xa8, 0xc8, 0x20, 0xd1, 0x46, 0x8e, 0x0f, 0xb7,
-	0xdf, 0x47, 0x49, 0xa1, 0xf5, 0x80, 0xcc, 0xed, 0x12, 0x37, 0x61, 0x0b, 0x8a, 0x13, 0xc9, 0x8f,
-	0x76, 0x8c, 0x4d, 0xed, 0x02, 0x1e, 0xa5, 0x27, 0xfc, 0x41, 0x3a, 0x79, 0x06, 0x75, 0x31, 0x18,
-	0x75, 0x0f, 0x0d, 0xb4, 0x6e, 0xd0, 0x8c, 0x04, 0xad, 0x09, 0x94, 0x46, 0xa0, 0x06, 0x50, 0x1a,
-	0x72, 0xc6, 0x7d, 0x8a, 0xbf, 0x68, 0xbf, 0x2b, 0x50, 0x09, 0x8d, 0xd8, 0xf9, 0x0e, 0x40, 0xe0,
-	0xa3, 0xa9, 0xfb, 0x53, 0x66, 0x24, 0x02, 0x86, 0xc8, 0x30, 0x04, 0xc8, 0x57, 0xd0, 0x60, 0x37,
-	0xcc, 0xb2, 0xd9, 0xc8, 0xc6, 0x88, 0x23, 0xb7, 0xa8, 0x27, 0xb0, 0x24, 0x3e, 0x83, 0xba, 0xf0,
-	0x93, 0xb4, 0x68, 0x54, 0xc0, 0x5a, 0x88, 0x26, 0xcd, 0x4c, 0x0e, 0xe1, 0x71, 0xea, 0x2f, 0xe5,
-	0xca, 0x0b, 0x94, 0x24, 0x4b, 0xc9, 0x0f, 0xda, 0x3b, 0xa8, 0xdd, 0x53, 0x98, 0x10, 0xc8, 0x89,
-	0x4e, 0x17, 0xb7, 0x3e, 0x15, 0xdf, 0xf7, 0x27, 0xb9, 0xba, 0x30, 0xc9, 0x45, 0x8f, 0x04, 0x23,
-	0xdb, 0x32, 0xf4, 0xf7, 0x78, 0x1b, 0x8d, 0xa0, 0xb2, 0x44, 0xbe, 0xc7, 0x5b, 0xad, 0x0e, 0xd5,
-	0x3e, 0xf3, 0xaf, 0x47, 0x2e, 0xf3, 0xcc, 0x50, 0xa1, 0xbf, 0x55, 0xa8, 0x27, 0x80, 0xd0, 0x8d,
-	0x7c, 0x0e, 0xc5, 0xf8, 0xbe, 0x91, 0x15, 0x28, 0x38, 0xf2, 0x62, 0xf9, 0x1a, 0x9a, 0x62, 0xc1,
-	0x70, 0x1d, 0x07, 0xc5, 0x95, 0xec, 0x47, 0xfa, 0x34, 0x42, 0xfc, 0xbb, 0x14, 0x26, 0x07, 0xf0,
-	0x68, 0xe4, 0xba, 0xdc, 0xe7, 0x1e, 0x9b, 0xea, 0xcc, 0x34, 0x3d, 0xf4, 0x7d, 0x11, 0x4c, 0x99,
-	0x36, 0x93, 0x85, 0x63, 0x89, 0x87, 0x7e, 0xad, 0x70, 0x0a, 0x38, 0xcc, 0x4e, 0xb8, 0x39, 0xc1,
-	0x6d, 0xc4, 0xf8, 0x1c, 0x15, 0x67, 0x0b, 0x54, 0xf9, 0xca, 0x68, 0xc4, 0x78, 0x4c, 0x7d, 0x05,
-	0x79, 0x3f, 0xcc, 0x47, 0xbc, 0x33, 0x2a, 0x47, 0x3b, 0x2b, 0x9a, 0x39, 0xed, 0x0c, 0x2a, 0xb9,
-	0x64, 0x17, 0x20, 0xcd, 0x4e, 0x3c, 0x2e, 0x4a, 0x74, 0x0e, 0x21, 0x2f, 0xa1, 0x10, 0x4c, 0xb9,
-	0x35, 0xc1, 0x56, 0x49, 0x78, 0xdd, 0xee, 0xc9, 0xb7, 0x5d, 0x2f, 0x7e, 0xdb, 0xf5, 0xfa, 0xd1,
-	0xdb, 0x8e, 0x46, 0xc4, 0x7d, 0x0a, 0x8d, 0x85, 0x07, 0x0d, 0x29, 0x42, 0xf6, 0xfc, 0xf2, 0xa2,
-	0x99, 0x09, 0x3f, 0x5e, 0x9f, 0x5e, 0x34, 0x15, 0x52, 0x83, 0xf2, 0xeb, 0xd3, 0x0b, 0xfd, 0xf8,
-	0xb2, 0x3f, 0xb8, 0x68, 0xaa, 0xa4, 0x0e, 0x10, 0x9a, 0xf4, 0xf4, 0xfc, 0x78, 0x40, 0x9b, 0xd9,
-	0xd0, 0x3e, 0xbf, 0x4c, 0xec, 0xdc, 0xd1, 0xbf, 0x59, 0x68, 0xa6, 0x47, 0x87, 0x8a, 0x74, 0x48,
-	0x1f, 0xf2, 0x02, 0x23, 0xdb, 0x6b, 0x06, 0xe2, 0xc0, 0x6c, 0xef, 0xae, 0xbb, 0x64, 0xa5, 0x0c,
-	0x5a, 0x86, 0xbc, 0x85, 0x52, 0x34, 0x76, 0x90, 0x74, 0x1e, 0x9a, 0xac, 0xed, 0xe7, 0x0f, 0x31,
-	0xe4, 0xe4, 0xd2, 0x32, 0x5d, 0xe5, 0x85, 0x42, 0xce, 0x20, 0x2f, 0xdf, 0x17, 0x4f, 0x36, 0xdd,
-	0xf5, 0xed, 0xa7, 0x9b, 0x56, 0x93, 0x48, 0xbb, 0x0a, 0x79, 0x03, 0x85, 0x68, 0xa2, 0xed, 0xac,
-	0xf9, 0x45, 0x2e, 0xb7, 0xbf, 0xdc, 0xb8, 0x9c, 0x26, 0xdf, 0x0f, 0x03, 0x0c, 0xfb, 0xa0, 0xbd,
-	0xba, 0x5b, 0xc2, 0xa1, 0xd2, 0xde, 0xdc, 0x49, 0x5a, 0x86, 0xfc, 0x08, 0xe5, 0xe4, 0x48, 0x91,
-	0x15, 0x8a, 0xcf, 0x1f, 0xc0, 0x76, 0x67, 0xc3, 0xba, 0xd8, 0x52, 0xcb, 0xbc, 0x50, 0x4e, 0x72,
-	0x6f, 0xd5, 0xe9, 0x68, 0x54, 0x10, 0x5d, 0xf6, 0xea, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7f,
-	0x14, 0x59, 0xb7, 0x81, 0x0c, 0x00, 0x00,
+func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_fd3f4d789d139767) }
+
+var fileDescriptor_piecestore_fd3f4d789d139767 = []byte{
+	// 1615 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x4b, 0x6f, 0x1b, 0x47,
+	0x12, 0xd6, 0xf0, 0x25, 0xb2, 0xf8, 0x54, 0x4b, 0xeb, 0xa5, 0xb8, 0x96, 0x45, 0x8f, 0xd7, 0x5e,
+	0x5a, 0xc6, 0x52, 0x36, 0x0d, 0x2c, 0xb0, 0xb7, 0x95, 0x56, 0xb2, 0x97, 0xd8, 0x5d, 0x5b, 0xdb,
+	0x92, 0x2e, 0x5e, 0xc0, 0xe3, 0x26, 0xa7, 0x45, 0x75, 0x34, 0x9c, 0xa1, 0x67, 0x9a, 0x0e, 0xe5,
+	0x63, 0x80, 0xfc, 0x82, 0xfc, 0x99, 0x20, 0xb7, 0xdc, 0xf2, 0x0b, 0x72, 0xc8, 0xc1, 0x40, 0x90,
+	0xdc, 0x73, 0xcf, 0x29, 0xe8, 0xc7, 0x3c, 0xf8, 0x92, 0x1c, 0x01, 0xbe, 0x4d, 0x7f, 0x55, 0x5d,
+	0x5d, 0xf5, 0x75, 0x55, 0x4d, 0x35, 0xd4, 0x46, 0x8c, 0xf6, 0x69, 0xc0, 0x3d, 0x9f, 0xb6, 0x47,
+	0xbe, 0xc7, 0x3d, 0x94, 0x40, 0x7c, 0x6f, 0xcc, 0x69, 0xd0, 0x80, 0x81, 0x37, 0xf0, 0x94, 0xb4,
+	0x71, 0x67, 0xe0, 0x79, 0x03, 0x87, 0xee, 0xca, 0x55, 0x6f, 0x7c, 0xb6, 0x6b, 0x8f, 0x7d, 0xc2,
+	0x99, 0xe7, 0x2a, 0xb9, 0xf9, 0x4d, 0x1a, 0xea, 0x47, 0xe4, 0x92, 0xfa, 0xfb, 0xc4, 0xb5, 0x3f,
+	0x67, 0x36, 0x3f, 0xdf, 0x73, 0x1c, 0xaf, 0x2f, 0x55, 0xd0, 0x13, 0x28, 0x05, 0x84, 0x53, 0xc7,
+	0x61, 0x9c, 0x5a, 0xcc, 0xae, 0x1b, 0x4d, 0xa3, 0x55, 0xda, 0xaf, 0x7c, 0xf7, 0x61, 0x7b, 0xe5,
+	0x87, 0x0f, 0xdb, 0xb9, 0x17, 0x9e, 0x4d, 0xbb, 0x07, 0xb8, 0x18, 0xe9, 0x74, 0x6d, 0xf4, 0x08,
+	0x0a, 0xe3, 0x91, 0xc3, 0xdc, 0x0b, 0xa1, 0x9f, 0x5a, 0xa8, 0x9f, 0x57, 0x0a, 0x5d, 0x1b, 0x6d,
+	0x42, 0x7e, 0x48, 0x26, 0x56, 0xc0, 0xde, 0xd3, 0x7a, 0xba, 0x69, 0xb4, 0xd2, 0x78, 0x75, 0x48,
+	0x26, 0xc7, 0xec, 0x3d, 0x45, 0x6d, 0x58, 0xa7, 0x93, 0x11, 0x53, 0xbe, 0x5a, 0x63, 0x97, 0x4d,
+	0xac, 0x80, 0xf6, 0xeb, 0x19, 0xa9, 0xb5, 0x16, 0x8b, 0x4e, 0x5d, 0x36, 0x39, 0xa6, 0x7d, 0x74,
+	0x0f, 0xca, 0x01, 0xf5, 0x19, 0x71, 0x2c, 0x77, 0x3c, 0xec, 0x51, 0xbf, 0x9e, 0x6d, 0x1a, 0xad,
+	0x02, 0x2e, 0x29, 0xf0, 0x85, 0xc4, 0xd0, 0xdf, 0x21, 0x47, 0xfa, 0x62, 0x57, 0x3d, 0xd7, 0x34,
+	0x5a, 0x95, 0xce, 0xdd, 0xf6, 0x2c, 0x77, 0xed, 0x98, 0x06, 0xa9, 0x88, 0xf5, 0x06, 0xd4, 0x82,
+	0x5a, 0xdf, 0xa7, 0x84, 0x53, 0x3b, 0x76, 0x66, 0x55, 0x3a, 0x53, 0xd1, 0x78, 0xe8, 0xc9, 0x06,
+	0x64, 0xfb, 0xd4, 0xe7, 0x41, 0x3d, 0xdf, 0x4c, 0xb7, 0x4a, 0x58, 0x2d, 0xd0, 0x6d, 0x28, 0x04,
+	0x6c, 0xe0, 0x12, 0x3e, 0xf6, 0x69, 0xbd, 0x20, 0x78, 0xc1, 0x31, 0x80, 0xb6, 0x00, 0x46, 0xbe,
+	0xf7, 0x19, 0xed, 0x73, 0x41, 0x1b, 0x28, 0xb1, 0x46, 0xba, 0x36, 0xba, 0x05, 0xb9, 0xde, 0xb8,
+	0x7f, 0x41, 0x79, 0xbd, 0x28, 0xa3, 0xd2, 0x2b, 0xf3, 0x57, 0x03, 0x36, 0x31, 0x75, 0xf9, 0xe2,
+	0xdb, 0xfb, 0x3f, 0xd4, 0x46, 0xe2, 0x66, 0x2d, 0x12, 0x61, 0xf2, 0x06, 0x8b, 0x9d, 0x9d, 0xf9,
+	0xb8, 0x97, 0xe5, 0xc0, 0x7e, 0x46, 0xdc, 0x1e, 0xae, 0x4a, 0x4b, 0x09, 0xe3, 0x1b, 0x90, 0xe5,
+	0x1e, 0x27, 0x8e, 0xbc, 0xe3, 0x34, 0x56, 0x0b, 0xf4, 0x37, 0xa8, 0x0a, 0xa3, 0x64, 0x40, 0x2d,
+	0xd7, 0xb3, 0x65, 0xce, 0xa4, 0x17, 0xe6, 0x40, 0x59, 0xab, 0xc9, 0xa5, 0x1d, 0x73, 0x96, 0x59,
+	0xca, 0x59, 0x76, 0x86, 0x33, 0xf3, 0xc7, 0x14, 0xc0, 0x91, 0x08, 0xe3, 0x58, 0x84, 0x81, 0x5e,
+	0xc3, 0x46, 0x2f, 0x74, 0x7f, 0x3e, 0xe2, 0x47, 0xf3, 0x11, 0x2f, 0x25, 0x0e, 0xaf, 0xf7, 0x16,
+	0xb0, 0x79, 0x08, 0x20, 0x4d, 0x58, 0x36, 0xe1, 0x44, 0x46, 0x5d, 0xec, 0x3c, 0x58, 0xc0, 0x63,
+	0xe4, 0x91, 0xfa, 0x3c, 0x20, 0x9c, 0xe0, 0xc2, 0x28, 0xfc, 0x44, 0x87, 0x50, 0x26, 0x63, 0x7e,
+	0xee, 0xf9, 0xec, 0xbd, 0xf2, 0x2f, 0x2d, 0x2d, 0x6d, 0xcf, 0x5b, 0x3a, 0x66, 0x03, 0x97, 0xda,
+	0xff, 0xa5, 0x41, 0x40, 0x06, 0x14, 0x4f, 0xef, 0x6a, 0x50, 0x28, 0x44, 0xe6, 0x51, 0x05, 0x52,
+	0xba, 0x38, 0x0b, 0x38, 0xc5, 0xec, 0x65, 0xb5, 0x93, 0x5a, 0x56, 0x3b, 0x75, 0x58, 0xed, 0x7b,
+	0x2e, 0xa7, 0x2e, 0x57, 0xb7, 0x85, 0xc3, 0xa5, 0xf9, 0x06, 0x56, 0xe5, 0x31, 0x5d, 0x7b, 0xee,
+	0x90, 0xb9, 0x40, 0x52, 0x37, 0x09, 0xc4, 0xfc, 0xda, 0x80, 0x92, 0xe2, 0x6c, 0x3c, 0x1c, 0x12,
+	0xff, 0x72, 0xee, 0x9c, 0xad, 0x90, 0x77, 0xd9, 0x25, 0x54, 0x0c, 0x8a, 0xcf, 0xab, 0xfa, 0x44,
+	0x7a, 0x59, 0xac, 0x0f, 0xa0, 0x7a, 0x4e, 0x82, 0x73, 0xab, 0xe7, 0x78, 0xfd, 0x0b, 0x65, 0x53,
+	0xf5, 0x94, 0xb2, 0x80, 0xf7, 0x05, 0x2a, 0xed, 0xde, 0x85, 0x92, 0x52, 0x11, 0x30, 0x0d, 0xea,
+	0x59, 0x99, 0x98, 0x45, 0x89, 0xfd, 0x4b, 0x42, 0xe6, 0xf7, 0x29, 0xa8, 0x48, 0xd7, 0x31, 0xe5,
+	0x3e, 0xa3, 0xef, 0x88, 0xf3, 0xc9, 0x93, 0xb0, 0xbb, 0x20, 0x09, 0x77, 0x96, 0x24, 0x61, 0xe4,
+	0xd5, 0x27, 0x4d, 0x44, 0x7c, 0x55, 0x22, 0x5e, 0x73, 0x77, 0xb7, 0x20, 0xe7, 0x9d, 0x9d, 0x05,
+	0x94, 0xeb, 0xeb, 0xd2, 0x2b, 0xf3, 0x25, 0x6c, 0x4c, 0x47, 0x70, 0xcc, 0x7d, 0x4a, 0x86, 0x33,
+	0xe6, 0x8c, 0x59, 0x73, 0x89, 0x34, 0x4e, 0x4d, 0xa7, 0xb1, 0x0d, 0x45, 0xe5, 0x24, 0x75, 0x28,
+	0xa7, 0xd7, 0xa7, 0xf2, 0x8d, 0xa8, 0x30, 0xdb, 0x80, 0x12, 0xa7, 0x84, 0xf9, 0x5c, 0x87, 0xd5,
+	0xa1, 0xd2, 0xd7, 0x27, 0x86, 0x4b, 0xf3, 0x0b, 0x43, 0x73, 0x27, 0xf2, 0x69, 0xce, 0x29, 0x04,
+	0x19, 0x91, 0x7a, 0x3a, 0x14, 0xf9, 0x2d, 0x08, 0x48, 0xe4, 0x6d, 0x56, 0x11, 0xd0, 0x8b, 0x72,
+	0x36, 0xea, 0xa2, 0xe9, 0xa5, 0x5d, 0x34, 0x33, 0xdb, 0x45, 0xbf, 0x34, 0x60, 0x2d, 0xee, 0x59,
+	0xd7, 0x3a, 0x8d, 0xee, 0x43, 0x45, 0xb6, 0x7a, 0xcb, 0xa7, 0x7d, 0xca, 0xde, 0x51, 0x5b, 0x5f,
+	0x6b, 0x59, 0xa2, 0x58, 0x83, 0x68, 0x57, 0x7b, 0xaf, 0x98, 0xfc, 0xd3, 0x92, 0x14, 0x15, 0x81,
+	0xab, 0xd0, 0xcc, 0x63, 0x28, 0x63, 0xca, 0x09, 0x73, 0x31, 0x7d, 0x3b, 0xa6, 0x01, 0x47, 0x3b,
+	0xb0, 0x26, 0x7f, 0xac, 0x53, 0x65, 0xad, 0xee, 0xbc, 0x1a, 0x0a, 0xc2, 0xa2, 0xbe, 0x05, 0xb9,
+	0x33, 0xe6, 0x70, 0xea, 0x6b, 0xb6, 0xf4, 0xca, 0x7c, 0x18, 0x1a, 0x4d, 0xc4, 0xc5, 0x7d, 0x51,
+	0xbd, 0xb6, 0x36, 0x15, 0x2e, 0xcd, 0x3f, 0xc0, 0x3a, 0x56, 0x0e, 0x9e, 0x08, 0x44, 0x7b, 0x61,
+	0x3e, 0x99, 0x86, 0x43, 0x3b, 0x0d, 0xc8, 0xfb, 0x0a, 0x0e, 0x0d, 0x45, 0x6b, 0x73, 0x1f, 0x8a,
+	0x87, 0x13, 0xc6, 0xc3, 0x38, 0x9e, 0x42, 0x39, 0x39, 0x43, 0x05, 0x75, 0xa3, 0x99, 0x5e, 0xf0,
+	0x43, 0x2c, 0x25, 0x86, 0xa8, 0xc0, 0xdc, 0x53, 0x36, 0xc2, 0xe3, 0x3a, 0x90, 0xa5, 0x13, 0xc6,
+	0xd5, 0xde, 0x62, 0xe7, 0xf6, 0x3c, 0x9d, 0x52, 0x9b, 0x13, 0x3e, 0x0e, 0xb0, 0x52, 0x35, 0xbf,
+	0x4d, 0x01, 0xc4, 0xe8, 0x4d, 0x46, 0xb9, 0x16, 0xd4, 0x02, 0x4e, 0xfc, 0xa9, 0x91, 0x47, 0x5d,
+	0x76, 0x45, 0xe3, 0x21, 0xff, 0x3b, 0xb0, 0x76, 0xc6, 0x5c, 0x16, 0x9c, 0x27, 0x55, 0x55, 0x4d,
+	0x57, 0x43, 0x41, 0xa8, 0xfb, 0x57, 0x40, 0xca, 0x7b, 0x8b, 0xfb, 0xc4, 0x0d, 0xce, 0xa8, 0x2f,
+	0x48, 0xd4, 0x73, 0x9d, 0x92, 0x9c, 0xc4, 0x02, 0x31, 0xd7, 0x69, 0xf5, 0x33, 0xc2, 0x1c, 0x6a,
+	0xeb, 0xac, 0x2f, 0x29, 0xf0, 0x99, 0xc4, 0xd0, 0x23, 0x58, 0xeb, 0x5d, 0xf2, 0x19, 0x93, 0x39,
+	0xa9, 0x58, 0x93, 0x82, 0xa4, 0xc5, 0x87, 0xe1, 0x0c, 0x6d, 0xf9, 0x74, 0x48, 0x98, 0xcb, 0xdc,
+	0x81, 0x9e, 0xe4, 0xaa, 0x0a, 0xc7, 0x21, 0x6c, 0x02, 0xe4, 0x05, 0x7d, 0x01, 0xa6, 0x6f, 0xcd,
+	0x5f, 0x0c, 0x28, 0x8a, 0x45, 0x78, 0x27, 0x5b, 0x00, 0xe3, 0x80, 0xda, 0x56, 0x30, 0x22, 0xfd,
+	0xa8, 0x19, 0x09, 0xe4, 0x58, 0x00, 0xe8, 0x2f, 0x50, 0x25, 0xef, 0x08, 0x73, 0x48, 0xcf, 0xa1,
+	0x5a, 0x47, 0x73, 0x17, 0xc1, 0x4a, 0xf1, 0x3e, 0x54, 0xa4, 0x9d, 0xa8, 0xdd, 0x6b, 0xe2, 0xca,
+	0x02, 0x8d, 0x7e, 0x0c, 0x68, 0x17, 0xd6, 0x63, 0x7b, 0xb1, 0xae, 0xe2, 0x0d, 0x45, 0xa2, 0x78,
+	0xc3, 0x3f, 0x00, 0xa2, 0xcb, 0x54, 0xbf, 0xaf, 0x62, 0xa7, 0xb9, 0xa0, 0xa3, 0x85, 0x3a, 0x2a,
+	0xd0, 0xc4, 0x1e, 0xf3, 0x27, 0x03, 0x2a, 0xd3, 0xe2, 0x9b, 0x64, 0xd1, 0x34, 0x4f, 0xa9, 0x8f,
+	0xe0, 0x29, 0xfd, 0x91, 0x3c, 0x65, 0x7e, 0x07, 0x4f, 0xd9, 0x65, 0x3c, 0x99, 0x6f, 0xa0, 0x3c,
+	0xd5, 0xd5, 0x45, 0xe3, 0x95, 0x7f, 0x57, 0x43, 0x35, 0x5e, 0xf1, 0x3d, 0xdd, 0x43, 0x53, 0x8b,
+	0xa6, 0xf7, 0x71, 0xcf, 0x61, 0x7d, 0xeb, 0x82, 0x5e, 0xea, 0x11, 0xaa, 0xa0, 0x90, 0x7f, 0xd3,
+	0x4b, 0xb3, 0x02, 0xa5, 0x03, 0x12, 0x9c, 0xf7, 0x3c, 0xe2, 0xdb, 0x22, 0x93, 0xbe, 0x4a, 0x43,
+	0x25, 0x02, 0x14, 0xaf, 0x7f, 0x84, 0xd5, 0x70, 0x5e, 0x56, 0xfd, 0x36, 0xe7, 0xaa, 0xc1, 0xf8,
+	0x21, 0xd4, 0xa4, 0xa0, 0xef, 0xb9, 0x2e, 0x95, 0x2f, 0x91, 0x40, 0x73, 0x58, 0x15, 0xf8, 0x3f,
+	0x63, 0x58, 0x16, 0x81, 0xe7, 0xf1, 0x80, 0xfb, 0x64, 0x64, 0x11, 0xdb, 0xf6, 0x69, 0x10, 0x48,
+	0x67, 0x0a, 0xb8, 0x16, 0x09, 0xf6, 0x14, 0x2e, 0xec, 0x32, 0x97, 0x53, 0xdf, 0x25, 0x4e, 0xa4,
+	0x9b, 0x91, 0xba, 0xd5, 0x10, 0x4f, 0xa8, 0xd2, 0xc9, 0x8c, 0xaa, 0x7a, 0x5c, 0x55, 0xe9, 0x64,
+	0x5a, 0xf5, 0x29, 0x64, 0x03, 0x11, 0x8f, 0xac, 0xbd, 0x62, 0x67, 0x6b, 0x41, 0xba, 0xc5, 0x15,
+	0x84, 0x95, 0x2e, 0xba, 0x03, 0x10, 0x47, 0x27, 0x2b, 0x31, 0x8f, 0x13, 0x08, 0x7a, 0x02, 0xb9,
+	0xf1, 0x88, 0xb3, 0x21, 0xad, 0xe7, 0xa5, 0xd5, 0xcd, 0xb6, 0x7a, 0xd2, 0xb6, 0xc3, 0x27, 0x6d,
+	0xfb, 0x40, 0x3f, 0x69, 0xb1, 0x56, 0x8c, 0xfb, 0x65, 0xe1, 0xa3, 0xfb, 0xe5, 0x0e, 0x86, 0xea,
+	0xcc, 0xdb, 0x0f, 0xad, 0x42, 0xfa, 0xe8, 0xf4, 0xa4, 0xb6, 0x22, 0x3e, 0x9e, 0x1f, 0x9e, 0xd4,
+	0x0c, 0x54, 0x86, 0xc2, 0xf3, 0xc3, 0x13, 0x6b, 0xef, 0xf4, 0xa0, 0x7b, 0x52, 0x4b, 0xa1, 0x0a,
+	0x80, 0x58, 0xe2, 0xc3, 0xa3, 0xbd, 0x2e, 0xae, 0xa5, 0xc5, 0xfa, 0xe8, 0x34, 0x5a, 0x67, 0x3a,
+	0x3f, 0x67, 0xa1, 0x16, 0xff, 0x5c, 0xb1, 0x3c, 0x1a, 0x1d, 0x40, 0x56, 0x62, 0x68, 0x73, 0xc9,
+	0x5f, 0xb1, 0x6b, 0x37, 0xee, 0x2c, 0x11, 0x69, 0xea, 0xcc, 0x15, 0xf4, 0x0a, 0xf2, 0x7a, 0x3c,
+	0xa2, 0xa8, 0x79, 0xdd, 0x04, 0xd8, 0x78, 0x70, 0x9d, 0x86, 0x9a, 0xb0, 0xcc, 0x95, 0x96, 0xf1,
+	0xd8, 0x40, 0x2f, 0x20, 0xab, 0xde, 0x54, 0xb7, 0xaf, 0x7a, 0xdf, 0x34, 0xee, 0x5d, 0x25, 0x8d,
+	0x3c, 0x6d, 0x19, 0xe8, 0x25, 0xe4, 0xf4, 0xe4, 0xb5, 0xb5, 0x64, 0x8b, 0x12, 0x37, 0xfe, 0x7c,
+	0xa5, 0x38, 0x0e, 0xfe, 0x40, 0x38, 0x28, 0x72, 0xa7, 0xb1, 0x38, 0xc3, 0x44, 0xc3, 0x6e, 0x5c,
+	0x9d, 0x7d, 0xe6, 0x0a, 0xfa, 0x1f, 0x14, 0xa2, 0x32, 0x44, 0x0b, 0x18, 0x4f, 0x16, 0x6d, 0xa3,
+	0x79, 0x85, 0x5c, 0x1e, 0x69, 0xae, 0x3c, 0x36, 0xd0, 0x7f, 0x20, 0xa7, 0x06, 0x0e, 0xb4, 0xbd,
+	0x68, 0xd6, 0x4f, 0xcc, 0x37, 0x8d, 0xa5, 0x0a, 0xb1, 0x83, 0xaf, 0xa1, 0x94, 0x1c, 0x3e, 0xd0,
+	0xfd, 0x45, 0x5b, 0xe6, 0x66, 0x96, 0xc6, 0x35, 0x6a, 0xb1, 0xfd, 0x67, 0x90, 0x11, 0x75, 0xb0,
+	0xe8, 0x56, 0x12, 0x13, 0x4c, 0x63, 0x89, 0x38, 0xb2, 0xb3, 0x9f, 0x79, 0x95, 0x1a, 0xf5, 0x7a,
+	0x39, 0x59, 0x8f, 0x4f, 0x7f, 0x1b, 0x00, 0x4f, 0x17, 0xf9, 0xe0, 0xa2, 0x12, 0x00, 0x00,
 }
@@ -62,6 +62,24 @@ func (mr *MockPieceStoreRoutesClientMockRecorder) Delete(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Delete), varargs...)
 }
 
+// Exit mocks base method
+func (m *MockPieceStoreRoutesClient) Exit(arg0 context.Context, arg1 *ExitRequest, arg2 ...grpc.CallOption) (*ExitSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exit", varargs...)
+	ret0, _ := ret[0].(*ExitSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exit indicates an expected call of Exit
+func (mr *MockPieceStoreRoutesClientMockRecorder) Exit(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exit", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Exit), varargs...)
+}
+
 // Piece mocks base method
 func (m *MockPieceStoreRoutesClient) Piece(arg0 context.Context, arg1 *PieceId, arg2 ...grpc.CallOption) (*PieceSummary, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -98,6 +116,42 @@ func (mr *MockPieceStoreRoutesClientMockRecorder) Retrieve(arg0 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retrieve", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Retrieve), varargs...)
 }
 
+// RestoreTrash mocks base method
+func (m *MockPieceStoreRoutesClient) RestoreTrash(arg0 context.Context, arg1 *RestoreTrashRequest, arg2 ...grpc.CallOption) (*RestoreTrashSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreTrash", varargs...)
+	ret0, _ := ret[0].(*RestoreTrashSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTrash indicates an expected call of RestoreTrash
+func (mr *MockPieceStoreRoutesClientMockRecorder) RestoreTrash(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTrash", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).RestoreTrash), varargs...)
+}
+
+// Retain mocks base method
+func (m *MockPieceStoreRoutesClient) Retain(arg0 context.Context, arg1 *RetainRequest, arg2 ...grpc.CallOption) (*RetainSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Retain", varargs...)
+	ret0, _ := ret[0].(*RetainSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retain indicates an expected call of Retain
+func (mr *MockPieceStoreRoutesClientMockRecorder) Retain(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retain", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Retain), varargs...)
+}
+
 // Stats mocks base method
 func (m *MockPieceStoreRoutesClient) Stats(arg0 context.Context, arg1 *StatsReq, arg2 ...grpc.CallOption) (*StatSummary, error) {
 	varargs := []interface{}{arg0, arg1}
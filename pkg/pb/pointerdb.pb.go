@@ -42,7 +42,7 @@ func (x RedundancyScheme_SchemeType) String() string {
 	return proto.EnumName(RedundancyScheme_SchemeType_name, int32(x))
 }
 func (RedundancyScheme_SchemeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{0, 0}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{0, 0}
 }
 
 type Pointer_DataType int32
@@ -65,7 +65,7 @@ func (x Pointer_DataType) String() string {
 	return proto.EnumName(Pointer_DataType_name, int32(x))
 }
 func (Pointer_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{3, 0}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{3, 0}
 }
 
 type RedundancyScheme struct {
@@ -85,7 +85,7 @@ func (m *RedundancyScheme) Reset()         { *m = RedundancyScheme{} }
 func (m *RedundancyScheme) String() string { return proto.CompactTextString(m) }
 func (*RedundancyScheme) ProtoMessage()    {}
 func (*RedundancyScheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{0}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{0}
 }
 func (m *RedundancyScheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedundancyScheme.Unmarshal(m, b)
@@ -148,18 +148,20 @@ func (m *RedundancyScheme) GetErasureShareSize() int32 {
 }
 
 type RemotePiece struct {
-	PieceNum             int32    `protobuf:"varint,1,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
-	NodeId               NodeID   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PieceNum             int32      `protobuf:"varint,1,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
+	NodeId               NodeID     `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Hash                 []byte     `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	SignedHash           *PieceHash `protobuf:"bytes,4,opt,name=signed_hash,json=signedHash,proto3" json:"signed_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *RemotePiece) Reset()         { *m = RemotePiece{} }
 func (m *RemotePiece) String() string { return proto.CompactTextString(m) }
 func (*RemotePiece) ProtoMessage()    {}
 func (*RemotePiece) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{1}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{1}
 }
 func (m *RemotePiece) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePiece.Unmarshal(m, b)
@@ -186,22 +188,44 @@ func (m *RemotePiece) GetPieceNum() int32 {
 	return 0
 }
 
+func (m *RemotePiece) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RemotePiece) GetSignedHash() *PieceHash {
+	if m != nil {
+		return m.SignedHash
+	}
+	return nil
+}
+
 type RemoteSegment struct {
 	Redundancy *RedundancyScheme `protobuf:"bytes,1,opt,name=redundancy,proto3" json:"redundancy,omitempty"`
 	// TODO: may want to use customtype and fixed-length byte slice
-	PieceId              string         `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
-	RemotePieces         []*RemotePiece `protobuf:"bytes,3,rep,name=remote_pieces,json=remotePieces,proto3" json:"remote_pieces,omitempty"`
-	MerkleRoot           []byte         `protobuf:"bytes,4,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	PieceId      string         `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	RemotePieces []*RemotePiece `protobuf:"bytes,3,rep,name=remote_pieces,json=remotePieces,proto3" json:"remote_pieces,omitempty"`
+	MerkleRoot   []byte         `protobuf:"bytes,4,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	// dedup_id is the address of a segment referenced by several pointers,
+	// either the content address of a deduplicated segment or the id of a
+	// copied one. Its pieces are kept by the entry of the segment under
+	// dedup/<project>/<dedup_id>.
+	DedupId []byte `protobuf:"bytes,6,opt,name=dedup_id,json=dedupId,proto3" json:"dedup_id,omitempty"`
+	// references is the number of pointers referencing the pieces of a
+	// segment, set only on its entry
+	References           int64    `protobuf:"varint,7,opt,name=references,proto3" json:"references,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RemoteSegment) Reset()         { *m = RemoteSegment{} }
 func (m *RemoteSegment) String() string { return proto.CompactTextString(m) }
 func (*RemoteSegment) ProtoMessage()    {}
 func (*RemoteSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{2}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{2}
 }
 func (m *RemoteSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteSegment.Unmarshal(m, b)
@@ -249,6 +273,20 @@ func (m *RemoteSegment) GetMerkleRoot() []byte {
 	return nil
 }
 
+func (m *RemoteSegment) GetDedupId() []byte {
+	if m != nil {
+		return m.DedupId
+	}
+	return nil
+}
+
+func (m *RemoteSegment) GetReferences() int64 {
+	if m != nil {
+		return m.References
+	}
+	return 0
+}
+
 type Pointer struct {
 	Type                 Pointer_DataType     `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.Pointer_DataType" json:"type,omitempty"`
 	InlineSegment        []byte               `protobuf:"bytes,3,opt,name=inline_segment,json=inlineSegment,proto3" json:"inline_segment,omitempty"`
@@ -266,7 +304,7 @@ func (m *Pointer) Reset()         { *m = Pointer{} }
 func (m *Pointer) String() string { return proto.CompactTextString(m) }
 func (*Pointer) ProtoMessage()    {}
 func (*Pointer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{3}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{3}
 }
 func (m *Pointer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pointer.Unmarshal(m, b)
@@ -348,7 +386,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{4}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{4}
 }
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutRequest.Unmarshal(m, b)
@@ -394,7 +432,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{5}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{5}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -438,7 +476,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{6}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{6}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -511,7 +549,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{7}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{7}
 }
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutResponse.Unmarshal(m, b)
@@ -546,7 +584,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{8}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{8}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -607,7 +645,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{9}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{9}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -654,7 +692,7 @@ func (m *ListResponse_Item) Reset()         { *m = ListResponse_Item{} }
 func (m *ListResponse_Item) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Item) ProtoMessage()    {}
 func (*ListResponse_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{9, 0}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{9, 0}
 }
 func (m *ListResponse_Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse_Item.Unmarshal(m, b)
@@ -706,7 +744,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{10}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{10}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -735,6 +773,9 @@ func (m *DeleteRequest) GetPath() string {
 
 // DeleteResponse is a response message for the Delete rpc call
 type DeleteResponse struct {
+	// released is the segment whose pieces aren't referenced by any pointer
+	// anymore, which are deleted from the storage nodes by the uplink
+	Released             *Pointer `protobuf:"bytes,1,opt,name=released,proto3" json:"released,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -744,7 +785,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{11}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{11}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
@@ -764,6 +805,451 @@ func (m *DeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
 
+func (m *DeleteResponse) GetReleased() *Pointer {
+	if m != nil {
+		return m.Released
+	}
+	return nil
+}
+
+// CopyRequest is a request message for the Copy rpc call
+type CopyRequest struct {
+	SourcePath           string   `protobuf:"bytes,1,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	DestinationPath      string   `protobuf:"bytes,2,opt,name=destination_path,json=destinationPath,proto3" json:"destination_path,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyRequest) Reset()         { *m = CopyRequest{} }
+func (m *CopyRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyRequest) ProtoMessage()    {}
+func (*CopyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{12}
+}
+func (m *CopyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyRequest.Unmarshal(m, b)
+}
+func (m *CopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyRequest.Marshal(b, m, deterministic)
+}
+func (dst *CopyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyRequest.Merge(dst, src)
+}
+func (m *CopyRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyRequest.Size(m)
+}
+func (m *CopyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyRequest proto.InternalMessageInfo
+
+func (m *CopyRequest) GetSourcePath() string {
+	if m != nil {
+		return m.SourcePath
+	}
+	return ""
+}
+
+func (m *CopyRequest) GetDestinationPath() string {
+	if m != nil {
+		return m.DestinationPath
+	}
+	return ""
+}
+
+func (m *CopyRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// CopyResponse is a response message for the Copy rpc call
+type CopyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyResponse) Reset()         { *m = CopyResponse{} }
+func (m *CopyResponse) String() string { return proto.CompactTextString(m) }
+func (*CopyResponse) ProtoMessage()    {}
+func (*CopyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{13}
+}
+func (m *CopyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyResponse.Unmarshal(m, b)
+}
+func (m *CopyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyResponse.Marshal(b, m, deterministic)
+}
+func (dst *CopyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyResponse.Merge(dst, src)
+}
+func (m *CopyResponse) XXX_Size() int {
+	return xxx_messageInfo_CopyResponse.Size(m)
+}
+func (m *CopyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyResponse proto.InternalMessageInfo
+
+// UpdateMetadataRequest is a request message for the UpdateMetadata rpc call
+type UpdateMetadataRequest struct {
+	Path                 string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Metadata             []byte               `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ExpirationDate       *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdateMetadataRequest) Reset()         { *m = UpdateMetadataRequest{} }
+func (m *UpdateMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMetadataRequest) ProtoMessage()    {}
+func (*UpdateMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{14}
+}
+func (m *UpdateMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMetadataRequest.Unmarshal(m, b)
+}
+func (m *UpdateMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMetadataRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMetadataRequest.Merge(dst, src)
+}
+func (m *UpdateMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateMetadataRequest.Size(m)
+}
+func (m *UpdateMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMetadataRequest proto.InternalMessageInfo
+
+func (m *UpdateMetadataRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *UpdateMetadataRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *UpdateMetadataRequest) GetExpirationDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpirationDate
+	}
+	return nil
+}
+
+// UpdateMetadataResponse is a response message for the UpdateMetadata rpc call
+type UpdateMetadataResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateMetadataResponse) Reset()         { *m = UpdateMetadataResponse{} }
+func (m *UpdateMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateMetadataResponse) ProtoMessage()    {}
+func (*UpdateMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{15}
+}
+func (m *UpdateMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMetadataResponse.Unmarshal(m, b)
+}
+func (m *UpdateMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMetadataResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMetadataResponse.Merge(dst, src)
+}
+func (m *UpdateMetadataResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateMetadataResponse.Size(m)
+}
+func (m *UpdateMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMetadataResponse proto.InternalMessageInfo
+
+// LifecycleRule deletes the objects under a prefix of a bucket
+type LifecycleRule struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Enabled              bool     `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	ExpirationDays       int32    `protobuf:"varint,4,opt,name=expiration_days,json=expirationDays,proto3" json:"expiration_days,omitempty"`
+	AbortPendingDays     int32    `protobuf:"varint,5,opt,name=abort_pending_days,json=abortPendingDays,proto3" json:"abort_pending_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LifecycleRule) Reset()         { *m = LifecycleRule{} }
+func (m *LifecycleRule) String() string { return proto.CompactTextString(m) }
+func (*LifecycleRule) ProtoMessage()    {}
+func (*LifecycleRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{16}
+}
+func (m *LifecycleRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LifecycleRule.Unmarshal(m, b)
+}
+func (m *LifecycleRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LifecycleRule.Marshal(b, m, deterministic)
+}
+func (dst *LifecycleRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LifecycleRule.Merge(dst, src)
+}
+func (m *LifecycleRule) XXX_Size() int {
+	return xxx_messageInfo_LifecycleRule.Size(m)
+}
+func (m *LifecycleRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_LifecycleRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LifecycleRule proto.InternalMessageInfo
+
+func (m *LifecycleRule) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LifecycleRule) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *LifecycleRule) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *LifecycleRule) GetExpirationDays() int32 {
+	if m != nil {
+		return m.ExpirationDays
+	}
+	return 0
+}
+
+func (m *LifecycleRule) GetAbortPendingDays() int32 {
+	if m != nil {
+		return m.AbortPendingDays
+	}
+	return 0
+}
+
+// BucketLifecycle is the lifecycle configuration of a bucket
+type BucketLifecycle struct {
+	Rules                []*LifecycleRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BucketLifecycle) Reset()         { *m = BucketLifecycle{} }
+func (m *BucketLifecycle) String() string { return proto.CompactTextString(m) }
+func (*BucketLifecycle) ProtoMessage()    {}
+func (*BucketLifecycle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{17}
+}
+func (m *BucketLifecycle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketLifecycle.Unmarshal(m, b)
+}
+func (m *BucketLifecycle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketLifecycle.Marshal(b, m, deterministic)
+}
+func (dst *BucketLifecycle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketLifecycle.Merge(dst, src)
+}
+func (m *BucketLifecycle) XXX_Size() int {
+	return xxx_messageInfo_BucketLifecycle.Size(m)
+}
+func (m *BucketLifecycle) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketLifecycle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketLifecycle proto.InternalMessageInfo
+
+func (m *BucketLifecycle) GetRules() []*LifecycleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+// SetLifecycleRequest is a request message for the SetLifecycle rpc call
+type SetLifecycleRequest struct {
+	Bucket               string           `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Lifecycle            *BucketLifecycle `protobuf:"bytes,2,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SetLifecycleRequest) Reset()         { *m = SetLifecycleRequest{} }
+func (m *SetLifecycleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLifecycleRequest) ProtoMessage()    {}
+func (*SetLifecycleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{18}
+}
+func (m *SetLifecycleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLifecycleRequest.Unmarshal(m, b)
+}
+func (m *SetLifecycleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLifecycleRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetLifecycleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLifecycleRequest.Merge(dst, src)
+}
+func (m *SetLifecycleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLifecycleRequest.Size(m)
+}
+func (m *SetLifecycleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLifecycleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLifecycleRequest proto.InternalMessageInfo
+
+func (m *SetLifecycleRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SetLifecycleRequest) GetLifecycle() *BucketLifecycle {
+	if m != nil {
+		return m.Lifecycle
+	}
+	return nil
+}
+
+// SetLifecycleResponse is a response message for the SetLifecycle rpc call
+type SetLifecycleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLifecycleResponse) Reset()         { *m = SetLifecycleResponse{} }
+func (m *SetLifecycleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetLifecycleResponse) ProtoMessage()    {}
+func (*SetLifecycleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{19}
+}
+func (m *SetLifecycleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLifecycleResponse.Unmarshal(m, b)
+}
+func (m *SetLifecycleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLifecycleResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetLifecycleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLifecycleResponse.Merge(dst, src)
+}
+func (m *SetLifecycleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetLifecycleResponse.Size(m)
+}
+func (m *SetLifecycleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLifecycleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLifecycleResponse proto.InternalMessageInfo
+
+// GetLifecycleRequest is a request message for the GetLifecycle rpc call
+type GetLifecycleRequest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLifecycleRequest) Reset()         { *m = GetLifecycleRequest{} }
+func (m *GetLifecycleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLifecycleRequest) ProtoMessage()    {}
+func (*GetLifecycleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{20}
+}
+func (m *GetLifecycleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLifecycleRequest.Unmarshal(m, b)
+}
+func (m *GetLifecycleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLifecycleRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetLifecycleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLifecycleRequest.Merge(dst, src)
+}
+func (m *GetLifecycleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLifecycleRequest.Size(m)
+}
+func (m *GetLifecycleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLifecycleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLifecycleRequest proto.InternalMessageInfo
+
+func (m *GetLifecycleRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+// GetLifecycleResponse is a response message for the GetLifecycle rpc call
+type GetLifecycleResponse struct {
+	Lifecycle            *BucketLifecycle `protobuf:"bytes,1,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetLifecycleResponse) Reset()         { *m = GetLifecycleResponse{} }
+func (m *GetLifecycleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLifecycleResponse) ProtoMessage()    {}
+func (*GetLifecycleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{21}
+}
+func (m *GetLifecycleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLifecycleResponse.Unmarshal(m, b)
+}
+func (m *GetLifecycleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLifecycleResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetLifecycleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLifecycleResponse.Merge(dst, src)
+}
+func (m *GetLifecycleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLifecycleResponse.Size(m)
+}
+func (m *GetLifecycleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLifecycleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLifecycleResponse proto.InternalMessageInfo
+
+func (m *GetLifecycleResponse) GetLifecycle() *BucketLifecycle {
+	if m != nil {
+		return m.Lifecycle
+	}
+	return nil
+}
+
 // IterateRequest is a request message for the Iterate rpc call
 type IterateRequest struct {
 	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
@@ -779,7 +1265,7 @@ func (m *IterateRequest) Reset()         { *m = IterateRequest{} }
 func (m *IterateRequest) String() string { return proto.CompactTextString(m) }
 func (*IterateRequest) ProtoMessage()    {}
 func (*IterateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{12}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{22}
 }
 func (m *IterateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IterateRequest.Unmarshal(m, b)
@@ -827,6 +1313,85 @@ func (m *IterateRequest) GetReverse() bool {
 	return false
 }
 
+// ReportCorruptionRequest is a request message for the ReportCorruption rpc call
+type ReportCorruptionRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	PieceNum             int32    `protobuf:"varint,2,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
+	NodeId               NodeID   `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportCorruptionRequest) Reset()         { *m = ReportCorruptionRequest{} }
+func (m *ReportCorruptionRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportCorruptionRequest) ProtoMessage()    {}
+func (*ReportCorruptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{23}
+}
+func (m *ReportCorruptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportCorruptionRequest.Unmarshal(m, b)
+}
+func (m *ReportCorruptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportCorruptionRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReportCorruptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportCorruptionRequest.Merge(dst, src)
+}
+func (m *ReportCorruptionRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportCorruptionRequest.Size(m)
+}
+func (m *ReportCorruptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportCorruptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportCorruptionRequest proto.InternalMessageInfo
+
+func (m *ReportCorruptionRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ReportCorruptionRequest) GetPieceNum() int32 {
+	if m != nil {
+		return m.PieceNum
+	}
+	return 0
+}
+
+// ReportCorruptionResponse is a response message for the ReportCorruption rpc call
+type ReportCorruptionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportCorruptionResponse) Reset()         { *m = ReportCorruptionResponse{} }
+func (m *ReportCorruptionResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportCorruptionResponse) ProtoMessage()    {}
+func (*ReportCorruptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{24}
+}
+func (m *ReportCorruptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportCorruptionResponse.Unmarshal(m, b)
+}
+func (m *ReportCorruptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportCorruptionResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReportCorruptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportCorruptionResponse.Merge(dst, src)
+}
+func (m *ReportCorruptionResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportCorruptionResponse.Size(m)
+}
+func (m *ReportCorruptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportCorruptionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportCorruptionResponse proto.InternalMessageInfo
+
 type PayerBandwidthAllocationRequest struct {
 	Action               BandwidthAction `protobuf:"varint,1,opt,name=action,proto3,enum=piecestoreroutes.BandwidthAction" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -838,7 +1403,7 @@ func (m *PayerBandwidthAllocationRequest) Reset()         { *m = PayerBandwidthA
 func (m *PayerBandwidthAllocationRequest) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationRequest) ProtoMessage()    {}
 func (*PayerBandwidthAllocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{13}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{25}
 }
 func (m *PayerBandwidthAllocationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationRequest.Unmarshal(m, b)
@@ -876,7 +1441,7 @@ func (m *PayerBandwidthAllocationResponse) Reset()         { *m = PayerBandwidth
 func (m *PayerBandwidthAllocationResponse) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationResponse) ProtoMessage()    {}
 func (*PayerBandwidthAllocationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_afdf954a8fe5efcf, []int{14}
+	return fileDescriptor_pointerdb_6193ec5ffdb79b26, []int{26}
 }
 func (m *PayerBandwidthAllocationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationResponse.Unmarshal(m, b)
@@ -917,7 +1482,19 @@ func init() {
 	proto.RegisterType((*ListResponse_Item)(nil), "pointerdb.ListResponse.Item")
 	proto.RegisterType((*DeleteRequest)(nil), "pointerdb.DeleteRequest")
 	proto.RegisterType((*DeleteResponse)(nil), "pointerdb.DeleteResponse")
+	proto.RegisterType((*CopyRequest)(nil), "pointerdb.CopyRequest")
+	proto.RegisterType((*CopyResponse)(nil), "pointerdb.CopyResponse")
+	proto.RegisterType((*UpdateMetadataRequest)(nil), "pointerdb.UpdateMetadataRequest")
+	proto.RegisterType((*UpdateMetadataResponse)(nil), "pointerdb.UpdateMetadataResponse")
+	proto.RegisterType((*LifecycleRule)(nil), "pointerdb.LifecycleRule")
+	proto.RegisterType((*BucketLifecycle)(nil), "pointerdb.BucketLifecycle")
+	proto.RegisterType((*SetLifecycleRequest)(nil), "pointerdb.SetLifecycleRequest")
+	proto.RegisterType((*SetLifecycleResponse)(nil), "pointerdb.SetLifecycleResponse")
+	proto.RegisterType((*GetLifecycleRequest)(nil), "pointerdb.GetLifecycleRequest")
+	proto.RegisterType((*GetLifecycleResponse)(nil), "pointerdb.GetLifecycleResponse")
 	proto.RegisterType((*IterateRequest)(nil), "pointerdb.IterateRequest")
+	proto.RegisterType((*ReportCorruptionRequest)(nil), "pointerdb.ReportCorruptionRequest")
+	proto.RegisterType((*ReportCorruptionResponse)(nil), "pointerdb.ReportCorruptionResponse")
 	proto.RegisterType((*PayerBandwidthAllocationRequest)(nil), "pointerdb.PayerBandwidthAllocationRequest")
 	proto.RegisterType((*PayerBandwidthAllocationResponse)(nil), "pointerdb.PayerBandwidthAllocationResponse")
 	proto.RegisterEnum("pointerdb.RedundancyScheme_SchemeType", RedundancyScheme_SchemeType_name, RedundancyScheme_SchemeType_value)
@@ -944,8 +1521,18 @@ type PointerDBClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	// Delete formats and hands off a file path to delete from boltdb
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Copy duplicates a pointer under a new path without copying the data
+	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error)
+	// UpdateMetadata replaces the metadata and expiration of a pointer in place
+	UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error)
+	// SetLifecycle replaces the lifecycle rules of a bucket
+	SetLifecycle(ctx context.Context, in *SetLifecycleRequest, opts ...grpc.CallOption) (*SetLifecycleResponse, error)
+	// GetLifecycle returns the lifecycle rules of a bucket
+	GetLifecycle(ctx context.Context, in *GetLifecycleRequest, opts ...grpc.CallOption) (*GetLifecycleResponse, error)
 	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
 	PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error)
+	// ReportCorruption reports a piece of a segment not matching its hash
+	ReportCorruption(ctx context.Context, in *ReportCorruptionRequest, opts ...grpc.CallOption) (*ReportCorruptionResponse, error)
 }
 
 type pointerDBClient struct {
@@ -992,6 +1579,42 @@ func (c *pointerDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ..
 	return out, nil
 }
 
+func (c *pointerDBClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error) {
+	out := new(CopyResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Copy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error) {
+	out := new(UpdateMetadataResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/UpdateMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) SetLifecycle(ctx context.Context, in *SetLifecycleRequest, opts ...grpc.CallOption) (*SetLifecycleResponse, error) {
+	out := new(SetLifecycleResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/SetLifecycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) GetLifecycle(ctx context.Context, in *GetLifecycleRequest, opts ...grpc.CallOption) (*GetLifecycleResponse, error) {
+	out := new(GetLifecycleResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/GetLifecycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error) {
 	out := new(PayerBandwidthAllocationResponse)
 	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/PayerBandwidthAllocation", in, out, opts...)
@@ -1001,6 +1624,15 @@ func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *Paye
 	return out, nil
 }
 
+func (c *pointerDBClient) ReportCorruption(ctx context.Context, in *ReportCorruptionRequest, opts ...grpc.CallOption) (*ReportCorruptionResponse, error) {
+	out := new(ReportCorruptionResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/ReportCorruption", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PointerDBServer is the server API for PointerDB service.
 type PointerDBServer interface {
 	// Put formats and hands off a file path to be saved to boltdb
@@ -1011,8 +1643,18 @@ type PointerDBServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	// Delete formats and hands off a file path to delete from boltdb
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Copy duplicates a pointer under a new path without copying the data
+	Copy(context.Context, *CopyRequest) (*CopyResponse, error)
+	// UpdateMetadata replaces the metadata and expiration of a pointer in place
+	UpdateMetadata(context.Context, *UpdateMetadataRequest) (*UpdateMetadataResponse, error)
+	// SetLifecycle replaces the lifecycle rules of a bucket
+	SetLifecycle(context.Context, *SetLifecycleRequest) (*SetLifecycleResponse, error)
+	// GetLifecycle returns the lifecycle rules of a bucket
+	GetLifecycle(context.Context, *GetLifecycleRequest) (*GetLifecycleResponse, error)
 	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
 	PayerBandwidthAllocation(context.Context, *PayerBandwidthAllocationRequest) (*PayerBandwidthAllocationResponse, error)
+	// ReportCorruption reports a piece of a segment not matching its hash
+	ReportCorruption(context.Context, *ReportCorruptionRequest) (*ReportCorruptionResponse, error)
 }
 
 func RegisterPointerDBServer(s *grpc.Server, srv PointerDBServer) {
@@ -1091,6 +1733,78 @@ func _PointerDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_Copy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).Copy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/Copy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).Copy(ctx, req.(*CopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).UpdateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/UpdateMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).UpdateMetadata(ctx, req.(*UpdateMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_SetLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLifecycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).SetLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/SetLifecycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).SetLifecycle(ctx, req.(*SetLifecycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_GetLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLifecycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).GetLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/GetLifecycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).GetLifecycle(ctx, req.(*GetLifecycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PayerBandwidthAllocationRequest)
 	if err := dec(in); err != nil {
@@ -1109,6 +1823,24 @@ func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_ReportCorruption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCorruptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).ReportCorruption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/ReportCorruption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).ReportCorruption(ctx, req.(*ReportCorruptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PointerDB_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pointerdb.PointerDB",
 	HandlerType: (*PointerDBServer)(nil),
@@ -1129,86 +1861,133 @@ var _PointerDB_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PointerDB_Delete_Handler,
 		},
+		{
+			MethodName: "Copy",
+			Handler:    _PointerDB_Copy_Handler,
+		},
+		{
+			MethodName: "UpdateMetadata",
+			Handler:    _PointerDB_UpdateMetadata_Handler,
+		},
+		{
+			MethodName: "SetLifecycle",
+			Handler:    _PointerDB_SetLifecycle_Handler,
+		},
+		{
+			MethodName: "GetLifecycle",
+			Handler:    _PointerDB_GetLifecycle_Handler,
+		},
 		{
 			MethodName: "PayerBandwidthAllocation",
 			Handler:    _PointerDB_PayerBandwidthAllocation_Handler,
 		},
+		{
+			MethodName: "ReportCorruption",
+			Handler:    _PointerDB_ReportCorruption_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pointerdb.proto",
 }
 
-func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_afdf954a8fe5efcf) }
-
-var fileDescriptor_pointerdb_afdf954a8fe5efcf = []byte{
-	// 1090 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xaf, 0xff, 0xc7, 0x73, 0x76, 0x6a, 0x56, 0x25, 0xbd, 0xba, 0x45, 0x49, 0x0f, 0x01, 0xa5,
-	0xad, 0x5c, 0x64, 0x2a, 0x21, 0x28, 0x08, 0x35, 0x24, 0x54, 0x96, 0xda, 0x10, 0xad, 0xf3, 0x84,
-	0x90, 0x8e, 0xb5, 0x6f, 0x6c, 0xaf, 0xf0, 0xfd, 0xe9, 0xee, 0x5e, 0x69, 0xf2, 0x4d, 0xf8, 0x26,
-	0xbc, 0xf0, 0x88, 0xc4, 0x67, 0xe0, 0xa1, 0x0f, 0x88, 0x8f, 0xc1, 0x03, 0xda, 0x3f, 0x67, 0x9f,
-	0x1b, 0x92, 0x22, 0x78, 0x49, 0x6e, 0x66, 0x7e, 0x33, 0x3b, 0x33, 0xbf, 0xdf, 0xae, 0xe1, 0x6a,
-	0x96, 0xf2, 0x44, 0xa1, 0x88, 0x26, 0x83, 0x4c, 0xa4, 0x2a, 0x25, 0xed, 0x95, 0xa3, 0xbf, 0x3b,
-	0x4f, 0xd3, 0xf9, 0x12, 0x1f, 0x98, 0xc0, 0x24, 0x9f, 0x3d, 0x50, 0x3c, 0x46, 0xa9, 0x58, 0x9c,
-	0x59, 0x6c, 0x1f, 0xe6, 0xe9, 0x3c, 0x2d, 0xbe, 0x93, 0x34, 0x42, 0xf7, 0xdd, 0xcb, 0x38, 0x4e,
-	0x51, 0xaa, 0x54, 0x38, 0x4f, 0xf0, 0x53, 0x15, 0x7a, 0x14, 0xa3, 0x3c, 0x89, 0x58, 0x32, 0x3d,
-	0x1d, 0x4f, 0x17, 0x18, 0x23, 0xf9, 0x0c, 0xea, 0xea, 0x34, 0x43, 0xbf, 0xb2, 0x57, 0xb9, 0xb3,
-	0x3d, 0x7c, 0x7f, 0xb0, 0x6e, 0xe5, 0x75, 0xe8, 0xc0, 0xfe, 0x3b, 0x39, 0xcd, 0x90, 0x9a, 0x1c,
-	0x72, 0x1d, 0x5a, 0x31, 0x4f, 0x42, 0x81, 0xcf, 0xfd, 0xea, 0x5e, 0xe5, 0x4e, 0x83, 0x36, 0x63,
-	0x9e, 0x50, 0x7c, 0x4e, 0xae, 0x41, 0x43, 0xa5, 0x8a, 0x2d, 0xfd, 0x9a, 0x71, 0x5b, 0x83, 0x7c,
-	0x08, 0x3d, 0x81, 0x19, 0xe3, 0x22, 0x54, 0x0b, 0x81, 0x72, 0x91, 0x2e, 0x23, 0xbf, 0x6e, 0x00,
-	0x57, 0xad, 0xff, 0xa4, 0x70, 0x93, 0x7b, 0xf0, 0x96, 0xcc, 0xa7, 0x53, 0x94, 0xb2, 0x84, 0x6d,
-	0x18, 0x6c, 0xcf, 0x05, 0xd6, 0xe0, 0xfb, 0x40, 0x50, 0x30, 0x99, 0x0b, 0x0c, 0xe5, 0x82, 0xe9,
-	0xbf, 0xfc, 0x0c, 0xfd, 0xa6, 0x45, 0xbb, 0xc8, 0x58, 0x07, 0xc6, 0xfc, 0x0c, 0x83, 0x6b, 0x00,
-	0xeb, 0x41, 0x48, 0x13, 0xaa, 0x74, 0xdc, 0xbb, 0x12, 0x8c, 0xc1, 0xa3, 0x18, 0xa7, 0x0a, 0x8f,
-	0xf5, 0xd6, 0xc8, 0x4d, 0x68, 0x9b, 0xf5, 0x85, 0x49, 0x1e, 0x9b, 0xd5, 0x34, 0xe8, 0x96, 0x71,
-	0x1c, 0xe5, 0x31, 0xf9, 0x00, 0x5a, 0x7a, 0xcf, 0x21, 0x8f, 0xcc, 0xd8, 0x9d, 0xfd, 0xed, 0xdf,
-	0x5e, 0xed, 0x5e, 0xf9, 0xfd, 0xd5, 0x6e, 0xf3, 0x28, 0x8d, 0x70, 0x74, 0x40, 0x9b, 0x3a, 0x3c,
-	0x8a, 0x82, 0x5f, 0x2b, 0xd0, 0xb5, 0x55, 0xc7, 0x38, 0x8f, 0x31, 0x51, 0xe4, 0x11, 0x80, 0x58,
-	0xad, 0xd5, 0x14, 0xf6, 0x86, 0x37, 0x2f, 0xd9, 0x39, 0x2d, 0xc1, 0xc9, 0x0d, 0xb0, 0x3d, 0x14,
-	0x07, 0xb7, 0x69, 0xcb, 0xd8, 0xa3, 0x88, 0x3c, 0x82, 0xae, 0x30, 0x07, 0x85, 0x96, 0x75, 0xbf,
-	0xb6, 0x57, 0xbb, 0xe3, 0x0d, 0x77, 0x36, 0x4a, 0xaf, 0xc6, 0xa3, 0x1d, 0xb1, 0x36, 0x24, 0xd9,
-	0x05, 0x2f, 0x46, 0xf1, 0xc3, 0x12, 0x43, 0x91, 0xa6, 0xca, 0x50, 0xd2, 0xa1, 0x60, 0x5d, 0x34,
-	0x4d, 0x55, 0xf0, 0x57, 0x15, 0x5a, 0xc7, 0xb6, 0x10, 0x79, 0xb0, 0xa1, 0x97, 0x72, 0xef, 0x0e,
-	0x31, 0x38, 0x60, 0x8a, 0x95, 0x44, 0xf2, 0x1e, 0x6c, 0xf3, 0x64, 0xc9, 0x13, 0x0c, 0xa5, 0x5d,
-	0x82, 0x11, 0x45, 0x87, 0x76, 0xad, 0xb7, 0xd8, 0xcc, 0x47, 0xd0, 0xb4, 0x4d, 0x99, 0xf3, 0xbd,
-	0xa1, 0x7f, 0xae, 0x75, 0x87, 0xa4, 0x0e, 0x47, 0x6e, 0x43, 0xc7, 0x55, 0xb4, 0x84, 0x6b, 0x79,
-	0xd4, 0xa8, 0xe7, 0x7c, 0x9a, 0x6b, 0xf2, 0x25, 0x74, 0xa7, 0x02, 0x99, 0xe2, 0x69, 0x12, 0x46,
-	0x4c, 0x59, 0x51, 0x78, 0xc3, 0xfe, 0xc0, 0x5e, 0xaa, 0x41, 0x71, 0xa9, 0x06, 0x27, 0xc5, 0xa5,
-	0xa2, 0x9d, 0x22, 0xe1, 0x80, 0x29, 0x24, 0x5f, 0xc1, 0x55, 0x7c, 0x99, 0x71, 0x51, 0x2a, 0xd1,
-	0x7a, 0x63, 0x89, 0xed, 0x75, 0x8a, 0x29, 0xd2, 0x87, 0xad, 0x18, 0x15, 0x8b, 0x98, 0x62, 0xfe,
-	0x96, 0x99, 0x7d, 0x65, 0x07, 0x01, 0x6c, 0x15, 0xfb, 0x22, 0x00, 0xcd, 0xd1, 0xd1, 0xd3, 0xd1,
-	0xd1, 0x61, 0xef, 0x8a, 0xfe, 0xa6, 0x87, 0xcf, 0xbe, 0x39, 0x39, 0xec, 0x55, 0x82, 0x23, 0x80,
-	0xe3, 0x5c, 0x51, 0x7c, 0x9e, 0xa3, 0x54, 0x84, 0x40, 0x3d, 0x63, 0x6a, 0x61, 0x08, 0x68, 0x53,
-	0xf3, 0x4d, 0xee, 0x43, 0xcb, 0x6d, 0xcb, 0x08, 0xc3, 0x1b, 0x92, 0xf3, 0xbc, 0xd0, 0x02, 0x12,
-	0xec, 0x01, 0x3c, 0xc1, 0xcb, 0xea, 0x05, 0x3f, 0x57, 0xc0, 0x7b, 0xca, 0xe5, 0x0a, 0xb3, 0x03,
-	0xcd, 0x4c, 0xe0, 0x8c, 0xbf, 0x74, 0x28, 0x67, 0x69, 0xe5, 0x48, 0xc5, 0x84, 0x0a, 0xd9, 0xac,
-	0x38, 0xbb, 0x4d, 0xc1, 0xb8, 0x1e, 0x6b, 0x0f, 0x79, 0x07, 0x00, 0x93, 0x28, 0x9c, 0xe0, 0x2c,
-	0x15, 0x68, 0x88, 0x6f, 0xd3, 0x36, 0x26, 0xd1, 0xbe, 0x71, 0x90, 0x5b, 0xd0, 0x16, 0x38, 0xcd,
-	0x85, 0xe4, 0x2f, 0x2c, 0xef, 0x5b, 0x74, 0xed, 0xd0, 0xaf, 0xc8, 0x92, 0xc7, 0x5c, 0xb9, 0x8b,
-	0x6f, 0x0d, 0x5d, 0x52, 0x6f, 0x2f, 0x9c, 0x2d, 0xd9, 0x5c, 0x1a, 0x42, 0x5b, 0xb4, 0xad, 0x3d,
-	0x5f, 0x6b, 0x47, 0xd0, 0x05, 0xcf, 0x2c, 0x4b, 0x66, 0x69, 0x22, 0x31, 0xf8, 0xa3, 0x02, 0x9e,
-	0x19, 0xd6, 0xda, 0xe5, 0x4d, 0x55, 0xde, 0xb8, 0x29, 0xb2, 0x07, 0x0d, 0x7d, 0x95, 0xa5, 0x5f,
-	0x35, 0xd7, 0x09, 0x06, 0xe6, 0x7d, 0xd5, 0xb7, 0x9c, 0xda, 0x00, 0xf9, 0x1c, 0x6a, 0xd9, 0x84,
-	0x99, 0xc9, 0xbc, 0xe1, 0xdd, 0xc1, 0xfa, 0xcd, 0x15, 0x69, 0xae, 0x50, 0x0e, 0x8e, 0xd9, 0x29,
-	0x8a, 0x7d, 0x96, 0x44, 0x3f, 0xf2, 0x48, 0x2d, 0x1e, 0x2f, 0x97, 0xe9, 0xd4, 0x08, 0x83, 0xea,
-	0x34, 0x72, 0x08, 0x5d, 0x96, 0xab, 0x45, 0x2a, 0xf8, 0x99, 0xf1, 0x3a, 0xed, 0xef, 0x9e, 0xaf,
-	0x33, 0xe6, 0xf3, 0x04, 0xa3, 0x67, 0x28, 0x25, 0x9b, 0x23, 0xdd, 0xcc, 0x0a, 0x7e, 0xa9, 0x40,
-	0xc7, 0xd2, 0xe5, 0xa6, 0x1c, 0x42, 0x83, 0x2b, 0x8c, 0xa5, 0x5f, 0x31, 0x7d, 0xdf, 0x2a, 0xcd,
-	0x58, 0xc6, 0x0d, 0x46, 0x0a, 0x63, 0x6a, 0xa1, 0x5a, 0x07, 0xb1, 0x26, 0xa9, 0x6a, 0x68, 0x30,
-	0xdf, 0x7d, 0x84, 0xba, 0x86, 0xfc, 0x7f, 0xcd, 0xe9, 0x07, 0x95, 0xcb, 0xd0, 0x89, 0xa8, 0x66,
-	0x8e, 0xd8, 0xe2, 0xf2, 0xd8, 0xd8, 0xc1, 0xbb, 0xd0, 0x3d, 0xc0, 0x25, 0x2a, 0xbc, 0x4c, 0x93,
-	0x3d, 0xd8, 0x2e, 0x40, 0x8e, 0x5b, 0x01, 0xdb, 0x23, 0x85, 0x82, 0xad, 0xf3, 0x2e, 0xd2, 0xe9,
-	0x35, 0x68, 0xcc, 0xb8, 0x90, 0xca, 0x29, 0xd4, 0x1a, 0xc4, 0x87, 0x96, 0x15, 0x1b, 0xba, 0x8e,
-	0x0a, 0xd3, 0x46, 0x5e, 0xa0, 0x8e, 0xd4, 0x8b, 0x88, 0x31, 0x83, 0xef, 0x60, 0xf7, 0x42, 0x4a,
-	0x5d, 0x13, 0x9f, 0x42, 0x93, 0x4d, 0x0d, 0x9b, 0xf6, 0x8d, 0xbc, 0x7d, 0x9e, 0xcd, 0x75, 0xb6,
-	0x01, 0x52, 0x97, 0x10, 0x7c, 0x0f, 0x7b, 0x17, 0x57, 0x77, 0xdc, 0x3a, 0xc5, 0x55, 0xfe, 0x93,
-	0xe2, 0x86, 0x7f, 0x56, 0xa1, 0xed, 0xc8, 0x39, 0xd8, 0x27, 0x0f, 0xa1, 0x76, 0x9c, 0x2b, 0xf2,
-	0x76, 0x99, 0xb9, 0xd5, 0x4b, 0xd3, 0xdf, 0x79, 0xdd, 0xed, 0x3a, 0x78, 0x08, 0xb5, 0x27, 0xb8,
-	0x99, 0xb5, 0x7e, 0x4f, 0x36, 0xb2, 0xca, 0x37, 0xef, 0x13, 0xa8, 0x6b, 0xed, 0x91, 0x9d, 0x73,
-	0x62, 0xb4, 0x79, 0xd7, 0x2f, 0x10, 0x29, 0xf9, 0x02, 0x9a, 0x96, 0x78, 0x52, 0xfe, 0x4d, 0xd8,
-	0x10, 0x4c, 0xff, 0xc6, 0x3f, 0x44, 0x5c, 0xba, 0x04, 0xff, 0xa2, 0x95, 0x90, 0xbb, 0xe5, 0x09,
-	0x2f, 0xa7, 0xb5, 0x7f, 0xef, 0x5f, 0x61, 0xed, 0xa1, 0xfb, 0xf5, 0x6f, 0xab, 0xd9, 0x64, 0xd2,
-	0x34, 0x3f, 0x0e, 0x1f, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x68, 0xba, 0x5b, 0xd0, 0xe0, 0x09,
-	0x00, 0x00,
+func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_6193ec5ffdb79b26) }
+
+var fileDescriptor_pointerdb_6193ec5ffdb79b26 = []byte{
+	// 1525 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x72, 0x1b, 0x59,
+	0x15, 0x4e, 0x4b, 0x96, 0x64, 0x1d, 0xfd, 0x58, 0xdc, 0xf1, 0xd8, 0x3d, 0x9a, 0x61, 0xac, 0xf4,
+	0x14, 0x8c, 0xe7, 0x07, 0x85, 0x12, 0x53, 0xc5, 0xcf, 0x84, 0x82, 0x38, 0x36, 0x42, 0x55, 0x89,
+	0xa3, 0xba, 0x76, 0x36, 0x40, 0x55, 0xd3, 0x52, 0x1f, 0x49, 0x5d, 0x69, 0x75, 0x77, 0xee, 0xbd,
+	0x1d, 0xa2, 0xac, 0x78, 0x00, 0x5e, 0x80, 0x07, 0xa0, 0x8a, 0x47, 0x60, 0xc3, 0x9e, 0x67, 0x60,
+	0x91, 0x05, 0xaf, 0xc0, 0x96, 0x05, 0x75, 0x7f, 0x5a, 0x6a, 0xd9, 0x92, 0x9c, 0xc0, 0x46, 0xea,
+	0x73, 0xce, 0x77, 0xcf, 0xff, 0x39, 0xf7, 0xc2, 0x41, 0x12, 0x07, 0x91, 0x40, 0xe6, 0x8f, 0xba,
+	0x09, 0x8b, 0x45, 0x4c, 0xaa, 0x4b, 0x46, 0xfb, 0x64, 0x1a, 0xc7, 0xd3, 0x10, 0x1f, 0x28, 0xc1,
+	0x28, 0x9d, 0x3c, 0x10, 0xc1, 0x1c, 0xb9, 0xf0, 0xe6, 0x89, 0xc6, 0xb6, 0x61, 0x1a, 0x4f, 0xe3,
+	0xec, 0x3b, 0x8a, 0x7d, 0x34, 0xdf, 0xad, 0x24, 0xc0, 0x31, 0x72, 0x11, 0x33, 0xc3, 0x71, 0xfe,
+	0x5c, 0x80, 0x16, 0x45, 0x3f, 0x8d, 0x7c, 0x2f, 0x1a, 0x2f, 0xae, 0xc6, 0x33, 0x9c, 0x23, 0xf9,
+	0x19, 0xec, 0x89, 0x45, 0x82, 0xb6, 0xd5, 0xb1, 0x4e, 0x9b, 0xbd, 0xef, 0x77, 0x57, 0xae, 0xdc,
+	0x84, 0x76, 0xf5, 0xdf, 0xf5, 0x22, 0x41, 0xaa, 0xce, 0x90, 0x63, 0xa8, 0xcc, 0x83, 0xc8, 0x65,
+	0xf8, 0xd2, 0x2e, 0x74, 0xac, 0xd3, 0x12, 0x2d, 0xcf, 0x83, 0x88, 0xe2, 0x4b, 0x72, 0x08, 0x25,
+	0x11, 0x0b, 0x2f, 0xb4, 0x8b, 0x8a, 0xad, 0x09, 0xf2, 0x05, 0xb4, 0x18, 0x26, 0x5e, 0xc0, 0x5c,
+	0x31, 0x63, 0xc8, 0x67, 0x71, 0xe8, 0xdb, 0x7b, 0x0a, 0x70, 0xa0, 0xf9, 0xd7, 0x19, 0x9b, 0x7c,
+	0x05, 0xdf, 0xe1, 0xe9, 0x78, 0x8c, 0x9c, 0xe7, 0xb0, 0x25, 0x85, 0x6d, 0x19, 0xc1, 0x0a, 0xfc,
+	0x35, 0x10, 0x64, 0x1e, 0x4f, 0x19, 0xba, 0x7c, 0xe6, 0xc9, 0xdf, 0xe0, 0x0d, 0xda, 0x65, 0x8d,
+	0x36, 0x92, 0x2b, 0x29, 0xb8, 0x0a, 0xde, 0xa0, 0x73, 0x08, 0xb0, 0x0a, 0x84, 0x94, 0xa1, 0x40,
+	0xaf, 0x5a, 0xf7, 0x9c, 0xbf, 0x58, 0x50, 0xa3, 0x38, 0x8f, 0x05, 0x0e, 0x65, 0xda, 0xc8, 0xc7,
+	0x50, 0x55, 0xf9, 0x73, 0xa3, 0x74, 0xae, 0x72, 0x53, 0xa2, 0xfb, 0x8a, 0x71, 0x99, 0xce, 0xc9,
+	0xe7, 0x50, 0x91, 0x89, 0x76, 0x03, 0x5f, 0xc5, 0x5d, 0x3f, 0x6b, 0xfe, 0xe3, 0xed, 0xc9, 0xbd,
+	0x7f, 0xbe, 0x3d, 0x29, 0x5f, 0xc6, 0x3e, 0x0e, 0xce, 0x69, 0x59, 0x8a, 0x07, 0x3e, 0x21, 0xb0,
+	0x37, 0xf3, 0xf8, 0x4c, 0xa5, 0xa1, 0x4e, 0xd5, 0x37, 0x79, 0x08, 0x35, 0x1e, 0x4c, 0x23, 0xf4,
+	0x5d, 0x25, 0x92, 0x09, 0xa8, 0xf5, 0x3e, 0xee, 0xae, 0xaa, 0xc5, 0xe2, 0x54, 0x20, 0xef, 0x2a,
+	0x3f, 0x7e, 0xed, 0xf1, 0x19, 0x05, 0x8d, 0x97, 0xdf, 0xce, 0x1f, 0x0b, 0xd0, 0xd0, 0x7e, 0x5e,
+	0xe1, 0x74, 0x8e, 0x91, 0x20, 0xdf, 0x02, 0xb0, 0x65, 0xa5, 0x6c, 0x2b, 0x53, 0xb7, 0xb5, 0x8c,
+	0x34, 0x07, 0x27, 0x1f, 0x81, 0x8e, 0x2a, 0x0b, 0xa5, 0x4a, 0x2b, 0x8a, 0x1e, 0xf8, 0xe4, 0x5b,
+	0x68, 0x30, 0x65, 0xc8, 0xd5, 0xae, 0xd9, 0xc5, 0x4e, 0xf1, 0xb4, 0xd6, 0x3b, 0x5a, 0x53, 0xbd,
+	0x4c, 0x18, 0xad, 0xb3, 0x15, 0xc1, 0xc9, 0x09, 0xd4, 0xe6, 0xc8, 0x5e, 0x84, 0xe8, 0xb2, 0x38,
+	0x16, 0x2a, 0xc8, 0x3a, 0x05, 0xcd, 0xa2, 0x71, 0x2c, 0xa4, 0x61, 0x1f, 0xfd, 0x34, 0x91, 0x86,
+	0xcb, 0x4a, 0x5a, 0x51, 0xf4, 0xc0, 0x27, 0x9f, 0xca, 0x80, 0x26, 0xc8, 0x30, 0x92, 0x56, 0x2b,
+	0x1d, 0xeb, 0xb4, 0x48, 0x73, 0x1c, 0xe7, 0x3f, 0x05, 0xa8, 0x0c, 0xb5, 0x0f, 0xe4, 0xc1, 0x5a,
+	0xf7, 0xe6, 0xc3, 0x36, 0x88, 0xee, 0xb9, 0x27, 0xbc, 0x5c, 0xcb, 0x7e, 0x0f, 0x9a, 0x41, 0x14,
+	0x06, 0x11, 0xba, 0x5c, 0xe7, 0xcf, 0xd4, 0xa6, 0xa1, 0xb9, 0x59, 0x52, 0x7f, 0x08, 0x65, 0x1d,
+	0x8f, 0xa9, 0x8f, 0x7d, 0x2b, 0x6a, 0x83, 0xa4, 0x06, 0x47, 0xee, 0x43, 0xdd, 0x68, 0xd4, 0xed,
+	0x57, 0x52, 0x7e, 0xd7, 0x0c, 0x4f, 0x76, 0x1e, 0xf9, 0x05, 0x34, 0xc6, 0x0c, 0x3d, 0x11, 0xc4,
+	0x91, 0xeb, 0x7b, 0x42, 0xb7, 0x68, 0xad, 0xd7, 0xee, 0xea, 0x11, 0xef, 0x66, 0x23, 0xde, 0xbd,
+	0xce, 0x46, 0x9c, 0xd6, 0xb3, 0x03, 0xe7, 0x9e, 0x40, 0xf2, 0x18, 0x0e, 0xf0, 0x75, 0x12, 0xb0,
+	0x9c, 0x8a, 0xca, 0x9d, 0x2a, 0x9a, 0xab, 0x23, 0x4a, 0x49, 0x1b, 0xf6, 0xe7, 0x28, 0x3c, 0xdf,
+	0x13, 0x9e, 0xbd, 0xaf, 0x62, 0x5f, 0xd2, 0x8e, 0x03, 0xfb, 0x59, 0xbe, 0x08, 0x40, 0x79, 0x70,
+	0xf9, 0x64, 0x70, 0x79, 0xd1, 0xba, 0x27, 0xbf, 0xe9, 0xc5, 0xd3, 0x67, 0xd7, 0x17, 0x2d, 0xcb,
+	0xb9, 0x04, 0x18, 0xa6, 0x82, 0xe2, 0xcb, 0x14, 0xb9, 0x90, 0x1d, 0x9e, 0x78, 0x62, 0xa6, 0x0a,
+	0x50, 0xa5, 0xea, 0x9b, 0x7c, 0x0d, 0x15, 0x93, 0x2d, 0xd5, 0x53, 0xb5, 0x1e, 0xb9, 0x5d, 0x17,
+	0x9a, 0x41, 0x9c, 0x0e, 0x40, 0x1f, 0x77, 0xe9, 0x73, 0xfe, 0x66, 0x41, 0xed, 0x49, 0xc0, 0x97,
+	0x98, 0x23, 0x28, 0x27, 0x0c, 0x27, 0xc1, 0x6b, 0x83, 0x32, 0x94, 0x6c, 0x3a, 0x2e, 0x3c, 0x26,
+	0x5c, 0x6f, 0x92, 0xd9, 0xae, 0x52, 0x50, 0xac, 0x47, 0x92, 0x43, 0xbe, 0x0b, 0x80, 0x91, 0xef,
+	0x8e, 0x70, 0x12, 0x33, 0x54, 0x85, 0xaf, 0xd2, 0x2a, 0x46, 0xfe, 0x99, 0x62, 0x90, 0x4f, 0xa0,
+	0xca, 0x70, 0x9c, 0x32, 0x1e, 0xbc, 0xd2, 0x75, 0xdf, 0xa7, 0x2b, 0x86, 0xdc, 0x69, 0x61, 0x30,
+	0x0f, 0x84, 0x59, 0x43, 0x9a, 0x90, 0x2a, 0x65, 0xf6, 0xdc, 0x49, 0xe8, 0x4d, 0xb9, 0x2a, 0x68,
+	0x85, 0x56, 0x25, 0xe7, 0x57, 0x92, 0xe1, 0x34, 0xa0, 0xa6, 0x92, 0xc5, 0x93, 0x38, 0xe2, 0xe8,
+	0xfc, 0xcb, 0x82, 0x5a, 0x1f, 0x97, 0x74, 0x3e, 0x53, 0xd6, 0x9d, 0x99, 0x22, 0x1d, 0x28, 0xc9,
+	0xbd, 0xc2, 0xed, 0x82, 0x9a, 0x44, 0xe8, 0x4a, 0xaa, 0x2b, 0x57, 0x0e, 0xd5, 0x02, 0xf2, 0x10,
+	0x8a, 0xc9, 0xc8, 0x53, 0x91, 0xd5, 0x7a, 0x5f, 0x6e, 0xd8, 0x29, 0xde, 0x02, 0xd9, 0x99, 0x17,
+	0xf9, 0x7f, 0x08, 0x7c, 0x31, 0x7b, 0x14, 0x86, 0xf1, 0x58, 0x35, 0x06, 0x95, 0xc7, 0xc8, 0x05,
+	0x34, 0xbc, 0x54, 0xcc, 0x62, 0x16, 0xbc, 0x51, 0x5c, 0xd3, 0xfb, 0x27, 0xb7, 0xf5, 0x5c, 0xa9,
+	0x85, 0xf4, 0x14, 0x39, 0xf7, 0xa6, 0x48, 0xd7, 0x4f, 0x39, 0x7f, 0xb7, 0xa0, 0xae, 0xcb, 0x65,
+	0xa2, 0xec, 0x41, 0x29, 0x10, 0x38, 0xe7, 0xb6, 0xa5, 0xfc, 0xfe, 0x24, 0x17, 0x63, 0x1e, 0xd7,
+	0x1d, 0x08, 0x9c, 0x53, 0x0d, 0x95, 0x7d, 0x30, 0x97, 0x45, 0x2a, 0xa8, 0x32, 0xa8, 0xef, 0x36,
+	0xc2, 0x9e, 0x84, 0xfc, 0xff, 0x3d, 0x27, 0xb7, 0x7b, 0xc0, 0x5d, 0xd3, 0x44, 0x45, 0x65, 0x62,
+	0x3f, 0xe0, 0x43, 0x45, 0x3b, 0x9f, 0x41, 0xe3, 0x1c, 0x43, 0x14, 0xb8, 0xab, 0x27, 0x7f, 0x09,
+	0xcd, 0x0c, 0x64, 0xa2, 0xec, 0xc2, 0x3e, 0xc3, 0x10, 0x3d, 0x8e, 0xfe, 0x8e, 0x62, 0x2e, 0x31,
+	0x4e, 0x0a, 0xb5, 0xc7, 0x71, 0xb2, 0xc8, 0x8c, 0xc8, 0xe6, 0x8d, 0x53, 0x36, 0x46, 0x37, 0x67,
+	0x0b, 0x34, 0x6b, 0x28, 0x23, 0xfc, 0x02, 0x5a, 0x3e, 0x72, 0x11, 0x44, 0x7a, 0xfa, 0x15, 0x4a,
+	0xb7, 0xf8, 0x41, 0x8e, 0xaf, 0xa0, 0xf9, 0x11, 0x2f, 0xde, 0x18, 0xf1, 0x26, 0xd4, 0xb5, 0x59,
+	0xd3, 0x92, 0x7f, 0xb2, 0xe0, 0xc3, 0xe7, 0x89, 0xdc, 0x25, 0x4f, 0x0d, 0x64, 0xd7, 0x68, 0xe7,
+	0x35, 0x17, 0xd6, 0x35, 0x6f, 0xda, 0x4e, 0xc5, 0xf7, 0xdd, 0x4e, 0x8e, 0x0d, 0x47, 0x37, 0xbd,
+	0x31, 0x8e, 0xfe, 0xd5, 0x82, 0xc6, 0x93, 0x60, 0x82, 0xe3, 0xc5, 0x38, 0x44, 0x9a, 0x86, 0x48,
+	0x9a, 0x50, 0x08, 0x7c, 0xe3, 0x5e, 0x21, 0xf0, 0x73, 0x7b, 0xa1, 0xb0, 0xb6, 0x17, 0x6c, 0xa8,
+	0x60, 0xe4, 0x8d, 0x42, 0xf4, 0x4d, 0xad, 0x33, 0x92, 0x7c, 0x7e, 0xc3, 0xe5, 0x05, 0x37, 0x0f,
+	0x92, 0x35, 0xb7, 0x16, 0x5c, 0x3e, 0x31, 0xbc, 0x51, 0xcc, 0x84, 0x9b, 0x60, 0xe4, 0x07, 0xd1,
+	0x54, 0x63, 0xcd, 0x83, 0x44, 0x49, 0x86, 0x5a, 0x20, 0xd1, 0xce, 0x23, 0x38, 0x38, 0x4b, 0xc7,
+	0x2f, 0x50, 0x2c, 0xfd, 0x25, 0x5d, 0x28, 0xb1, 0x34, 0xc4, 0x6c, 0x06, 0xec, 0xb5, 0x19, 0xc8,
+	0x05, 0x45, 0x35, 0xcc, 0x99, 0xc2, 0x07, 0x57, 0xb9, 0xf3, 0xb9, 0xd5, 0x37, 0x52, 0x9a, 0xb3,
+	0xd5, 0xa7, 0x29, 0xf2, 0x13, 0xa8, 0x86, 0x19, 0xd6, 0x0c, 0x40, 0x3b, 0x67, 0xe2, 0x86, 0x37,
+	0x74, 0x05, 0x76, 0x8e, 0xe0, 0x70, 0xdd, 0x90, 0x49, 0xf7, 0x0f, 0xe0, 0x83, 0xfe, 0xbb, 0x3b,
+	0xe0, 0x0c, 0xe1, 0xb0, 0xbf, 0x41, 0xcd, 0xba, 0x63, 0xd6, 0xfb, 0x38, 0xc6, 0xa0, 0x39, 0x10,
+	0xc8, 0x3c, 0x81, 0x77, 0xed, 0xfd, 0x43, 0x28, 0x4d, 0x02, 0xc6, 0x85, 0x29, 0xbb, 0x26, 0x64,
+	0xd5, 0xf5, 0xf2, 0xc6, 0xac, 0xea, 0x86, 0xd4, 0x92, 0x57, 0x28, 0x25, 0x7b, 0x99, 0x44, 0x91,
+	0x0e, 0x87, 0x63, 0x8a, 0x49, 0xcc, 0xc4, 0xe3, 0x98, 0xb1, 0x34, 0x51, 0xab, 0x71, 0xc7, 0x34,
+	0xac, 0x3d, 0x12, 0x0b, 0xdb, 0x1f, 0x89, 0xc5, 0x5d, 0x8f, 0x44, 0xa7, 0x0d, 0xf6, 0x6d, 0xa3,
+	0xa6, 0x0a, 0xbf, 0x83, 0x93, 0xad, 0x3b, 0xdb, 0x38, 0xf6, 0x53, 0x28, 0x7b, 0x63, 0xc9, 0x30,
+	0x8f, 0xa0, 0xfb, 0xb7, 0xd7, 0xf5, 0xea, 0xb4, 0x02, 0x52, 0x73, 0xc0, 0xf9, 0x3d, 0x74, 0xb6,
+	0x6b, 0x37, 0x05, 0x34, 0x57, 0x8a, 0xf5, 0x3f, 0x5d, 0x29, 0xbd, 0x7f, 0x97, 0xa0, 0x6a, 0x56,
+	0xdf, 0xf9, 0x19, 0xf9, 0x06, 0x8a, 0xc3, 0x54, 0x90, 0x0f, 0xf3, 0x7b, 0x71, 0xf9, 0x94, 0x68,
+	0x1f, 0xdd, 0x64, 0x1b, 0x0f, 0xbe, 0x81, 0x62, 0x1f, 0xd7, 0x4f, 0xf5, 0x71, 0xe3, 0xa9, 0xfc,
+	0xd5, 0xfa, 0x63, 0xd8, 0x93, 0x97, 0x0b, 0x39, 0xba, 0x75, 0xdb, 0xe8, 0x73, 0xc7, 0x5b, 0x6e,
+	0x21, 0xf2, 0x73, 0x28, 0xeb, 0xcd, 0x4e, 0xf2, 0x43, 0xba, 0x76, 0x23, 0xb4, 0x3f, 0xda, 0x20,
+	0x59, 0xd9, 0x95, 0xfb, 0x75, 0xcd, 0x6e, 0x6e, 0xcf, 0xb7, 0x8f, 0x6f, 0xf1, 0xcd, 0xc1, 0xe7,
+	0xd0, 0x5c, 0xdf, 0x7c, 0xa4, 0x93, 0x83, 0x6e, 0x5c, 0xd1, 0xed, 0xfb, 0x3b, 0x10, 0x46, 0xed,
+	0x33, 0xa8, 0xe7, 0xe7, 0x9b, 0x7c, 0x9a, 0x3b, 0xb2, 0x61, 0xc3, 0xb4, 0x4f, 0xb6, 0xca, 0x57,
+	0x0a, 0xfb, 0xdb, 0x14, 0xf6, 0xef, 0x50, 0xb8, 0x71, 0x45, 0x70, 0xb0, 0xb7, 0x35, 0x11, 0xf9,
+	0x32, 0xdf, 0x13, 0xbb, 0x07, 0xa1, 0xfd, 0xd5, 0x3b, 0x61, 0x8d, 0xd1, 0xdf, 0x42, 0xeb, 0xe6,
+	0xd0, 0x11, 0x67, 0xed, 0x91, 0xbf, 0x71, 0x0d, 0xb4, 0x3f, 0xdb, 0x89, 0xd1, 0xca, 0xcf, 0xf6,
+	0x7e, 0x53, 0x48, 0x46, 0xa3, 0xb2, 0xba, 0xee, 0x7e, 0xf4, 0xdf, 0x01, 0x00, 0x88, 0x10, 0x84,
+	0x65, 0xde, 0x0f, 0x00, 0x00,
 }
@@ -19,8 +19,16 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type SegmentMeta struct {
-	EncryptedKey         []byte   `protobuf:"bytes,1,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
-	KeyNonce             []byte   `protobuf:"bytes,2,opt,name=key_nonce,json=keyNonce,proto3" json:"key_nonce,omitempty"`
+	EncryptedKey []byte `protobuf:"bytes,1,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	KeyNonce     []byte `protobuf:"bytes,2,opt,name=key_nonce,json=keyNonce,proto3" json:"key_nonce,omitempty"`
+	// nonce of the content of a segment of a concatenated stream, which
+	// doesn't follow from the index of the segment
+	ContentNonce []byte `protobuf:"bytes,3,opt,name=content_nonce,json=contentNonce,proto3" json:"content_nonce,omitempty"`
+	// size of the blocks of the content of a compressed segment, which are
+	// compressed on their own, and the offsets of the compressed blocks
+	// followed by the size of the compressed content
+	CompressedBlockSize  int64    `protobuf:"varint,4,opt,name=compressed_block_size,json=compressedBlockSize,proto3" json:"compressed_block_size,omitempty"`
+	CompressedOffsets    []int64  `protobuf:"varint,5,rep,packed,name=compressed_offsets,json=compressedOffsets,proto3" json:"compressed_offsets,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -30,7 +38,7 @@ func (m *SegmentMeta) Reset()         { *m = SegmentMeta{} }
 func (m *SegmentMeta) String() string { return proto.CompactTextString(m) }
 func (*SegmentMeta) ProtoMessage()    {}
 func (*SegmentMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_bbbe703970d9d652, []int{0}
+	return fileDescriptor_streams_9fbd398846f66c26, []int{0}
 }
 func (m *SegmentMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentMeta.Unmarshal(m, b)
@@ -64,11 +72,43 @@ func (m *SegmentMeta) GetKeyNonce() []byte {
 	return nil
 }
 
+func (m *SegmentMeta) GetContentNonce() []byte {
+	if m != nil {
+		return m.ContentNonce
+	}
+	return nil
+}
+
+func (m *SegmentMeta) GetCompressedBlockSize() int64 {
+	if m != nil {
+		return m.CompressedBlockSize
+	}
+	return 0
+}
+
+func (m *SegmentMeta) GetCompressedOffsets() []int64 {
+	if m != nil {
+		return m.CompressedOffsets
+	}
+	return nil
+}
+
 type StreamInfo struct {
-	NumberOfSegments     int64    `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
-	SegmentsSize         int64    `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
-	LastSegmentSize      int64    `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
-	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	NumberOfSegments int64  `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
+	SegmentsSize     int64  `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
+	LastSegmentSize  int64  `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
+	Metadata         []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CompressionType  int32  `protobuf:"varint,5,opt,name=compression_type,json=compressionType,proto3" json:"compression_type,omitempty"`
+	Deduplicated     bool   `protobuf:"varint,6,opt,name=deduplicated,proto3" json:"deduplicated,omitempty"`
+	// MD5 and SHA-256 checksums of the content, or the states of the
+	// checksums of the committed segments in the marker of a pending upload,
+	// as computed for the checksum type
+	Checksum       []byte `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ChecksumType   int32  `protobuf:"varint,8,opt,name=checksum_type,json=checksumType,proto3" json:"checksum_type,omitempty"`
+	Sha256Checksum []byte `protobuf:"bytes,10,opt,name=sha256_checksum,json=sha256Checksum,proto3" json:"sha256_checksum,omitempty"`
+	// sizes of all the segments but the last one of a concatenated stream,
+	// whose segments don't have the same size
+	SegmentSizes         []int64  `protobuf:"varint,9,rep,packed,name=segment_sizes,json=segmentSizes,proto3" json:"segment_sizes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -78,7 +118,7 @@ func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
 func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
 func (*StreamInfo) ProtoMessage()    {}
 func (*StreamInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_bbbe703970d9d652, []int{1}
+	return fileDescriptor_streams_9fbd398846f66c26, []int{1}
 }
 func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamInfo.Unmarshal(m, b)
@@ -126,11 +166,54 @@ func (m *StreamInfo) GetMetadata() []byte {
 	return nil
 }
 
+func (m *StreamInfo) GetCompressionType() int32 {
+	if m != nil {
+		return m.CompressionType
+	}
+	return 0
+}
+
+func (m *StreamInfo) GetDeduplicated() bool {
+	if m != nil {
+		return m.Deduplicated
+	}
+	return false
+}
+
+func (m *StreamInfo) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetChecksumType() int32 {
+	if m != nil {
+		return m.ChecksumType
+	}
+	return 0
+}
+
+func (m *StreamInfo) GetSha256Checksum() []byte {
+	if m != nil {
+		return m.Sha256Checksum
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetSegmentSizes() []int64 {
+	if m != nil {
+		return m.SegmentSizes
+	}
+	return nil
+}
+
 type StreamMeta struct {
 	EncryptedStreamInfo  []byte       `protobuf:"bytes,1,opt,name=encrypted_stream_info,json=encryptedStreamInfo,proto3" json:"encrypted_stream_info,omitempty"`
 	EncryptionType       int32        `protobuf:"varint,2,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
 	EncryptionBlockSize  int32        `protobuf:"varint,3,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
 	LastSegmentMeta      *SegmentMeta `protobuf:"bytes,4,opt,name=last_segment_meta,json=lastSegmentMeta,proto3" json:"last_segment_meta,omitempty"`
+	StreamInfoNonce      []byte       `protobuf:"bytes,5,opt,name=stream_info_nonce,json=streamInfoNonce,proto3" json:"stream_info_nonce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -140,7 +223,7 @@ func (m *StreamMeta) Reset()         { *m = StreamMeta{} }
 func (m *StreamMeta) String() string { return proto.CompactTextString(m) }
 func (*StreamMeta) ProtoMessage()    {}
 func (*StreamMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_bbbe703970d9d652, []int{2}
+	return fileDescriptor_streams_9fbd398846f66c26, []int{2}
 }
 func (m *StreamMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamMeta.Unmarshal(m, b)
@@ -188,33 +271,51 @@ func (m *StreamMeta) GetLastSegmentMeta() *SegmentMeta {
 	return nil
 }
 
+func (m *StreamMeta) GetStreamInfoNonce() []byte {
+	if m != nil {
+		return m.StreamInfoNonce
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SegmentMeta)(nil), "streams.SegmentMeta")
 	proto.RegisterType((*StreamInfo)(nil), "streams.StreamInfo")
 	proto.RegisterType((*StreamMeta)(nil), "streams.StreamMeta")
 }
 
-func init() { proto.RegisterFile("streams.proto", fileDescriptor_streams_bbbe703970d9d652) }
-
-var fileDescriptor_streams_bbbe703970d9d652 = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x51, 0xcb, 0x4e, 0xc3, 0x30,
-	0x10, 0x54, 0x5f, 0x50, 0xb6, 0x29, 0x05, 0x03, 0x52, 0x04, 0x17, 0x14, 0x0e, 0x20, 0x84, 0x7a,
-	0x28, 0x3f, 0x80, 0x7a, 0x43, 0x08, 0x2a, 0x25, 0x9c, 0xb8, 0x58, 0x4e, 0xba, 0x41, 0x51, 0x1a,
-	0x3b, 0x8a, 0xcd, 0xc1, 0xfd, 0x21, 0x3e, 0x8b, 0x5f, 0x41, 0xb6, 0xf3, 0x82, 0xe3, 0xce, 0x8c,
-	0x66, 0x67, 0x76, 0x61, 0x2e, 0x55, 0x85, 0xac, 0x90, 0xcb, 0xb2, 0x12, 0x4a, 0x90, 0xc3, 0x7a,
-	0x0c, 0x36, 0x30, 0x8b, 0xf0, 0xb3, 0x40, 0xae, 0x5e, 0x51, 0x31, 0x72, 0x03, 0x73, 0xe4, 0x49,
-	0xa5, 0x4b, 0x85, 0x5b, 0x9a, 0xa3, 0xf6, 0x07, 0xd7, 0x83, 0x3b, 0x2f, 0xf4, 0x5a, 0xf0, 0x05,
-	0x35, 0xb9, 0x82, 0xa3, 0x1c, 0x35, 0xe5, 0x82, 0x27, 0xe8, 0x0f, 0xad, 0x60, 0x9a, 0xa3, 0x7e,
-	0x33, 0x73, 0xf0, 0x3d, 0x00, 0x88, 0xac, 0xf9, 0x33, 0x4f, 0x05, 0x79, 0x00, 0xc2, 0xbf, 0x8a,
-	0x18, 0x2b, 0x2a, 0x52, 0x2a, 0xdd, 0x26, 0x69, 0x5d, 0x47, 0xe1, 0x89, 0x63, 0x36, 0x69, 0x9d,
-	0x40, 0x9a, 0xf5, 0x8d, 0x86, 0xca, 0x6c, 0xef, 0xdc, 0x47, 0xa1, 0xd7, 0x80, 0x51, 0xb6, 0x47,
-	0x72, 0x0f, 0xa7, 0x3b, 0x26, 0x55, 0xe3, 0xe6, 0x84, 0x23, 0x2b, 0x5c, 0x18, 0xa2, 0x76, 0xb3,
-	0xda, 0x4b, 0x98, 0x16, 0xa8, 0xd8, 0x96, 0x29, 0xe6, 0x8f, 0x5d, 0xd2, 0x66, 0x0e, 0x7e, 0xda,
-	0xa4, 0xb6, 0xfa, 0x0a, 0x2e, 0xba, 0xea, 0xee, 0x3c, 0x34, 0xe3, 0xa9, 0xa8, 0x4f, 0x70, 0xd6,
-	0x92, 0xbd, 0x76, 0xb7, 0xb0, 0xa8, 0xe1, 0x4c, 0x70, 0xaa, 0x74, 0xe9, 0x12, 0x4f, 0xc2, 0xe3,
-	0x0e, 0x7e, 0xd7, 0x25, 0xf6, 0xcc, 0x8d, 0x30, 0xde, 0x89, 0x24, 0xef, 0x72, 0x4f, 0x5a, 0xf3,
-	0x4c, 0xf0, 0xb5, 0xe1, 0x6c, 0xf6, 0xa7, 0x7f, 0x3d, 0x4d, 0x70, 0x5b, 0x62, 0xb6, 0x3a, 0x5f,
-	0x36, 0xef, 0xec, 0x3d, 0xef, 0x4f, 0x7b, 0x03, 0xac, 0xc7, 0x1f, 0xc3, 0x32, 0x8e, 0x0f, 0xec,
-	0xcb, 0x1f, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x5e, 0x32, 0x31, 0x03, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("streams.proto", fileDescriptor_streams_9fbd398846f66c26) }
+
+var fileDescriptor_streams_9fbd398846f66c26 = []byte{
+	// 472 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x53, 0xb1, 0x6e, 0x9c, 0x40,
+	0x10, 0x15, 0xe0, 0xb3, 0xcf, 0x63, 0x6c, 0x6c, 0x2e, 0x96, 0x50, 0xd2, 0xa0, 0x4b, 0x61, 0x62,
+	0x25, 0x2e, 0x2e, 0x4a, 0xea, 0xc8, 0xa9, 0xa2, 0x28, 0xb1, 0xc4, 0xa5, 0x4a, 0xb3, 0xe2, 0x60,
+	0x88, 0x11, 0xc7, 0x2e, 0x62, 0xf7, 0x0a, 0xdc, 0xa7, 0xca, 0x07, 0xe6, 0x77, 0x22, 0x66, 0x17,
+	0x58, 0xa7, 0x63, 0xdf, 0x3c, 0xde, 0xcc, 0xdb, 0x79, 0x0b, 0xe7, 0x52, 0x75, 0x98, 0x35, 0xf2,
+	0xae, 0xed, 0x84, 0x12, 0xe1, 0x89, 0x39, 0xae, 0xff, 0x3a, 0x70, 0xb6, 0xc5, 0x5f, 0x0d, 0x72,
+	0xf5, 0x0d, 0x55, 0x16, 0xbe, 0x86, 0x73, 0xe4, 0x79, 0xd7, 0xb7, 0x0a, 0x0b, 0x56, 0x63, 0x1f,
+	0x39, 0xb1, 0x93, 0xf8, 0xa9, 0x3f, 0x81, 0x5f, 0xb1, 0x0f, 0x5f, 0xc1, 0x69, 0x8d, 0x3d, 0xe3,
+	0x82, 0xe7, 0x18, 0xb9, 0x44, 0x58, 0xd6, 0xd8, 0x7f, 0x1f, 0xce, 0x83, 0x42, 0x2e, 0xb8, 0x42,
+	0xae, 0x0c, 0xc1, 0xd3, 0x0a, 0x06, 0xd4, 0xa4, 0x0d, 0x5c, 0xe7, 0xa2, 0x69, 0x3b, 0x94, 0x12,
+	0x0b, 0xb6, 0xdb, 0x8b, 0xbc, 0x66, 0xb2, 0x7a, 0xc2, 0xe8, 0x28, 0x76, 0x12, 0x2f, 0x5d, 0xcd,
+	0xc5, 0xfb, 0xa1, 0xb6, 0xad, 0x9e, 0x30, 0x7c, 0x07, 0xa1, 0xf5, 0x8f, 0x28, 0x4b, 0x89, 0x4a,
+	0x46, 0x8b, 0xd8, 0x4b, 0xbc, 0xf4, 0x6a, 0xae, 0x3c, 0xe8, 0xc2, 0xfa, 0xb7, 0x07, 0xb0, 0x25,
+	0x97, 0x5f, 0x78, 0x29, 0xc2, 0xb7, 0x10, 0xf2, 0x43, 0xb3, 0xc3, 0x8e, 0x89, 0x92, 0x49, 0xed,
+	0x58, 0x92, 0x3b, 0x2f, 0xbd, 0xd4, 0x95, 0x87, 0xd2, 0xdc, 0x84, 0x1c, 0x4c, 0x8c, 0x1c, 0x3d,
+	0x97, 0x4b, 0x44, 0x7f, 0x04, 0x69, 0xa0, 0x5b, 0xb8, 0xda, 0x67, 0x52, 0x8d, 0x6a, 0x9a, 0xe8,
+	0x11, 0x31, 0x18, 0x0a, 0x46, 0x8d, 0xb8, 0x2f, 0x61, 0xd9, 0xa0, 0xca, 0x8a, 0x4c, 0x65, 0xe4,
+	0xd1, 0x4f, 0xa7, 0x73, 0xf8, 0x06, 0x2e, 0xc7, 0xf1, 0x2b, 0xc1, 0x99, 0xea, 0x5b, 0x8c, 0x16,
+	0xb1, 0x93, 0x2c, 0xd2, 0xc0, 0xc2, 0x7f, 0xf4, 0x2d, 0x86, 0x6b, 0xf0, 0x0b, 0x2c, 0x0e, 0xed,
+	0xbe, 0xca, 0x33, 0x85, 0x45, 0x74, 0x1c, 0x3b, 0xc9, 0x32, 0x7d, 0x86, 0x0d, 0xad, 0xf2, 0x47,
+	0xcc, 0x6b, 0x79, 0x68, 0xa2, 0x13, 0xdd, 0x6a, 0x3c, 0xd3, 0x72, 0xcc, 0xb7, 0xee, 0xb3, 0xa4,
+	0x3e, 0xfe, 0x08, 0x52, 0x93, 0x1b, 0x08, 0xe4, 0x63, 0xb6, 0xf9, 0xf0, 0x91, 0x4d, 0x3a, 0x40,
+	0x3a, 0x17, 0x1a, 0xfe, 0x6c, 0xa9, 0xd9, 0xde, 0x65, 0x74, 0x1a, 0x7b, 0xd6, 0x2d, 0x0d, 0xc6,
+	0xe5, 0xfa, 0x8f, 0x3b, 0xee, 0x81, 0x02, 0xb6, 0x81, 0xeb, 0x39, 0x60, 0x3a, 0x85, 0xac, 0xe2,
+	0xa5, 0x30, 0x41, 0x5b, 0x4d, 0x45, 0x6b, 0x77, 0x37, 0x10, 0x18, 0x78, 0xba, 0x1f, 0x97, 0xe6,
+	0xbe, 0x98, 0x61, 0x9a, 0x7c, 0x16, 0x1f, 0x88, 0x56, 0xac, 0x3c, 0xa2, 0xaf, 0xe6, 0xe2, 0x1c,
+	0xab, 0x4f, 0xff, 0x6d, 0xb1, 0x41, 0xb3, 0xa2, 0xb3, 0xcd, 0x8b, 0xbb, 0xf1, 0xd5, 0x58, 0x4f,
+	0xe4, 0xd9, 0x6e, 0xc9, 0xd2, 0x2d, 0x5c, 0x59, 0x46, 0x4c, 0xea, 0x17, 0x64, 0x27, 0x90, 0x93,
+	0x0b, 0x0a, 0xfe, 0xfd, 0xd1, 0x4f, 0xb7, 0xdd, 0xed, 0x8e, 0xe9, 0x15, 0xbe, 0xff, 0x37, 0x00,
+	0x7a, 0x60, 0xdd, 0xce, 0x96, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,225 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/storage"
+)
+
+var mon = monkit.Package()
+
+var _ storage.Blobs = (*Migration)(nil)
+
+// Migration stores pieces in blobs, and moves the pieces of the legacy
+// storage into blobs while the storage node is running. Until then, the
+// pieces are still served from the legacy storage.
+type Migration struct {
+	log       *zap.Logger
+	legacy    *Storage
+	blobs     storage.Blobs
+	namespace func(id string) ([]byte, error)
+	interval  time.Duration
+
+	// mu prevents deleting or trashing a piece while it's being moved
+	mu sync.Mutex
+}
+
+// NewMigration creates a migration from legacy to blobs. The namespace
+// function returns the namespace of piece id in blobs, or nil when it's
+// unknown, in which case the piece stays in the legacy storage until it's
+// known. The pieces left are retried every interval, unless it's zero.
+func NewMigration(log *zap.Logger, legacy *Storage, blobs storage.Blobs, namespace func(id string) ([]byte, error), interval time.Duration) *Migration {
+	return &Migration{
+		log:       log,
+		legacy:    legacy,
+		blobs:     blobs,
+		namespace: namespace,
+		interval:  interval,
+	}
+}
+
+// Create creates a new piece in blobs
+func (migration *Migration) Create(ctx context.Context, ref storage.BlobRef, size int64) (storage.BlobWriter, error) {
+	return migration.blobs.Create(ctx, ref, size)
+}
+
+// Load loads the piece from blobs or from the legacy storage
+func (migration *Migration) Load(ctx context.Context, ref storage.BlobRef) (storage.ReadSeekCloser, error) {
+	reader, err := migration.blobs.Load(ctx, ref)
+	if !os.IsNotExist(err) {
+		return reader, err
+	}
+
+	reader, err = migration.legacy.Load(ctx, ref)
+	if os.IsNotExist(err) {
+		// the piece may have been moved since it was looked up in blobs
+		return migration.blobs.Load(ctx, ref)
+	}
+	return reader, err
+}
+
+// Delete deletes the piece from both blobs and the legacy storage
+func (migration *Migration) Delete(ctx context.Context, ref storage.BlobRef) error {
+	migration.mu.Lock()
+	defer migration.mu.Unlock()
+
+	return errs.Combine(
+		migration.blobs.Delete(ctx, ref),
+		migration.legacy.Delete(ctx, ref),
+	)
+}
+
+// Trash moves the piece to the trash of the storage it's in
+func (migration *Migration) Trash(ctx context.Context, ref storage.BlobRef) error {
+	migration.mu.Lock()
+	defer migration.mu.Unlock()
+
+	err := migration.blobs.Trash(ctx, ref)
+	if os.IsNotExist(err) {
+		return migration.legacy.Trash(ctx, ref)
+	}
+	return err
+}
+
+// RestoreTrash moves the piece back from the trash of the storage it's in
+func (migration *Migration) RestoreTrash(ctx context.Context, ref storage.BlobRef) error {
+	migration.mu.Lock()
+	defer migration.mu.Unlock()
+
+	err := migration.blobs.RestoreTrash(ctx, ref)
+	if os.IsNotExist(err) {
+		return migration.legacy.RestoreTrash(ctx, ref)
+	}
+	return err
+}
+
+// DeleteTrash deletes the piece from the trash of both storages
+func (migration *Migration) DeleteTrash(ctx context.Context, ref storage.BlobRef) error {
+	return errs.Combine(
+		migration.blobs.DeleteTrash(ctx, ref),
+		migration.legacy.DeleteTrash(ctx, ref),
+	)
+}
+
+// FreeSpace returns how much space is left on the disk of blobs
+func (migration *Migration) FreeSpace() (int64, error) {
+	return migration.blobs.FreeSpace()
+}
+
+// Run moves the pieces of the legacy storage into blobs. With an interval,
+// the pieces left in the legacy storage are retried every interval until ctx
+// is canceled, as their namespaces are learned while they're accessed.
+func (migration *Migration) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if migration.interval <= 0 {
+		return migration.migrate(ctx)
+	}
+
+	ticker := time.NewTicker(migration.interval)
+	defer ticker.Stop()
+
+	for {
+		err := migration.migrate(ctx)
+		if err != nil {
+			if err == ctx.Err() {
+				return err
+			}
+			migration.log.Error("migration failed", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// migrate moves the pieces of the legacy storage with a known namespace into
+// blobs
+func (migration *Migration) migrate(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var moved, skipped int
+	err = migration.legacy.Walk(func(id string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		namespace, err := migration.namespace(id)
+		if err != nil {
+			return err
+		}
+		if namespace == nil {
+			skipped++
+			return nil
+		}
+
+		if err := migration.move(ctx, storage.BlobRef{Namespace: namespace, Key: []byte(id)}); err != nil {
+			migration.log.Error("failed to migrate piece", zap.String("Piece ID", id), zap.Error(err))
+			skipped++
+			return nil
+		}
+		moved++
+		return nil
+	})
+
+	if moved > 0 || skipped > 0 {
+		migration.log.Info("migrated pieces", zap.Int("moved", moved), zap.Int("skipped", skipped))
+	}
+	if err != nil && err == ctx.Err() {
+		return err
+	}
+	return Error.Wrap(err)
+}
+
+// move moves the piece from the legacy storage into blobs
+func (migration *Migration) move(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	migration.mu.Lock()
+	defer migration.mu.Unlock()
+
+	reader, err := migration.legacy.Load(ctx, ref)
+	if os.IsNotExist(err) {
+		// the piece was deleted or trashed in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = migration.copy(ctx, ref, reader)
+	// the piece must be closed before deleting it on some OS-es
+	err = errs.Combine(err, reader.Close())
+	if err != nil {
+		return err
+	}
+
+	return migration.legacy.Delete(ctx, ref)
+}
+
+// copy copies the content of reader to the piece in blobs
+func (migration *Migration) copy(ctx context.Context, ref storage.BlobRef, reader storage.ReadSeekCloser) error {
+	writer, err := migration.blobs.Create(ctx, ref, reader.Size())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		return errs.Combine(err, writer.Cancel())
+	}
+	return writer.Commit()
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/testsuite"
+)
+
+func TestMigrationBlobs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	blobs, err := filestore.NewAt(ctx.Dir("blobs"))
+	require.NoError(t, err)
+
+	migration := NewMigration(zaptest.NewLogger(t), NewStorage(ctx.Dir("legacy")), blobs,
+		func(id string) ([]byte, error) { return nil, nil }, 0)
+
+	testsuite.RunBlobTests(t, migration)
+}
+
+func TestMigration(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	legacy := NewStorage(ctx.Dir("legacy"))
+	blobs, err := filestore.NewAt(ctx.Dir("blobs"))
+	require.NoError(t, err)
+
+	known := strings.Repeat("AB01", 10)
+	unknown := strings.Repeat("CD01", 10)
+	namespace := []byte("satellite")
+
+	for _, id := range []string{known, unknown} {
+		writer, err := legacy.Create(ctx, storage.BlobRef{Key: []byte(id)}, -1)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(id))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit())
+	}
+
+	migration := NewMigration(zaptest.NewLogger(t), legacy, blobs, func(id string) ([]byte, error) {
+		if id == known {
+			return namespace, nil
+		}
+		return nil, nil
+	}, 0)
+
+	knownRef := storage.BlobRef{Namespace: namespace, Key: []byte(known)}
+	unknownRef := storage.BlobRef{Namespace: namespace, Key: []byte(unknown)}
+
+	load := func(store storage.Blobs, ref storage.BlobRef) (string, error) {
+		reader, err := store.Load(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		defer ctx.Check(reader.Close)
+
+		data, err := ioutil.ReadAll(reader)
+		return string(data), err
+	}
+
+	// the pieces are served from the legacy storage before the migration
+	for _, ref := range []storage.BlobRef{knownRef, unknownRef} {
+		data, err := load(migration, ref)
+		require.NoError(t, err)
+		assert.Equal(t, string(ref.Key), data)
+	}
+
+	require.NoError(t, migration.Run(ctx))
+
+	// the piece with a known namespace is moved
+	data, err := load(blobs, knownRef)
+	require.NoError(t, err)
+	assert.Equal(t, known, data)
+
+	_, err = load(legacy, knownRef)
+	assert.True(t, os.IsNotExist(err))
+
+	// the other one stays in the legacy storage
+	data, err = load(legacy, unknownRef)
+	require.NoError(t, err)
+	assert.Equal(t, unknown, data)
+
+	// both are still served
+	for _, ref := range []storage.BlobRef{knownRef, unknownRef} {
+		data, err := load(migration, ref)
+		require.NoError(t, err)
+		assert.Equal(t, string(ref.Key), data)
+	}
+
+	// and can be trashed and deleted wherever they are
+	for _, ref := range []storage.BlobRef{knownRef, unknownRef} {
+		require.NoError(t, migration.Trash(ctx, ref))
+		require.NoError(t, migration.RestoreTrash(ctx, ref))
+		require.NoError(t, migration.Delete(ctx, ref))
+
+		_, err := load(migration, ref)
+		assert.True(t, os.IsNotExist(err))
+	}
+}
@@ -5,6 +5,8 @@ package psclient
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
 	"flag"
 	"fmt"
 	"io"
@@ -43,10 +45,12 @@ func init() {
 
 // Client is an interface describing the functions for interacting with piecestore nodes
 type Client interface {
-	Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, error)
-	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
+	Meta(ctx context.Context, id PieceID, authorization *pb.SignedMessage) (*pb.PieceSummary, error)
+	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (*pb.PieceHash, error)
 	Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
 	Delete(ctx context.Context, pieceID PieceID, authorization *pb.SignedMessage) error
+	Retain(ctx context.Context, created time.Time, filter []byte) (trashed int64, err error)
+	RestoreTrash(ctx context.Context) (restored int64, err error)
 	io.Closer
 }
 
@@ -112,16 +116,18 @@ func (ps *PieceStore) Close() error {
 	return ps.closeFunc()
 }
 
-// Meta requests info about a piece by Id
-func (ps *PieceStore) Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, error) {
-	return ps.client.Piece(ctx, &pb.PieceId{Id: id.String()})
+// Meta requests info about a piece by Id, including the hashes of its blocks
+func (ps *PieceStore) Meta(ctx context.Context, id PieceID, authorization *pb.SignedMessage) (*pb.PieceSummary, error) {
+	return ps.client.Piece(ctx, &pb.PieceId{Id: id.String(), Authorization: authorization})
 }
 
-// Put uploads a Piece to a piece store Server
-func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error {
+// Put uploads a Piece to a piece store Server. It returns the hash of the
+// piece signed by the storage node, once it's verified to match the uploaded
+// content, or nil if the storage node didn't return any.
+func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (*pb.PieceHash, error) {
 	stream, err := ps.client.Store(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	msg := &pb.PieceStore{
@@ -133,10 +139,13 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 			zap.S().Errorf("error closing stream %s :: %v.Send() = %v", closeErr, stream, closeErr)
 		}
 
-		return fmt.Errorf("%v.Send() = %v", stream, err)
+		return nil, fmt.Errorf("%v.Send() = %v", stream, err)
 	}
 
 	writer := &StreamWriter{signer: ps, stream: stream, pba: ba}
+	// the storage nodes hash the pieces either whole or in blocks
+	hash := sha256.New()
+	blocks := NewBlockHasher(HashBlockSize)
 
 	defer func() {
 		if err := writer.Close(); err != nil && err != io.EOF {
@@ -146,20 +155,56 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 
 	bufw := bufio.NewWriterSize(writer, 32*1024)
 
-	_, err = io.Copy(bufw, data)
+	_, err = io.Copy(bufw, io.TeeReader(data, io.MultiWriter(hash, blocks)))
 	if err == io.ErrUnexpectedEOF {
 		_ = writer.Close()
 		zap.S().Infof("Node cut from upload due to slow connection. Deleting piece %s...", id)
 		deleteErr := ps.Delete(ctx, id, authorization)
 		if deleteErr != nil {
-			return deleteErr
+			return nil, deleteErr
 		}
 	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err = bufw.Flush(); err != nil {
+		return nil, err
 	}
 
-	return bufw.Flush()
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	signed := writer.summary.GetHash()
+	switch signed.GetBlockSize() {
+	case 0:
+		return ps.verifyHash(id, signed, hash.Sum(nil))
+	case HashBlockSize:
+		return ps.verifyHash(id, signed, BlocksHash(blocks.BlockHashes()))
+	default:
+		return nil, ClientError.New("hash of piece %s has unsupported block size %d", id, signed.GetBlockSize())
+	}
+}
+
+// verifyHash checks that the hash of piece id signed by the storage node
+// matches the hash of the uploaded content. The storage nodes which don't
+// sign the hashes of the pieces yet return none.
+func (ps *PieceStore) verifyHash(id PieceID, signed *pb.PieceHash, hash []byte) (*pb.PieceHash, error) {
+	if signed == nil {
+		zap.S().Warnf("Node %s returned no hash of piece %s, it can't be verified", ps.remoteID, id)
+		return nil, nil
+	}
+
+	if err := auth.VerifyMsg(signed, ps.remoteID); err != nil {
+		return nil, ClientError.Wrap(err)
+	}
+
+	if signed.GetId() != id.String() || !bytes.Equal(signed.GetHash(), hash) {
+		return nil, ClientError.New("hash of piece %s differs from the uploaded content", id)
+	}
+
+	return signed, nil
 }
 
 // Get begins downloading a Piece from a piece store Server
@@ -182,6 +227,27 @@ func (ps *PieceStore) Delete(ctx context.Context, id PieceID, authorization *pb.
 	return nil
 }
 
+// Retain asks the storage node to move to the trash the pieces uploaded with
+// the authorization of this client, as a satellite, before created which are
+// not in filter
+func (ps *PieceStore) Retain(ctx context.Context, created time.Time, filter []byte) (trashed int64, err error) {
+	reply, err := ps.client.Retain(ctx, &pb.RetainRequest{CreationUnixSec: created.Unix(), Filter: filter})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetTrashed(), nil
+}
+
+// RestoreTrash asks the storage node to restore the pieces of this client,
+// as a satellite, from the trash
+func (ps *PieceStore) RestoreTrash(ctx context.Context) (restored int64, err error) {
+	reply, err := ps.client.RestoreTrash(ctx, &pb.RestoreTrashRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetRestored(), nil
+}
+
 // sign a message using the clients private key
 func (ps *PieceStore) sign(rba *pb.RenterBandwidthAllocation) (err error) {
 	return auth.SignMessage(rba, *ps.selfID)
@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"io"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// HashBlockSize is the size of the blocks of the pieces hashed separately by
+// the storage nodes, so that any block read can be verified on its own
+const HashBlockSize = 32 << 10
+
+// BlockHasher computes the hashes of the blocks of the content written to it
+type BlockHasher struct {
+	blockSize int64
+	block     hash.Hash
+	written   int64
+	hashes    [][]byte
+}
+
+// NewBlockHasher creates a BlockHasher for blocks of blockSize
+func NewBlockHasher(blockSize int64) *BlockHasher {
+	return &BlockHasher{blockSize: blockSize, block: sha256.New()}
+}
+
+// Write implements io.Writer
+func (h *BlockHasher) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if left := h.blockSize - h.written; int64(len(chunk)) > left {
+			chunk = chunk[:left]
+		}
+		_, _ = h.block.Write(chunk)
+		h.written += int64(len(chunk))
+		n += len(chunk)
+		p = p[len(chunk):]
+
+		if h.written == h.blockSize {
+			h.hashes = append(h.hashes, h.block.Sum(nil))
+			h.block.Reset()
+			h.written = 0
+		}
+	}
+	return n, nil
+}
+
+// BlockHashes returns the hashes of the blocks written so far, the last of
+// them being shorter than the block size if the content doesn't end on a
+// block boundary
+func (h *BlockHasher) BlockHashes() [][]byte {
+	if h.written == 0 {
+		return h.hashes
+	}
+	return append(h.hashes[:len(h.hashes):len(h.hashes)], h.block.Sum(nil))
+}
+
+// BlocksHash returns the hash of a piece hashed in blocks, which is the
+// SHA-256 of the hashes of its blocks
+func BlocksHash(hashes [][]byte) []byte {
+	h := sha256.New()
+	for _, blockHash := range hashes {
+		_, _ = h.Write(blockHash)
+	}
+	return h.Sum(nil)
+}
+
+// VerifyPiece returns whether the content of a piece read from r matches the
+// hash signed by the storage node, which is hashed in blocks of the size of
+// the signed hash, if any
+func VerifyPiece(r io.Reader, signed *pb.PieceHash) (bool, error) {
+	if signed.GetBlockSize() == 0 {
+		h := sha256.New()
+		if _, err := io.Copy(h, r); err != nil {
+			return false, err
+		}
+		return bytes.Equal(h.Sum(nil), signed.GetHash()), nil
+	}
+
+	h := NewBlockHasher(signed.GetBlockSize())
+	if _, err := io.Copy(h, r); err != nil {
+		return false, err
+	}
+	return bytes.Equal(BlocksHash(h.BlockHashes()), signed.GetHash()), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestBlockHasher(t *testing.T) {
+	data := []byte("some piece data")
+
+	for _, writes := range [][]int{{15}, {1, 14}, {4, 4, 7}, {3, 5, 2, 5}} {
+		h := NewBlockHasher(4)
+		rest := data
+		for _, n := range writes {
+			written, err := h.Write(rest[:n])
+			require.NoError(t, err)
+			assert.Equal(t, n, written)
+			rest = rest[n:]
+		}
+
+		var expected [][]byte
+		for _, block := range [][]byte{data[:4], data[4:8], data[8:12], data[12:]} {
+			sum := sha256.Sum256(block)
+			expected = append(expected, sum[:])
+		}
+		assert.Equal(t, expected, h.BlockHashes(), writes)
+		// the hashes can be requested again
+		assert.Equal(t, expected, h.BlockHashes(), writes)
+	}
+
+	// content ending on a block boundary has no trailing block
+	h := NewBlockHasher(5)
+	_, _ = h.Write(data)
+	assert.Len(t, h.BlockHashes(), 3)
+	assert.Empty(t, NewBlockHasher(5).BlockHashes())
+}
+
+func TestVerifyPiece(t *testing.T) {
+	data := []byte("some piece data")
+	whole := sha256.Sum256(data)
+	h := NewBlockHasher(4)
+	_, _ = h.Write(data)
+
+	for i, tt := range []struct {
+		hash  *pb.PieceHash
+		piece []byte
+		valid bool
+	}{
+		{&pb.PieceHash{Hash: whole[:]}, data, true},
+		{&pb.PieceHash{Hash: whole[:]}, []byte("some piece dat4"), false},
+		{&pb.PieceHash{Hash: BlocksHash(h.BlockHashes()), BlockSize: 4}, data, true},
+		{&pb.PieceHash{Hash: BlocksHash(h.BlockHashes()), BlockSize: 4}, []byte("some piece dat4"), false},
+		{&pb.PieceHash{Hash: BlocksHash(h.BlockHashes()), BlockSize: 5}, data, false},
+	} {
+		valid, err := VerifyPiece(bytes.NewReader(tt.piece), tt.hash)
+		require.NoError(t, err, i)
+		assert.Equal(t, tt.valid, valid, i)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 )
 
@@ -14,6 +15,7 @@ import (
 type LiteClient interface {
 	Stats(ctx context.Context) (*pb.StatSummary, error)
 	Dashboard(ctx context.Context) (pb.PieceStoreRoutes_DashboardClient, error)
+	Exit(ctx context.Context, satellites []storj.NodeID) ([]*pb.ExitStatus, error)
 }
 
 // PieceStoreLite is the struct that holds the client
@@ -26,6 +28,16 @@ func (psl *PieceStoreLite) Dashboard(ctx context.Context) (pb.PieceStoreRoutes_D
 	return psl.client.Dashboard(ctx, &pb.DashboardReq{})
 }
 
+// Exit requests the storage node to gracefully exit satellites, or all the
+// satellites when none is given, and returns the progress of the exits
+func (psl *PieceStoreLite) Exit(ctx context.Context, satellites []storj.NodeID) ([]*pb.ExitStatus, error) {
+	summary, err := psl.client.Exit(ctx, &pb.ExitRequest{SatelliteIds: satellites})
+	if err != nil {
+		return nil, err
+	}
+	return summary.GetExits(), nil
+}
+
 // Stats will retrieve stats about a piece storage node
 func (psl *PieceStoreLite) Stats(ctx context.Context) (*pb.StatSummary, error) {
 	return psl.client.Stats(ctx, &pb.StatsReq{})
@@ -36,6 +36,21 @@ func (id PieceID) IsValid() bool {
 	return len(id) >= 20
 }
 
+// Namespace returns the id under which a storage node keeps the piece id
+// uploaded with the authorization of namespace, the id of a satellite
+func (id PieceID) Namespace(namespace []byte) (PieceID, error) {
+	if namespace == nil {
+		return id, nil
+	}
+
+	mac := hmac.New(sha512.New, namespace)
+	_, err := mac.Write([]byte(id))
+	if err != nil {
+		return "", err
+	}
+	return PieceID(base58.Encode(mac.Sum(nil))), nil
+}
+
 // Derive a new PieceID from the current PieceID and the given secret
 func (id PieceID) Derive(secret []byte) (derived PieceID, err error) {
 	mac := hmac.New(sha512.New, secret)
@@ -29,7 +29,7 @@ type pieceRanger struct {
 
 // PieceRanger PieceRanger returns a Ranger from a PieceID.
 func PieceRanger(ctx context.Context, c *PieceStore, stream pb.PieceStoreRoutes_RetrieveClient, id PieceID, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error) {
-	piece, err := c.Meta(ctx, id)
+	piece, err := c.Meta(ctx, id, authorization)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,9 @@ type StreamWriter struct {
 	signer       *PieceStore // We need this for signing
 	totalWritten int64
 	pba          *pb.PayerBandwidthAllocation
+
+	closed  bool
+	summary *pb.PieceStoreSummary // reply of the server, once closed
 }
 
 // Write Piece data to a piece store server upload stream
@@ -48,10 +51,16 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 
 // Close the piece store Write Stream
 func (s *StreamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
 	reply, err := s.stream.CloseAndRecv()
 	if err != nil {
 		return err
 	}
+	s.summary = reply
 
 	zap.S().Infof("Stream close and recv summary: %v", reply)
 
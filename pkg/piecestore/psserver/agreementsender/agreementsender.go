@@ -4,12 +4,16 @@
 package agreementsender
 
 import (
+	"io"
+	"sync"
 	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/internal/sync2"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/pb"
@@ -21,39 +25,59 @@ import (
 var (
 	// ASError wraps errors returned from agreementsender package
 	ASError = errs.Class("agreement sender error")
+	mon     = monkit.Package()
 )
 
+// Config contains how agreements are settled with the satellites
+type Config struct {
+	CheckInterval time.Duration
+	BatchSize     int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
 // AgreementSender maintains variables required for reading bandwidth agreements from a DB and sending them to a Payers
 type AgreementSender struct { // TODO: rename to service
-	DB            *psdb.DB
-	log           *zap.Logger
-	transport     transport.Client
-	kad           *kademlia.Kademlia
-	checkInterval time.Duration
+	DB        *psdb.DB
+	log       *zap.Logger
+	transport transport.Client
+	kad       *kademlia.Kademlia
+	config    Config
 }
 
 // TODO: take transport instead of identity as argument
 
 // New creates an Agreement Sender
-func New(log *zap.Logger, DB *psdb.DB, identity *identity.FullIdentity, kad *kademlia.Kademlia, checkInterval time.Duration) *AgreementSender {
-	return &AgreementSender{DB: DB, log: log, transport: transport.NewClient(identity), kad: kad, checkInterval: checkInterval}
+func New(log *zap.Logger, DB *psdb.DB, identity *identity.FullIdentity, kad *kademlia.Kademlia, config Config) *AgreementSender {
+	return &AgreementSender{DB: DB, log: log, transport: transport.NewClient(identity), kad: kad, config: config}
 }
 
 // Run the agreement sender with a context to check for cancel
 func (as *AgreementSender) Run(ctx context.Context) error {
-	//todo:  we likely don't want to stop on err, but consider returning errors via a channel
-	ticker := time.NewTicker(as.checkInterval)
+	ticker := time.NewTicker(as.config.CheckInterval)
 	defer ticker.Stop()
 	for {
-		as.log.Debug("AgreementSender is running", zap.Duration("duration", as.checkInterval))
+		as.log.Debug("AgreementSender is running", zap.Duration("duration", as.config.CheckInterval))
 		agreementGroups, err := as.DB.GetBandwidthAllocations()
 		if err != nil {
 			as.log.Error("Agreementsender could not retrieve bandwidth allocations", zap.Error(err))
-			continue
 		}
+		// the satellites are settled with independently, so that the retries
+		// of one don't delay the others
+		var group sync.WaitGroup
 		for satellite, agreements := range agreementGroups {
-			as.sendAgreementsToSatellite(ctx, satellite, agreements)
+			satellite, agreements := satellite, agreements
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				if err := as.SettleWithSatellite(ctx, satellite, agreements); err != nil {
+					as.log.Warn("Agreementsender could not settle agreements with satellite : will retry",
+						zap.String("satellite id", satellite.String()), zap.Error(err))
+				}
+			}()
 		}
+		group.Wait()
+
 		select {
 		case <-ticker.C:
 		case <-ctx.Done():
@@ -62,22 +86,25 @@ func (as *AgreementSender) Run(ctx context.Context) error {
 	}
 }
 
-func (as *AgreementSender) sendAgreementsToSatellite(ctx context.Context, satID storj.NodeID, agreements []*psdb.Agreement) {
+// SettleWithSatellite settles agreements with satellite, retrying the ones
+// which couldn't be settled with exponentially increasing delays. The
+// agreements left once the delay exceeds the maximum are sent again on the
+// next check.
+func (as *AgreementSender) SettleWithSatellite(ctx context.Context, satID storj.NodeID, agreements []*psdb.Agreement) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	as.log.Info("Sending agreements to satellite", zap.Int("number of agreements", len(agreements)), zap.String("satellite id", satID.String()))
 	// todo: cache kad responses if this interval is very small
 	// Get satellite ip from kademlia
 	satellite, err := as.kad.FindNode(ctx, satID)
 	if err != nil {
-		as.log.Warn("Agreementsender could not find satellite", zap.Error(err))
-		return
+		return ASError.Wrap(err)
 	}
 	// Create client from satellite ip
 	conn, err := as.transport.DialNode(ctx, &satellite)
 	if err != nil {
-		as.log.Warn("Agreementsender could not dial satellite", zap.Error(err))
-		return
+		return ASError.Wrap(err)
 	}
-	client := pb.NewBandwidthClient(conn)
 	defer func() {
 		err := conn.Close()
 		if err != nil {
@@ -85,25 +112,101 @@ func (as *AgreementSender) sendAgreementsToSatellite(ctx context.Context, satID
 		}
 	}()
 
-	//todo:  stop sending these one-by-one, send all at once
-	for _, agreement := range agreements {
-		rba := agreement.Agreement
+	return as.settleWithRetries(ctx, pb.NewBandwidthClient(conn), agreements)
+}
+
+// settleWithRetries settles agreements with client until none is left to
+// retry or the delay between the retries exceeds the maximum
+func (as *AgreementSender) settleWithRetries(ctx context.Context, client pb.BandwidthClient, agreements []*psdb.Agreement) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	delay := as.config.RetryDelay
+	for {
+		agreements, err = as.settle(ctx, client, agreements)
 		if err != nil {
-			as.log.Warn("Agreementsender failed to deserialize agreement : will delete", zap.Error(err))
-		} else {
-			// Send agreement to satellite
-			r, err := client.BandwidthAgreements(ctx, &rba)
-			if err != nil || r.GetStatus() == pb.AgreementsSummary_FAIL {
-				as.log.Warn("Agreementsender failed to send agreement to satellite : will retry", zap.Error(err))
-				continue
-			} else if r.GetStatus() == pb.AgreementsSummary_REJECTED {
-				//todo: something better than a delete here?
-				as.log.Error("Agreementsender had agreement explicitly rejected by satellite : will delete", zap.Error(err))
-			}
+			as.log.Warn("Agreementsender failed to send agreements to satellite", zap.Error(err))
+		}
+		if len(agreements) == 0 {
+			return nil
+		}
+
+		if delay <= 0 || delay > as.config.MaxRetryDelay {
+			return ASError.New("%d agreements left unsettled", len(agreements))
+		}
+		as.log.Debug("Agreementsender retrying agreements", zap.Int("number of agreements", len(agreements)), zap.Duration("delay", delay))
+		if !sync2.Sleep(ctx, delay) {
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// settle sends agreements to the satellite in batches over a single stream.
+// The accepted agreements are deleted, the rejected ones are kept for review,
+// and the ones which should be retried are returned.
+func (as *AgreementSender) settle(ctx context.Context, client pb.BandwidthClient, agreements []*psdb.Agreement) (retry []*psdb.Agreement, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stream, err := client.Settlement(ctx)
+	if err != nil {
+		return agreements, err
+	}
+
+	batchSize := as.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(agreements)
+	}
+
+	for len(agreements) > 0 {
+		batch := agreements
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+
+		req := &pb.SettlementRequest{}
+		for _, agreement := range batch {
+			rba := agreement.Agreement
+			req.Allocations = append(req.Allocations, &rba)
+		}
+
+		if err := stream.Send(req); err != nil {
+			return append(retry, agreements...), err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return append(retry, agreements...), err
 		}
-		// Delete from PSDB by signature
-		if err = as.DB.DeleteBandwidthAllocationBySignature(agreement.Signature); err != nil {
-			as.log.Error("Agreementsender failed to delete bandwidth allocation", zap.Error(err))
+		results := resp.GetResults()
+		if len(results) != len(batch) {
+			return append(retry, agreements...), ASError.New("got %d results for %d agreements", len(results), len(batch))
 		}
+
+		for i, result := range results {
+			agreement := batch[i]
+			switch result.GetStatus() {
+			case pb.SettlementResult_ACCEPTED:
+				if err := as.DB.DeleteBandwidthAllocationBySignature(agreement.Signature); err != nil {
+					as.log.Error("Agreementsender failed to delete bandwidth allocation", zap.Error(err))
+				}
+			case pb.SettlementResult_REJECTED:
+				as.log.Error("Agreementsender had agreement explicitly rejected by satellite : will keep for review",
+					zap.String("reason", result.GetReason()))
+				if err := as.DB.RejectBandwidthAllocation(agreement.Signature, result.GetReason()); err != nil {
+					as.log.Error("Agreementsender failed to keep rejected bandwidth allocation", zap.Error(err))
+				}
+			default:
+				retry = append(retry, agreement)
+			}
+		}
+		agreements = agreements[len(batch):]
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return retry, err
+	}
+	// the satellite ends the stream once it received all the batches
+	if _, err := stream.Recv(); err != io.EOF {
+		return retry, err
 	}
+	return retry, nil
 }
@@ -0,0 +1,151 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package agreementsender
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+func TestSettleWithRetries(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db, err := psdb.OpenInMemory()
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	satellite, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	for _, signature := range []string{"accepted", "rejected", "retried", "accepted later"} {
+		require.NoError(t, db.WriteBandwidthAllocToDB(&pb.RenterBandwidthAllocation{
+			PayerAllocation: pb.PayerBandwidthAllocation{SatelliteId: satellite.ID},
+			Signature:       []byte(signature),
+		}))
+	}
+
+	sender := &AgreementSender{
+		DB:  db,
+		log: zap.NewNop(),
+		config: Config{
+			BatchSize:     2,
+			RetryDelay:    time.Millisecond,
+			MaxRetryDelay: 4 * time.Millisecond,
+		},
+	}
+
+	client := &settlementClient{statuses: map[string][]pb.SettlementResult_Status{
+		"accepted":       {pb.SettlementResult_ACCEPTED},
+		"rejected":       {pb.SettlementResult_REJECTED},
+		"retried":        {pb.SettlementResult_RETRY},
+		"accepted later": {pb.SettlementResult_RETRY, pb.SettlementResult_ACCEPTED},
+	}}
+
+	agreements, err := db.GetBandwidthAllocations()
+	require.NoError(t, err)
+	err = sender.settleWithRetries(ctx, client, agreements[satellite.ID])
+	assert.Error(t, err)
+
+	// the agreements are sent in batches, the ones to retry over new streams
+	// until the delay exceeds the maximum
+	require.Len(t, client.streams, 4)
+	assert.Equal(t, []int{2, 2}, client.streams[0].batches)
+	assert.Equal(t, []int{2}, client.streams[1].batches)
+	assert.Equal(t, []int{1}, client.streams[2].batches)
+	assert.Equal(t, []int{1}, client.streams[3].batches)
+	for _, stream := range client.streams {
+		assert.True(t, stream.closed)
+	}
+
+	// the accepted agreements are deleted, the one never settled is left to
+	// be sent on the next check
+	agreements, err = db.GetBandwidthAllocations()
+	require.NoError(t, err)
+	require.Len(t, agreements[satellite.ID], 1)
+	assert.Equal(t, []byte("retried"), agreements[satellite.ID][0].Signature)
+
+	// the rejected agreement is kept for review
+	rejected, err := db.GetRejectedBandwidthAllocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, []byte("rejected"), rejected[0].Signature)
+	assert.Equal(t, "rejected by test", rejected[0].Reason)
+}
+
+// settlementClient settles agreements with the statuses of their signatures,
+// one per attempt, the last one being repeated
+type settlementClient struct {
+	pb.BandwidthClient
+	statuses map[string][]pb.SettlementResult_Status
+	streams  []*settlementStream
+}
+
+func (client *settlementClient) Settlement(ctx context.Context, opts ...grpc.CallOption) (pb.Bandwidth_SettlementClient, error) {
+	stream := &settlementStream{client: client}
+	client.streams = append(client.streams, stream)
+	return stream, nil
+}
+
+func (client *settlementClient) status(signature string) pb.SettlementResult_Status {
+	statuses := client.statuses[signature]
+	status := statuses[0]
+	if len(statuses) > 1 {
+		client.statuses[signature] = statuses[1:]
+	}
+	return status
+}
+
+// settlementStream records the sizes of the batches sent over it
+type settlementStream struct {
+	grpc.ClientStream
+	client    *settlementClient
+	batches   []int
+	responses []*pb.SettlementResponse
+	closed    bool
+}
+
+func (stream *settlementStream) Send(req *pb.SettlementRequest) error {
+	stream.batches = append(stream.batches, len(req.GetAllocations()))
+
+	resp := &pb.SettlementResponse{}
+	for _, rba := range req.GetAllocations() {
+		result := &pb.SettlementResult{Status: stream.client.status(string(rba.GetSignature()))}
+		if result.Status == pb.SettlementResult_REJECTED {
+			result.Reason = "rejected by test"
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	stream.responses = append(stream.responses, resp)
+	return nil
+}
+
+func (stream *settlementStream) Recv() (*pb.SettlementResponse, error) {
+	if len(stream.responses) == 0 {
+		if stream.closed {
+			return nil, io.EOF
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	resp := stream.responses[0]
+	stream.responses = stream.responses[1:]
+	return resp, nil
+}
+
+func (stream *settlementStream) CloseSend() error {
+	stream.closed = true
+	return nil
+}
@@ -10,29 +10,32 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
-	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/storage"
 )
 
 // ErrorCollector is error class for piece collector
 var ErrorCollector = errs.Class("piecestore collector")
 
-// Collector collects expired pieces from database and disk.
+// Collector collects expired pieces, and the pieces kept in the trash longer
+// than trashExpiration, from database and disk.
 type Collector struct {
 	log     *zap.Logger
 	db      *psdb.DB
-	storage *pstore.Storage
+	storage storage.Blobs
 
-	interval time.Duration
+	interval        time.Duration
+	trashExpiration time.Duration
 }
 
 // NewCollector returns a new piece collector
-func NewCollector(log *zap.Logger, db *psdb.DB, storage *pstore.Storage, interval time.Duration) *Collector {
+func NewCollector(log *zap.Logger, db *psdb.DB, storage storage.Blobs, interval, trashExpiration time.Duration) *Collector {
 	return &Collector{
-		log:      log,
-		db:       db,
-		storage:  storage,
-		interval: interval,
+		log:             log,
+		db:              db,
+		storage:         storage,
+		interval:        interval,
+		trashExpiration: trashExpiration,
 	}
 }
 
@@ -57,6 +60,10 @@ func (service *Collector) Run(ctx context.Context) error {
 
 // Collect collects expired pieces att this moment.
 func (service *Collector) Collect(ctx context.Context) error {
+	if err := service.EmptyTrash(ctx); err != nil {
+		return err
+	}
+
 	for {
 		expired, err := service.db.DeleteExpired(ctx)
 		if err != nil {
@@ -67,8 +74,8 @@ func (service *Collector) Collect(ctx context.Context) error {
 		}
 
 		var errlist errs.Group
-		for _, id := range expired {
-			errlist.Add(service.storage.Delete(id))
+		for _, piece := range expired {
+			errlist.Add(service.storage.Delete(ctx, pieceRef(piece.Satellite, piece.ID)))
 		}
 
 		if err := errlist.Err(); err != nil {
@@ -76,3 +83,18 @@ func (service *Collector) Collect(ctx context.Context) error {
 		}
 	}
 }
+
+// EmptyTrash permanently deletes the pieces kept in the trash longer than
+// the trash expiration
+func (service *Collector) EmptyTrash(ctx context.Context) error {
+	trashed, err := service.db.DeleteTrash(ctx, time.Now().Add(-service.trashExpiration))
+	if err != nil {
+		return ErrorCollector.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, piece := range trashed {
+		errlist.Add(service.storage.DeleteTrash(ctx, pieceRef(piece.Satellite, piece.ID)))
+	}
+	return ErrorCollector.Wrap(errlist.Err())
+}
@@ -22,8 +22,15 @@ type Config struct {
 	SatelliteIDRestriction  bool          `help:"if true, only allow data from approved satellites" default:"false"`
 	AllocatedDiskSpace      memory.Size   `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
 	AllocatedBandwidth      memory.Size   `user:"true" help:"total allocated bandwidth in bytes" default:"500GiB"`
+	SatelliteAllocations    string        `user:"true" help:"a comma-separated list of satellite allocations as <satellite id>:<disk space>:<bandwidth>, within the total allocations" default:""`
 	KBucketRefreshInterval  time.Duration `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
 
 	AgreementSenderCheckInterval time.Duration `help:"duration between agreement checks" default:"1h0m0s"`
+	AgreementSenderBatchSize     int           `help:"how many agreements are sent to a satellite at once" default:"1000"`
+	AgreementSenderRetryDelay    time.Duration `help:"delay before sending the agreements a satellite couldn't settle again, doubled on every retry" default:"1s"`
+	AgreementSenderMaxRetryDelay time.Duration `help:"maximum delay between retries, after which the agreements are sent on the next check" default:"5m0s"`
 	CollectorInterval            time.Duration `help:"interval to check for expired pieces" default:"1h0m0s"`
+	TrashExpiration              time.Duration `help:"how long the pieces collected as garbage are kept in the trash" default:"168h0m0s"`
+	ExitInterval                 time.Duration `help:"interval to continue the requested graceful exits" default:"1m0s"`
+	MigrationInterval            time.Duration `help:"interval to move the pieces left in the legacy storage, whose satellites are recorded as they're accessed" default:"24h0m0s"`
 }
@@ -0,0 +1,79 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+// Exit starts the graceful exit from the requested satellites, or from all
+// the satellites pieces are stored for. The exits are carried out by the
+// exiter. Only the operator of the node can request it.
+func (s *Server) Exit(ctx context.Context, in *pb.ExitRequest) (_ *pb.ExitSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	if peer.ID != s.identity.ID {
+		return nil, ServerError.New("only the operator of the node can request an exit")
+	}
+
+	satellites := in.SatelliteIds
+	if len(satellites) == 0 {
+		satellites, err = s.DB.GetSatellites(ctx)
+		if err != nil {
+			return nil, ServerError.Wrap(err)
+		}
+	}
+
+	for _, satellite := range satellites {
+		if err := s.DB.AddExit(satellite); err != nil {
+			return nil, ServerError.Wrap(err)
+		}
+		s.log.Info("Requested to exit satellite", zap.String("Satellite ID", satellite.String()))
+	}
+
+	exits, err := s.exitStatuses(ctx)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.ExitSummary{Exits: exits}, nil
+}
+
+// exitStatuses returns the progress of the graceful exits
+func (s *Server) exitStatuses(ctx context.Context) ([]*pb.ExitStatus, error) {
+	exits, err := s.DB.GetExits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*pb.ExitStatus, 0, len(exits))
+	for _, exit := range exits {
+		remaining, err := s.DB.CountSatellitePieces(exit.Satellite)
+		if err != nil {
+			return nil, err
+		}
+
+		status := &pb.ExitStatus{
+			SatelliteId:       exit.Satellite,
+			StartedUnixSec:    exit.Started.Unix(),
+			PiecesTransferred: exit.PiecesTransferred,
+			PiecesFailed:      exit.PiecesFailed,
+			BytesTransferred:  exit.BytesTransferred,
+			PiecesRemaining:   remaining,
+		}
+		if !exit.Finished.IsZero() {
+			status.FinishedUnixSec = exit.Finished.Unix()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
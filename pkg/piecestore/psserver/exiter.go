@@ -0,0 +1,236 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+)
+
+// ErrorExiter is error class for the graceful exit service
+var ErrorExiter = errs.Class("piecestore exiter")
+
+// transfersPerRequest is how many transfers are requested from a satellite at once
+const transfersPerRequest = 100
+
+// Exiter gracefully exits the satellites the operator asked to exit, by
+// transferring the pieces of each satellite to the nodes it chooses.
+type Exiter struct {
+	log       *zap.Logger
+	db        *psdb.DB
+	storage   storage.Blobs
+	transport transport.Client
+	kad       *kademlia.Kademlia
+
+	interval time.Duration
+}
+
+// NewExiter returns a new graceful exit service
+func NewExiter(log *zap.Logger, db *psdb.DB, storage storage.Blobs, identity *identity.FullIdentity, kad *kademlia.Kademlia, interval time.Duration) *Exiter {
+	return &Exiter{
+		log:       log,
+		db:        db,
+		storage:   storage,
+		transport: transport.NewClient(identity),
+		kad:       kad,
+		interval:  interval,
+	}
+}
+
+// Run runs the exiter at regular intervals
+func (service *Exiter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(service.interval)
+	defer ticker.Stop()
+
+	for {
+		err := service.Exit(ctx)
+		if err != nil {
+			service.log.Error("exit", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the exiter is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Exit continues the exits which aren't complete yet
+func (service *Exiter) Exit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exits, err := service.db.GetExits(ctx)
+	if err != nil {
+		return ErrorExiter.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, exit := range exits {
+		if !exit.Finished.IsZero() {
+			continue
+		}
+		errlist.Add(service.exitSatellite(ctx, exit.Satellite))
+	}
+	return ErrorExiter.Wrap(errlist.Err())
+}
+
+// exitSatellite transfers the pieces of satellite and completes the exit
+func (service *Exiter) exitSatellite(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satellite, err := service.kad.FindNode(ctx, satelliteID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := service.transport.DialNode(ctx, &satellite)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	client := pb.NewGracefulExitClient(conn)
+
+	progress, err := client.Initiate(ctx, &pb.InitiateRequest{})
+	if err != nil {
+		return err
+	}
+
+	if progress.GetFinishedUnixSec() == 0 {
+		service.log.Info("Exiting satellite", zap.String("Satellite ID", satelliteID.String()))
+
+		for {
+			resp, err := client.GetTransfers(ctx, &pb.GetTransfersRequest{Limit: transfersPerRequest})
+			if err != nil {
+				return err
+			}
+
+			for _, transfer := range resp.GetTransfers() {
+				if err := service.transfer(ctx, client, satelliteID, transfer); err != nil {
+					service.log.Warn("failed to transfer piece", zap.String("path", transfer.GetPath()),
+						zap.Int32("piece", transfer.GetPieceNum()), zap.Error(err))
+
+					_, err = client.TransferFailed(ctx, &pb.TransferFailedRequest{
+						Path:     transfer.GetPath(),
+						PieceNum: transfer.GetPieceNum(),
+						Error:    err.Error(),
+					})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			if !resp.GetMore() {
+				break
+			}
+		}
+
+		progress, err = client.Complete(ctx, &pb.CompleteRequest{})
+		if err != nil {
+			return err
+		}
+
+		service.log.Info("Exited satellite", zap.String("Satellite ID", satelliteID.String()),
+			zap.Int64("transferred", progress.GetPiecesTransferred()), zap.Int64("failed", progress.GetPiecesFailed()))
+	}
+
+	return service.db.UpdateExit(&psdb.Exit{
+		Satellite:         satelliteID,
+		Finished:          time.Unix(progress.GetFinishedUnixSec(), 0),
+		PiecesTransferred: progress.GetPiecesTransferred(),
+		PiecesFailed:      progress.GetPiecesFailed(),
+		BytesTransferred:  progress.GetBytesTransferred(),
+	})
+}
+
+// transfer uploads a piece of satellite to the receiver of transfer, reports
+// it to the satellite and deletes the local piece
+func (service *Exiter) transfer(ctx context.Context, client pb.GracefulExitClient, satelliteID storj.NodeID, transfer *pb.Transfer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	derivedID, err := psclient.PieceID(transfer.GetPieceId()).Derive(service.transport.Identity().ID.Bytes())
+	if err != nil {
+		return err
+	}
+	id, err := getNamespacedPieceID([]byte(derivedID), satelliteID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	hash, size, err := service.upload(ctx, satelliteID, id, transfer)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Transferred(ctx, &pb.TransferredRequest{
+		Path:         transfer.GetPath(),
+		PieceNum:     transfer.GetPieceNum(),
+		ReceiverId:   transfer.GetReceiver().Id,
+		Hash:         hash,
+		Size:         size,
+		SerialNumber: transfer.GetPayerAllocation().GetSerialNumber(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the piece belongs to the receiver from now on
+	if err := service.storage.Delete(ctx, pieceRef(satelliteID, id)); err != nil {
+		service.log.Warn("failed to delete transferred piece", zap.String("Piece ID", id), zap.Error(err))
+		return nil
+	}
+	if err := service.db.DeleteTTLByID(id); err != nil {
+		service.log.Warn("failed to delete transferred piece", zap.String("Piece ID", id), zap.Error(err))
+	}
+	return nil
+}
+
+// upload uploads the stored piece id to the receiver of transfer, returning
+// the hash signed by the receiver and the size of the piece
+func (service *Exiter) upload(ctx context.Context, satelliteID storj.NodeID, id string, transfer *pb.Transfer) (_ *pb.PieceHash, size int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	expiration, err := service.db.GetTTLByID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reader, err := service.storage.Load(ctx, pieceRef(satelliteID, id))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	receiver := transfer.GetReceiver()
+	receiverID, err := psclient.PieceID(transfer.GetPieceId()).Derive(receiver.Id.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ps, err := psclient.NewPSClient(ctx, service.transport, receiver, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = errs.Combine(err, ps.Close()) }()
+
+	hash, err := ps.Put(ctx, receiverID, reader, time.Unix(expiration, 0), transfer.GetPayerAllocation(), transfer.GetAuthorization())
+	if err != nil {
+		return nil, 0, err
+	}
+	return hash, reader.Size(), nil
+}
@@ -4,7 +4,9 @@
 package psdb
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -42,6 +44,40 @@ type Agreement struct {
 	Signature []byte
 }
 
+// RejectedAgreement is a bandwidth agreement the satellite rejected, which is
+// kept for the operator to review
+type RejectedAgreement struct {
+	Agreement
+	Reason   string
+	Rejected time.Time
+}
+
+// Piece is a stored piece and the satellite it's stored for, which is zero
+// when unknown
+type Piece struct {
+	ID        string
+	Satellite storj.NodeID
+}
+
+// Exit is the progress of the graceful exit from a satellite, as reported by
+// the satellite
+type Exit struct {
+	Satellite         storj.NodeID
+	Started           time.Time
+	Finished          time.Time // zero until the exit is complete
+	PiecesTransferred int64
+	PiecesFailed      int64
+	BytesTransferred  int64
+}
+
+// SatelliteUsage is the disk space used by the pieces of a satellite, and the
+// bandwidth used for it
+type SatelliteUsage struct {
+	Satellite storj.NodeID
+	Space     int64
+	Bandwidth int64
+}
+
 // Open opens DB at DBPath
 func Open(DBPath string) (db *DB, err error) {
 	if err = os.MkdirAll(filepath.Dir(DBPath), 0700); err != nil {
@@ -97,6 +133,11 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `rejected_agreements` (`satellite` BLOB, `agreement` BLOB, `signature` BLOB, `reason` TEXT, `rejected` INT(10));")
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_ttl_expires ON ttl (expires);")
 	if err != nil {
 		return err
@@ -107,6 +148,36 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `satellite_bwusage` (`satellite` BLOB, `size` INT(10), `daystartdate` INT(10), UNIQUE(`satellite`, `daystartdate`));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `satellite_pieces` (`satellite` BLOB, `id` BLOB UNIQUE);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_satellite_pieces_satellite ON satellite_pieces (satellite);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `trash` (`id` BLOB UNIQUE, `satellite` BLOB, `created` INT(10), `expires` INT(10), `size` INT(10), `trashed` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `exits` (`satellite` BLOB UNIQUE, `started` INT(10), `finished` INT(10), `transferred` INT(10), `failed` INT(10), `bytes` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `piece_hashes` (`id` BLOB UNIQUE, `block_size` INT(10), `hashes` BLOB);")
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -129,7 +200,7 @@ func (db *DB) locked() func() {
 }
 
 // DeleteExpired deletes expired pieces
-func (db *DB) DeleteExpired(ctx context.Context) (expired []string, err error) {
+func (db *DB) DeleteExpired(ctx context.Context) (expired []Piece, err error) {
 	defer mon.Task()(&ctx)(&err)
 	defer db.locked()()
 
@@ -143,22 +214,34 @@ func (db *DB) DeleteExpired(ctx context.Context) (expired []string, err error) {
 
 	now := time.Now().Unix()
 
-	rows, err := tx.Query("SELECT id FROM ttl WHERE 0 < expires AND ? < expires", now)
+	rows, err := tx.Query(`SELECT ttl.id, satellite_pieces.satellite FROM ttl
+		LEFT JOIN satellite_pieces ON ttl.id = satellite_pieces.id
+		WHERE 0 < ttl.expires AND ? < ttl.expires`, now)
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
+		piece, err := scanPiece(rows)
+		if err != nil {
+			return nil, errs.Combine(err, rows.Close())
 		}
-		expired = append(expired, id)
+		expired = append(expired, piece)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
 	}
 
+	_, err = tx.Exec(`DELETE FROM satellite_pieces WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND ? < expires)`, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM piece_hashes WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND ? < expires)`, now)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = tx.Exec(`DELETE FROM ttl WHERE 0 < expires AND ? < expires`, now)
 	if err != nil {
 		return nil, err
@@ -193,6 +276,60 @@ func (db *DB) DeleteBandwidthAllocationBySignature(signature []byte) error {
 	return err
 }
 
+// RejectBandwidthAllocation moves the allocation with signature to the
+// rejected allocations, together with the reason of the rejection
+func (db *DB) RejectBandwidthAllocation(signature []byte, reason string) error {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`INSERT INTO rejected_agreements (satellite, agreement, signature, reason, rejected)
+		SELECT satellite, agreement, signature, ?, ? FROM bandwidth_agreements WHERE signature = ?`,
+		reason, time.Now().Unix(), signature)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM bandwidth_agreements WHERE signature = ?`, signature)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetRejectedBandwidthAllocations returns the rejected allocations, in the
+// order they were rejected
+func (db *DB) GetRejectedBandwidthAllocations(ctx context.Context) (rejected []*RejectedAgreement, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT agreement, signature, reason, rejected FROM rejected_agreements ORDER BY rejected`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var rbaBytes []byte
+		var rejectedUnix int64
+		agreement := &RejectedAgreement{}
+		if err := rows.Scan(&rbaBytes, &agreement.Signature, &agreement.Reason, &rejectedUnix); err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(rbaBytes, &agreement.Agreement.Agreement); err != nil {
+			return nil, err
+		}
+		agreement.Rejected = time.Unix(rejectedUnix, 0)
+		rejected = append(rejected, agreement)
+	}
+	return rejected, rows.Err()
+}
+
 // GetBandwidthAllocationBySignature finds allocation info by signature
 func (db *DB) GetBandwidthAllocationBySignature(signature []byte) ([]*pb.RenterBandwidthAllocation, error) {
 	defer db.locked()()
@@ -299,7 +436,8 @@ func (db *DB) SumTTLSizes() (sum int64, err error) {
 	return sum, err
 }
 
-// DeleteTTLByID finds the TTL in the database by id and delete it
+// DeleteTTLByID finds the TTL in the database by id and delete it, together
+// with the satellite and the block hashes of the piece
 func (db *DB) DeleteTTLByID(id string) error {
 	defer db.locked()()
 
@@ -307,11 +445,364 @@ func (db *DB) DeleteTTLByID(id string) error {
 	if err == sql.ErrNoRows {
 		err = nil
 	}
+	if err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec(`DELETE FROM satellite_pieces WHERE id=?`, id)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec(`DELETE FROM piece_hashes WHERE id=?`, id)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return err
+}
+
+// AddPieceHashes records the hashes of the blocks of blockSize of piece id
+func (db *DB) AddPieceHashes(id string, blockSize int64, hashes [][]byte) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO piece_hashes (id, block_size, hashes) VALUES (?, ?, ?)", id, blockSize, bytes.Join(hashes, nil))
+	return err
+}
+
+// GetPieceHashes returns the hashes of the blocks of piece id and their size,
+// which is zero when the piece wasn't hashed in blocks
+func (db *DB) GetPieceHashes(id string) (blockSize int64, hashes [][]byte, err error) {
+	defer db.locked()()
+
+	var joined []byte
+	err = db.DB.QueryRow(`SELECT block_size, hashes FROM piece_hashes WHERE id=?`, id).Scan(&blockSize, &joined)
+	if err == sql.ErrNoRows {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(joined)%sha256.Size != 0 {
+		return 0, nil, Error.New("invalid hashes of piece %s", id)
+	}
+	for len(joined) > 0 {
+		hashes = append(hashes, joined[:sha256.Size])
+		joined = joined[sha256.Size:]
+	}
+	return blockSize, hashes, nil
+}
+
+// AddSatellitePiece records that piece id was uploaded with the authorization
+// of satellite
+func (db *DB) AddSatellitePiece(satellite storj.NodeID, id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO satellite_pieces (satellite, id) VALUES (?, ?)", satellite.Bytes(), id)
 	return err
 }
 
-// AddBandwidthUsed adds bandwidth usage into database by date
-func (db *DB) AddBandwidthUsed(size int64) (err error) {
+// AttributePiece records that piece id is stored for satellite, unless it's
+// not stored or its satellite is already known. The pieces stored before
+// the storage node recorded their satellites are attributed as they're
+// accessed with the authorization of their satellite.
+func (db *DB) AttributePiece(satellite storj.NodeID, id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`INSERT OR IGNORE INTO satellite_pieces (satellite, id)
+		SELECT ?, id FROM ttl WHERE id = ?`, satellite.Bytes(), id)
+	return err
+}
+
+// GetPieceSatellite returns the satellite piece id was stored for, which is
+// zero when unknown
+func (db *DB) GetPieceSatellite(id string) (satellite storj.NodeID, err error) {
+	defer db.locked()()
+
+	var satelliteBytes []byte
+	err = db.DB.QueryRow(`SELECT satellite FROM satellite_pieces WHERE id=?`, id).Scan(&satelliteBytes)
+	if err == sql.ErrNoRows {
+		return storj.NodeID{}, nil
+	}
+	if err != nil {
+		return storj.NodeID{}, err
+	}
+	return storj.NodeIDFromBytes(satelliteBytes)
+}
+
+// GetSatellitePieces returns the ids of the pieces of satellite which were
+// stored before createdBefore
+func (db *DB) GetSatellitePieces(ctx context.Context, satellite storj.NodeID, createdBefore time.Time) (ids []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT ttl.id FROM ttl JOIN satellite_pieces ON ttl.id = satellite_pieces.id
+		WHERE satellite_pieces.satellite = ? AND ttl.created < ?`, satellite.Bytes(), createdBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetUnattributedPieces returns the ids of the pieces stored before
+// createdBefore which aren't attributed to any satellite, as they were stored
+// before the storage node recorded the satellites of the pieces
+func (db *DB) GetUnattributedPieces(ctx context.Context, createdBefore time.Time) (ids []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT ttl.id FROM ttl LEFT JOIN satellite_pieces ON ttl.id = satellite_pieces.id
+		WHERE satellite_pieces.id IS NULL AND ttl.created < ?`, createdBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// TrashPiece moves the records of piece id to the trash
+func (db *DB) TrashPiece(id string) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO trash (id, satellite, created, expires, size, trashed)
+		SELECT ttl.id, satellite_pieces.satellite, ttl.created, ttl.expires, ttl.size, ?
+		FROM ttl LEFT JOIN satellite_pieces ON ttl.id = satellite_pieces.id WHERE ttl.id = ?`, time.Now().Unix(), id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM ttl WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM satellite_pieces WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RestoreTrash moves the records of the trashed pieces of satellite back and
+// returns them. The trashed pieces which aren't attributed to any satellite
+// may be pieces of satellite, so they're restored as well.
+func (db *DB) RestoreTrash(ctx context.Context, satellite storj.NodeID) (pieces []Piece, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`SELECT id, satellite FROM trash WHERE satellite = ? OR satellite IS NULL`, satellite.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		piece, err := scanPiece(rows)
+		if err != nil {
+			return nil, errs.Combine(err, rows.Close())
+		}
+		pieces = append(pieces, piece)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO ttl (id, created, expires, size)
+		SELECT id, created, expires, size FROM trash WHERE satellite = ? OR satellite IS NULL`, satellite.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO satellite_pieces (satellite, id)
+		SELECT satellite, id FROM trash WHERE satellite = ?`, satellite.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM trash WHERE satellite = ? OR satellite IS NULL`, satellite.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return pieces, tx.Commit()
+}
+
+// DeleteTrash deletes the records of the pieces trashed before trashedBefore
+// and returns them
+func (db *DB) DeleteTrash(ctx context.Context, trashedBefore time.Time) (pieces []Piece, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`SELECT id, satellite FROM trash WHERE trashed < ?`, trashedBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		piece, err := scanPiece(rows)
+		if err != nil {
+			return nil, errs.Combine(err, rows.Close())
+		}
+		pieces = append(pieces, piece)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM piece_hashes WHERE id IN (SELECT id FROM trash WHERE trashed < ?)`, trashedBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM trash WHERE trashed < ?`, trashedBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	return pieces, tx.Commit()
+}
+
+// GetSatellites returns the satellites which pieces are stored for
+func (db *DB) GetSatellites(ctx context.Context) (satellites []storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT DISTINCT satellite FROM satellite_pieces`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var satelliteBytes []byte
+		if err := rows.Scan(&satelliteBytes); err != nil {
+			return nil, err
+		}
+		satellite, err := storj.NodeIDFromBytes(satelliteBytes)
+		if err != nil {
+			return nil, err
+		}
+		satellites = append(satellites, satellite)
+	}
+	return satellites, rows.Err()
+}
+
+// CountSatellitePieces returns the number of pieces stored for satellite
+func (db *DB) CountSatellitePieces(satellite storj.NodeID) (count int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT COUNT(*) FROM satellite_pieces WHERE satellite = ?`, satellite.Bytes()).Scan(&count)
+	return count, err
+}
+
+// AddExit starts the graceful exit from satellite, unless it was already
+// started
+func (db *DB) AddExit(satellite storj.NodeID) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`INSERT OR IGNORE INTO exits (satellite, started, finished, transferred, failed, bytes)
+		VALUES (?, ?, 0, 0, 0, 0)`, satellite.Bytes(), time.Now().Unix())
+	return err
+}
+
+// UpdateExit updates the progress of the graceful exit from a satellite
+func (db *DB) UpdateExit(exit *Exit) error {
+	defer db.locked()()
+
+	var finished int64
+	if !exit.Finished.IsZero() {
+		finished = exit.Finished.Unix()
+	}
+
+	_, err := db.DB.Exec(`UPDATE exits SET finished = ?, transferred = ?, failed = ?, bytes = ? WHERE satellite = ?`,
+		finished, exit.PiecesTransferred, exit.PiecesFailed, exit.BytesTransferred, exit.Satellite.Bytes())
+	return err
+}
+
+// GetExits returns the graceful exits from satellites
+func (db *DB) GetExits(ctx context.Context) (exits []*Exit, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT satellite, started, finished, transferred, failed, bytes FROM exits ORDER BY started`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var satelliteBytes []byte
+		var started, finished int64
+		exit := &Exit{}
+		if err := rows.Scan(&satelliteBytes, &started, &finished, &exit.PiecesTransferred, &exit.PiecesFailed, &exit.BytesTransferred); err != nil {
+			return nil, err
+		}
+		exit.Satellite, err = storj.NodeIDFromBytes(satelliteBytes)
+		if err != nil {
+			return nil, err
+		}
+		exit.Started = time.Unix(started, 0)
+		if finished != 0 {
+			exit.Finished = time.Unix(finished, 0)
+		}
+		exits = append(exits, exit)
+	}
+	return exits, rows.Err()
+}
+
+// scanPiece scans the id and the satellite, if any, of a piece
+func scanPiece(rows *sql.Rows) (piece Piece, err error) {
+	var satellite []byte
+	if err := rows.Scan(&piece.ID, &satellite); err != nil {
+		return Piece{}, err
+	}
+	if len(satellite) > 0 {
+		piece.Satellite, err = storj.NodeIDFromBytes(satellite)
+	}
+	return piece, err
+}
+
+// AddBandwidthUsed adds bandwidth usage into database by date, in total and
+// for satellite unless it's zero
+func (db *DB) AddBandwidthUsed(satellite storj.NodeID, size int64) (err error) {
 	defer db.locked()()
 
 	t := time.Now()
@@ -319,20 +810,24 @@ func (db *DB) AddBandwidthUsed(size int64) (err error) {
 	dayendunixtime := time.Date(t.Year(), t.Month(), t.Day(), 24, 0, 0, 0, t.Location()).Unix()
 
 	var getSize int64
-	if (t.Unix() >= daystartunixtime) && (t.Unix() <= dayendunixtime) {
-		err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate <= ? AND ? <= dayenddate`, t.Unix(), t.Unix()).Scan(&getSize)
-		switch {
-		case err == sql.ErrNoRows:
-			_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, daystartdate, dayenddate) VALUES (?, ?, ?)", size, daystartunixtime, dayendunixtime)
-			return err
-		case err != nil:
-			return err
-		default:
-			getSize = size + getSize
-			_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ? WHERE daystartdate = ?", getSize, daystartunixtime)
-			return err
-		}
+	err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate <= ? AND ? <= dayenddate`, t.Unix(), t.Unix()).Scan(&getSize)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, daystartdate, dayenddate) VALUES (?, ?, ?)", size, daystartunixtime, dayendunixtime)
+	case err != nil:
+	default:
+		getSize = size + getSize
+		_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ? WHERE daystartdate = ?", getSize, daystartunixtime)
+	}
+	if err != nil || satellite.IsZero() {
+		return err
 	}
+
+	_, err = db.DB.Exec("INSERT OR IGNORE INTO satellite_bwusage (satellite, size, daystartdate) VALUES (?, 0, ?)", satellite.Bytes(), daystartunixtime)
+	if err != nil {
+		return err
+	}
+	_, err = db.DB.Exec("UPDATE satellite_bwusage SET size = size + ? WHERE satellite = ? AND daystartdate = ?", size, satellite.Bytes(), daystartunixtime)
 	return err
 }
 
@@ -371,3 +866,60 @@ func (db *DB) GetTotalBandwidthBetween(startdate time.Time, enddate time.Time) (
 	err = db.DB.QueryRow(`SELECT SUM(size) FROM bwusagetbl WHERE daystartdate BETWEEN ? AND ?`, startTimeUnix, endTimeUnix).Scan(&totalbwusage)
 	return totalbwusage, err
 }
+
+// GetSatelliteUsage returns the disk space used by the pieces of satellite
+// and the bandwidth used for it since the day of bandwidthSince
+func (db *DB) GetSatelliteUsage(satellite storj.NodeID, bandwidthSince time.Time) (usage *SatelliteUsage, err error) {
+	defer db.locked()()
+
+	usage = &SatelliteUsage{Satellite: satellite}
+	err = db.DB.QueryRow(`SELECT COALESCE(SUM(ttl.size), 0) FROM ttl JOIN satellite_pieces ON ttl.id = satellite_pieces.id
+		WHERE satellite_pieces.satellite = ?`, satellite.Bytes()).Scan(&usage.Space)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.DB.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM satellite_bwusage WHERE satellite = ? AND daystartdate >= ?`,
+		satellite.Bytes(), dayStart(bandwidthSince)).Scan(&usage.Bandwidth)
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetSatelliteUsages returns the usage of every satellite which has pieces
+// stored or used bandwidth since the day of bandwidthSince
+func (db *DB) GetSatelliteUsages(ctx context.Context, bandwidthSince time.Time) (usages []*SatelliteUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	rows, err := db.DB.QueryContext(ctx, `SELECT satellite, SUM(space), SUM(bandwidth) FROM (
+			SELECT satellite_pieces.satellite AS satellite, ttl.size AS space, 0 AS bandwidth FROM ttl
+				JOIN satellite_pieces ON ttl.id = satellite_pieces.id
+			UNION ALL
+			SELECT satellite, 0, size FROM satellite_bwusage WHERE daystartdate >= ?
+		) GROUP BY satellite ORDER BY satellite`, dayStart(bandwidthSince))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var satelliteBytes []byte
+		usage := &SatelliteUsage{}
+		if err := rows.Scan(&satelliteBytes, &usage.Space, &usage.Bandwidth); err != nil {
+			return nil, err
+		}
+		usage.Satellite, err = storj.NodeIDFromBytes(satelliteBytes)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, rows.Err()
+}
+
+// dayStart returns the unix time of the start of the day of t
+func dayStart(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Unix()
+}
@@ -4,6 +4,8 @@
 package psdb
 
 import (
+	"bytes"
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,6 +14,8 @@ import (
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/pb"
@@ -230,7 +234,7 @@ func TestBandwidthUsage(t *testing.T) {
 			t.Run("#"+strconv.Itoa(P), func(t *testing.T) {
 				t.Parallel()
 				for _, bw := range bwtests {
-					err := db.AddBandwidthUsed(bw.size)
+					err := db.AddBandwidthUsed(storj.NodeID{}, bw.size)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -274,6 +278,293 @@ func TestBandwidthUsage(t *testing.T) {
 	})
 }
 
+func TestTrash(t *testing.T) {
+	db, cleanup := newDB(t, "3")
+	defer cleanup()
+
+	ctx := context.Background()
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+
+	for id, owner := range map[string]storj.NodeID{"piece1": satellite, "piece2": satellite, "piece3": other} {
+		require.NoError(t, db.AddTTL(id, 0, 10))
+		require.NoError(t, db.AddSatellitePiece(owner, id))
+	}
+
+	ids, err := db.GetSatellitePieces(ctx, satellite, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, err = db.GetSatellitePieces(ctx, satellite, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"piece1", "piece2"}, ids)
+
+	require.NoError(t, db.TrashPiece("piece1"))
+
+	ids, err = db.GetSatellitePieces(ctx, satellite, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"piece2"}, ids)
+
+	used, err := db.SumTTLSizes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), used)
+
+	// the trash of another satellite is left untouched
+	restored, err := db.RestoreTrash(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	restored, err = db.RestoreTrash(ctx, satellite)
+	require.NoError(t, err)
+	assert.Equal(t, []Piece{{ID: "piece1", Satellite: satellite}}, restored)
+
+	expiration, err := db.GetTTLByID("piece1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), expiration)
+
+	satelliteOf, err := db.GetPieceSatellite("piece1")
+	require.NoError(t, err)
+	assert.Equal(t, satellite, satelliteOf)
+
+	require.NoError(t, db.TrashPiece("piece2"))
+
+	satelliteOf, err = db.GetPieceSatellite("piece2")
+	require.NoError(t, err)
+	assert.True(t, satelliteOf.IsZero())
+
+	deleted, err := db.DeleteTrash(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	deleted, err = db.DeleteTrash(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []Piece{{ID: "piece2", Satellite: satellite}}, deleted)
+
+	restored, err = db.RestoreTrash(ctx, satellite)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	// the pieces stored before they were attributed to satellites are trashed
+	// without one, and restored with the trash of any satellite
+	require.NoError(t, db.AddTTL("piece4", 0, 10))
+
+	ids, err = db.GetUnattributedPieces(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, err = db.GetUnattributedPieces(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"piece4"}, ids)
+
+	require.NoError(t, db.TrashPiece("piece4"))
+
+	ids, err = db.GetUnattributedPieces(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	restored, err = db.RestoreTrash(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, []Piece{{ID: "piece4"}}, restored)
+
+	ids, err = db.GetUnattributedPieces(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"piece4"}, ids)
+}
+
+func TestAttributePiece(t *testing.T) {
+	db, cleanup := newDB(t, "attribute")
+	defer cleanup()
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+
+	// only the stored pieces are attributed
+	require.NoError(t, db.AttributePiece(satellite, "piece1"))
+	satelliteOf, err := db.GetPieceSatellite("piece1")
+	require.NoError(t, err)
+	assert.True(t, satelliteOf.IsZero())
+
+	require.NoError(t, db.AddTTL("piece1", 0, 10))
+	require.NoError(t, db.AttributePiece(satellite, "piece1"))
+	satelliteOf, err = db.GetPieceSatellite("piece1")
+	require.NoError(t, err)
+	assert.Equal(t, satellite, satelliteOf)
+
+	// and the satellite of a piece isn't replaced
+	require.NoError(t, db.AttributePiece(other, "piece1"))
+	satelliteOf, err = db.GetPieceSatellite("piece1")
+	require.NoError(t, err)
+	assert.Equal(t, satellite, satelliteOf)
+}
+
+func TestPieceHashes(t *testing.T) {
+	db, cleanup := newDB(t, "hashes")
+	defer cleanup()
+
+	ctx := context.Background()
+	satellite := teststorj.NodeIDFromString("satellite")
+	hashes := [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)}
+
+	// the pieces stored before they were hashed in blocks have no hashes
+	blockSize, found, err := db.GetPieceHashes("piece1")
+	require.NoError(t, err)
+	assert.Zero(t, blockSize)
+	assert.Empty(t, found)
+
+	for _, id := range []string{"piece1", "piece2"} {
+		require.NoError(t, db.AddTTL(id, 0, 10))
+		require.NoError(t, db.AddSatellitePiece(satellite, id))
+		require.NoError(t, db.AddPieceHashes(id, 1024, hashes))
+	}
+
+	blockSize, found, err = db.GetPieceHashes("piece1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), blockSize)
+	assert.Equal(t, hashes, found)
+
+	// the hashes are deleted with the piece
+	require.NoError(t, db.DeleteTTLByID("piece1"))
+	_, found, err = db.GetPieceHashes("piece1")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	// and kept in the trash until it's emptied
+	require.NoError(t, db.TrashPiece("piece2"))
+	_, found, err = db.GetPieceHashes("piece2")
+	require.NoError(t, err)
+	assert.Equal(t, hashes, found)
+
+	_, err = db.DeleteTrash(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, found, err = db.GetPieceHashes("piece2")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestExits(t *testing.T) {
+	db, cleanup := newDB(t, "4")
+	defer cleanup()
+
+	ctx := context.Background()
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+
+	for id, owner := range map[string]storj.NodeID{"piece1": satellite, "piece2": satellite, "piece3": other} {
+		require.NoError(t, db.AddTTL(id, 0, 10))
+		require.NoError(t, db.AddSatellitePiece(owner, id))
+	}
+
+	satellites, err := db.GetSatellites(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []storj.NodeID{satellite, other}, satellites)
+
+	count, err := db.CountSatellitePieces(satellite)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	exits, err := db.GetExits(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, exits)
+
+	require.NoError(t, db.AddExit(satellite))
+
+	exits, err = db.GetExits(ctx)
+	require.NoError(t, err)
+	require.Len(t, exits, 1)
+	assert.Equal(t, satellite, exits[0].Satellite)
+	assert.False(t, exits[0].Started.IsZero())
+	assert.True(t, exits[0].Finished.IsZero())
+
+	finished := time.Unix(time.Now().Unix(), 0)
+	require.NoError(t, db.UpdateExit(&Exit{
+		Satellite:         satellite,
+		Finished:          finished,
+		PiecesTransferred: 2,
+		PiecesFailed:      1,
+		BytesTransferred:  20,
+	}))
+
+	// starting the exit again keeps its progress
+	require.NoError(t, db.AddExit(satellite))
+
+	exits, err = db.GetExits(ctx)
+	require.NoError(t, err)
+	require.Len(t, exits, 1)
+	assert.Equal(t, finished, exits[0].Finished)
+	assert.Equal(t, int64(2), exits[0].PiecesTransferred)
+	assert.Equal(t, int64(1), exits[0].PiecesFailed)
+	assert.Equal(t, int64(20), exits[0].BytesTransferred)
+}
+
+func TestSatelliteUsage(t *testing.T) {
+	db, cleanup := newDB(t, "5")
+	defer cleanup()
+
+	ctx := context.Background()
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+
+	for id, owner := range map[string]storj.NodeID{"piece1": satellite, "piece2": satellite, "piece3": other} {
+		require.NoError(t, db.AddTTL(id, 0, 10))
+		require.NoError(t, db.AddSatellitePiece(owner, id))
+	}
+
+	require.NoError(t, db.AddBandwidthUsed(satellite, 100))
+	require.NoError(t, db.AddBandwidthUsed(satellite, 50))
+	require.NoError(t, db.AddBandwidthUsed(storj.NodeID{}, 1))
+
+	// the bandwidth used for any satellite counts to the total
+	total, err := db.GetTotalBandwidthBetween(time.Now(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(151), total)
+
+	usage, err := db.GetSatelliteUsage(satellite, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, &SatelliteUsage{Satellite: satellite, Space: 20, Bandwidth: 150}, usage)
+
+	usage, err = db.GetSatelliteUsage(satellite, time.Now().AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, &SatelliteUsage{Satellite: satellite, Space: 20}, usage)
+
+	usages, err := db.GetSatelliteUsages(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []*SatelliteUsage{
+		{Satellite: other, Space: 10},
+		{Satellite: satellite, Space: 20, Bandwidth: 150},
+	}, usages)
+}
+
+func TestRejectedAgreements(t *testing.T) {
+	db, cleanup := newDB(t, "6")
+	defer cleanup()
+
+	ctx := context.Background()
+	satellite := teststorj.NodeIDFromString("satellite")
+
+	for _, signature := range []string{"accepted", "rejected"} {
+		require.NoError(t, db.WriteBandwidthAllocToDB(&pb.RenterBandwidthAllocation{
+			PayerAllocation: pb.PayerBandwidthAllocation{SatelliteId: satellite},
+			Total:           10,
+			Signature:       []byte(signature),
+		}))
+	}
+
+	require.NoError(t, db.RejectBandwidthAllocation([]byte("rejected"), "expired"))
+
+	agreements, err := db.GetBandwidthAllocations()
+	require.NoError(t, err)
+	require.Len(t, agreements[satellite], 1)
+	assert.Equal(t, []byte("accepted"), agreements[satellite][0].Signature)
+
+	rejected, err := db.GetRejectedBandwidthAllocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, []byte("rejected"), rejected[0].Signature)
+	assert.Equal(t, satellite, rejected[0].Agreement.Agreement.PayerAllocation.SatelliteId)
+	assert.Equal(t, "expired", rejected[0].Reason)
+	assert.False(t, rejected[0].Rejected.IsZero())
+}
+
 func BenchmarkWriteBandwidthAllocation(b *testing.B) {
 	db, cleanup := newDB(b, "3")
 	defer cleanup()
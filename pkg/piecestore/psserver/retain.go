@@ -0,0 +1,181 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/bloomfilter"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// retainFilters are the last filters of the pieces to retain received from
+// the satellites, which are needed to collect the pieces stored before the
+// storage node recorded the satellites of the pieces
+type retainFilters struct {
+	mu      sync.Mutex
+	filters map[storj.NodeID]retainFilter
+}
+
+// retainFilter is a filter of the pieces to retain and its creation time
+type retainFilter struct {
+	filter  *bloomfilter.Filter
+	created time.Time
+}
+
+// Retain moves to the trash the pieces of the calling satellite which were
+// stored before the creation of the filter of the request and are not in it
+func (s *Server) Retain(ctx context.Context, in *pb.RetainRequest) (_ *pb.RetainSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satelliteID, err := s.satelliteFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := bloomfilter.NewFromBytes(in.GetFilter())
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	created := time.Unix(in.GetCreationUnixSec(), 0)
+
+	ids, err := s.DB.GetSatellitePieces(ctx, satelliteID, created)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	var trashed int64
+	for _, id := range ids {
+		if filter.Contains([]byte(id)) {
+			continue
+		}
+		if err := s.trash(ctx, satelliteID, id); err != nil {
+			return nil, ServerError.Wrap(err)
+		}
+		trashed++
+	}
+
+	unattributed, err := s.retainUnattributed(ctx, satelliteID, retainFilter{filter: filter, created: created})
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	trashed += unattributed
+
+	s.log.Info("Moved garbage to the trash", zap.String("Satellite ID", satelliteID.String()), zap.Int64("pieces", trashed))
+	return &pb.RetainSummary{Trashed: trashed}, nil
+}
+
+// retainUnattributed keeps filter as the last filter of satellite, and moves
+// to the trash the pieces without a satellite which are in none of the last
+// filters of the approved satellites. The pieces without a satellite are
+// only collected once every approved satellite sent a filter, and never when
+// any satellite is accepted.
+func (s *Server) retainUnattributed(ctx context.Context, satellite storj.NodeID, filter retainFilter) (trashed int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.retained.mu.Lock()
+	defer s.retained.mu.Unlock()
+
+	if s.retained.filters == nil {
+		s.retained.filters = make(map[storj.NodeID]retainFilter)
+	}
+	s.retained.filters[satellite] = filter
+
+	if len(s.whitelist) == 0 {
+		return 0, nil
+	}
+
+	// only the pieces stored before every filter was created may be collected
+	createdBefore := filter.created
+	for _, approved := range s.whitelist {
+		last, ok := s.retained.filters[approved]
+		if !ok {
+			return 0, nil
+		}
+		if last.created.Before(createdBefore) {
+			createdBefore = last.created
+		}
+	}
+
+	ids, err := s.DB.GetUnattributedPieces(ctx, createdBefore)
+	if err != nil {
+		return 0, err
+	}
+
+next:
+	for _, id := range ids {
+		for _, approved := range s.whitelist {
+			if s.retained.filters[approved].filter.Contains([]byte(id)) {
+				continue next
+			}
+		}
+		if err := s.trash(ctx, storj.NodeID{}, id); err != nil {
+			return trashed, err
+		}
+		trashed++
+	}
+	return trashed, nil
+}
+
+// trash moves the piece id of satellite to the trash, which may be zero when
+// the satellite of the piece is unknown
+func (s *Server) trash(ctx context.Context, satellite storj.NodeID, id string) error {
+	if err := s.storage.Trash(ctx, pieceRef(satellite, id)); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		// nothing to keep in the trash for a piece which is already gone
+		return s.DB.DeleteTTLByID(id)
+	}
+	return s.DB.TrashPiece(id)
+}
+
+// RestoreTrash restores the pieces of the calling satellite from the trash
+func (s *Server) RestoreTrash(ctx context.Context, in *pb.RestoreTrashRequest) (_ *pb.RestoreTrashSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satelliteID, err := s.satelliteFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pieces, err := s.DB.RestoreTrash(ctx, satelliteID)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, piece := range pieces {
+		errlist.Add(s.storage.RestoreTrash(ctx, pieceRef(piece.Satellite, piece.ID)))
+	}
+	if err := errlist.Err(); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	s.log.Info("Restored the trash", zap.String("Satellite ID", satelliteID.String()), zap.Int("pieces", len(pieces)))
+	return &pb.RestoreTrashSummary{Restored: int64(len(pieces))}, nil
+}
+
+// satelliteFromContext returns the id of the calling satellite, as long as
+// it's approved
+func (s *Server) satelliteFromContext(ctx context.Context) (storj.NodeID, error) {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return storj.NodeID{}, ServerError.Wrap(err)
+	}
+
+	if s.whitelist != nil && !s.approved(peer.ID) {
+		return storj.NodeID{}, ServerError.New("satellite %s is not approved", peer.ID)
+	}
+
+	return peer.ID, nil
+}
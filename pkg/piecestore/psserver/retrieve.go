@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"sync/atomic"
 
 	"github.com/zeebo/errs"
@@ -15,6 +14,7 @@ import (
 
 	"storj.io/storj/internal/sync2"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 )
 
 // RetrieveError is a type of error for failures in Server.Retrieve()
@@ -55,28 +55,48 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 		return err
 	}
 
-	// Get path to data being retrieved
-	path, err := s.storage.PiecePath(id)
-	if err != nil {
+	if err := validatePieceID(id); err != nil {
 		return err
 	}
 
-	// Verify that the path exists
-	fileInfo, err := os.Stat(path)
+	satelliteID := getSatellite(authorization)
+	storeFile, err := s.storage.Load(ctx, pieceRef(satelliteID, id))
 	if err != nil {
 		return RetrieveError.Wrap(err)
 	}
+	s.attribute(satelliteID, id)
+
+	defer func() {
+		err = errs.Combine(err, storeFile.Close())
+	}()
 
 	// Read the size specified
 	totalToRead := pd.GetPieceSize()
-	fileSize := fileInfo.Size()
+	fileSize := storeFile.Size()
+
+	if pd.GetOffset() >= fileSize || pd.GetOffset() < 0 {
+		return RetrieveError.New("invalid offset: %v", pd.GetOffset())
+	}
 
 	// Read the entire file if specified -1 but make sure we do it from the correct offset
 	if pd.GetPieceSize() <= -1 || totalToRead+pd.GetOffset() > fileSize {
 		totalToRead = fileSize - pd.GetOffset()
 	}
 
-	retrieved, allocated, err := s.retrieveData(ctx, stream, id, pd.GetOffset(), totalToRead)
+	// the piece is only sent within the bandwidth left for the satellite
+	_, bandwidthLeft, err := s.remaining(satelliteID)
+	if err != nil {
+		return RetrieveError.Wrap(err)
+	}
+	if totalToRead > bandwidthLeft {
+		return RetrieveError.New("out of bandwidth")
+	}
+
+	if _, err := storeFile.Seek(pd.GetOffset(), io.SeekStart); err != nil {
+		return RetrieveError.Wrap(err)
+	}
+
+	retrieved, allocated, err := s.retrieveData(ctx, stream, satelliteID, storeFile, totalToRead)
 	if err != nil {
 		return err
 	}
@@ -89,18 +109,9 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 	return nil
 }
 
-func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, id string, offset, length int64) (retrieved, allocated int64, err error) {
+func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, satellite storj.NodeID, storeFile io.Reader, length int64) (retrieved, allocated int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	storeFile, err := s.storage.Reader(ctx, id, offset, length)
-	if err != nil {
-		return 0, 0, RetrieveError.Wrap(err)
-	}
-
-	defer func() {
-		err = errs.Combine(err, storeFile.Close())
-	}()
-
 	writer := NewStreamWriter(s, stream)
 	allocationTracking := sync2.NewThrottle()
 	totalAllocated := int64(0)
@@ -183,7 +194,7 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 	}
 
 	// write to bandwidth usage table
-	if err = s.DB.AddBandwidthUsed(used); err != nil {
+	if err = s.DB.AddBandwidthUsed(satellite, used); err != nil {
 		return retrieved, allocated, RetrieveError.New("failed to write bandwidth info to database: %v", err)
 	}
 
@@ -4,9 +4,6 @@
 package psserver
 
 import (
-	"crypto"
-	"crypto/hmac"
-	"crypto/sha512"
 	"errors"
 	"fmt"
 	"os"
@@ -16,18 +13,20 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
-	"github.com/mr-tron/base58/base58"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/pb"
 	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -57,29 +56,36 @@ func DirSize(path string) (int64, error) {
 type Server struct {
 	startTime        time.Time
 	log              *zap.Logger
-	storage          *pstore.Storage
+	storage          storage.Blobs
 	DB               *psdb.DB
-	pkey             crypto.PrivateKey
+	identity         *identity.FullIdentity
 	totalAllocated   int64 // TODO: use memory.Size
 	totalBwAllocated int64 // TODO: use memory.Size
 	whitelist        []storj.NodeID
+	allocations      map[storj.NodeID]allocation
 	verifier         auth.SignedMessageVerifier
 	kad              *kademlia.Kademlia
+	retained         retainFilters
+}
+
+// allocation is the disk space and the monthly bandwidth allocated to a
+// satellite
+type allocation struct {
+	space     int64
+	bandwidth int64
 }
 
 // NewEndpoint creates a new endpoint
-func NewEndpoint(log *zap.Logger, config Config, storage *pstore.Storage, db *psdb.DB, pkey crypto.PrivateKey, k *kademlia.Kademlia) (*Server, error) {
+func NewEndpoint(log *zap.Logger, config Config, storage storage.Blobs, db *psdb.DB, identity *identity.FullIdentity, k *kademlia.Kademlia) (*Server, error) {
 	// read the allocated disk space from the config file
 	allocatedDiskSpace := config.AllocatedDiskSpace.Int64()
 	allocatedBandwidth := config.AllocatedBandwidth.Int64()
 
 	// get the disk space details
-	// The returned path ends in a slash only if it represents a root directory, such as "/" on Unix or `C:\` on Windows.
-	info, err := storage.Info()
+	freeDiskSpace, err := storage.FreeSpace()
 	if err != nil {
 		return nil, ServerError.Wrap(err)
 	}
-	freeDiskSpace := info.AvailableSpace
 
 	// get how much is currently used, if for the first time totalUsed = 0
 	totalUsed, err := db.SumTTLSizes()
@@ -124,37 +130,75 @@ func NewEndpoint(log *zap.Logger, config Config, storage *pstore.Storage, db *ps
 	var whitelist []storj.NodeID
 	if config.SatelliteIDRestriction {
 		idStrings := strings.Split(config.WhitelistedSatelliteIDs, ",")
-		for i, s := range idStrings {
-			whitelist[i], err = storj.NodeIDFromString(s)
+		for _, s := range idStrings {
+			id, err := storj.NodeIDFromString(s)
 			if err != nil {
 				return nil, err
 			}
+			whitelist = append(whitelist, id)
 		}
 	}
 
+	allocations, err := parseAllocations(config.SatelliteAllocations)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	for satellite, allocation := range allocations {
+		log.Info("Satellite allocation", zap.String("Satellite ID", satellite.String()),
+			zap.Int64("disk space", allocation.space), zap.Int64("bandwidth", allocation.bandwidth))
+	}
+
 	return &Server{
 		startTime:        time.Now(),
 		log:              log,
 		storage:          storage,
 		DB:               db,
-		pkey:             pkey,
+		identity:         identity,
 		totalAllocated:   allocatedDiskSpace,
 		totalBwAllocated: allocatedBandwidth,
 		whitelist:        whitelist,
+		allocations:      allocations,
 		verifier:         auth.NewSignedMessageVerifier(),
 		kad:              k,
 	}, nil
 }
 
+// parseAllocations parses the comma-separated list of satellite allocations
+// formatted as <satellite id>:<disk space>:<bandwidth>
+func parseAllocations(list string) (map[storj.NodeID]allocation, error) {
+	allocations := make(map[storj.NodeID]allocation)
+	if list == "" {
+		return allocations, nil
+	}
+
+	for _, entry := range strings.Split(list, ",") {
+		fields := strings.Split(entry, ":")
+		if len(fields) != 3 {
+			return nil, errs.New("invalid satellite allocation %q", entry)
+		}
+
+		satellite, err := storj.NodeIDFromString(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		var space, bandwidth memory.Size
+		if err := space.Set(fields[1]); err != nil {
+			return nil, err
+		}
+		if err := bandwidth.Set(fields[2]); err != nil {
+			return nil, err
+		}
+		allocations[satellite] = allocation{space: space.Int64(), bandwidth: bandwidth.Int64()}
+	}
+	return allocations, nil
+}
+
 // Close stops the server
 func (s *Server) Close() error { return nil }
 
 // Stop the piececstore node
 func (s *Server) Stop(ctx context.Context) error {
-	return errs.Combine(
-		s.DB.Close(),
-		s.storage.Close(),
-	)
+	return s.DB.Close()
 }
 
 // Piece -- Send meta data about a piece stored by Id
@@ -171,8 +215,7 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, err
 	}
 
-	path, err := s.storage.PiecePath(id)
-	if err != nil {
+	if err := validatePieceID(id); err != nil {
 		return nil, err
 	}
 
@@ -185,10 +228,16 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, ServerError.New("invalid ID")
 	}
 
-	fileInfo, err := os.Stat(path)
+	satelliteID := getSatellite(authorization)
+	reader, err := s.storage.Load(ctx, pieceRef(satelliteID, id))
 	if err != nil {
 		return nil, err
 	}
+	s.attribute(satelliteID, id)
+	pieceSize := reader.Size()
+	if err := reader.Close(); err != nil {
+		return nil, err
+	}
 
 	// Read database to calculate expiration
 	ttl, err := s.DB.GetTTLByID(id)
@@ -196,15 +245,27 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, err
 	}
 
+	// the pieces stored before they were hashed in blocks have no hashes
+	blockSize, hashes, err := s.DB.GetPieceHashes(id)
+	if err != nil {
+		return nil, err
+	}
+
 	s.log.Info("Successfully retrieved meta", zap.String("Piece ID", in.GetId()))
-	return &pb.PieceSummary{Id: in.GetId(), PieceSize: fileInfo.Size(), ExpirationUnixSec: ttl}, nil
+	return &pb.PieceSummary{
+		Id:                in.GetId(),
+		PieceSize:         pieceSize,
+		ExpirationUnixSec: ttl,
+		HashBlockSize:     blockSize,
+		BlockHashes:       hashes,
+	}, nil
 }
 
 // Stats will return statistics about the Server
 func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, error) {
 	s.log.Debug("Getting Stats...")
 
-	statsSummary, err := s.retrieveStats()
+	statsSummary, err := s.retrieveStats(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +275,7 @@ func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, e
 	return statsSummary, nil
 }
 
-func (s *Server) retrieveStats() (*pb.StatSummary, error) {
+func (s *Server) retrieveStats(ctx context.Context) (*pb.StatSummary, error) {
 	totalUsed, err := s.DB.SumTTLSizes()
 	if err != nil {
 		return nil, err
@@ -225,7 +286,74 @@ func (s *Server) retrieveStats() (*pb.StatSummary, error) {
 		return nil, err
 	}
 
-	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}, nil
+	stats := &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}
+
+	usages, err := s.DB.GetSatelliteUsages(ctx, getBeginningOfMonth())
+	if err != nil {
+		return nil, err
+	}
+
+	// satellites with an allocation are reported even before they're used
+	reported := make(map[storj.NodeID]bool)
+	for _, usage := range usages {
+		reported[usage.Satellite] = true
+	}
+	for satellite := range s.allocations {
+		if !reported[satellite] {
+			usages = append(usages, &psdb.SatelliteUsage{Satellite: satellite})
+		}
+	}
+
+	for _, usage := range usages {
+		stats.Satellites = append(stats.Satellites, s.satelliteStats(usage, stats.AvailableSpace, stats.AvailableBandwidth))
+	}
+	return stats, nil
+}
+
+// satelliteStats returns the usage of a satellite with what's left of its
+// allocation, which never exceeds what's left of the total allocation
+func (s *Server) satelliteStats(usage *psdb.SatelliteUsage, spaceLeft, bandwidthLeft int64) *pb.SatelliteStats {
+	stats := &pb.SatelliteStats{
+		SatelliteId:        usage.Satellite,
+		UsedSpace:          usage.Space,
+		AvailableSpace:     spaceLeft,
+		UsedBandwidth:      usage.Bandwidth,
+		AvailableBandwidth: bandwidthLeft,
+	}
+	if allocation, ok := s.allocations[usage.Satellite]; ok {
+		if left := allocation.space - usage.Space; left < stats.AvailableSpace {
+			stats.AvailableSpace = left
+		}
+		if left := allocation.bandwidth - usage.Bandwidth; left < stats.AvailableBandwidth {
+			stats.AvailableBandwidth = left
+		}
+	}
+	return stats
+}
+
+// remaining returns the disk space and the bandwidth left this month for
+// satellite, or in total when the satellite is unknown
+func (s *Server) remaining(satellite storj.NodeID) (space, bandwidth int64, err error) {
+	spaceUsed, err := s.DB.SumTTLSizes()
+	if err != nil {
+		return 0, 0, err
+	}
+	bandwidthUsed, err := s.DB.GetTotalBandwidthBetween(getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return 0, 0, err
+	}
+	space, bandwidth = s.totalAllocated-spaceUsed, s.totalBwAllocated-bandwidthUsed
+
+	if _, ok := s.allocations[satellite]; !ok {
+		return space, bandwidth, nil
+	}
+
+	usage, err := s.DB.GetSatelliteUsage(satellite, getBeginningOfMonth())
+	if err != nil {
+		return 0, 0, err
+	}
+	stats := s.satelliteStats(usage, space, bandwidth)
+	return stats.AvailableSpace, stats.AvailableBandwidth, nil
 }
 
 // Dashboard is a stream that sends data every `interval` seconds to the listener.
@@ -267,7 +395,10 @@ func (s *Server) Delete(ctx context.Context, in *pb.PieceDelete) (*pb.PieceDelet
 	if err != nil {
 		return nil, err
 	}
-	if err := s.deleteByID(id); err != nil {
+	if err := validatePieceID(id); err != nil {
+		return nil, err
+	}
+	if err := s.deleteByID(ctx, getSatellite(authorization), id); err != nil {
 		return nil, err
 	}
 
@@ -276,8 +407,8 @@ func (s *Server) Delete(ctx context.Context, in *pb.PieceDelete) (*pb.PieceDelet
 	return &pb.PieceDeleteSummary{Message: OK}, nil
 }
 
-func (s *Server) deleteByID(id string) error {
-	if err := s.storage.Delete(id); err != nil {
+func (s *Server) deleteByID(ctx context.Context, satellite storj.NodeID, id string) error {
+	if err := s.storage.Delete(ctx, pieceRef(satellite, id)); err != nil {
 		return err
 	}
 	if err := s.DB.DeleteTTLByID(id); err != nil {
@@ -347,25 +478,68 @@ func getBeginningOfMonth() time.Time {
 }
 
 func getNamespacedPieceID(pieceID, namespace []byte) (string, error) {
-	if namespace == nil {
-		return string(pieceID), nil
-	}
+	id, err := psclient.PieceID(pieceID).Namespace(namespace)
+	return id.String(), err
+}
+
+func getNamespace(signedMessage *pb.SignedMessage) []byte {
+	return signedMessage.GetData()
+}
 
-	mac := hmac.New(sha512.New, namespace)
-	_, err := mac.Write(pieceID)
+// getSatellite returns the satellite which signed the authorization, which
+// is zero when the authorization has no namespace
+func getSatellite(signedMessage *pb.SignedMessage) storj.NodeID {
+	satelliteID, err := storj.NodeIDFromBytes(getNamespace(signedMessage))
 	if err != nil {
-		return "", err
+		return storj.NodeID{}
 	}
-	h := mac.Sum(nil)
-	return base58.Encode(h), nil
+	return satelliteID
 }
 
-func getNamespace(signedMessage *pb.SignedMessage) []byte {
-	return signedMessage.GetData()
+// attribute records that piece id is stored for satellite, if it wasn't
+// known yet, so that the piece is migrated and collected as garbage. The ids
+// are namespaced by the satellite, so a piece found for satellite is its.
+func (s *Server) attribute(satellite storj.NodeID, id string) {
+	if satellite.IsZero() {
+		return
+	}
+	if err := s.DB.AttributePiece(satellite, id); err != nil {
+		s.log.Warn("failed to attribute piece", zap.String("Piece ID", id), zap.Error(err))
+	}
+}
+
+// pieceRef returns the reference of the blob of piece id, which is
+// namespaced by the satellite the piece is stored for, when known
+func pieceRef(satellite storj.NodeID, id string) storage.BlobRef {
+	ref := storage.BlobRef{Key: []byte(id)}
+	if !satellite.IsZero() {
+		ref.Namespace = satellite.Bytes()
+	}
+	return ref
+}
+
+// PieceNamespaces returns the function looking up the namespace of the blob
+// of a stored piece, for migrating it from the legacy storage
+func PieceNamespaces(db *psdb.DB) func(id string) ([]byte, error) {
+	return func(id string) ([]byte, error) {
+		satellite, err := db.GetPieceSatellite(id)
+		if err != nil {
+			return nil, err
+		}
+		return pieceRef(satellite, id).Namespace, nil
+	}
+}
+
+// validatePieceID checks the length of the stored id of a piece
+func validatePieceID(id string) error {
+	if len(id) < pstore.IDLength {
+		return pstore.Error.New("invalid id length")
+	}
+	return nil
 }
 
 func (s *Server) getDashboardData(ctx context.Context) (*pb.DashboardStats, error) {
-	statsSummary, err := s.retrieveStats()
+	statsSummary, err := s.retrieveStats(ctx)
 	if err != nil {
 		return &pb.DashboardStats{}, ServerError.Wrap(err)
 	}
@@ -380,6 +554,11 @@ func (s *Server) getDashboardData(ctx context.Context) (*pb.DashboardStats, erro
 		return &pb.DashboardStats{}, ServerError.Wrap(err)
 	}
 
+	exits, err := s.exitStatuses(ctx)
+	if err != nil {
+		return &pb.DashboardStats{}, ServerError.Wrap(err)
+	}
+
 	bootstrapNodes := s.kad.GetBootstrapNodes()
 
 	bsNodes := make([]string, len(bootstrapNodes))
@@ -397,5 +576,6 @@ func (s *Server) getDashboardData(ctx context.Context) (*pb.DashboardStats, erro
 		Connection:       true,
 		Uptime:           ptypes.DurationProto(time.Since(s.startTime)),
 		Stats:            statsSummary,
+		Exits:            exits,
 	}, nil
 }
@@ -4,6 +4,7 @@
 package psserver
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -27,13 +28,18 @@ import (
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/bloomfilter"
 	"storj.io/storj/pkg/bwagreement/testbwagreement"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage/filestore"
 )
 
 func TestPiece(t *testing.T) {
@@ -49,7 +55,7 @@ func TestPiece(t *testing.T) {
 		return
 	}
 
-	defer func() { _ = s.storage.Delete("11111111111111111111") }()
+	defer func() { _ = s.storage.Delete(ctx, pieceRef(storj.NodeID{}, "11111111111111111111")) }()
 
 	// set up test cases
 	tests := []struct {
@@ -74,9 +80,9 @@ func TestPiece(t *testing.T) {
 			id:         "22222222222222222222",
 			size:       5,
 			expiration: 9999999999,
-			err: fmt.Sprintf("rpc error: code = Unknown desc = stat %s: no such file or directory", func() string {
-				path, _ := s.storage.PiecePath("22222222222222222222")
-				return path
+			err: fmt.Sprintf("rpc error: code = Unknown desc = %v", func() error {
+				_, err := s.storage.Load(ctx, pieceRef(storj.NodeID{}, "22222222222222222222"))
+				return err
 			}()),
 		},
 		{ // server should err with invalid TTL
@@ -134,7 +140,7 @@ func TestRetrieve(t *testing.T) {
 		return
 	}
 
-	defer func() { _ = s.storage.Delete("11111111111111111111") }()
+	defer func() { _ = s.storage.Delete(ctx, pieceRef(storj.NodeID{}, "11111111111111111111")) }()
 
 	// set up test cases
 	tests := []struct {
@@ -198,9 +204,9 @@ func TestRetrieve(t *testing.T) {
 			allocSize: 5,
 			offset:    0,
 			content:   []byte("xyzwq"),
-			err: fmt.Sprintf("rpc error: code = Unknown desc = retrieve error: stat %s: no such file or directory", func() string {
-				path, _ := s.storage.PiecePath("22222222222222222222")
-				return path
+			err: fmt.Sprintf("rpc error: code = Unknown desc = retrieve error: %v", func() error {
+				_, err := s.storage.Load(ctx, pieceRef(storj.NodeID{}, "22222222222222222222"))
+				return err
 			}()),
 		},
 		{ // server should return expected content and respSize with offset and excess reqSize
@@ -383,6 +389,14 @@ func TestStore(t *testing.T) {
 			require.NotNil(t, resp)
 			require.Equal(t, tt.message, resp.Message)
 			require.Equal(t, tt.totalReceived, resp.TotalReceived)
+
+			// the hash of the blocks of the piece is signed by the storage node
+			hash := sha256.Sum256(tt.content)
+			require.NotNil(t, resp.Hash)
+			require.Equal(t, tt.id, resp.Hash.GetId())
+			require.Equal(t, int64(psclient.HashBlockSize), resp.Hash.GetBlockSize())
+			require.Equal(t, psclient.BlocksHash([][]byte{hash[:]}), resp.Hash.GetHash())
+			require.NoError(t, auth.VerifyMsg(resp.Hash, snID.ID))
 		})
 	}
 }
@@ -439,7 +453,7 @@ func TestPbaValidation(t *testing.T) {
 			require.NoError(t, err)
 
 			//cleanup incase tests previously paniced
-			_ = s.storage.Delete("99999999999999999999")
+			_ = s.storage.Delete(ctx, pieceRef(storj.NodeID{}, "99999999999999999999"))
 			// Write the buffer to the stream we opened earlier
 			err = stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: "99999999999999999999", ExpirationUnixSec: 9999999999}})
 			require.NoError(t, err)
@@ -472,6 +486,53 @@ func TestPbaValidation(t *testing.T) {
 	}
 }
 
+func TestAllocations(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	snID, upID := newTestID(ctx, t), newTestID(ctx, t)
+	satellite, other := newTestID(ctx, t).ID, newTestID(ctx, t).ID
+
+	allocations, err := parseAllocations(satellite.String() + ":1KB:2KB")
+	require.NoError(t, err)
+	assert.Equal(t, map[storj.NodeID]allocation{satellite: {space: 1000, bandwidth: 2000}}, allocations)
+
+	_, err = parseAllocations(satellite.String() + ":1KB")
+	assert.Error(t, err)
+
+	s, _, cleanup := NewTest(ctx, t, snID, upID, []storj.NodeID{})
+	defer cleanup()
+	s.totalAllocated = 10000
+	s.totalBwAllocated = 10000
+	s.allocations = allocations
+
+	require.NoError(t, s.DB.AddTTL("piece1", 0, 600))
+	require.NoError(t, s.DB.AddSatellitePiece(satellite, "piece1"))
+	require.NoError(t, s.DB.AddBandwidthUsed(satellite, 500))
+	require.NoError(t, s.DB.AddBandwidthUsed(other, 1000))
+
+	// the allocation of a satellite bounds what's left for it
+	space, bandwidth, err := s.remaining(satellite)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), space)
+	assert.Equal(t, int64(1500), bandwidth)
+
+	// satellites without an allocation share the total allocation
+	space, bandwidth, err = s.remaining(other)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9400), space)
+	assert.Equal(t, int64(8500), bandwidth)
+
+	stats, err := s.retrieveStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), stats.UsedSpace)
+	assert.Equal(t, int64(1500), stats.UsedBandwidth)
+	assert.ElementsMatch(t, []*pb.SatelliteStats{
+		{SatelliteId: satellite, UsedSpace: 600, AvailableSpace: 400, UsedBandwidth: 500, AvailableBandwidth: 1500},
+		{SatelliteId: other, UsedSpace: 0, AvailableSpace: 9400, UsedBandwidth: 1000, AvailableBandwidth: 8500},
+	}, stats.Satellites)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -523,7 +584,7 @@ func TestDelete(t *testing.T) {
 			}()
 
 			defer func() {
-				require.NoError(t, s.storage.Delete("11111111111111111111"))
+				require.NoError(t, s.storage.Delete(ctx, pieceRef(storj.NodeID{}, "11111111111111111111")))
 			}()
 
 			req := &pb.PieceDelete{Id: tt.id}
@@ -538,9 +599,8 @@ func TestDelete(t *testing.T) {
 			require.Equal(t, tt.message, resp.GetMessage())
 
 			// if test passes, check if file was indeed deleted
-			filePath, err := s.storage.PiecePath(tt.id)
-			require.NoError(t, err)
-			if _, err = os.Stat(filePath); os.IsExist(err) {
+			_, err = s.storage.Load(ctx, pieceRef(storj.NodeID{}, tt.id))
+			if !os.IsNotExist(err) {
 				t.Errorf("File not deleted")
 				return
 			}
@@ -548,6 +608,46 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRetainUnattributed(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite1 := teststorj.NodeIDFromString("satellite1")
+	satellite2 := teststorj.NodeIDFromString("satellite2")
+
+	snID, upID := newTestID(ctx, t), newTestID(ctx, t)
+	s, _, cleanup := NewTest(ctx, t, snID, upID, []storj.NodeID{satellite1, satellite2})
+	defer cleanup()
+
+	// the pieces stored before they were attributed to satellites
+	for _, id := range []string{"11111111111111111111", "22222222222222222222", "33333333333333333333"} {
+		require.NoError(t, writeFile(s, id))
+		require.NoError(t, s.DB.AddTTL(id, 0, 5))
+	}
+
+	newFilter := func(id string) retainFilter {
+		filter := bloomfilter.NewOptimal(10, 0.01)
+		filter.Add([]byte(id))
+		return retainFilter{filter: filter, created: time.Now().Add(time.Hour)}
+	}
+
+	// nothing is collected until every approved satellite sent a filter
+	trashed, err := s.retainUnattributed(ctx, satellite1, newFilter("11111111111111111111"))
+	require.NoError(t, err)
+	assert.Zero(t, trashed)
+
+	trashed, err = s.retainUnattributed(ctx, satellite2, newFilter("22222222222222222222"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), trashed)
+
+	ids, err := s.DB.GetUnattributedPieces(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"11111111111111111111", "22222222222222222222"}, ids)
+
+	_, err = s.storage.Load(ctx, pieceRef(storj.NodeID{}, "33333333333333333333"))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func NewTest(ctx context.Context, t *testing.T, snID, upID *identity.FullIdentity,
 	ids []storj.NodeID) (*Server, pb.PieceStoreRoutesClient, func()) {
 	//init ps server backend
@@ -555,9 +655,11 @@ func NewTest(ctx context.Context, t *testing.T, snID, upID *identity.FullIdentit
 	require.NoError(t, err)
 	tempDBPath := filepath.Join(tmp, "test.db")
 	tempDir := filepath.Join(tmp, "test-data", "3000")
-	storage := pstore.NewStorage(tempDir)
+	pieces, err := filestore.NewAt(filepath.Join(tempDir, "blobs"))
+	require.NoError(t, err)
 	psDB, err := psdb.Open(tempDBPath)
 	require.NoError(t, err)
+	storage := pstore.NewMigration(zaptest.NewLogger(t), pstore.NewStorage(tempDir), pieces, PieceNamespaces(psDB), 0)
 	verifier := func(authorization *pb.SignedMessage) error {
 		return nil
 	}
@@ -565,6 +667,7 @@ func NewTest(ctx context.Context, t *testing.T, snID, upID *identity.FullIdentit
 		log:              zaptest.NewLogger(t),
 		storage:          storage,
 		DB:               psDB,
+		identity:         snID,
 		verifier:         verifier,
 		totalAllocated:   math.MaxInt64,
 		totalBwAllocated: math.MaxInt64,
@@ -605,10 +708,12 @@ func newTestID(ctx context.Context, t *testing.T) *identity.FullIdentity {
 }
 
 func writeFile(s *Server, pieceID string) error {
-	file, err := s.storage.Writer(pieceID)
+	file, err := s.storage.Create(context.Background(), pieceRef(storj.NodeID{}, pieceID), -1)
 	if err != nil {
 		return err
 	}
-	_, err = file.Write([]byte("xyzwq"))
-	return errs.Combine(err, file.Close())
+	if _, err = file.Write([]byte("xyzwq")); err != nil {
+		return errs.Combine(err, file.Cancel())
+	}
+	return file.Commit()
 }
@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/auth"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
 )
 
@@ -55,65 +57,85 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	if err != nil {
 		return err
 	}
-	total, err := s.storeData(ctx, reqStream, id)
+	if err := validatePieceID(id); err != nil {
+		return err
+	}
+
+	satelliteID := getSatellite(authorization)
+	total, hashes, err := s.storeData(ctx, reqStream, satelliteID, id)
 	if err != nil {
 		return err
 	}
 
 	if err = s.DB.AddTTL(id, pd.GetExpirationUnixSec(), total); err != nil {
-		deleteErr := s.deleteByID(id)
+		deleteErr := s.deleteByID(ctx, satelliteID, id)
 		return StoreError.New("failed to write piece meta data to database: %v", utils.CombineErrors(err, deleteErr))
 	}
 
-	if err = s.DB.AddBandwidthUsed(total); err != nil {
+	// the hashes of the blocks are sent with the meta of the piece, so that
+	// the blocks can be verified as they are retrieved
+	if err = s.DB.AddPieceHashes(id, psclient.HashBlockSize, hashes); err != nil {
+		deleteErr := s.deleteByID(ctx, satelliteID, id)
+		return StoreError.New("failed to write piece hashes to database: %v", utils.CombineErrors(err, deleteErr))
+	}
+
+	// the satellite of the piece is kept for its garbage collection
+	if !satelliteID.IsZero() {
+		if err = s.DB.AddSatellitePiece(satelliteID, id); err != nil {
+			deleteErr := s.deleteByID(ctx, satelliteID, id)
+			return StoreError.New("failed to write piece satellite to database: %v", utils.CombineErrors(err, deleteErr))
+		}
+	}
+
+	if err = s.DB.AddBandwidthUsed(satelliteID, total); err != nil {
 		return StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
 	s.log.Info("Successfully stored", zap.String("Piece ID", fmt.Sprint(pd.GetId())))
 
-	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total})
+	// the signed hash commits the node to the content it received
+	pieceHash := &pb.PieceHash{Id: pd.GetId(), Hash: psclient.BlocksHash(hashes), BlockSize: psclient.HashBlockSize}
+	if err = auth.SignMessage(pieceHash, *s.identity); err != nil {
+		return StoreError.Wrap(err)
+	}
+
+	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total, Hash: pieceHash})
 }
 
-func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, id string) (total int64, err error) {
+// storeData stores the received content of piece id of satellite, within
+// what's left of its allocations, and returns its size and the hashes of its
+// blocks
+func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, satellite storj.NodeID, id string) (total int64, hashes [][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// Delete data if we error
-	defer func() {
-		if err != nil && err != io.EOF {
-			if deleteErr := s.deleteByID(id); deleteErr != nil {
-				s.log.Error("Failed on deleteByID in Store", zap.Error(deleteErr))
-			}
-		}
-	}()
-
 	// Initialize file for storing data
-	storeFile, err := s.storage.Writer(id)
+	storeFile, err := s.storage.Create(ctx, pieceRef(satellite, id), -1)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
+	// Discard data if we error
 	defer func() {
-		err = errs.Combine(err, storeFile.Close())
+		if err != nil {
+			err = errs.Combine(err, storeFile.Cancel())
+			return
+		}
+		err = storeFile.Commit()
 	}()
 
-	bwUsed, err := s.DB.GetTotalBandwidthBetween(getBeginningOfMonth(), time.Now())
-	if err != nil {
-		return 0, err
-	}
-	spaceUsed, err := s.DB.SumTTLSizes()
+	spaceLeft, bwLeft, err := s.remaining(satellite)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-	bwLeft := s.totalBwAllocated - bwUsed
-	spaceLeft := s.totalAllocated - spaceUsed
 	reader := NewStreamReader(s, stream, bwLeft, spaceLeft)
 
-	total, err = io.Copy(storeFile, reader)
+	h := psclient.NewBlockHasher(psclient.HashBlockSize)
+	total, err = io.Copy(io.MultiWriter(storeFile, h), reader)
 
 	if err != nil && err != io.EOF {
-		return 0, err
+		return 0, nil, err
 	}
 
 	err = s.DB.WriteBandwidthAllocToDB(reader.bandwidthAllocation)
 
-	return total, err
+	return total, h.BlockHashes(), err
 }
@@ -5,17 +5,21 @@ package pstore
 
 import (
 	"context"
-	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/shirou/gopsutil/disk"
 	"github.com/zeebo/errs"
 
-	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/storage"
 )
 
-// Storage stores piecestore pieces
+var _ storage.Blobs = (*Storage)(nil)
+
+// Storage stores piecestore pieces in the legacy layout, where the path of a
+// piece is derived from its id alone. Namespaces of blob references are
+// ignored, as the stored piece ids are already namespaced.
 type Storage struct {
 	dir string
 }
@@ -28,21 +32,14 @@ func NewStorage(dir string) *Storage {
 // Close closes resources
 func (storage *Storage) Close() error { return nil }
 
-// DiskInfo contains statistics about the disk
-type DiskInfo struct {
-	AvailableSpace int64 // TODO: use memory.Size
-}
-
-// Info returns information about the current state of the dir
-func (storage *Storage) Info() (DiskInfo, error) {
+// FreeSpace returns how much space is left on the disk of the storage
+func (storage *Storage) FreeSpace() (int64, error) {
 	rootPath := filepath.Dir(filepath.Clean(storage.dir))
 	diskSpace, err := disk.Usage(rootPath)
 	if err != nil {
-		return DiskInfo{}, err
+		return 0, err
 	}
-	return DiskInfo{
-		AvailableSpace: int64(diskSpace.Free),
-	}, nil
+	return int64(diskSpace.Free), nil
 }
 
 // IDLength -- Minimum ID length
@@ -64,9 +61,10 @@ func (storage *Storage) PiecePath(pieceID string) (string, error) {
 	return filepath.Join(storage.dir, folder1, folder2, filename), nil
 }
 
-// Writer returns a writer that can be used to store piece.
-func (storage *Storage) Writer(pieceID string) (io.WriteCloser, error) {
-	path, err := storage.PiecePath(pieceID)
+// Create returns a writer that can be used to store piece. The legacy layout
+// has no atomic commits, so the piece is visible while it's being written.
+func (storage *Storage) Create(ctx context.Context, ref storage.BlobRef, size int64) (storage.BlobWriter, error) {
+	path, err := storage.PiecePath(string(ref.Key))
 	if err != nil {
 		return nil, err
 	}
@@ -77,46 +75,175 @@ func (storage *Storage) Writer(pieceID string) (io.WriteCloser, error) {
 	if err != nil {
 		return nil, Open.Wrap(err)
 	}
-	return file, nil
+	return &pieceWriter{file}, nil
 }
 
-// Reader returns a reader for the specified piece at the location
-func (storage *Storage) Reader(ctx context.Context, pieceID string, offset int64, length int64) (io.ReadCloser, error) {
-	path, err := storage.PiecePath(pieceID)
+// Load returns a reader for the specified piece
+func (storage *Storage) Load(ctx context.Context, ref storage.BlobRef) (storage.ReadSeekCloser, error) {
+	path, err := storage.PiecePath(string(ref.Key))
 	if err != nil {
 		return nil, err
 	}
-	info, err := os.Stat(path)
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	if offset >= info.Size() || offset < 0 {
-		return nil, Error.New("invalid offset: %v", offset)
+	return &pieceReader{file}, nil
+}
+
+// Delete deletes piece from storage
+func (storage *Storage) Delete(ctx context.Context, ref storage.BlobRef) error {
+	path, err := storage.PiecePath(string(ref.Key))
+	if err != nil {
+		return err
 	}
-	if length <= -1 {
-		length = info.Size()
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		err = nil
 	}
-	// If trying to read past the end of the file, just read to the end
-	if info.Size() < offset+length {
-		length = info.Size() - offset
+	return err
+}
+
+// trashPath returns the path of piece id in the trash, which mirrors the
+// layout of the pieces
+func (storage *Storage) trashPath(pieceID string) (string, error) {
+	if len(pieceID) < IDLength {
+		return "", Error.New("invalid id length")
 	}
-	rr, err := ranger.FileRanger(path)
+	folder1, folder2, filename := pieceID[0:2], pieceID[2:4], pieceID[4:]
+	return filepath.Join(storage.dir, "trash", folder1, folder2, filename), nil
+}
+
+// Trash moves piece to the trash, from where it can be restored
+func (storage *Storage) Trash(ctx context.Context, ref storage.BlobRef) error {
+	path, err := storage.PiecePath(string(ref.Key))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return rr.Range(ctx, offset, length)
+	trashPath, err := storage.trashPath(string(ref.Key))
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(trashPath), 0700); err != nil {
+		return MkDir.Wrap(err)
+	}
+	return os.Rename(path, trashPath)
 }
 
-// Delete deletes piece from storage
-func (storage *Storage) Delete(pieceID string) error {
-	path, err := storage.PiecePath(pieceID)
+// RestoreTrash moves piece back from the trash
+func (storage *Storage) RestoreTrash(ctx context.Context, ref storage.BlobRef) error {
+	path, err := storage.PiecePath(string(ref.Key))
 	if err != nil {
 		return err
 	}
+	trashPath, err := storage.trashPath(string(ref.Key))
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(trashPath); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return MkDir.Wrap(err)
+	}
+	return os.Rename(trashPath, path)
+}
 
-	err = os.Remove(path)
+// DeleteTrash permanently deletes piece from the trash
+func (storage *Storage) DeleteTrash(ctx context.Context, ref storage.BlobRef) error {
+	trashPath, err := storage.trashPath(string(ref.Key))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(trashPath)
 	if os.IsNotExist(err) {
 		err = nil
 	}
 	return err
 }
+
+// Walk calls fn with the id of every piece in the storage, except the ones in
+// the trash
+func (storage *Storage) Walk(fn func(id string) error) error {
+	folders1, err := subdirs(storage.dir)
+	if err != nil {
+		return err
+	}
+	for _, folder1 := range folders1 {
+		folders2, err := subdirs(filepath.Join(storage.dir, folder1))
+		if err != nil {
+			return err
+		}
+		for _, folder2 := range folders2 {
+			files, err := ioutil.ReadDir(filepath.Join(storage.dir, folder1, folder2))
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				if file.IsDir() {
+					continue
+				}
+				if err := fn(folder1 + folder2 + file.Name()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// subdirs returns the names of the directories of the layout in dir, which
+// are two characters long
+func subdirs(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() && len(info.Name()) == 2 {
+			names = append(names, info.Name())
+		}
+	}
+	return names, nil
+}
+
+// pieceReader reads a piece from its file
+type pieceReader struct {
+	*os.File
+}
+
+// Size returns the size of the piece
+func (reader *pieceReader) Size() int64 {
+	info, err := reader.Stat()
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// pieceWriter writes a piece directly to its file
+type pieceWriter struct {
+	file *os.File
+}
+
+// Write writes data to the piece
+func (writer *pieceWriter) Write(data []byte) (int, error) {
+	return writer.file.Write(data)
+}
+
+// Cancel deletes the partially written piece
+func (writer *pieceWriter) Cancel() error {
+	return errs.Combine(writer.file.Close(), os.Remove(writer.file.Name()))
+}
+
+// Commit finishes writing the piece
+func (writer *pieceWriter) Commit() error {
+	return writer.file.Close()
+}
@@ -4,10 +4,8 @@
 package pstore
 
 import (
-	"bytes"
-	"io"
-	"io/ioutil"
-	"math/rand"
+	"os"
+	"sort"
 	"strings"
 	"testing"
 
@@ -15,67 +13,57 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/testsuite"
 )
 
-func TestStore(t *testing.T) {
+func TestBlobs(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
 	store := NewStorage(ctx.Dir("example"))
 	defer ctx.Check(store.Close)
 
-	pieceID := strings.Repeat("AB01", 10)
-
-	source := make([]byte, 8000)
-	_, _ = rand.Read(source[:])
-
-	{ // write data
-		w, err := store.Writer(pieceID)
-		require.NoError(t, err)
-
-		n, err := io.Copy(w, bytes.NewReader(source))
-		assert.Equal(t, n, int64(len(source)))
-		assert.NoError(t, err)
-
-		assert.NoError(t, w.Close())
-	}
-
-	{ // valid reads
-		read := func(offset, length int64) []byte {
-			reader, err := store.Reader(ctx, pieceID, offset, length)
-			if assert.NoError(t, err) {
-				data, err := ioutil.ReadAll(reader)
-				assert.NoError(t, err)
-				assert.NoError(t, reader.Close())
-				return data
-			}
-			return nil
-		}
-
-		assert.Equal(t, source, read(0, -1))
-		assert.Equal(t, source, read(0, 16000))
+	testsuite.RunBlobTests(t, store)
+}
 
-		assert.Equal(t, source[10:1010], read(10, 1000))
-		assert.Equal(t, source[10:11], read(10, 1))
-	}
+func TestWalk(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
 
-	{ // invalid reads
-		badread := func(offset, length int64) error {
-			reader, err := store.Reader(ctx, pieceID, offset, length)
-			if err == nil {
-				assert.NoError(t, reader.Close())
-			}
-			return err
-		}
+	store := NewStorage(ctx.Dir("example"))
+	defer ctx.Check(store.Close)
 
-		assert.Error(t, badread(-100, 0))
-		assert.Error(t, badread(-100, -10))
+	ids := []string{strings.Repeat("AB01", 10), strings.Repeat("AB02", 10), strings.Repeat("CD01", 10)}
+	for _, id := range ids {
+		writer, err := store.Create(ctx, storage.BlobRef{Key: []byte(id)}, -1)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(id))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit())
 	}
 
-	{ // test delete
-		assert.NoError(t, store.Delete(pieceID))
-
-		_, err := store.Reader(ctx, pieceID, 0, -1)
-		assert.Error(t, err)
-	}
+	// pieces are stored under their id alone
+	path, err := store.PiecePath(ids[0])
+	require.NoError(t, err)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	// the trash isn't walked
+	require.NoError(t, store.Trash(ctx, storage.BlobRef{Key: []byte(ids[2])}))
+
+	var walked []string
+	require.NoError(t, store.Walk(func(id string) error {
+		walked = append(walked, id)
+		return nil
+	}))
+	sort.Strings(walked)
+	assert.Equal(t, ids[:2], walked)
+
+	// a storage which was never written is empty
+	empty := NewStorage(ctx.Dir("empty"))
+	assert.NoError(t, empty.Walk(func(id string) error {
+		t.Fatal("unexpected piece", id)
+		return nil
+	}))
 }
@@ -28,8 +28,10 @@ func NewAllocationSigner(satelliteIdentity *identity.FullIdentity, bwExpiration
 	}
 }
 
-// PayerBandwidthAllocation returns generated payer bandwidth allocation
-func (allocation *AllocationSigner) PayerBandwidthAllocation(ctx context.Context, peerIdentity *identity.PeerIdentity, action pb.BandwidthAction) (pba *pb.PayerBandwidthAllocation, err error) {
+// PayerBandwidthAllocation returns generated payer bandwidth allocation,
+// the bandwidth is attributed to projectID, which is nil for the satellite,
+// and to bucket of the project when it's known
+func (allocation *AllocationSigner) PayerBandwidthAllocation(ctx context.Context, peerIdentity *identity.PeerIdentity, projectID []byte, bucket string, action pb.BandwidthAction) (pba *pb.PayerBandwidthAllocation, err error) {
 	if peerIdentity == nil {
 		return nil, Error.New("missing peer identity")
 	}
@@ -48,6 +50,8 @@ func (allocation *AllocationSigner) PayerBandwidthAllocation(ctx context.Context
 		ExpirationUnixSec: created + int64(ttl),
 		Action:            action,
 		SerialNumber:      serialNum.String(),
+		ProjectId:         projectID,
+		Bucket:            bucket,
 	}
 	if err := auth.SignMessage(pba, *allocation.satelliteIdentity); err != nil {
 		return nil, err
@@ -17,13 +17,22 @@ const (
 )
 
 // Config is a configuration struct that is everything you need to start a
-// PointerDB responsibility
+// PointerDB responsibility. The maximum bounds of the remote segments aren't
+// enforced when zero.
 type Config struct {
 	DatabaseURL          string      `help:"the database connection string to use" default:"bolt://$CONFDIR/pointerdb.db"`
 	MinRemoteSegmentSize memory.Size `default:"1240" help:"minimum remote segment size"`
+	MaxRemoteSegmentSize memory.Size `default:"128M" help:"maximum remote segment size"`
 	MaxInlineSegmentSize memory.Size `default:"8000" help:"maximum inline segment size"`
+	MinRequired          int         `default:"10"   help:"minimum number of pieces required to recover a remote segment"`
+	MaxRequired          int         `default:"64"   help:"maximum number of pieces required to recover a remote segment"`
+	MinRepairThreshold   int         `default:"15"   help:"minimum number of pieces below which a remote segment is repaired"`
+	MaxRepairThreshold   int         `default:"96"   help:"maximum number of pieces below which a remote segment is repaired"`
+	MinTotal             int         `default:"20"   help:"minimum total number of pieces of a remote segment"`
+	MaxTotal             int         `default:"130"  help:"maximum total number of pieces of a remote segment"`
 	Overlay              bool        `default:"true" help:"toggle flag if overlay is enabled"`
 	BwExpiration         int         `default:"45"   help:"lifespan of bandwidth agreements in days"`
+	Auth                 bool        `default:"true" help:"toggle flag if api keys issued by the console are required"`
 }
 
 // NewStore returns database for storing pointer data
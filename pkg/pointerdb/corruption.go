@@ -0,0 +1,138 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+// PieceVerifier verifies the pieces stored on the storage nodes
+type PieceVerifier interface {
+	// Corrupted returns whether piece of the segment of pointer doesn't match
+	// the hash signed by the storage node storing it
+	Corrupted(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece) (bool, error)
+	// Matches returns whether the piece of the segment of pointer stored on
+	// the node matches hash
+	Matches(ctx context.Context, pointer *pb.Pointer, nodeID storj.NodeID, hash *pb.PieceHash) (bool, error)
+}
+
+// nodePieceVerifier verifies the pieces by downloading them from the storage
+// nodes with the identity of the satellite
+type nodePieceVerifier struct {
+	transport  transport.Client
+	cache      *overlay.Cache
+	allocation *AllocationSigner
+	identity   *identity.FullIdentity
+}
+
+// NewPieceVerifier creates a PieceVerifier downloading the pieces from the
+// storage nodes
+func NewPieceVerifier(cache *overlay.Cache, allocation *AllocationSigner, identity *identity.FullIdentity) PieceVerifier {
+	return &nodePieceVerifier{
+		transport:  transport.NewClient(identity),
+		cache:      cache,
+		allocation: allocation,
+		identity:   identity,
+	}
+}
+
+// Corrupted implements PieceVerifier
+func (verifier *nodePieceVerifier) Corrupted(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece) (corrupted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the hash signed by the node is the evidence of what it stored
+	signed := piece.GetSignedHash()
+	if err := auth.VerifyMsg(signed, piece.NodeId); err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	derivedID, err := psclient.PieceID(pointer.GetRemote().GetPieceId()).Derive(piece.NodeId.Bytes())
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	if signed.GetId() != derivedID.String() {
+		return false, Error.New("hash of piece %s instead of %s", signed.GetId(), derivedID)
+	}
+
+	matches, err := verifier.Matches(ctx, pointer, piece.NodeId, signed)
+	return !matches, err
+}
+
+// Matches implements PieceVerifier
+func (verifier *nodePieceVerifier) Matches(ctx context.Context, pointer *pb.Pointer, nodeID storj.NodeID, hash *pb.PieceHash) (matches bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	derivedID, err := psclient.PieceID(pointer.GetRemote().GetPieceId()).Derive(nodeID.Bytes())
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	node, err := verifier.cache.Get(ctx, nodeID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	peerIdentity := &identity.PeerIdentity{ID: verifier.identity.ID, Leaf: verifier.identity.Leaf}
+	pba, err := verifier.allocation.PayerBandwidthAllocation(ctx, peerIdentity, nil, "", pb.BandwidthAction_GET_AUDIT)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	signature, err := auth.GenerateSignature(verifier.identity.ID.Bytes(), verifier.identity)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	authorization, err := auth.NewSignedMessage(signature, verifier.identity)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	ps, err := psclient.NewPSClient(ctx, verifier.transport, node, 0)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ps.Close()) }()
+
+	size := pieceSize(pointer)
+	rr, err := ps.Get(ctx, derivedID, size, pba, authorization)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	rc, err := rr.Range(ctx, 0, size)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rc.Close()) }()
+
+	matches, err = psclient.VerifyPiece(rc, hash)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	return matches, nil
+}
+
+// pieceSize returns the size of the pieces of the remote segment of pointer,
+// which is padded to whole stripes
+func pieceSize(pointer *pb.Pointer) int64 {
+	redundancy := pointer.GetRemote().GetRedundancy()
+	required := int64(redundancy.GetMinReq())
+	stripeSize := int64(redundancy.GetErasureShareSize()) * required
+	if stripeSize <= 0 {
+		return 0
+	}
+
+	stripes := (pointer.GetSegmentSize() + stripeSize - 1) / stripeSize
+	return stripes * stripeSize / required
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/accounting"
+)
+
+// ErrLimitExceeded is the error class of requests over the limits of a project
+var ErrLimitExceeded = errs.Class("project limit exceeded")
+
+// ProjectLimits is the store of the usage limits of the projects
+type ProjectLimits interface {
+	// GetLimits returns the storage and egress limits of a project in bytes, zero means unlimited
+	GetLimits(ctx context.Context, projectID uuid.UUID) (storage, egress int64, err error)
+}
+
+// ProjectUsage is the store of the usage of the projects as calculated by tally
+type ProjectUsage interface {
+	// GetProjectUsage retrieves the last tallied usage of a project
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*accounting.ProjectUsage, error)
+}
+
+// Limiter enforces the usage limits of the projects. The usage of a project
+// is the one of the last tally plus the usage which the satellite allowed
+// since then.
+type Limiter struct {
+	limits ProjectLimits
+	usage  ProjectUsage
+
+	mu       sync.Mutex
+	inflight map[uuid.UUID]*inflightUsage
+}
+
+// inflightUsage is the usage of a project since the tally at since
+type inflightUsage struct {
+	since   time.Time
+	storage int64
+	egress  int64
+}
+
+// NewLimiter creates a Limiter of the projects in limits, using the usage
+// calculated by tally
+func NewLimiter(limits ProjectLimits, usage ProjectUsage) *Limiter {
+	return &Limiter{
+		limits:   limits,
+		usage:    usage,
+		inflight: make(map[uuid.UUID]*inflightUsage),
+	}
+}
+
+// CheckStorage returns an ErrLimitExceeded error when storing size more
+// bytes exceeds the storage limit of the project
+func (limiter *Limiter) CheckStorage(ctx context.Context, project uuid.UUID, size int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limit, _, err := limiter.limits.GetLimits(ctx, project)
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	usage, err := limiter.usage.GetProjectUsage(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	inflight := limiter.since(project, usage.TalliedAt)
+	if usage.AtRestTotal+inflight.storage+size > limit {
+		return ErrLimitExceeded.New("storage limit of %d bytes", limit)
+	}
+	return nil
+}
+
+// CheckEgress returns an ErrLimitExceeded error when the project already
+// reached its egress limit
+func (limiter *Limiter) CheckEgress(ctx context.Context, project uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, limit, err := limiter.limits.GetLimits(ctx, project)
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	usage, err := limiter.usage.GetProjectUsage(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	inflight := limiter.since(project, usage.TalliedAt)
+	if usage.EgressTotal+inflight.egress >= limit {
+		return ErrLimitExceeded.New("egress limit of %d bytes", limit)
+	}
+	return nil
+}
+
+// AddStorage records size bytes stored by the project
+func (limiter *Limiter) AddStorage(project uuid.UUID, size int64) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.get(project).storage += size
+}
+
+// AddEgress records size bytes downloaded from the project
+func (limiter *Limiter) AddEgress(project uuid.UUID, size int64) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.get(project).egress += size
+}
+
+// since returns the in-flight usage of project, resetting it when the project
+// was tallied after it started
+func (limiter *Limiter) since(project uuid.UUID, talliedAt time.Time) inflightUsage {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	inflight := limiter.get(project)
+	if inflight.since.Before(talliedAt) {
+		*inflight = inflightUsage{since: talliedAt}
+	}
+	return *inflight
+}
+
+// get returns the in-flight usage of project, the caller must hold mu
+func (limiter *Limiter) get(project uuid.UUID) *inflightUsage {
+	inflight, ok := limiter.inflight[project]
+	if !ok {
+		inflight = &inflightUsage{}
+		limiter.inflight[project] = inflight
+	}
+	return inflight
+}
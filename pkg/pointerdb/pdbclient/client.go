@@ -6,8 +6,11 @@ package pdbclient
 import (
 	"context"
 	"sync/atomic"
+	"time"
 	"unsafe"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -51,7 +54,14 @@ type Client interface {
 	Put(ctx context.Context, path storj.Path, pointer *pb.Pointer) error
 	Get(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, *pb.PayerBandwidthAllocation, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
-	Delete(ctx context.Context, path storj.Path) error
+	Delete(ctx context.Context, path storj.Path) (released *pb.Pointer, err error)
+	Copy(ctx context.Context, source, destination storj.Path, metadata []byte) error
+
+	SetLifecycle(ctx context.Context, bucket string, lifecycle *pb.BucketLifecycle) error
+	GetLifecycle(ctx context.Context, bucket string) (*pb.BucketLifecycle, error)
+
+	UpdateMetadata(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) error
+	ReportCorruption(ctx context.Context, path storj.Path, pieceNum int32, nodeID storj.NodeID) error
 
 	SignedMessage() *pb.SignedMessage
 	PayerBandwidthAllocation(context.Context, pb.BandwidthAction) (*pb.PayerBandwidthAllocation, error)
@@ -135,11 +145,91 @@ func (pdb *PointerDB) List(ctx context.Context, prefix, startAfter, endBefore st
 	return items, res.GetMore(), nil
 }
 
-// Delete is the interface to make a Delete request, needs Path and APIKey
-func (pdb *PointerDB) Delete(ctx context.Context, path storj.Path) (err error) {
+// Delete is the interface to make a Delete request, needs Path and APIKey.
+// It returns the segment whose pieces aren't referenced anymore, if any.
+func (pdb *PointerDB) Delete(ctx context.Context, path storj.Path) (released *pb.Pointer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.Delete(ctx, &pb.DeleteRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetReleased(), nil
+}
+
+// Copy is the interface to make a Copy request, needs the source and destination paths and APIKey
+func (pdb *PointerDB) Copy(ctx context.Context, source, destination storj.Path, metadata []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = pdb.client.Copy(ctx, &pb.CopyRequest{
+		SourcePath:      source,
+		DestinationPath: destination,
+		Metadata:        metadata,
+	})
+	if status.Code(err) == codes.NotFound {
+		return storage.ErrKeyNotFound.Wrap(err)
+	}
+
+	return err
+}
+
+// SetLifecycle replaces the lifecycle rules of a bucket
+func (pdb *PointerDB) SetLifecycle(ctx context.Context, bucket string, lifecycle *pb.BucketLifecycle) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = pdb.client.SetLifecycle(ctx, &pb.SetLifecycleRequest{
+		Bucket:    bucket,
+		Lifecycle: lifecycle,
+	})
+
+	return err
+}
+
+// GetLifecycle returns the lifecycle rules of a bucket
+func (pdb *PointerDB) GetLifecycle(ctx context.Context, bucket string) (lifecycle *pb.BucketLifecycle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.GetLifecycle(ctx, &pb.GetLifecycleRequest{Bucket: bucket})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetLifecycle(), nil
+}
+
+// UpdateMetadata replaces the metadata, unless metadata is empty, and the
+// expiration of the pointer under path
+func (pdb *PointerDB) UpdateMetadata(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	_, err = pdb.client.Delete(ctx, &pb.DeleteRequest{Path: path})
+	var exp *timestamp.Timestamp
+	if !expiration.IsZero() {
+		exp, err = ptypes.TimestampProto(expiration)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = pdb.client.UpdateMetadata(ctx, &pb.UpdateMetadataRequest{
+		Path:           path,
+		Metadata:       metadata,
+		ExpirationDate: exp,
+	})
+
+	return err
+}
+
+// ReportCorruption reports that the node storing the piece pieceNum of the
+// segment under path returned a piece not matching its hash
+func (pdb *PointerDB) ReportCorruption(ctx context.Context, path storj.Path, pieceNum int32, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = pdb.client.ReportCorruption(ctx, &pb.ReportCorruptionRequest{
+		Path:     path,
+		PieceNum: pieceNum,
+		NodeId:   nodeID,
+	})
 
 	return err
 }
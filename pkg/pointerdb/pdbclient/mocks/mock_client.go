@@ -7,11 +7,13 @@ package mock_pointerdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
 	pb "storj.io/storj/pkg/pb"
 	pdbclient "storj.io/storj/pkg/pointerdb/pdbclient"
+	storj "storj.io/storj/pkg/storj"
 )
 
 // MockClient is a mock of Client interface
@@ -37,13 +39,26 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
-// Delete mocks base method
-func (m *MockClient) Delete(arg0 context.Context, arg1 string) error {
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+// Copy mocks base method
+func (m *MockClient) Copy(arg0 context.Context, arg1, arg2 string, arg3 []byte) error {
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Copy indicates an expected call of Copy
+func (mr *MockClientMockRecorder) Copy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockClient)(nil).Copy), arg0, arg1, arg2, arg3)
+}
+
+// Delete mocks base method
+func (m *MockClient) Delete(arg0 context.Context, arg1 string) (*pb.Pointer, error) {
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*pb.Pointer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Delete indicates an expected call of Delete
 func (mr *MockClientMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClient)(nil).Delete), arg0, arg1)
@@ -64,6 +79,19 @@ func (mr *MockClientMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), arg0, arg1)
 }
 
+// GetLifecycle mocks base method
+func (m *MockClient) GetLifecycle(arg0 context.Context, arg1 string) (*pb.BucketLifecycle, error) {
+	ret := m.ctrl.Call(m, "GetLifecycle", arg0, arg1)
+	ret0, _ := ret[0].(*pb.BucketLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLifecycle indicates an expected call of GetLifecycle
+func (mr *MockClientMockRecorder) GetLifecycle(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLifecycle", reflect.TypeOf((*MockClient)(nil).GetLifecycle), arg0, arg1)
+}
+
 // List mocks base method
 func (m *MockClient) List(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool, arg5 int, arg6 uint32) ([]pdbclient.ListItem, bool, error) {
 	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
@@ -103,6 +131,30 @@ func (mr *MockClientMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockClient)(nil).Put), arg0, arg1, arg2)
 }
 
+// ReportCorruption mocks base method
+func (m *MockClient) ReportCorruption(arg0 context.Context, arg1 string, arg2 int32, arg3 storj.NodeID) error {
+	ret := m.ctrl.Call(m, "ReportCorruption", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportCorruption indicates an expected call of ReportCorruption
+func (mr *MockClientMockRecorder) ReportCorruption(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportCorruption", reflect.TypeOf((*MockClient)(nil).ReportCorruption), arg0, arg1, arg2, arg3)
+}
+
+// SetLifecycle mocks base method
+func (m *MockClient) SetLifecycle(arg0 context.Context, arg1 string, arg2 *pb.BucketLifecycle) error {
+	ret := m.ctrl.Call(m, "SetLifecycle", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLifecycle indicates an expected call of SetLifecycle
+func (mr *MockClientMockRecorder) SetLifecycle(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLifecycle", reflect.TypeOf((*MockClient)(nil).SetLifecycle), arg0, arg1, arg2)
+}
+
 // SignedMessage mocks base method
 func (m *MockClient) SignedMessage() *pb.SignedMessage {
 	ret := m.ctrl.Call(m, "SignedMessage")
@@ -114,3 +166,15 @@ func (m *MockClient) SignedMessage() *pb.SignedMessage {
 func (mr *MockClientMockRecorder) SignedMessage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedMessage", reflect.TypeOf((*MockClient)(nil).SignedMessage))
 }
+
+// UpdateMetadata mocks base method
+func (m *MockClient) UpdateMetadata(arg0 context.Context, arg1 string, arg2 []byte, arg3 time.Time) error {
+	ret := m.ctrl.Call(m, "UpdateMetadata", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata
+func (mr *MockClientMockRecorder) UpdateMetadata(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockClient)(nil).UpdateMetadata), arg0, arg1, arg2, arg3)
+}
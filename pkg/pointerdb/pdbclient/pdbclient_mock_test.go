@@ -40,6 +40,24 @@ func (m *MockPointerDBClient) EXPECT() *MockPointerDBClientMockRecorder {
 	return m.recorder
 }
 
+// Copy mocks base method
+func (m *MockPointerDBClient) Copy(arg0 context.Context, arg1 *pb.CopyRequest, arg2 ...grpc.CallOption) (*pb.CopyResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Copy", varargs...)
+	ret0, _ := ret[0].(*pb.CopyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy
+func (mr *MockPointerDBClientMockRecorder) Copy(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockPointerDBClient)(nil).Copy), varargs...)
+}
+
 // Delete mocks base method
 func (m *MockPointerDBClient) Delete(arg0 context.Context, arg1 *pb.DeleteRequest, arg2 ...grpc.CallOption) (*pb.DeleteResponse, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -76,6 +94,24 @@ func (mr *MockPointerDBClientMockRecorder) Get(arg0, arg1 interface{}, arg2 ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPointerDBClient)(nil).Get), varargs...)
 }
 
+// GetLifecycle mocks base method
+func (m *MockPointerDBClient) GetLifecycle(arg0 context.Context, arg1 *pb.GetLifecycleRequest, arg2 ...grpc.CallOption) (*pb.GetLifecycleResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLifecycle", varargs...)
+	ret0, _ := ret[0].(*pb.GetLifecycleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLifecycle indicates an expected call of GetLifecycle
+func (mr *MockPointerDBClientMockRecorder) GetLifecycle(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLifecycle", reflect.TypeOf((*MockPointerDBClient)(nil).GetLifecycle), varargs...)
+}
+
 // List mocks base method
 func (m *MockPointerDBClient) List(arg0 context.Context, arg1 *pb.ListRequest, arg2 ...grpc.CallOption) (*pb.ListResponse, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -129,3 +165,57 @@ func (mr *MockPointerDBClientMockRecorder) Put(arg0, arg1 interface{}, arg2 ...i
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPointerDBClient)(nil).Put), varargs...)
 }
+
+// ReportCorruption mocks base method
+func (m *MockPointerDBClient) ReportCorruption(arg0 context.Context, arg1 *pb.ReportCorruptionRequest, arg2 ...grpc.CallOption) (*pb.ReportCorruptionResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReportCorruption", varargs...)
+	ret0, _ := ret[0].(*pb.ReportCorruptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportCorruption indicates an expected call of ReportCorruption
+func (mr *MockPointerDBClientMockRecorder) ReportCorruption(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportCorruption", reflect.TypeOf((*MockPointerDBClient)(nil).ReportCorruption), varargs...)
+}
+
+// SetLifecycle mocks base method
+func (m *MockPointerDBClient) SetLifecycle(arg0 context.Context, arg1 *pb.SetLifecycleRequest, arg2 ...grpc.CallOption) (*pb.SetLifecycleResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetLifecycle", varargs...)
+	ret0, _ := ret[0].(*pb.SetLifecycleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLifecycle indicates an expected call of SetLifecycle
+func (mr *MockPointerDBClientMockRecorder) SetLifecycle(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLifecycle", reflect.TypeOf((*MockPointerDBClient)(nil).SetLifecycle), varargs...)
+}
+
+// UpdateMetadata mocks base method
+func (m *MockPointerDBClient) UpdateMetadata(arg0 context.Context, arg1 *pb.UpdateMetadataRequest, arg2 ...grpc.CallOption) (*pb.UpdateMetadataResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMetadata", varargs...)
+	ret0, _ := ret[0].(*pb.UpdateMetadataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata
+func (mr *MockPointerDBClientMockRecorder) UpdateMetadata(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockPointerDBClient)(nil).UpdateMetadata), varargs...)
+}
@@ -5,7 +5,10 @@ package pointerdb
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -13,10 +16,12 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/datarepair/queue"
 	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/macaroon"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
-	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
 
@@ -25,57 +30,248 @@ var (
 	segmentError = errs.Class("segment error")
 )
 
+// APIKeys is the store of the root secrets of the api keys
+type APIKeys interface {
+	// GetSecret retrieves the root secret of the macaroon of the api key with given ID and the project of the key
+	GetSecret(ctx context.Context, id uuid.UUID) (secret []byte, projectID uuid.UUID, err error)
+}
+
 // Server implements the network state RPC service
 type Server struct {
-	logger     *zap.Logger
-	service    *Service
-	allocation *AllocationSigner
-	cache      *overlay.Cache
-	config     Config
-	identity   *identity.FullIdentity
+	logger      *zap.Logger
+	service     *Service
+	allocation  *AllocationSigner
+	cache       *overlay.Cache
+	config      Config
+	identity    *identity.FullIdentity
+	apiKeys     APIKeys
+	limiter     *Limiter
+	repairQueue queue.RepairQueue
+	pieces      PieceVerifier
 }
 
-// NewServer creates instance of Server
-func NewServer(logger *zap.Logger, service *Service, allocation *AllocationSigner, cache *overlay.Cache, config Config, identity *identity.FullIdentity) *Server {
+// NewServer creates instance of Server, the limits of the projects aren't
+// enforced when limiter is nil, and the segments with reported corrupted
+// pieces aren't queued for repair when repairQueue is nil
+func NewServer(logger *zap.Logger, service *Service, allocation *AllocationSigner, cache *overlay.Cache, config Config, identity *identity.FullIdentity, apiKeys APIKeys, limiter *Limiter, repairQueue queue.RepairQueue) *Server {
 	return &Server{
-		logger:     logger,
-		service:    service,
-		allocation: allocation,
-		cache:      cache,
-		config:     config,
-		identity:   identity,
+		logger:      logger,
+		service:     service,
+		allocation:  allocation,
+		cache:       cache,
+		config:      config,
+		identity:    identity,
+		apiKeys:     apiKeys,
+		limiter:     limiter,
+		repairQueue: repairQueue,
+		pieces:      NewPieceVerifier(cache, allocation, identity),
 	}
 }
 
 // Close closes resources
 func (s *Server) Close() error { return nil }
 
-// TODO: ZZZ temporarily disabled until endpoint and service split
-const disableAuth = true
+// validateAuth checks that the api key of the request allows action and
+// returns the project of the key. The project is nil when api keys aren't
+// required and for requests of the satellite itself.
+func (s *Server) validateAuth(ctx context.Context, action macaroon.Action) (project *uuid.UUID, err error) {
+	if !s.config.Auth || s.isSatellite(ctx) {
+		return nil, nil
+	}
 
-func (s *Server) validateAuth(ctx context.Context) error {
-	// TODO: ZZZ temporarily disabled until endpoint and service split
-	if disableAuth {
-		return nil
+	key, secret, project, err := s.getAPIKey(ctx)
+	if err == nil {
+		err = key.Check(secret, action)
+	}
+	if err != nil {
+		return nil, s.unauthenticated(err)
+	}
+	return project, nil
+}
+
+// validateOperation checks that the api key of the request allows the
+// operation of action, regardless of the path, and returns the project
+// of the key like validateAuth
+func (s *Server) validateOperation(ctx context.Context, action macaroon.Action) (project *uuid.UUID, err error) {
+	if !s.config.Auth || s.isSatellite(ctx) {
+		return nil, nil
+	}
+
+	key, secret, project, err := s.getAPIKey(ctx)
+	if err == nil {
+		err = key.CheckOperation(secret, action)
+	}
+	if err != nil {
+		return nil, s.unauthenticated(err)
+	}
+	return project, nil
+}
+
+// isSatellite returns whether the request comes from the satellite itself,
+// like the requests of the repairer
+func (s *Server) isSatellite(ctx context.Context) bool {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	return peer.ID == s.identity.ID
+}
+
+// getAPIKey parses the api key of the request and retrieves its root secret
+// and project
+func (s *Server) getAPIKey(ctx context.Context) (key *macaroon.APIKey, secret []byte, project *uuid.UUID, err error) {
+	keyData, ok := auth.GetAPIKey(ctx)
+	if !ok {
+		return nil, nil, nil, macaroon.ErrUnauthorized.New("no api key was provided")
+	}
+
+	key, err = macaroon.ParseAPIKey(string(keyData))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	head := key.Head()
+	if len(head) != len(uuid.UUID{}) {
+		return nil, nil, nil, macaroon.ErrFormat.New("invalid head")
+	}
+
+	var id uuid.UUID
+	copy(id[:], head)
+
+	secret, projectID, err := s.apiKeys.GetSecret(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return key, secret, &projectID, nil
+}
+
+func (s *Server) unauthenticated(err error) error {
+	s.logger.Error("unauthorized request: ", zap.Error(err))
+	return status.Errorf(codes.Unauthenticated, "Invalid API credential")
+}
+
+// limitError converts an error of checking the limits of a project
+func (s *Server) limitError(err error) error {
+	if ErrLimitExceeded.Has(err) {
+		return status.Errorf(codes.ResourceExhausted, err.Error())
+	}
+	s.logger.Error("err checking project limits", zap.Error(err))
+	return status.Errorf(codes.Internal, err.Error())
+}
+
+// putError converts an error of putting a pointer
+func (s *Server) putError(err error) error {
+	if storage.ErrKeyNotFound.Has(err) {
+		return status.Errorf(codes.NotFound, err.Error())
 	}
+	s.logger.Error("err putting pointer", zap.Error(err))
+	return status.Errorf(codes.Internal, err.Error())
+}
+
+// isReserved returns whether path is under one of the prefixes of the entries
+// kept by pointerdb itself, like the bucket lifecycle rules and the entries of
+// the deduplicated segments
+func isReserved(path string) bool {
+	root := strings.SplitN(path, "/", 2)[0] + "/"
+	return root == LifecyclePrefix || root == DedupPrefix
+}
+
+// validatePaths rejects the requests for reserved paths, which only the
+// satellite itself may access
+func (s *Server) validatePaths(ctx context.Context, paths ...string) error {
+	for _, path := range paths {
+		if isReserved(path) && !s.isSatellite(ctx) {
+			return status.Errorf(codes.InvalidArgument, "path %q is reserved", path)
+		}
+	}
+	return nil
+}
 
-	APIKey, ok := auth.GetAPIKey(ctx)
-	if !ok || !pointerdbAuth.ValidateAPIKey(string(APIKey)) {
-		s.logger.Error("unauthorized request: ", zap.Error(status.Errorf(codes.Unauthenticated, "Invalid API credential")))
-		return status.Errorf(codes.Unauthenticated, "Invalid API credential")
+// validateBuckets rejects the requests putting the entries of buckets named
+// like the pseudo-buckets of the multipart uploads, which are in the form of
+// <segment>/<bucket>
+func validateBuckets(paths ...string) error {
+	for _, path := range paths {
+		parts := strings.Split(path, "/")
+		if len(parts) == 2 && storj.IsMultipartBucket(parts[1]) {
+			return status.Errorf(codes.InvalidArgument, "bucket name %q is reserved", parts[1])
+		}
 	}
 	return nil
 }
 
-func (s *Server) validateSegment(req *pb.PutRequest) error {
+// pathAction returns the action of op on a pointerdb path, which is in the
+// form of <segment>/<bucket>/<encrypted path>. The actions on the multipart
+// uploads of a bucket are actions on the bucket.
+func pathAction(op macaroon.ActionType, path string) macaroon.Action {
+	action := macaroon.Action{Op: op, Time: time.Now()}
+
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) > 1 {
+		action.Bucket = storj.MultipartBucket(parts[1])
+	}
+	if len(parts) > 2 {
+		action.EncryptedPath = parts[2]
+	}
+
+	return action
+}
+
+// projectPath namespaces a pointerdb path with project, by inserting the
+// project ID right after the segment, so that the buckets of a project are
+// stored as <segment>/<project id>/<bucket>/<encrypted path>
+func projectPath(project *uuid.UUID, path string) string {
+	if project == nil {
+		return path
+	}
+
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) == 1 {
+		return storj.JoinPaths(path, project.String())
+	}
+	return storj.JoinPaths(parts[0], project.String(), parts[1])
+}
+
+// projectBucket namespaces a bucket name with project like projectPath
+func projectBucket(project *uuid.UUID, bucket string) string {
+	if project == nil {
+		return bucket
+	}
+	return storj.JoinPaths(project.String(), bucket)
+}
+
+func (s *Server) validateSegment(ctx context.Context, req *pb.PutRequest) error {
 	min := s.config.MinRemoteSegmentSize
 	remote := req.GetPointer().Remote
 	remoteSize := req.GetPointer().GetSegmentSize()
 
+	// the references to deduplicated segments have neither pieces nor size
+	if len(remote.GetDedupId()) > 0 && len(remote.GetRemotePieces()) == 0 {
+		remote = nil
+	}
+
 	if remote != nil && remoteSize < int64(min) {
 		return segmentError.New("remote segment size %d less than minimum allowed %d", remoteSize, min)
 	}
 
+	if max := s.config.MaxRemoteSegmentSize; remote != nil && max > 0 && remoteSize > int64(max) {
+		return segmentError.New("remote segment size %d greater than maximum allowed %d", remoteSize, max)
+	}
+
+	if remote != nil {
+		if err := s.validateRedundancy(ctx, remote.GetRedundancy()); err != nil {
+			return err
+		}
+
+		// the segments must be stored on as many nodes as the upload needed
+		// to succeed, but the repairs may leave fewer pieces
+		pieces, success := len(remote.GetRemotePieces()), int(remote.GetRedundancy().GetSuccessThreshold())
+		if pieces < success && !s.isSatellite(ctx) {
+			return segmentError.New("%d pieces less than success threshold %d", pieces, success)
+		}
+	}
+
 	max := s.config.MaxInlineSegmentSize.Int()
 	inlineSize := len(req.GetPointer().InlineSegment)
 
@@ -86,22 +282,96 @@ func (s *Server) validateSegment(req *pb.PutRequest) error {
 	return nil
 }
 
+// validateRedundancy checks that the redundancy scheme of a remote segment is
+// consistent and within the configured bounds
+func (s *Server) validateRedundancy(ctx context.Context, rs *pb.RedundancyScheme) error {
+	required, repair, total := int(rs.GetMinReq()), int(rs.GetRepairThreshold()), int(rs.GetTotal())
+	success := int(rs.GetSuccessThreshold())
+
+	if required <= 0 || repair < required || success < repair || total < success {
+		return segmentError.New("invalid redundancy scheme %d/%d/%d/%d", required, repair, success, total)
+	}
+
+	// the segments repaired by the satellite keep the redundancy they were
+	// uploaded with, even if it's out of the bounds configured since
+	if s.isSatellite(ctx) {
+		return nil
+	}
+
+	for _, bound := range []struct {
+		name     string
+		value    int
+		min, max int
+	}{
+		{"required pieces", required, s.config.MinRequired, s.config.MaxRequired},
+		{"repair threshold", repair, s.config.MinRepairThreshold, s.config.MaxRepairThreshold},
+		{"total pieces", total, s.config.MinTotal, s.config.MaxTotal},
+	} {
+		if bound.value < bound.min {
+			return segmentError.New("%s %d less than minimum allowed %d", bound.name, bound.value, bound.min)
+		}
+		if bound.max > 0 && bound.value > bound.max {
+			return segmentError.New("%s %d greater than maximum allowed %d", bound.name, bound.value, bound.max)
+		}
+	}
+
+	return nil
+}
+
 // Put formats and hands off a key/value (path/pointer) to be saved to boltdb
 func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	err = s.validateSegment(req)
+	if err = s.validatePaths(ctx, req.GetPath()); err != nil {
+		return nil, err
+	}
+	if err = validateBuckets(req.GetPath()); err != nil {
+		return nil, err
+	}
+
+	// the segment is validated for authenticated requests only
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionWrite, req.GetPath()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateSegment(ctx, req)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
-	if err = s.validateAuth(ctx); err != nil {
-		return nil, err
+	// the requests of the satellite itself, like the ones of the repairer,
+	// put the entries of the deduplicated segments as they are
+	path := projectPath(project, req.GetPath())
+	deduplicated := len(req.GetPointer().GetRemote().GetDedupId()) > 0 && !s.isSatellite(ctx)
+
+	size := req.GetPointer().GetSegmentSize()
+	if deduplicated {
+		// the references to deduplicated segments count with the size of the
+		// segments for the storage limit
+		size, err = s.service.SegmentSize(path, req.GetPointer())
+		if err != nil {
+			return nil, s.putError(err)
+		}
 	}
 
-	if err = s.service.Put(req.GetPath(), req.GetPointer()); err != nil {
-		s.logger.Error("err putting pointer", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+	if project != nil && s.limiter != nil {
+		if err = s.limiter.CheckStorage(ctx, *project, size); err != nil {
+			return nil, s.limitError(err)
+		}
+	}
+
+	if deduplicated {
+		err = s.service.PutDeduplicated(path, req.GetPointer())
+	} else {
+		err = s.service.Put(path, req.GetPointer())
+	}
+	if err != nil {
+		return nil, s.putError(err)
+	}
+
+	if project != nil && s.limiter != nil {
+		s.limiter.AddStorage(*project, size)
 	}
 
 	return &pb.PutResponse{}, nil
@@ -111,11 +381,21 @@ func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutRespo
 func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validatePaths(ctx, req.GetPath()); err != nil {
+		return nil, err
+	}
+
+	action := pathAction(macaroon.ActionRead, req.GetPath())
+	project, err := s.validateAuth(ctx, action)
+	if err != nil {
 		return nil, err
 	}
 
-	pointer, err := s.service.Get(req.GetPath())
+	path := projectPath(project, req.GetPath())
+	pointer, err := s.service.Get(path)
+	if err == nil {
+		err = s.service.Resolve(path, pointer)
+	}
 	if err != nil {
 		if storage.ErrKeyNotFound.Has(err) {
 			return nil, status.Errorf(codes.NotFound, err.Error())
@@ -124,12 +404,19 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	pba, err := s.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: pb.BandwidthAction_GET})
+	pba, err := s.payerBandwidthAllocation(ctx, project, action.Bucket, pb.BandwidthAction_GET)
 	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			return nil, err
+		}
 		s.logger.Error("err getting payer bandwidth allocation", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	if project != nil && s.limiter != nil {
+		s.limiter.AddEgress(*project, pointer.GetSegmentSize())
+	}
+
 	authorization, err := s.getSignedMessage()
 	if err != nil {
 		s.logger.Error("err getting signed message", zap.Error(err))
@@ -141,7 +428,7 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 	var r = &pb.GetResponse{
 		Pointer:       pointer,
 		Nodes:         nil,
-		Pba:           pba.GetPba(),
+		Pba:           pba,
 		Authorization: authorization,
 	}
 
@@ -163,7 +450,7 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 	r = &pb.GetResponse{
 		Pointer:       pointer,
 		Nodes:         nodes,
-		Pba:           pba.GetPba(),
+		Pba:           pba,
 		Authorization: authorization,
 	}
 
@@ -174,15 +461,31 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validatePaths(ctx, req.GetPrefix()); err != nil {
 		return nil, err
 	}
 
-	items, more, err := s.service.List(req.Prefix, req.StartAfter, req.EndBefore, req.Recursive, req.Limit, req.MetaFlags)
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionList, req.GetPrefix()))
+	if err != nil {
+		return nil, err
+	}
+
+	items, more, err := s.service.List(projectPath(project, req.Prefix), req.StartAfter, req.EndBefore, req.Recursive, req.Limit, req.MetaFlags)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "ListV2: %v", err)
 	}
 
+	// the listings of the whole database leave out the reserved paths
+	if project == nil && req.GetPrefix() == "" && !s.isSatellite(ctx) {
+		listed := items[:0]
+		for _, item := range items {
+			if !isReserved(item.GetPath()) {
+				listed = append(listed, item)
+			}
+		}
+		items = listed
+	}
+
 	return &pb.ListResponse{Items: items, More: more}, nil
 }
 
@@ -190,38 +493,295 @@ func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListRe
 func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (resp *pb.DeleteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validatePaths(ctx, req.GetPath()); err != nil {
 		return nil, err
 	}
 
-	err = s.service.Delete(req.GetPath())
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionDelete, req.GetPath()))
+	if err != nil {
+		return nil, err
+	}
+
+	released, err := s.service.Unlink(projectPath(project, req.GetPath()))
 	if err != nil {
 		s.logger.Error("err deleting path and pointer", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	return &pb.DeleteResponse{}, nil
+	return &pb.DeleteResponse{Released: released}, nil
+}
+
+// Copy duplicates a pointer under a new path without copying the data
+func (s *Server) Copy(ctx context.Context, req *pb.CopyRequest) (resp *pb.CopyResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validatePaths(ctx, req.GetSourcePath(), req.GetDestinationPath()); err != nil {
+		return nil, err
+	}
+	if err = validateBuckets(req.GetDestinationPath()); err != nil {
+		return nil, err
+	}
+
+	if _, err = s.validateAuth(ctx, pathAction(macaroon.ActionRead, req.GetSourcePath())); err != nil {
+		return nil, err
+	}
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionWrite, req.GetDestinationPath()))
+	if err != nil {
+		return nil, err
+	}
+
+	source := projectPath(project, req.GetSourcePath())
+	pointer, err := s.service.Get(source)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("err getting pointer", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	// the copy is accounted like an uploaded segment
+	size := pointer.GetSegmentSize()
+	if project != nil && s.limiter != nil {
+		if err = s.limiter.CheckStorage(ctx, *project, size); err != nil {
+			return nil, s.limitError(err)
+		}
+	}
+
+	err = s.service.Copy(source, projectPath(project, req.GetDestinationPath()), req.GetMetadata())
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("err copying pointer", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	if project != nil && s.limiter != nil {
+		s.limiter.AddStorage(*project, size)
+	}
+
+	return &pb.CopyResponse{}, nil
+}
+
+// UpdateMetadata replaces the metadata and expiration of a pointer without
+// changing its segment
+func (s *Server) UpdateMetadata(ctx context.Context, req *pb.UpdateMetadataRequest) (resp *pb.UpdateMetadataResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validatePaths(ctx, req.GetPath()); err != nil {
+		return nil, err
+	}
+
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionWrite, req.GetPath()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.service.UpdateMetadata(projectPath(project, req.GetPath()), req.GetMetadata(), req.GetExpirationDate())
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		if storj.ErrExpiration.Has(err) {
+			return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.Error("err updating pointer metadata", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.UpdateMetadataResponse{}, nil
+}
+
+// ReportCorruption queues the segment under the path of the request for the
+// repair of the piece which didn't match its hash when downloaded
+func (s *Server) ReportCorruption(ctx context.Context, req *pb.ReportCorruptionRequest) (resp *pb.ReportCorruptionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validatePaths(ctx, req.GetPath()); err != nil {
+		return nil, err
+	}
+
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionRead, req.GetPath()))
+	if err != nil {
+		return nil, err
+	}
+
+	path := projectPath(project, req.GetPath())
+	pointer, err := s.service.Get(path)
+
+	// the pieces of a deduplicated segment are kept by its entry
+	dedupID := pointer.GetRemote().GetDedupId()
+	if err == nil && len(dedupID) > 0 && len(pointer.GetRemote().GetRemotePieces()) == 0 {
+		path, err = s.service.dedupPath(path, dedupID)
+		if err == nil {
+			pointer, err = s.service.Get(path)
+		}
+	}
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("err getting pointer", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	piece := findPiece(pointer, req.GetPieceNum(), req.NodeId)
+	if piece == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "node %s doesn't store piece %d of the segment", req.NodeId, req.GetPieceNum())
+	}
+
+	// the claim is only trusted if the piece doesn't match the hash the node
+	// signed when storing it
+	if piece.GetSignedHash() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "piece %d of the segment has no signed hash to verify", req.GetPieceNum())
+	}
+	corrupted, err := s.pieces.Corrupted(ctx, pointer, piece)
+	if err != nil {
+		s.logger.Error("err verifying reported piece", zap.Error(err))
+		return nil, status.Errorf(codes.Unavailable, err.Error())
+	}
+	if !corrupted {
+		return nil, status.Errorf(codes.InvalidArgument, "piece %d of the segment matches its signed hash", req.GetPieceNum())
+	}
+
+	s.logger.Warn("corrupted piece reported", zap.String("path", path), zap.Int32("piece", req.GetPieceNum()), zap.Stringer("node", req.NodeId))
+
+	if s.repairQueue == nil {
+		return &pb.ReportCorruptionResponse{}, nil
+	}
+
+	err = s.repairQueue.Enqueue(ctx, &pb.InjuredSegment{
+		Path:       path,
+		LostPieces: []int32{req.GetPieceNum()},
+	})
+	if err != nil {
+		s.logger.Error("err queueing segment for repair", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.ReportCorruptionResponse{}, nil
+}
+
+// findPiece returns the piece pieceNum of the remote segment of pointer
+// stored on the node nodeID, if any
+func findPiece(pointer *pb.Pointer, pieceNum int32, nodeID storj.NodeID) *pb.RemotePiece {
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.GetPieceNum() == pieceNum && piece.NodeId == nodeID {
+			return piece
+		}
+	}
+	return nil
+}
+
+// SetLifecycle replaces the lifecycle rules of a bucket
+func (s *Server) SetLifecycle(ctx context.Context, req *pb.SetLifecycleRequest) (resp *pb.SetLifecycleResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	project, err := s.validateAuth(ctx, macaroon.Action{Op: macaroon.ActionWrite, Bucket: req.GetBucket(), Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateLifecycle(req.GetBucket(), req.GetLifecycle()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.service.SetLifecycle(projectBucket(project, req.GetBucket()), req.GetLifecycle())
+	if err != nil {
+		s.logger.Error("err setting lifecycle", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.SetLifecycleResponse{}, nil
+}
+
+// GetLifecycle returns the lifecycle rules of a bucket
+func (s *Server) GetLifecycle(ctx context.Context, req *pb.GetLifecycleRequest) (resp *pb.GetLifecycleResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	project, err := s.validateAuth(ctx, macaroon.Action{Op: macaroon.ActionRead, Bucket: req.GetBucket(), Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetBucket() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "no bucket specified")
+	}
+
+	lifecycle, err := s.service.GetLifecycle(projectBucket(project, req.GetBucket()))
+	if err != nil {
+		s.logger.Error("err getting lifecycle", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.GetLifecycleResponse{Lifecycle: lifecycle}, nil
+}
+
+func validateLifecycle(bucket string, lifecycle *pb.BucketLifecycle) error {
+	if bucket == "" || strings.Contains(bucket, "/") {
+		return Error.New("invalid bucket name %q", bucket)
+	}
+
+	for _, rule := range lifecycle.GetRules() {
+		if rule.ExpirationDays < 0 || rule.AbortPendingDays < 0 {
+			return Error.New("lifecycle rule %q has negative days", rule.Id)
+		}
+		if rule.ExpirationDays == 0 && rule.AbortPendingDays == 0 {
+			return Error.New("lifecycle rule %q has no action", rule.Id)
+		}
+	}
+
+	return nil
 }
 
 // Iterate iterates over items based on IterateRequest
 func (s *Server) Iterate(ctx context.Context, req *pb.IterateRequest, f func(it storage.Iterator) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	project, err := s.validateAuth(ctx, pathAction(macaroon.ActionList, req.Prefix))
+	if err != nil {
 		return err
 	}
 
-	return s.service.Iterate(req.Prefix, req.First, req.Recurse, req.Reverse, f)
+	return s.service.Iterate(projectPath(project, req.Prefix), req.First, req.Recurse, req.Reverse, f)
 }
 
 // PayerBandwidthAllocation returns PayerBandwidthAllocation struct, signed and with given action type
 func (s *Server) PayerBandwidthAllocation(ctx context.Context, req *pb.PayerBandwidthAllocationRequest) (res *pb.PayerBandwidthAllocationResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	// the allocations for repairs and audits are neither limited nor billed
+	// to the projects, so only the satellite may get them
+	if isSatelliteAction(req.GetAction()) && !s.isSatellite(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the satellite may get allocations for %s", req.GetAction())
+	}
+
+	project, err := s.validateOperation(ctx, macaroon.Action{Op: bandwidthActionType(req.GetAction()), Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+
+	pba, err := s.payerBandwidthAllocation(ctx, project, "", req.GetAction())
+	if err != nil {
 		return nil, err
 	}
 
+	return &pb.PayerBandwidthAllocationResponse{Pba: pba}, nil
+}
+
+// payerBandwidthAllocation checks the limits of project and signs a bandwidth
+// allocation for action on bucket of the project
+func (s *Server) payerBandwidthAllocation(ctx context.Context, project *uuid.UUID, bucket string, action pb.BandwidthAction) (pba *pb.PayerBandwidthAllocation, err error) {
+	var projectID []byte
+	if project != nil {
+		projectID = project[:]
+
+		if err = s.checkLimits(ctx, *project, action); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO(michal) should be replaced with renter id when available
 	// retrieve the public key
 	pi, err := identity.PeerIdentityFromContext(ctx)
@@ -229,12 +789,52 @@ func (s *Server) PayerBandwidthAllocation(ctx context.Context, req *pb.PayerBand
 		return nil, err
 	}
 
-	pba, err := s.allocation.PayerBandwidthAllocation(ctx, pi, req.GetAction())
+	pba, err = s.allocation.PayerBandwidthAllocation(ctx, pi, projectID, bucket, action)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	return &pb.PayerBandwidthAllocationResponse{Pba: pba}, nil
+	return pba, nil
+}
+
+// checkLimits checks that project didn't reach the limit of the usage of
+// a bandwidth allocation for action
+func (s *Server) checkLimits(ctx context.Context, project uuid.UUID, action pb.BandwidthAction) (err error) {
+	if s.limiter == nil {
+		return nil
+	}
+
+	switch action {
+	case pb.BandwidthAction_PUT:
+		err = s.limiter.CheckStorage(ctx, project, 0)
+	case pb.BandwidthAction_GET:
+		err = s.limiter.CheckEgress(ctx, project)
+	}
+	if err != nil {
+		return s.limitError(err)
+	}
+	return nil
+}
+
+// isSatelliteAction returns whether a bandwidth allocation for action is for
+// the satellite itself
+func isSatelliteAction(action pb.BandwidthAction) bool {
+	switch action {
+	case pb.BandwidthAction_GET_REPAIR, pb.BandwidthAction_PUT_REPAIR, pb.BandwidthAction_GET_AUDIT:
+		return true
+	default:
+		return false
+	}
+}
+
+// bandwidthActionType returns the operation a bandwidth allocation is for
+func bandwidthActionType(action pb.BandwidthAction) macaroon.ActionType {
+	switch action {
+	case pb.BandwidthAction_PUT, pb.BandwidthAction_PUT_REPAIR:
+		return macaroon.ActionWrite
+	default:
+		return macaroon.ActionRead
+	}
 }
 
 func (s *Server) getSignedMessage() (*pb.SignedMessage, error) {
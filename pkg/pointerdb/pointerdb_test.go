@@ -10,11 +10,15 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -22,9 +26,15 @@ import (
 	"google.golang.org/grpc/status"
 
 	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/macaroon"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/teststore"
 )
@@ -66,6 +76,117 @@ func TestServicePut(t *testing.T) {
 	}
 }
 
+func TestValidateSegment(t *testing.T) {
+	s := Server{config: Config{
+		MinRemoteSegmentSize: 1024,
+		MaxRemoteSegmentSize: 4096,
+		MaxInlineSegmentSize: 8000,
+		MinRequired:          2,
+		MaxRequired:          4,
+		MinRepairThreshold:   3,
+		MinTotal:             4,
+		MaxTotal:             8,
+	}}
+
+	remote := func(size int64, required, repair, total int32) *pb.Pointer {
+		return &pb.Pointer{
+			Type:        pb.Pointer_REMOTE,
+			SegmentSize: size,
+			Remote: &pb.RemoteSegment{
+				Redundancy:   &pb.RedundancyScheme{MinReq: required, RepairThreshold: repair, SuccessThreshold: repair, Total: total},
+				RemotePieces: []*pb.RemotePiece{{PieceNum: 0}},
+			},
+		}
+	}
+	// stored adds pieces to the pointer up to its success threshold
+	stored := func(pointer *pb.Pointer) *pb.Pointer {
+		remote := pointer.Remote
+		for i := len(remote.RemotePieces); i < int(remote.Redundancy.SuccessThreshold); i++ {
+			remote.RemotePieces = append(remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i)})
+		}
+		return pointer
+	}
+	successThreshold := func(pointer *pb.Pointer, success int32) *pb.Pointer {
+		pointer.Remote.Redundancy.SuccessThreshold = success
+		return pointer
+	}
+
+	for i, tt := range []struct {
+		pointer *pb.Pointer
+		valid   bool
+	}{
+		{&pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: make([]byte, 100)}, true},
+		{&pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: make([]byte, 8001)}, false},
+		{remote(2048, 2, 3, 4), false},
+		{stored(remote(2048, 2, 3, 4)), true},
+		{stored(remote(2048, 4, 6, 8)), true},
+		{stored(successThreshold(remote(2048, 2, 3, 4), 4)), true},
+		{successThreshold(stored(remote(2048, 2, 3, 4)), 4), false},
+		{stored(successThreshold(remote(2048, 2, 3, 4), 2)), false},
+		{stored(successThreshold(remote(2048, 2, 3, 4), 5)), false},
+		{remote(512, 2, 3, 4), false},
+		{remote(8192, 2, 3, 4), false},
+		{remote(2048, 1, 3, 4), false},
+		{remote(2048, 5, 6, 8), false},
+		{remote(2048, 2, 2, 4), false},
+		{remote(2048, 2, 3, 3), false},
+		{remote(2048, 2, 3, 10), false},
+		{remote(2048, 3, 2, 4), false},
+		{remote(2048, 0, 0, 0), false},
+		{&pb.Pointer{Type: pb.Pointer_REMOTE, Remote: &pb.RemoteSegment{DedupId: []byte{1}}}, true},
+	} {
+		err := s.validateSegment(context.Background(), &pb.PutRequest{Path: "a/b/c", Pointer: tt.pointer})
+		if tt.valid {
+			assert.NoError(t, err, fmt.Sprintf("Test case #%d", i))
+		} else {
+			assert.True(t, segmentError.Has(err), fmt.Sprintf("Test case #%d", i))
+		}
+	}
+}
+
+func TestRepairedSegment(t *testing.T) {
+	ctx := context.Background()
+	ca, err := testidentity.NewTestCA(ctx)
+	require.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	require.NoError(t, err)
+
+	service := NewService(zap.NewNop(), teststore.New())
+	server := Server{service: service, logger: zap.NewNop(), identity: identity, config: Config{
+		MinRemoteSegmentSize: 1024,
+		MaxInlineSegmentSize: 8000,
+		MinRequired:          4,
+		MinTotal:             8,
+	}}
+
+	// a segment uploaded before the bounds were raised, repaired to fewer
+	// pieces than its success threshold
+	pointer := &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 2048,
+		Remote: &pb.RemoteSegment{
+			Redundancy:   &pb.RedundancyScheme{MinReq: 2, RepairThreshold: 3, SuccessThreshold: 4, Total: 5},
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 0}, {PieceNum: 1}, {PieceNum: 2}},
+		},
+	}
+
+	_, err = server.Put(ctx, &pb.PutRequest{Path: "l/bucket/path", Pointer: pointer})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.Put(peerContext(ctx, identity), &pb.PutRequest{Path: "l/bucket/path", Pointer: pointer})
+	require.NoError(t, err)
+
+	stored, err := service.Get("l/bucket/path")
+	require.NoError(t, err)
+	assert.Len(t, stored.GetRemote().GetRemotePieces(), 3)
+}
+
+// peerContext returns ctx of a request from the peer with identity
+func peerContext(ctx context.Context, peerIdentity *identity.FullIdentity) context.Context {
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{peerIdentity.Leaf, peerIdentity.CA}}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+}
+
 func TestServiceGet(t *testing.T) {
 	ctx := context.Background()
 	ca, err := testidentity.NewTestCA(ctx)
@@ -96,7 +217,7 @@ func TestServiceGet(t *testing.T) {
 		db := teststore.New()
 		service := NewService(zap.NewNop(), db)
 		allocation := NewAllocationSigner(identity, 45)
-		s := NewServer(zap.NewNop(), service, allocation, nil, Config{}, identity)
+		s := NewServer(zap.NewNop(), service, allocation, nil, Config{}, identity, nil, nil, nil)
 
 		path := "a/b/c"
 
@@ -143,8 +264,11 @@ func TestServiceDelete(t *testing.T) {
 
 		path := "a/b/c"
 
+		prBytes, err := proto.Marshal(&pb.Pointer{SegmentSize: 123})
+		require.NoError(t, err, errTag)
+
 		db := teststore.New()
-		_ = db.Put(storage.Key(path), storage.Value("hello"))
+		_ = db.Put(storage.Key(path), storage.Value(prBytes))
 		service := NewService(zap.NewNop(), db)
 		s := Server{service: service, logger: zap.NewNop()}
 
@@ -153,7 +277,7 @@ func TestServiceDelete(t *testing.T) {
 		}
 
 		req := pb.DeleteRequest{Path: path}
-		_, err := s.Delete(ctx, &req)
+		_, err = s.Delete(ctx, &req)
 
 		if err != nil {
 			assert.EqualError(t, err, tt.errString, errTag)
@@ -350,3 +474,579 @@ func TestServiceList(t *testing.T) {
 		}
 	}
 }
+
+func TestReservedPaths(t *testing.T) {
+	ctx := context.Background()
+
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	server := Server{service: service, logger: zap.NewNop()}
+
+	require.NoError(t, service.Put("l/bucket/a", &pb.Pointer{SegmentSize: 1}))
+	require.NoError(t, service.Put(DedupPrefix+"bucket/010203", &pb.Pointer{SegmentSize: 1}))
+	require.NoError(t, service.SetLifecycle("bucket", &pb.BucketLifecycle{
+		Rules: []*pb.LifecycleRule{{Id: "all", Enabled: true, ExpirationDays: 1}},
+	}))
+
+	invalid := func(err error) {
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), fmt.Sprint(err))
+	}
+
+	for _, path := range []string{LifecyclePrefix + "bucket", DedupPrefix + "bucket/010203"} {
+		_, err := server.Put(ctx, &pb.PutRequest{Path: path, Pointer: &pb.Pointer{}})
+		invalid(err)
+		_, err = server.Get(ctx, &pb.GetRequest{Path: path})
+		invalid(err)
+		_, err = server.Delete(ctx, &pb.DeleteRequest{Path: path})
+		invalid(err)
+		_, err = server.Copy(ctx, &pb.CopyRequest{SourcePath: path, DestinationPath: "l/bucket/b"})
+		invalid(err)
+		_, err = server.Copy(ctx, &pb.CopyRequest{SourcePath: "l/bucket/a", DestinationPath: path})
+		invalid(err)
+		_, err = server.List(ctx, &pb.ListRequest{Prefix: path, Recursive: true})
+		invalid(err)
+	}
+
+	// the buckets can't be named like the pseudo-buckets of the multipart
+	// uploads, which are kept in them
+	for _, bucket := range []string{storj.MultipartUploadsPrefix + "bucket", storj.MultipartPartsPrefix + "bucket"} {
+		_, err := server.Put(ctx, &pb.PutRequest{Path: "l/" + bucket, Pointer: &pb.Pointer{}})
+		invalid(err)
+		_, err = server.Copy(ctx, &pb.CopyRequest{SourcePath: "l/bucket/a", DestinationPath: "l/" + bucket})
+		invalid(err)
+	}
+
+	// the reserved entries are kept
+	_, err := service.Get(DedupPrefix + "bucket/010203")
+	assert.NoError(t, err)
+	_, err = service.Get(LifecyclePrefix + "bucket")
+	assert.NoError(t, err)
+
+	// and left out of the listings of the whole database
+	for _, recursive := range []bool{true, false} {
+		resp, err := server.List(ctx, &pb.ListRequest{Recursive: recursive})
+		require.NoError(t, err)
+
+		var paths []string
+		for _, item := range resp.GetItems() {
+			paths = append(paths, item.GetPath())
+		}
+		if recursive {
+			assert.Equal(t, []string{"l/bucket/a"}, paths)
+		} else {
+			assert.Equal(t, []string{"l/"}, paths)
+		}
+	}
+}
+
+type mockAPIKeys map[uuid.UUID][]byte
+
+func (keys mockAPIKeys) GetSecret(ctx context.Context, id uuid.UUID) ([]byte, uuid.UUID, error) {
+	secret, ok := keys[id]
+	if !ok {
+		return nil, uuid.UUID{}, errors.New("api key not found")
+	}
+	// every key is in a project with the same id as the key
+	return secret, id, nil
+}
+
+func TestValidateAuth(t *testing.T) {
+	id, err := uuid.New()
+	require.NoError(t, err)
+
+	secret := []byte("secret")
+	apiKeys := mockAPIKeys{*id: secret}
+
+	serialize := func(key *macaroon.APIKey) string {
+		serialized, err := key.Serialize()
+		require.NoError(t, err)
+		return serialized
+	}
+
+	root := macaroon.NewAPIKey(id[:], secret)
+	readOnly, err := root.Restrict(pb.Caveat{ReadOnly: true, Bucket: "bucket"})
+	require.NoError(t, err)
+	forged := macaroon.NewAPIKey(id[:], []byte("wrong secret"))
+
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	server := Server{service: service, logger: zap.NewNop(), config: Config{Auth: true}, apiKeys: apiKeys}
+
+	for i, tt := range []struct {
+		apiKey string
+		path   string
+		put    bool
+		err    bool
+	}{
+		{"", "l/bucket/path", false, true},
+		{"not a key", "l/bucket/path", false, true},
+		{serialize(forged), "l/bucket/path", false, true},
+		{serialize(root), "l/bucket/path", true, false},
+		{serialize(readOnly), "l/bucket/path", true, true},
+		{serialize(readOnly), "l/other/path", false, true},
+		{serialize(readOnly), "l/bucket/path", false, false},
+		// the multipart uploads of a bucket are restricted like the bucket
+		{serialize(readOnly), "l/" + storj.MultipartUploadsPrefix + "bucket/path", false, false},
+		{serialize(readOnly), "l/" + storj.MultipartPartsPrefix + "bucket/path", true, true},
+		{serialize(readOnly), "l/" + storj.MultipartPartsPrefix + "other/path", false, true},
+	} {
+		ctx := auth.WithAPIKey(context.Background(), []byte(tt.apiKey))
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		if tt.put {
+			_, err = server.Put(ctx, &pb.PutRequest{Path: tt.path, Pointer: &pb.Pointer{}})
+		} else {
+			_, err = server.List(ctx, &pb.ListRequest{Prefix: tt.path})
+		}
+
+		if tt.err {
+			assert.EqualError(t, err, status.Errorf(codes.Unauthenticated, "Invalid API credential").Error(), errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+		}
+	}
+
+	// invalid segments are rejected only once the request is authenticated
+	invalidSegment := &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: make([]byte, 100)}
+	server.config.MaxInlineSegmentSize = 10
+	_, err = server.Put(auth.WithAPIKey(context.Background(), nil), &pb.PutRequest{Path: "l/bucket/path", Pointer: invalidSegment})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = server.Put(auth.WithAPIKey(context.Background(), []byte(serialize(root))), &pb.PutRequest{Path: "l/bucket/path", Pointer: invalidSegment})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the pointers are namespaced by the project of the key
+	_, err = db.Get(storage.Key("l/" + id.String() + "/bucket/path"))
+	assert.NoError(t, err)
+
+	resp, err := server.List(auth.WithAPIKey(context.Background(), []byte(serialize(readOnly))), &pb.ListRequest{Prefix: "l/bucket"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "path", resp.Items[0].Path)
+}
+
+type mockLimits struct{ storage, egress int64 }
+
+func (limits mockLimits) GetLimits(ctx context.Context, id uuid.UUID) (int64, int64, error) {
+	return limits.storage, limits.egress, nil
+}
+
+type mockUsage accounting.ProjectUsage
+
+func (usage mockUsage) GetProjectUsage(ctx context.Context, id uuid.UUID) (*accounting.ProjectUsage, error) {
+	projectUsage := accounting.ProjectUsage(usage)
+	projectUsage.ProjectID = id
+	return &projectUsage, nil
+}
+
+func TestProjectLimits(t *testing.T) {
+	id, err := uuid.New()
+	require.NoError(t, err)
+
+	secret := []byte("secret")
+	key, err := macaroon.NewAPIKey(id[:], secret).Serialize()
+	require.NoError(t, err)
+	ctx := auth.WithAPIKey(context.Background(), []byte(key))
+
+	limiter := NewLimiter(mockLimits{storage: 100, egress: 50}, mockUsage{AtRestTotal: 60, EgressTotal: 50})
+	service := NewService(zap.NewNop(), teststore.New())
+	server := Server{service: service, logger: zap.NewNop(), config: Config{Auth: true}, apiKeys: mockAPIKeys{*id: secret}, limiter: limiter}
+
+	// the tallied usage and the usage since the tally count for the limit
+	_, err = server.Put(ctx, &pb.PutRequest{Path: "l/bucket/first", Pointer: &pb.Pointer{SegmentSize: 30}})
+	assert.NoError(t, err)
+	_, err = server.Put(ctx, &pb.PutRequest{Path: "l/bucket/second", Pointer: &pb.Pointer{SegmentSize: 30}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// copies count for the storage limit like uploads
+	_, err = server.Copy(ctx, &pb.CopyRequest{SourcePath: "l/bucket/first", DestinationPath: "l/bucket/copy"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: pb.BandwidthAction_GET})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the references to deduplicated segments count with the size of the
+	// segments
+	require.NoError(t, service.PutDeduplicated("l/"+id.String()+"/bucket/dedup", &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 50,
+		Remote:      &pb.RemoteSegment{DedupId: []byte{1, 2, 3}, RemotePieces: []*pb.RemotePiece{{PieceNum: 1}}},
+	}))
+	_, err = server.Put(ctx, &pb.PutRequest{Path: "l/bucket/reference", Pointer: &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: []byte{1, 2, 3}},
+	}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the allocations for repairs and audits, which aren't limited, are for
+	// the satellite only
+	for _, action := range []pb.BandwidthAction{pb.BandwidthAction_GET_REPAIR, pb.BandwidthAction_PUT_REPAIR, pb.BandwidthAction_GET_AUDIT} {
+		_, err = server.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: action})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), action.String())
+	}
+}
+
+func TestSatelliteAllocations(t *testing.T) {
+	ctx := context.Background()
+	ca, err := testidentity.NewTestCA(ctx)
+	require.NoError(t, err)
+	satellite, err := ca.NewIdentity()
+	require.NoError(t, err)
+	uplink, err := ca.NewIdentity()
+	require.NoError(t, err)
+
+	server := NewServer(zap.NewNop(), NewService(zap.NewNop(), teststore.New()), NewAllocationSigner(satellite, 45), nil, Config{}, satellite, nil, nil, nil)
+
+	for _, action := range []pb.BandwidthAction{pb.BandwidthAction_GET_REPAIR, pb.BandwidthAction_PUT_REPAIR, pb.BandwidthAction_GET_AUDIT} {
+		_, err = server.PayerBandwidthAllocation(peerContext(ctx, uplink), &pb.PayerBandwidthAllocationRequest{Action: action})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), action.String())
+
+		resp, err := server.PayerBandwidthAllocation(peerContext(ctx, satellite), &pb.PayerBandwidthAllocationRequest{Action: action})
+		if assert.NoError(t, err, action.String()) {
+			assert.Equal(t, action, resp.GetPba().Action)
+		}
+	}
+}
+
+func TestServiceDeduplication(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+
+	dedupID := []byte{1, 2, 3}
+	pieces := []*pb.RemotePiece{{PieceNum: 1}, {PieceNum: 2}}
+	entryPath := DedupPrefix + "project/010203"
+
+	// without an entry, a reference is not found
+	err := service.PutDeduplicated("s0/project/bucket/a", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: dedupID},
+	})
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+	// the pieces of the first upload become the entry
+	err = service.PutDeduplicated("s0/project/bucket/a", &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 10,
+		Remote:      &pb.RemoteSegment{DedupId: dedupID, RemotePieces: pieces},
+	})
+	require.NoError(t, err)
+
+	entry, err := service.Get(entryPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), entry.Remote.References)
+	assert.Len(t, entry.Remote.RemotePieces, 2)
+
+	// the second upload only references the entry
+	err = service.PutDeduplicated("s0/project/bucket/b", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: dedupID},
+	})
+	require.NoError(t, err)
+
+	pointer, err := service.Get("s0/project/bucket/b")
+	require.NoError(t, err)
+	assert.Empty(t, pointer.Remote.RemotePieces)
+	assert.Equal(t, int64(10), pointer.SegmentSize)
+
+	require.NoError(t, service.Resolve("s0/project/bucket/b", pointer))
+	assert.Len(t, pointer.Remote.RemotePieces, 2)
+	assert.Equal(t, dedupID, pointer.Remote.DedupId)
+
+	// copies reference the entry too
+	require.NoError(t, service.Copy("s0/project/bucket/b", "s0/project/bucket/c", nil))
+
+	entry, err = service.Get(entryPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), entry.Remote.References)
+
+	// the entry is deleted and released with the last reference
+	for _, path := range []string{"s0/project/bucket/a", "s0/project/bucket/b"} {
+		released, err := service.Unlink(path)
+		require.NoError(t, err)
+		assert.Nil(t, released)
+	}
+
+	released, err := service.Unlink("s0/project/bucket/c")
+	require.NoError(t, err)
+	if assert.NotNil(t, released) {
+		assert.Len(t, released.Remote.RemotePieces, 2)
+	}
+
+	_, err = service.Get(entryPath)
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+}
+
+func TestServiceDeduplicationOverwrite(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+
+	pieces := []*pb.RemotePiece{{PieceNum: 1}, {PieceNum: 2}}
+	for path, dedupID := range map[string][]byte{"s0/project/bucket/a": {1}, "s0/project/bucket/b": {2}} {
+		require.NoError(t, service.PutDeduplicated(path, &pb.Pointer{
+			Type:        pb.Pointer_REMOTE,
+			SegmentSize: 10,
+			Remote:      &pb.RemoteSegment{DedupId: dedupID, RemotePieces: pieces},
+		}))
+	}
+
+	// overwriting a reference with a reference to another segment
+	// releases the overwritten one
+	require.NoError(t, service.PutDeduplicated("s0/project/bucket/a", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: []byte{2}},
+	}))
+
+	_, err := service.Get(DedupPrefix + "project/01")
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+	entry, err := service.Get(DedupPrefix + "project/02")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Remote.References)
+
+	// overwriting a reference with a reference to the same segment keeps it
+	require.NoError(t, service.PutDeduplicated("s0/project/bucket/b", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: []byte{2}},
+	}))
+
+	entry, err = service.Get(DedupPrefix + "project/02")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Remote.References)
+}
+
+func TestServiceDeduplicationSingleProject(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	service.SingleProject = true
+
+	dedupID := []byte{1, 2, 3}
+	require.NoError(t, service.PutDeduplicated("s0/bucket1/a", &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 10,
+		Remote:      &pb.RemoteSegment{DedupId: dedupID, RemotePieces: []*pb.RemotePiece{{PieceNum: 1}}},
+	}))
+
+	// the segments are deduplicated across the buckets
+	require.NoError(t, service.PutDeduplicated("s0/bucket2/b", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: dedupID},
+	}))
+
+	entry, err := service.Get(DedupPrefix + "010203")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Remote.References)
+}
+
+func TestServiceCopy(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+
+	pieces := []*pb.RemotePiece{{PieceNum: 1}, {PieceNum: 2}}
+	require.NoError(t, service.Put("s0/project/bucket/a", &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 10,
+		Metadata:    []byte("a"),
+		Remote:      &pb.RemoteSegment{PieceId: "piece", RemotePieces: pieces},
+	}))
+
+	require.NoError(t, service.Copy("s0/project/bucket/a", "s0/project/bucket/b", []byte("b")))
+
+	// both pointers reference the pieces moved to an entry
+	source, err := service.Get("s0/project/bucket/a")
+	require.NoError(t, err)
+	destination, err := service.Get("s0/project/bucket/b")
+	require.NoError(t, err)
+
+	assert.Empty(t, source.Remote.RemotePieces)
+	assert.Equal(t, []byte("a"), source.Metadata)
+	assert.Equal(t, []byte("b"), destination.Metadata)
+	assert.Equal(t, source.Remote.DedupId, destination.Remote.DedupId)
+
+	entryPath, err := service.dedupPath("s0/project/bucket/a", source.Remote.DedupId)
+	require.NoError(t, err)
+	entry, err := service.Get(entryPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Remote.References)
+
+	require.NoError(t, service.Resolve("s0/project/bucket/b", destination))
+	assert.Equal(t, "piece", destination.Remote.PieceId)
+	assert.Len(t, destination.Remote.RemotePieces, 2)
+
+	// the pieces are released with the last pointer only
+	released, err := service.Unlink("s0/project/bucket/a")
+	require.NoError(t, err)
+	assert.Nil(t, released)
+
+	released, err = service.Unlink("s0/project/bucket/b")
+	require.NoError(t, err)
+	if assert.NotNil(t, released) {
+		assert.Equal(t, "piece", released.Remote.PieceId)
+	}
+
+	_, err = service.Get(entryPath)
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+	// the pieces of a pointer which wasn't copied are released with it
+	require.NoError(t, service.Put("s0/project/bucket/c", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{PieceId: "other", RemotePieces: pieces},
+	}))
+	released, err = service.Unlink("s0/project/bucket/c")
+	require.NoError(t, err)
+	if assert.NotNil(t, released) {
+		assert.Equal(t, "other", released.Remote.PieceId)
+	}
+}
+
+func TestServiceUpdateMetadata(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+
+	expires := func(at time.Time) *timestamp.Timestamp {
+		ts, err := ptypes.TimestampProto(at)
+		require.NoError(t, err)
+		return ts
+	}
+	now := time.Now().UTC().Round(time.Second)
+
+	require.NoError(t, service.Put("s0/project/bucket/a", &pb.Pointer{
+		Type:     pb.Pointer_REMOTE,
+		Metadata: []byte("a"),
+		Remote:   &pb.RemoteSegment{RemotePieces: []*pb.RemotePiece{{PieceNum: 1}}},
+	}))
+
+	// an expiration can be set on a pointer which never expires
+	require.NoError(t, service.UpdateMetadata("s0/project/bucket/a", []byte("b"), expires(now.Add(2*time.Hour))))
+
+	// but neither extended nor cleared afterwards
+	err := service.UpdateMetadata("s0/project/bucket/a", nil, expires(now.Add(3*time.Hour)))
+	assert.True(t, storj.ErrExpiration.Has(err))
+	err = service.UpdateMetadata("s0/project/bucket/a", nil, nil)
+	assert.True(t, storj.ErrExpiration.Has(err))
+
+	require.NoError(t, service.UpdateMetadata("s0/project/bucket/a", nil, expires(now.Add(time.Hour))))
+
+	pointer, err := service.Get("s0/project/bucket/a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), pointer.Metadata)
+	assert.True(t, now.Add(time.Hour).Equal(expirationTime(pointer)))
+	assert.Len(t, pointer.Remote.RemotePieces, 1)
+}
+
+type testRepairQueue struct {
+	queue.RepairQueue
+	segments []*pb.InjuredSegment
+}
+
+func (q *testRepairQueue) Enqueue(ctx context.Context, seg *pb.InjuredSegment) error {
+	q.segments = append(q.segments, seg)
+	return nil
+}
+
+type testPieceVerifier struct {
+	corrupted bool
+	verified  int
+}
+
+func (verifier *testPieceVerifier) Corrupted(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece) (bool, error) {
+	verifier.verified++
+	return verifier.corrupted, nil
+}
+
+func (verifier *testPieceVerifier) Matches(ctx context.Context, pointer *pb.Pointer, nodeID storj.NodeID, hash *pb.PieceHash) (bool, error) {
+	verifier.verified++
+	return !verifier.corrupted, nil
+}
+
+func TestServiceReportCorruption(t *testing.T) {
+	ctx := context.Background()
+
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	repairQueue := &testRepairQueue{}
+	verifier := &testPieceVerifier{}
+	s := Server{service: service, logger: zap.NewNop(), repairQueue: repairQueue, pieces: verifier}
+
+	node := teststorj.NodeIDFromString("node")
+	pieces := []*pb.RemotePiece{
+		{PieceNum: 1, NodeId: node, SignedHash: &pb.PieceHash{Hash: []byte{1}}},
+		{PieceNum: 3, NodeId: node},
+	}
+
+	require.NoError(t, service.Put("s0/project/bucket/a", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{RemotePieces: pieces},
+	}))
+	require.NoError(t, service.PutDeduplicated("s0/project/bucket/b", &pb.Pointer{
+		Type:   pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{DedupId: []byte{1, 2, 3}, RemotePieces: pieces},
+	}))
+
+	// only pieces of the segment can be reported
+	_, err := s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/a", PieceNum: 2, NodeId: node})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/c", PieceNum: 1, NodeId: node})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// the pieces without a signed hash can't be verified
+	_, err = s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/a", PieceNum: 3, NodeId: node})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// the pieces matching their signed hash aren't repaired
+	_, err = s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/a", PieceNum: 1, NodeId: node})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, verifier.verified)
+
+	assert.Empty(t, repairQueue.segments)
+
+	verifier.corrupted = true
+	_, err = s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/a", PieceNum: 1, NodeId: node})
+	require.NoError(t, err)
+
+	// the pieces of the deduplicated segments are repaired in their entry
+	_, err = s.ReportCorruption(ctx, &pb.ReportCorruptionRequest{Path: "s0/project/bucket/b", PieceNum: 1, NodeId: node})
+	require.NoError(t, err)
+
+	require.Len(t, repairQueue.segments, 2)
+	assert.Equal(t, "s0/project/bucket/a", repairQueue.segments[0].GetPath())
+	assert.Equal(t, DedupPrefix+"project/010203", repairQueue.segments[1].GetPath())
+	for _, seg := range repairQueue.segments {
+		assert.Equal(t, []int32{1}, seg.GetLostPieces())
+	}
+}
+
+func TestServiceReplacePiece(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+
+	exiting := teststorj.NodeIDFromString("exiting")
+	other := teststorj.NodeIDFromString("other")
+	receiver := teststorj.NodeIDFromString("receiver")
+
+	require.NoError(t, service.Put("s0/project/bucket/a", &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{RemotePieces: []*pb.RemotePiece{
+			{PieceNum: 1, NodeId: exiting},
+			{PieceNum: 2, NodeId: other},
+		}},
+	}))
+
+	before, err := service.Get("s0/project/bucket/a")
+	require.NoError(t, err)
+
+	// only a piece stored on the node can be replaced
+	err = service.ReplacePiece("s0/project/bucket/a", 2, exiting, &pb.RemotePiece{PieceNum: 2, NodeId: receiver})
+	assert.Error(t, err)
+
+	err = service.ReplacePiece("s0/project/bucket/b", 1, exiting, &pb.RemotePiece{PieceNum: 1, NodeId: receiver})
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+	replacement := &pb.RemotePiece{PieceNum: 1, NodeId: receiver, Hash: []byte{1, 2, 3}}
+	require.NoError(t, service.ReplacePiece("s0/project/bucket/a", 1, exiting, replacement))
+
+	after, err := service.Get("s0/project/bucket/a")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(replacement, after.Remote.RemotePieces[0]))
+	assert.Equal(t, other, after.Remote.RemotePieces[1].NodeId)
+	assert.True(t, proto.Equal(before.CreationDate, after.CreationDate))
+}
@@ -4,20 +4,43 @@
 package pointerdb
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
 
+// LifecyclePrefix is the prefix of the pointers keeping bucket lifecycle rules
+const LifecyclePrefix = "lifecycle/"
+
+// DedupPrefix is the prefix of the entries of the segments referenced by
+// several pointers, which are the deduplicated and the copied segments
+const DedupPrefix = "dedup/"
+
 // Service structure
 type Service struct {
 	logger *zap.Logger
 	DB     storage.KeyValueStore
+
+	// SingleProject is set when the paths aren't namespaced by project,
+	// because the api keys aren't required. All the entries of the
+	// deduplicated segments are in the same scope then.
+	SingleProject bool
+
+	// dedupMu serializes the updates of the references of the segments
+	dedupMu sync.Mutex
 }
 
 // NewService creates new pointerdb service
@@ -141,9 +164,384 @@ func (s *Service) setMetadata(item *pb.ListResponse_Item, data []byte, metaFlags
 	return nil
 }
 
-// Delete deletes from item from db
+// Copy duplicates the pointer at source under destination, replacing its
+// metadata unless metadata is empty. The pieces of a remote segment are moved
+// to an entry referenced by both pointers, like a deduplicated segment, so
+// that they are deleted with the last pointer referencing them.
+func (s *Service) Copy(source, destination string, metadata []byte) (err error) {
+	s.dedupMu.Lock()
+	defer s.dedupMu.Unlock()
+
+	pointer, err := s.Get(source)
+	if err != nil {
+		return err
+	}
+
+	if dedupID := pointer.GetRemote().GetDedupId(); len(dedupID) > 0 {
+		_, err = s.reference(destination, dedupID, 1)
+		if err != nil {
+			return err
+		}
+	} else if pointer.GetType() == pb.Pointer_REMOTE {
+		var copyID [32]byte
+		if _, err = rand.Read(copyID[:]); err != nil {
+			return Error.Wrap(err)
+		}
+
+		entryPath, err := s.dedupPath(source, copyID[:])
+		if err != nil {
+			return err
+		}
+
+		entry := proto.Clone(pointer).(*pb.Pointer)
+		entry.Metadata = nil
+		entry.Remote.References = 2
+		if err = s.Put(entryPath, entry); err != nil {
+			return err
+		}
+
+		// write the source back directly to keep its creation date
+		pointer.Remote = &pb.RemoteSegment{DedupId: copyID[:]}
+		pointerBytes, err := proto.Marshal(pointer)
+		if err != nil {
+			return err
+		}
+		if err = s.DB.Put([]byte(source), pointerBytes); err != nil {
+			return err
+		}
+	}
+
+	if len(metadata) > 0 {
+		pointer.Metadata = metadata
+	}
+	if err = s.release(destination); err != nil {
+		return err
+	}
+	return s.Put(destination, pointer)
+}
+
+// UpdateMetadata replaces the metadata, unless metadata is empty, and the
+// expiration of the pointer at path. The segment itself is left unchanged, so
+// the expiration can be set or brought forward, but not extended or cleared.
+func (s *Service) UpdateMetadata(path string, metadata []byte, expiration *timestamp.Timestamp) (err error) {
+	pointer, err := s.Get(path)
+	if err != nil {
+		return err
+	}
+
+	// the storage nodes delete the pieces at the expiration they were stored
+	// with, so it can only be brought forward
+	current, updated := expirationTime(pointer), expirationTime(&pb.Pointer{ExpirationDate: expiration})
+	if !current.IsZero() && (updated.IsZero() || updated.After(current)) {
+		return storj.ErrExpiration.New("%q expires at %s", path, current)
+	}
+
+	if len(metadata) > 0 {
+		pointer.Metadata = metadata
+	}
+	pointer.ExpirationDate = expiration
+	return s.Put(path, pointer)
+}
+
+// ReplacePiece replaces the piece pieceNum of the remote segment at path,
+// which must be stored on node from, with piece to. The segment keeps its
+// creation date.
+func (s *Service) ReplacePiece(path string, pieceNum int32, from storj.NodeID, to *pb.RemotePiece) (err error) {
+	// entries of deduplicated segments are updated under this lock as well
+	s.dedupMu.Lock()
+	defer s.dedupMu.Unlock()
+
+	pointer, err := s.Get(path)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.GetPieceNum() == pieceNum && piece.NodeId == from {
+			pointer.Remote.RemotePieces[i] = to
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		return Error.New("no piece %d on node %s at %q", pieceNum, from, path)
+	}
+
+	pointerBytes, err := proto.Marshal(pointer)
+	if err != nil {
+		return err
+	}
+	return s.DB.Put([]byte(path), pointerBytes)
+}
+
+// SetLifecycle replaces the lifecycle rules of a bucket. The rules are kept
+// in an inline pointer under lifecycle/<bucket>.
+func (s *Service) SetLifecycle(bucket string, lifecycle *pb.BucketLifecycle) (err error) {
+	path := LifecyclePrefix + bucket
+
+	if len(lifecycle.GetRules()) == 0 {
+		err = s.Delete(path)
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		return err
+	}
+
+	lifecycleBytes, err := proto.Marshal(lifecycle)
+	if err != nil {
+		return err
+	}
+
+	return s.Put(path, &pb.Pointer{
+		Type:          pb.Pointer_INLINE,
+		InlineSegment: lifecycleBytes,
+		SegmentSize:   int64(len(lifecycleBytes)),
+	})
+}
+
+// GetLifecycle returns the lifecycle rules of a bucket
+func (s *Service) GetLifecycle(bucket string) (lifecycle *pb.BucketLifecycle, err error) {
+	pointer, err := s.Get(LifecyclePrefix + bucket)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return &pb.BucketLifecycle{}, nil
+		}
+		return nil, err
+	}
+
+	lifecycle = &pb.BucketLifecycle{}
+	err = proto.Unmarshal(pointer.GetInlineSegment(), lifecycle)
+	if err != nil {
+		return nil, errs.New("error unmarshaling lifecycle: %v", err)
+	}
+
+	return lifecycle, nil
+}
+
+// Delete deletes from item from db. Deleting a pointer referencing the pieces
+// of a segment releases its reference of the pieces.
 func (s *Service) Delete(path string) (err error) {
-	return s.DB.Delete([]byte(path))
+	_, err = s.Unlink(path)
+	return err
+}
+
+// Unlink deletes the pointer at path like Delete, and returns the segment
+// whose pieces aren't referenced by any pointer anymore, if any. The pieces
+// of the released segment should be deleted from the storage nodes.
+func (s *Service) Unlink(path string) (released *pb.Pointer, err error) {
+	s.dedupMu.Lock()
+	defer s.dedupMu.Unlock()
+
+	pointer, err := s.Get(path)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, s.DB.Delete([]byte(path))
+		}
+		return nil, err
+	}
+
+	if dedupID := pointer.GetRemote().GetDedupId(); len(dedupID) > 0 {
+		released, err = s.reference(path, dedupID, -1)
+		if err != nil {
+			return nil, err
+		}
+	} else if pointer.GetType() == pb.Pointer_REMOTE {
+		released = pointer
+	}
+
+	return released, s.DB.Delete([]byte(path))
+}
+
+// PutDeduplicated puts a pointer of a deduplicated segment under path. If the
+// remote segment of pointer has no pieces, it references the existing segment
+// with the same dedup ID in the project of path, or returns a not found error
+// if there is none which lives at least as long as pointer. Otherwise the
+// pieces of pointer become the entry of the segment, unless an entry already
+// exists, in which case the pointer keeps its pieces on its own. The reference
+// of the pointer overwritten under path, if any, is released.
+func (s *Service) PutDeduplicated(path string, pointer *pb.Pointer) (err error) {
+	dedupID := pointer.GetRemote().GetDedupId()
+	entryPath, err := s.dedupPath(path, dedupID)
+	if err != nil {
+		return err
+	}
+
+	s.dedupMu.Lock()
+	defer s.dedupMu.Unlock()
+
+	entry, err := s.Get(entryPath)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return err
+	}
+
+	if len(pointer.GetRemote().GetRemotePieces()) == 0 {
+		if entry == nil || !outlives(entry, pointer) {
+			return storage.ErrKeyNotFound.New("no deduplicated segment %x", dedupID)
+		}
+
+		entry.Remote.References++
+		if err = s.Put(entryPath, entry); err != nil {
+			return err
+		}
+
+		pointer.SegmentSize = entry.GetSegmentSize()
+		if err = s.release(path); err != nil {
+			return err
+		}
+		return s.Put(path, pointer)
+	}
+
+	if entry != nil {
+		// the same content was uploaded concurrently
+		pointer.Remote.DedupId = nil
+		if err = s.release(path); err != nil {
+			return err
+		}
+		return s.Put(path, pointer)
+	}
+
+	entry = proto.Clone(pointer).(*pb.Pointer)
+	entry.Metadata = nil
+	entry.Remote.DedupId = nil
+	entry.Remote.References = 1
+	if err = s.Put(entryPath, entry); err != nil {
+		return err
+	}
+
+	pointer.Remote = &pb.RemoteSegment{DedupId: dedupID}
+	if err = s.release(path); err != nil {
+		return err
+	}
+	return s.Put(path, pointer)
+}
+
+// SegmentSize returns the size of the segment of pointer, which is put under
+// path. The size of a reference to a deduplicated segment is the size of the
+// entry of the segment.
+func (s *Service) SegmentSize(path string, pointer *pb.Pointer) (size int64, err error) {
+	dedupID := pointer.GetRemote().GetDedupId()
+	if len(dedupID) == 0 || len(pointer.GetRemote().GetRemotePieces()) > 0 {
+		return pointer.GetSegmentSize(), nil
+	}
+
+	entryPath, err := s.dedupPath(path, dedupID)
+	if err != nil {
+		return 0, err
+	}
+
+	entry, err := s.Get(entryPath)
+	if err != nil {
+		return 0, err
+	}
+	return entry.GetSegmentSize(), nil
+}
+
+// Resolve sets the pieces of the entry of a deduplicated segment to the
+// remote segment of pointer, which was retrieved from path
+func (s *Service) Resolve(path string, pointer *pb.Pointer) (err error) {
+	dedupID := pointer.GetRemote().GetDedupId()
+	if len(dedupID) == 0 || len(pointer.GetRemote().GetRemotePieces()) > 0 {
+		return nil
+	}
+
+	entryPath, err := s.dedupPath(path, dedupID)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.Get(entryPath)
+	if err != nil {
+		return err
+	}
+
+	pointer.Remote = entry.Remote
+	pointer.Remote.DedupId = dedupID
+	pointer.Remote.References = 0
+	return nil
+}
+
+// reference adds delta to the references of the segment referenced by a
+// pointer under path. It must be called with dedupMu held. The entry is
+// deleted when no pointer references it anymore, and returned as released.
+func (s *Service) reference(path string, dedupID []byte, delta int64) (released *pb.Pointer, err error) {
+	entryPath, err := s.dedupPath(path, dedupID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.Get(entryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Remote.References += delta
+	if entry.Remote.References <= 0 {
+		return entry, s.DB.Delete([]byte(entryPath))
+	}
+	return nil, s.Put(entryPath, entry)
+}
+
+// release releases the reference of the pointer under path, which is about
+// to be overwritten, if it references a deduplicated segment. It must be
+// called with dedupMu held. The pieces of a segment released this way are
+// left to the garbage collection.
+func (s *Service) release(path string) error {
+	pointer, err := s.Get(path)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		return err
+	}
+
+	dedupID := pointer.GetRemote().GetDedupId()
+	if len(dedupID) == 0 || len(pointer.GetRemote().GetRemotePieces()) > 0 {
+		return nil
+	}
+
+	_, err = s.reference(path, dedupID, -1)
+	if storage.ErrKeyNotFound.Has(err) {
+		s.logger.Warn("overwritten pointer referenced a missing segment", zap.String("path", path))
+		return nil
+	}
+	return err
+}
+
+// dedupPath returns the path of the entry of a deduplicated segment of a
+// pointer under path. The entries are scoped by the project, which is the
+// second element of the namespaced paths, unless there is a single project.
+func (s *Service) dedupPath(path string, dedupID []byte) (string, error) {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 3 || len(dedupID) == 0 {
+		return "", Error.New("invalid deduplicated segment %q", path)
+	}
+	if s.SingleProject {
+		return DedupPrefix + hex.EncodeToString(dedupID), nil
+	}
+	return DedupPrefix + parts[1] + "/" + hex.EncodeToString(dedupID), nil
+}
+
+// outlives returns whether the pieces of entry don't expire before pointer
+func outlives(entry, pointer *pb.Pointer) bool {
+	entryExpiration := expirationTime(entry)
+	if entryExpiration.IsZero() {
+		return true
+	}
+	pointerExpiration := expirationTime(pointer)
+	if pointerExpiration.IsZero() {
+		return false
+	}
+	return !entryExpiration.Before(pointerExpiration)
+}
+
+// expirationTime returns the expiration date of pointer, zero if it never expires
+func expirationTime(pointer *pb.Pointer) time.Time {
+	expiration, err := ptypes.Timestamp(pointer.GetExpirationDate())
+	if err != nil {
+		return time.Time{}
+	}
+	return expiration
 }
 
 // Iterate iterates over items in db
@@ -22,7 +22,8 @@ type DB interface {
 	Create(ctx context.Context, nodeID storj.NodeID, initial *NodeStats) (stats *NodeStats, err error)
 	// Get returns node stats.
 	Get(ctx context.Context, nodeID storj.NodeID) (stats *NodeStats, err error)
-	// FindInvalidNodes finds a subset of storagenodes that have stats below provided reputation requirements.
+	// FindInvalidNodes finds a subset of storagenodes that have stats below provided reputation requirements,
+	// or which have gracefully exited.
 	FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList, maxStats *NodeStats) (invalid storj.NodeIDList, err error)
 	// Update all parts of single storagenode's stats.
 	Update(ctx context.Context, request *UpdateRequest) (stats *NodeStats, err error)
@@ -0,0 +1,11 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"github.com/zeebo/errs"
+)
+
+// Error is the errs class of standard bucket errors
+var Error = errs.Class("bucket error")
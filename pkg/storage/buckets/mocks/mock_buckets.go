@@ -12,7 +12,6 @@ import (
 
 	buckets "storj.io/storj/pkg/storage/buckets"
 	objects "storj.io/storj/pkg/storage/objects"
-	storj "storj.io/storj/pkg/storj"
 )
 
 // MockStore is a mock of Store interface
@@ -91,7 +90,7 @@ func (mr *MockStoreMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomoc
 }
 
 // Put mocks base method
-func (m *MockStore) Put(arg0 context.Context, arg1 string, arg2 storj.Cipher) (buckets.Meta, error) {
+func (m *MockStore) Put(arg0 context.Context, arg1 string, arg2 buckets.Meta) (buckets.Meta, error) {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
 	ret0, _ := ret[0].(buckets.Meta)
 	ret1, _ := ret[1].(error)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
@@ -25,7 +26,7 @@ var mon = monkit.Package()
 // Store creates an interface for interacting with buckets
 type Store interface {
 	Get(ctx context.Context, bucket string) (meta Meta, err error)
-	Put(ctx context.Context, bucket string, pathCipher storj.Cipher) (meta Meta, err error)
+	Put(ctx context.Context, bucket string, info Meta) (meta Meta, err error)
 	Delete(ctx context.Context, bucket string) (err error)
 	List(ctx context.Context, startAfter, endBefore string, limit int) (items []ListItem, more bool, err error)
 	GetObjectStore(ctx context.Context, bucketName string) (store objects.Store, err error)
@@ -47,6 +48,13 @@ type BucketStore struct {
 type Meta struct {
 	Created            time.Time
 	PathEncryptionType storj.Cipher
+	// Versioning keeps overwritten and deleted objects as prior versions
+	Versioning bool
+
+	// RedundancyScheme and EncryptionScheme are the defaults for the objects
+	// uploaded to the bucket, they aren't set when zero
+	RedundancyScheme storj.RedundancyScheme
+	EncryptionScheme storj.EncryptionScheme
 }
 
 // NewStore instantiates BucketStore
@@ -95,22 +103,40 @@ func (b *BucketStore) Get(ctx context.Context, bucket string) (meta Meta, err er
 	return convertMeta(objMeta)
 }
 
-// Put calls objects store Put
-func (b *BucketStore) Put(ctx context.Context, bucket string, pathCipher storj.Cipher) (meta Meta, err error) {
+// Put calls objects store Put. The Created field of info is ignored.
+func (b *BucketStore) Put(ctx context.Context, bucket string, info Meta) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if bucket == "" {
 		return Meta{}, storj.ErrNoBucket.New("")
 	}
+	if storj.IsMultipartBucket(bucket) {
+		return Meta{}, storj.ErrBucketNameReserved.New("%q", bucket)
+	}
 
+	pathCipher := info.PathEncryptionType
 	if pathCipher < storj.Unencrypted || pathCipher > storj.SecretBox {
 		return Meta{}, encryption.ErrInvalidConfig.New("encryption type %d is not supported", pathCipher)
 	}
 
+	if err = validateSchemes(info.RedundancyScheme, info.EncryptionScheme); err != nil {
+		return Meta{}, err
+	}
+
 	r := bytes.NewReader(nil)
 	userMeta := map[string]string{
 		"path-enc-type": strconv.Itoa(int(pathCipher)),
 	}
+	if info.Versioning {
+		userMeta["versioning"] = "enabled"
+	}
+	if rs := info.RedundancyScheme; !rs.IsZero() {
+		userMeta["redundancy"] = formatInts(int(rs.Algorithm), int(rs.ShareSize),
+			int(rs.RequiredShares), int(rs.RepairShares), int(rs.OptimalShares), int(rs.TotalShares))
+	}
+	if es := info.EncryptionScheme; !es.IsZero() {
+		userMeta["encryption"] = formatInts(int(es.Cipher), int(es.BlockSize))
+	}
 	var exp time.Time
 	m, err := b.store.Put(ctx, bucket, r, pb.SerializableMeta{UserDefined: userMeta}, exp)
 	if err != nil {
@@ -179,8 +205,94 @@ func convertMeta(m objects.Meta) (Meta, error) {
 		cipher = storj.Cipher(pet)
 	}
 
+	var rs storj.RedundancyScheme
+	if scheme, ok := m.UserDefined["redundancy"]; ok {
+		values, err := parseInts(scheme, 6)
+		if err != nil {
+			return Meta{}, err
+		}
+		rs = storj.RedundancyScheme{
+			Algorithm:      storj.RedundancyAlgorithm(values[0]),
+			ShareSize:      int32(values[1]),
+			RequiredShares: int16(values[2]),
+			RepairShares:   int16(values[3]),
+			OptimalShares:  int16(values[4]),
+			TotalShares:    int16(values[5]),
+		}
+	}
+
+	var es storj.EncryptionScheme
+	if scheme, ok := m.UserDefined["encryption"]; ok {
+		values, err := parseInts(scheme, 2)
+		if err != nil {
+			return Meta{}, err
+		}
+		es = storj.EncryptionScheme{
+			Cipher:    storj.Cipher(values[0]),
+			BlockSize: int32(values[1]),
+		}
+	}
+
 	return Meta{
 		Created:            m.Modified,
 		PathEncryptionType: cipher,
+		Versioning:         m.UserDefined["versioning"] == "enabled",
+		RedundancyScheme:   rs,
+		EncryptionScheme:   es,
 	}, nil
 }
+
+// validateSchemes checks the default schemes of a bucket, unless they're zero
+func validateSchemes(rs storj.RedundancyScheme, es storj.EncryptionScheme) error {
+	if !rs.IsZero() {
+		if rs.Algorithm != storj.ReedSolomon {
+			return Error.New("redundancy algorithm %d is not supported", rs.Algorithm)
+		}
+		if rs.ShareSize <= 0 {
+			return Error.New("share size must be larger than 0")
+		}
+		if rs.RequiredShares <= 0 || rs.RepairShares < rs.RequiredShares ||
+			rs.OptimalShares < rs.RepairShares || rs.TotalShares < rs.OptimalShares {
+			return Error.New("invalid redundancy scheme %d/%d/%d/%d",
+				rs.RequiredShares, rs.RepairShares, rs.OptimalShares, rs.TotalShares)
+		}
+	}
+
+	if !es.IsZero() {
+		if es.Cipher < storj.Unencrypted || es.Cipher > storj.SecretBox {
+			return encryption.ErrInvalidConfig.New("encryption type %d is not supported", es.Cipher)
+		}
+		if es.BlockSize <= 0 {
+			return encryption.ErrInvalidConfig.New("encryption block size must be larger than 0")
+		}
+	}
+
+	return nil
+}
+
+// formatInts formats values as a comma separated list
+func formatInts(values ...int) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = strconv.Itoa(value)
+	}
+	return strings.Join(formatted, ",")
+}
+
+// parseInts parses a comma separated list of count values
+func parseInts(list string, count int) ([]int, error) {
+	parts := strings.Split(list, ",")
+	if len(parts) != count {
+		return nil, Error.New("invalid list of values %q", list)
+	}
+
+	values := make([]int, count)
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -4,9 +4,13 @@
 package ecclient
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"hash"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"sort"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/internal/readcloser"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
@@ -26,15 +31,30 @@ import (
 
 var mon = monkit.Package()
 
+// minDownloadMargin is the minimum number of pieces requested on download
+// above the required count of the erasure scheme
+const minDownloadMargin = 2
+
 // Client defines an interface for storing erasure coded data to piece store nodes
 type Client interface {
 	Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error)
+		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error)
 	Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
+		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, hashes *PieceHashes) (ranger.Ranger, error)
 	Delete(ctx context.Context, nodes []*pb.Node, pieceID psclient.PieceID, authorization *pb.SignedMessage) error
 }
 
+// PieceHashes are the expected hashes of the pieces to download
+type PieceHashes struct {
+	// Hashes of the pieces signed by the storage nodes, indexed by piece
+	// number. The blocks of the pieces hashed in blocks are verified as they
+	// are read, the other pieces only when they are read whole.
+	Hashes []*pb.PieceHash
+	// Corrupted is called for every node returning a piece which doesn't
+	// match its hash, it may be nil
+	Corrupted func(pieceNum int, node *pb.Node)
+}
+
 type psClientFunc func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error)
 type psClientHelper func(context.Context, *pb.Node) (psclient.Client, error)
 
@@ -60,29 +80,35 @@ func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (psclient.Clien
 }
 
 func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error) {
+	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
 	defer mon.Task()(&ctx)(&err)
 	if len(nodes) != rs.TotalCount() {
-		return nil, Error.New("size of nodes slice (%d) does not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
+		return nil, nil, Error.New("size of nodes slice (%d) does not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
 	}
 
 	if nonNilCount(nodes) < rs.RepairThreshold() {
-		return nil, Error.New("number of non-nil nodes (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(nodes), rs.RepairThreshold())
+		return nil, nil, Error.New("number of non-nil nodes (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(nodes), rs.RepairThreshold())
 	}
 
 	if !unique(nodes) {
-		return nil, Error.New("duplicated nodes are not allowed")
+		return nil, nil, Error.New("duplicated nodes are not allowed")
 	}
 
+	// the uploads of all pieces are started, but once the optimal threshold
+	// of them succeeds, the remaining slowest ones are canceled
+	putCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	padded := eestream.PadReader(ioutil.NopCloser(data), rs.StripeSize())
-	readers, err := eestream.EncodeReader(ctx, padded, rs, ec.memoryLimit)
+	readers, err := eestream.EncodeReader(putCtx, padded, rs, ec.memoryLimit)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	type info struct {
-		i   int
-		err error
+		i    int
+		hash *pb.PieceHash
+		err  error
 	}
 	infos := make(chan info, len(nodes))
 
@@ -105,20 +131,21 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				infos <- info{i: i, err: err}
 				return
 			}
-			ps, err := ec.newPSClient(ctx, n)
+			ps, err := ec.newPSClient(putCtx, n)
 			if err != nil {
 				zap.S().Errorf("Failed dialing for putting piece %s -> %s to node %s: %v",
 					pieceID, derivedPieceID, n.Id, err)
 				infos <- info{i: i, err: err}
 				return
 			}
-			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, pba, authorization)
+			hash, err := ps.Put(putCtx, derivedPieceID, readers[i], expiration, pba, authorization)
 			// normally the bellow call should be deferred, but doing so fails
 			// randomly the unit tests
 			err = errs.Combine(err, ps.Close())
 			// io.ErrUnexpectedEOF means the piece upload was interrupted due to slow connection.
-			// No error logging for this case.
-			if err != nil && err != io.ErrUnexpectedEOF {
+			// No error logging for this case, nor for the long tail canceled after
+			// the optimal threshold was reached.
+			if err != nil && err != io.ErrUnexpectedEOF && putCtx.Err() == nil {
 				nodeAddress := "nil"
 				if n.Address != nil {
 					nodeAddress = n.Address.Address
@@ -126,17 +153,24 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				zap.S().Errorf("Failed putting piece %s -> %s to node %s (%+v): %v",
 					pieceID, derivedPieceID, n.Id, nodeAddress, err)
 			}
-			infos <- info{i: i, err: err}
+			infos <- info{i: i, hash: hash, err: err}
 		}(i, n)
 	}
 
 	successfulNodes = make([]*pb.Node, len(nodes))
+	successfulHashes = make([]*pb.PieceHash, len(nodes))
 	var successfulCount int
 	for range nodes {
 		info := <-infos
-		if info.err == nil {
-			successfulNodes[info.i] = nodes[info.i]
-			successfulCount++
+		if info.err != nil || nodes[info.i] == nil {
+			continue
+		}
+		successfulNodes[info.i] = nodes[info.i]
+		successfulHashes[info.i] = info.hash
+		successfulCount++
+		if successfulCount == rs.OptimalThreshold() {
+			// cancel the long tail of uploads, they are not needed anymore
+			cancel()
 		}
 	}
 
@@ -153,14 +187,14 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	}()
 
 	if successfulCount < rs.RepairThreshold() {
-		return nil, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
+		return nil, nil, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
 	}
 
-	return successfulNodes, nil
+	return successfulNodes, successfulHashes, nil
 }
 
 func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
+	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, hashes *PieceHashes) (rr ranger.Ranger, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(nodes) != es.TotalCount() {
@@ -209,6 +243,12 @@ func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.Erasu
 				pba:               pba,
 				authorization:     authorization,
 			}
+			if hashes != nil && i < len(hashes.Hashes) && len(hashes.Hashes[i].GetHash()) > 0 {
+				rr.hash = hashes.Hashes[i]
+				if hashes.Corrupted != nil {
+					rr.corrupted = func() { hashes.Corrupted(i, n) }
+				}
+			}
 
 			ch <- rangerInfo{i: i, rr: rr, err: nil}
 		}(i, n)
@@ -226,6 +266,18 @@ func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.Erasu
 		return nil, err
 	}
 
+	// request only a few more pieces than required, the stripe reader cancels
+	// the slowest of them once it has enough erasure shares. The pieces of all
+	// the nodes are requested only if the selected ones fail.
+	selected := selectForDownload(rrs, es)
+	if len(selected) < len(rrs) {
+		selectedRR, err := eestream.Decode(selected, es, ec.memoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		rr = &fallbackRanger{ranger: selectedRR, fallback: rr}
+	}
+
 	return eestream.Unpad(rr, int(paddedSize-size))
 }
 
@@ -319,6 +371,95 @@ func unique(nodes []*pb.Node) bool {
 	return true
 }
 
+// selectForDownload returns a random selection of a few more than the
+// required count of the piece rangers rrs.
+func selectForDownload(rrs map[int]ranger.Ranger, es eestream.ErasureScheme) map[int]ranger.Ranger {
+	margin := es.RequiredCount() / 4
+	if margin < minDownloadMargin {
+		margin = minDownloadMargin
+	}
+
+	pieceNums := make([]int, 0, len(rrs))
+	for pieceNum := range rrs {
+		pieceNums = append(pieceNums, pieceNum)
+	}
+	sort.Ints(pieceNums)
+
+	selected := make(map[int]ranger.Ranger)
+	for _, i := range rand.Perm(len(pieceNums)) {
+		if len(selected) >= es.RequiredCount()+margin {
+			break
+		}
+		selected[pieceNums[i]] = rrs[pieceNums[i]]
+	}
+	return selected
+}
+
+// fallbackRanger reads from ranger, and continues reading from fallback
+// where ranger failed. Both must have the same content.
+type fallbackRanger struct {
+	ranger   ranger.Ranger
+	fallback ranger.Ranger
+}
+
+// Size implements Ranger.Size
+func (rr *fallbackRanger) Size() int64 {
+	return rr.ranger.Size()
+}
+
+// Range implements Ranger.Range
+func (rr *fallbackRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	rc, err := rr.ranger.Range(ctx, offset, length)
+	if err != nil {
+		zap.S().Debugf("Falling back to all nodes for range: %v", err)
+		return rr.fallback.Range(ctx, offset, length)
+	}
+	return &fallbackReader{
+		ctx:      ctx,
+		reader:   rc,
+		fallback: rr.fallback,
+		offset:   offset,
+		length:   length,
+	}, nil
+}
+
+// fallbackReader reads the range at offset of length, switching to the
+// fallback ranger at the first error
+type fallbackReader struct {
+	ctx            context.Context
+	reader         io.ReadCloser
+	fallback       ranger.Ranger
+	offset, length int64
+	read           int64
+}
+
+// Read implements io.Reader
+func (r *fallbackReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.read += int64(n)
+	if err == nil || err == io.EOF || r.fallback == nil || r.ctx.Err() != nil {
+		return n, err
+	}
+
+	zap.S().Debugf("Falling back to all nodes after %d bytes: %v", r.read, err)
+	_ = r.reader.Close()
+	r.reader, err = r.fallback.Range(r.ctx, r.offset+r.read, r.length-r.read)
+	r.fallback = nil
+	if err != nil {
+		r.reader = readcloser.FatalReadCloser(err)
+		return n, err
+	}
+	if n > 0 {
+		return n, nil
+	}
+	return r.Read(p)
+}
+
+// Close implements io.Closer
+func (r *fallbackReader) Close() error {
+	return r.reader.Close()
+}
+
 func calcPadded(size int64, blockSize int) int64 {
 	mod := size % int64(blockSize)
 	if mod == 0 {
@@ -329,12 +470,16 @@ func calcPadded(size int64, blockSize int) int64 {
 
 type lazyPieceRanger struct {
 	ranger            ranger.Ranger
+	client            psclient.Client
 	newPSClientHelper psClientHelper
 	node              *pb.Node
 	id                psclient.PieceID
 	size              int64
 	pba               *pb.PayerBandwidthAllocation
 	authorization     *pb.SignedMessage
+	hash              *pb.PieceHash // expected hash of the piece, if known
+	blockHashes       [][]byte      // hashes of the blocks, once verified
+	corrupted         func()        // called if the piece doesn't match the hash
 }
 
 // Size implements Ranger.Size
@@ -354,9 +499,197 @@ func (lr *lazyPieceRanger) Range(ctx context.Context, offset, length int64) (io.
 		if err != nil {
 			return nil, err
 		}
+		lr.client = ps
 		lr.ranger = ranger
 	}
-	return lr.ranger.Range(ctx, offset, length)
+	if lr.hash.GetBlockSize() > 0 {
+		return lr.blockRange(ctx, offset, length)
+	}
+	rc, err := lr.ranger.Range(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	// the pieces hashed whole can only be verified when read whole
+	if lr.hash == nil || offset != 0 || length != lr.size {
+		return rc, nil
+	}
+	return &verifiedPieceReader{
+		ReadCloser: rc,
+		hash:       sha256.New(),
+		expected:   lr.hash.GetHash(),
+		remaining:  length,
+		corrupted:  lr.corrupted,
+	}, nil
+}
+
+// blockRange returns a reader of the range of a piece hashed in blocks, which
+// reads the whole blocks of the range and verifies each of them before
+// returning any of its bytes
+func (lr *lazyPieceRanger) blockRange(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length < 0 || offset+length > lr.size {
+		return nil, Error.New("invalid range %d+%d of piece of size %d", offset, length, lr.size)
+	}
+	if length == 0 {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	hashes, err := lr.getBlockHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockSize := lr.hash.GetBlockSize()
+	first := offset / blockSize
+	last := (offset + length - 1) / blockSize
+	start := first * blockSize
+	end := (last + 1) * blockSize
+	if end > lr.size {
+		end = lr.size
+	}
+
+	rc, err := lr.ranger.Range(ctx, start, end-start)
+	if err != nil {
+		return nil, err
+	}
+	return &verifiedBlockReader{
+		reader:    rc,
+		blockSize: blockSize,
+		hashes:    hashes[first : last+1],
+		unread:    end - start,
+		skip:      offset - start,
+		remaining: length,
+		corrupted: lr.corrupted,
+	}, nil
+}
+
+// getBlockHashes returns the hashes of the blocks of the piece, which are
+// requested from the storage node and verified against the hash of the piece
+// the first time
+func (lr *lazyPieceRanger) getBlockHashes(ctx context.Context) ([][]byte, error) {
+	if lr.blockHashes != nil {
+		return lr.blockHashes, nil
+	}
+
+	summary, err := lr.client.Meta(ctx, lr.id, lr.authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	blockSize := lr.hash.GetBlockSize()
+	hashes := summary.GetBlockHashes()
+	blocks := (lr.size + blockSize - 1) / blockSize
+	if summary.GetHashBlockSize() != blockSize || int64(len(hashes)) != blocks ||
+		!bytes.Equal(psclient.BlocksHash(hashes), lr.hash.GetHash()) {
+		if lr.corrupted != nil {
+			lr.corrupted()
+		}
+		return nil, ErrPieceHashMismatch.New("block hashes don't match the piece hash")
+	}
+
+	lr.blockHashes = hashes
+	return hashes, nil
+}
+
+// verifiedBlockReader reads the blocks of a piece and checks each of them
+// against its hash before returning its bytes, so that no byte of a corrupted
+// block is ever returned, even if the reader is closed before the end
+type verifiedBlockReader struct {
+	reader    io.ReadCloser
+	blockSize int64
+	hashes    [][]byte // hashes of the blocks left to read
+	unread    int64    // bytes of the blocks left to read
+	skip      int64    // bytes of the first block before the range
+	remaining int64    // bytes of the range left to return
+	block     []byte   // verified bytes of the range not returned yet
+	buf       []byte
+	corrupted func()
+}
+
+// Read implements io.Reader
+func (r *verifiedBlockReader) Read(p []byte) (n int, err error) {
+	for len(r.block) == 0 {
+		if r.remaining <= 0 {
+			return 0, io.EOF
+		}
+		if err := r.nextBlock(); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, r.block)
+	r.block = r.block[n:]
+	r.remaining -= int64(n)
+	return n, nil
+}
+
+// nextBlock reads and verifies the next block
+func (r *verifiedBlockReader) nextBlock() error {
+	size := r.blockSize
+	if size > r.unread {
+		size = r.unread
+	}
+	if size <= 0 || len(r.hashes) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	if r.buf == nil {
+		r.buf = make([]byte, r.blockSize)
+	}
+	block := r.buf[:size]
+	if _, err := io.ReadFull(r.reader, block); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	sum := sha256.Sum256(block)
+	if !bytes.Equal(sum[:], r.hashes[0]) {
+		if r.corrupted != nil {
+			r.corrupted()
+		}
+		return ErrPieceHashMismatch.New("piece block hash mismatch")
+	}
+
+	r.hashes = r.hashes[1:]
+	r.unread -= size
+	block = block[r.skip:]
+	r.skip = 0
+	if int64(len(block)) > r.remaining {
+		block = block[:r.remaining]
+	}
+	r.block = block
+	return nil
+}
+
+// Close implements io.Closer
+func (r *verifiedBlockReader) Close() error {
+	return r.reader.Close()
+}
+
+// verifiedPieceReader checks the hash of a piece when its last byte is read.
+// The last read fails on a mismatch, so the bytes of a corrupted piece are
+// never completely returned to the caller.
+type verifiedPieceReader struct {
+	io.ReadCloser
+	hash      hash.Hash
+	expected  []byte
+	remaining int64
+	corrupted func()
+}
+
+// Read implements io.Reader
+func (r *verifiedPieceReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	_, _ = r.hash.Write(p[:n])
+	r.remaining -= int64(n)
+	if n > 0 && r.remaining <= 0 && !bytes.Equal(r.hash.Sum(nil), r.expected) {
+		if r.corrupted != nil {
+			r.corrupted()
+		}
+		return 0, ErrPieceHashMismatch.New("piece hash mismatch")
+	}
+	return n, err
 }
 
 func nonNilCount(nodes []*pb.Node) int {
@@ -4,10 +4,12 @@
 package ecclient
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -132,9 +134,13 @@ TestLoop:
 			if !assert.NoError(t, err, errTag) {
 				continue TestLoop
 			}
+			var hash *pb.PieceHash
+			if errs[n] == nil {
+				hash = &pb.PieceHash{Id: derivedID.String()}
+			}
 			ps := NewMockPSClient(ctrl)
 			gomock.InOrder(
-				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(errs[n]).
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(hash, errs[n]).
 					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
 						// simulate that the mocked piece store client is reading the data
 						_, err := io.Copy(ioutil.Discard, data)
@@ -151,24 +157,99 @@ TestLoop:
 		r := io.LimitReader(rand.Reader, int64(size))
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
 
-		successfulNodes, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil)
+		successfulNodes, successfulHashes, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil)
 
 		if tt.errString != "" {
 			assert.EqualError(t, err, tt.errString, errTag)
 		} else {
 			assert.NoError(t, err, errTag)
 			assert.Equal(t, len(tt.nodes), len(successfulNodes), errTag)
+			assert.Equal(t, len(tt.nodes), len(successfulHashes), errTag)
 			for i := range tt.nodes {
-				if tt.errs[i] != nil {
+				if tt.errs[i] != nil || tt.nodes[i] == nil {
 					assert.Nil(t, successfulNodes[i], errTag)
+					assert.Nil(t, successfulHashes[i], errTag)
 				} else {
 					assert.Equal(t, tt.nodes[i], successfulNodes[i], errTag)
+					if assert.NotNil(t, successfulHashes[i], errTag) {
+						derivedID, err := id.Derive(tt.nodes[i].Id.Bytes())
+						assert.NoError(t, err, errTag)
+						assert.Equal(t, derivedID.String(), successfulHashes[i].GetId(), errTag)
+					}
 				}
 			}
 		}
 	}
 }
 
+func TestPutLongTail(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	size := 32 * 1024
+	fc, err := infectious.NewFEC(2, 4)
+	if !assert.NoError(t, err) {
+		return
+	}
+	es := eestream.NewRSScheme(fc, size/4)
+	// the uploads are done once 3 of the 4 pieces are stored
+	rs, err := eestream.NewRedundancyStrategy(es, 2, 3)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id := psclient.NewPieceID()
+	ttl := time.Now()
+	nodes := []*pb.Node{node0, node1, node2, node3}
+
+	canceled := make(chan struct{})
+	clients := make(map[*pb.Node]psclient.Client, len(nodes))
+	for _, n := range nodes {
+		n := n
+		derivedID, err := id.Derive(n.Id.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		ps := NewMockPSClient(ctrl)
+		gomock.InOrder(
+			ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (*pb.PieceHash, error) {
+					_, err := io.Copy(ioutil.Discard, data)
+					assert.NoError(t, err)
+					if n != node3 {
+						return &pb.PieceHash{Id: id.String()}, nil
+					}
+					// the slowest node never finishes on its own
+					<-ctx.Done()
+					close(canceled)
+					return nil, ctx.Err()
+				}),
+			ps.EXPECT().Close().Return(nil),
+		)
+		clients[n] = ps
+	}
+
+	ec := ecClient{newPSClientFunc: mockNewPSClient(clients)}
+	successfulNodes, successfulHashes, err := ec.Put(ctx, nodes, rs, id, io.LimitReader(rand.Reader, int64(size)), ttl, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("the upload of the long tail wasn't canceled")
+	}
+
+	assert.Equal(t, []*pb.Node{node0, node1, node2, nil}, successfulNodes)
+	for i := range nodes[:3] {
+		assert.NotNil(t, successfulHashes[i])
+	}
+	assert.Nil(t, successfulHashes[3])
+}
+
 func mockNewPSClient(clients map[*pb.Node]psclient.Client) psClientFunc {
 	return func(_ context.Context, _ transport.Client, n *pb.Node, _ int) (psclient.Client, error) {
 		n.Type.DPanicOnInvalid("mock new ps client")
@@ -242,7 +323,7 @@ TestLoop:
 			}
 		}
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
-		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), nil, nil)
+		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), nil, nil, nil)
 		if err == nil {
 			_, err := rr.Range(ctx, 0, 0)
 			assert.NoError(t, err, errTag)
@@ -256,6 +337,220 @@ TestLoop:
 	}
 }
 
+func TestGetFallback(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// only 4 of the 8 pieces are requested at first
+	k, n := 2, 8
+	fc, err := infectious.NewFEC(k, n)
+	if !assert.NoError(t, err) {
+		return
+	}
+	es := eestream.NewRSScheme(fc, 1024)
+
+	data := make([]byte, 4*es.StripeSize())
+	_, err = rand.Read(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pieces := make([][]byte, n)
+	for offset := 0; offset < len(data); offset += es.StripeSize() {
+		err := es.Encode(data[offset:offset+es.StripeSize()], func(num int, share []byte) {
+			pieces[num] = append(pieces[num], share...)
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	id := psclient.NewPieceID()
+	nodes := make([]*pb.Node, n)
+	clients := make(map[*pb.Node]psclient.Client, n)
+	for i := range nodes {
+		nodes[i] = teststorj.MockNode(fmt.Sprintf("fallback-node-%d", i))
+		derivedID, err := id.Derive(nodes[i].Id.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// only the last two nodes have their pieces, which are most likely
+		// not both selected at first
+		ps := NewMockPSClient(ctrl)
+		if i < n-k {
+			ps.EXPECT().Get(gomock.Any(), derivedID, int64(len(pieces[i])), gomock.Any(), gomock.Any()).
+				Return(nil, ErrOpFailed).AnyTimes()
+		} else {
+			ps.EXPECT().Get(gomock.Any(), derivedID, int64(len(pieces[i])), gomock.Any(), gomock.Any()).
+				Return(ranger.ByteRanger(pieces[i]), nil).AnyTimes()
+		}
+		clients[nodes[i]] = ps
+	}
+
+	ec := ecClient{newPSClientFunc: mockNewPSClient(clients)}
+	rr, err := ec.Get(ctx, nodes, es, id, int64(len(data)), nil, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, r := range []struct{ offset, length int64 }{
+		{0, int64(len(data))},
+		{100, 5000},
+	} {
+		rc, err := rr.Range(ctx, r.offset, r.length)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		read, err := ioutil.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+		assert.Equal(t, data[r.offset:r.offset+r.length], read)
+	}
+}
+
+func TestLazyPieceRangerHash(t *testing.T) {
+	ctx := context.Background()
+
+	data := []byte("some piece data")
+	sum := sha256.Sum256(data)
+
+	for i, tt := range []struct {
+		piece          []byte
+		offset, length int64
+		corrupted      bool
+	}{
+		{data, 0, int64(len(data)), false},
+		{[]byte("some piece dat4"), 0, int64(len(data)), true},
+		{[]byte("some piece dat4"), 0, 4, false},
+		{[]byte("some piece dat4"), 4, int64(len(data)) - 4, false},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		var reported bool
+		rr := &lazyPieceRanger{
+			ranger:    ranger.ByteRanger(tt.piece),
+			node:      node0,
+			size:      int64(len(tt.piece)),
+			hash:      &pb.PieceHash{Hash: sum[:]},
+			corrupted: func() { reported = true },
+		}
+
+		rc, err := rr.Range(ctx, tt.offset, tt.length)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		read, err := ioutil.ReadAll(rc)
+		assert.NoError(t, rc.Close(), errTag)
+
+		if tt.corrupted {
+			assert.True(t, ErrPieceHashMismatch.Has(err), errTag)
+			assert.True(t, reported, errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+			assert.False(t, reported, errTag)
+			assert.Equal(t, tt.piece[tt.offset:tt.offset+tt.length], read, errTag)
+		}
+	}
+}
+
+func TestLazyPieceRangerBlockHashes(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const blockSize = 4
+	data := []byte("some piece data")
+	hasher := psclient.NewBlockHasher(blockSize)
+	_, _ = hasher.Write(data)
+	hashes := hasher.BlockHashes()
+	hash := &pb.PieceHash{Hash: psclient.BlocksHash(hashes), BlockSize: blockSize}
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[9] = 'x'
+
+	for i, tt := range []struct {
+		piece          []byte
+		hashes         [][]byte
+		offset, length int64
+		corrupted      bool
+	}{
+		{data, hashes, 0, int64(len(data)), false},
+		{data, hashes, 5, 6, false},
+		{data, hashes, 13, 2, false},
+		{data, hashes, 7, 0, false},
+		// the blocks are verified on partial reads
+		{corrupted, hashes, 0, int64(len(data)), true},
+		{corrupted, hashes, 9, 1, true},
+		{corrupted, hashes, 0, 8, false},
+		{corrupted, hashes, 12, 3, false},
+		// the block hashes must match the hash of the piece
+		{data, hashes[1:], 0, 4, true},
+		{data, append([][]byte{hashes[1]}, hashes[1:]...), 0, 4, true},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		ps := NewMockPSClient(ctrl)
+		ps.EXPECT().Meta(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&pb.PieceSummary{HashBlockSize: blockSize, BlockHashes: tt.hashes}, nil).AnyTimes()
+
+		var reported bool
+		rr := &lazyPieceRanger{
+			ranger:    ranger.ByteRanger(tt.piece),
+			client:    ps,
+			node:      node0,
+			size:      int64(len(tt.piece)),
+			hash:      hash,
+			corrupted: func() { reported = true },
+		}
+
+		rc, err := rr.Range(ctx, tt.offset, tt.length)
+		var read []byte
+		if err == nil {
+			read, err = ioutil.ReadAll(rc)
+			assert.NoError(t, rc.Close(), errTag)
+		}
+
+		if tt.corrupted {
+			assert.True(t, ErrPieceHashMismatch.Has(err), errTag)
+			assert.True(t, reported, errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+			assert.False(t, reported, errTag)
+			assert.Equal(t, tt.piece[tt.offset:tt.offset+tt.length], read, errTag)
+		}
+	}
+}
+
+func TestVerifiedBlockReaderClosedEarly(t *testing.T) {
+	data := []byte("some piece data")
+	hasher := psclient.NewBlockHasher(4)
+	_, _ = hasher.Write(data)
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[5] = 'x'
+
+	var reported bool
+	r := &verifiedBlockReader{
+		reader:    ioutil.NopCloser(bytes.NewReader(corrupted)),
+		blockSize: 4,
+		hashes:    hasher.BlockHashes(),
+		unread:    int64(len(data)),
+		remaining: int64(len(data)),
+		corrupted: func() { reported = true },
+	}
+
+	// the bytes of the corrupted second block are never returned, even to a
+	// reader which stops within it
+	buf := make([]byte, 5)
+	n, err := io.ReadFull(r, buf)
+	assert.Equal(t, 4, n)
+	assert.True(t, ErrPieceHashMismatch.Has(err))
+	assert.True(t, reported)
+	assert.Equal(t, data[:4], buf[:n])
+	assert.NoError(t, r.Close())
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
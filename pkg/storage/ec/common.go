@@ -9,3 +9,6 @@ import (
 
 // Error is the errs class of standard Ranger errors
 var Error = errs.Class("ecclient error")
+
+// ErrPieceHashMismatch is the errs class of pieces not matching their hash
+var ErrPieceHashMismatch = errs.Class("piece hash mismatch")
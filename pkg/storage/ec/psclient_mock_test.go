@@ -83,23 +83,24 @@ func (mr *MockPSClientMockRecorder) Get(arg0, arg1, arg2, arg3, arg4 interface{}
 }
 
 // Meta mocks base method
-func (m *MockPSClient) Meta(arg0 context.Context, arg1 client.PieceID) (*pb.PieceSummary, error) {
-	ret := m.ctrl.Call(m, "Meta", arg0, arg1)
+func (m *MockPSClient) Meta(arg0 context.Context, arg1 client.PieceID, arg2 *pb.SignedMessage) (*pb.PieceSummary, error) {
+	ret := m.ctrl.Call(m, "Meta", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*pb.PieceSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Meta indicates an expected call of Meta
-func (mr *MockPSClientMockRecorder) Meta(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Meta", reflect.TypeOf((*MockPSClient)(nil).Meta), arg0, arg1)
+func (mr *MockPSClientMockRecorder) Meta(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Meta", reflect.TypeOf((*MockPSClient)(nil).Meta), arg0, arg1, arg2)
 }
 
 // Put mocks base method
-func (m *MockPSClient) Put(arg0 context.Context, arg1 client.PieceID, arg2 io.Reader, arg3 time.Time, arg4 *pb.PayerBandwidthAllocation, arg5 *pb.SignedMessage) error {
+func (m *MockPSClient) Put(arg0 context.Context, arg1 client.PieceID, arg2 io.Reader, arg3 time.Time, arg4 *pb.PayerBandwidthAllocation, arg5 *pb.SignedMessage) (*pb.PieceHash, error) {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*pb.PieceHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Put indicates an expected call of Put
@@ -107,6 +108,32 @@ func (mr *MockPSClientMockRecorder) Put(arg0, arg1, arg2, arg3, arg4, arg5 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPSClient)(nil).Put), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// RestoreTrash mocks base method
+func (m *MockPSClient) RestoreTrash(arg0 context.Context) (int64, error) {
+	ret := m.ctrl.Call(m, "RestoreTrash", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTrash indicates an expected call of RestoreTrash
+func (mr *MockPSClientMockRecorder) RestoreTrash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTrash", reflect.TypeOf((*MockPSClient)(nil).RestoreTrash), arg0)
+}
+
+// Retain mocks base method
+func (m *MockPSClient) Retain(arg0 context.Context, arg1 time.Time, arg2 []byte) (int64, error) {
+	ret := m.ctrl.Call(m, "Retain", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retain indicates an expected call of Retain
+func (mr *MockPSClientMockRecorder) Retain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retain", reflect.TypeOf((*MockPSClient)(nil).Retain), arg0, arg1, arg2)
+}
+
 // Stats mocks base method
 func (m *MockPSClient) Stats(arg0 context.Context) (*pb.StatSummary, error) {
 	ret := m.ctrl.Call(m, "Stats", arg0)
@@ -28,6 +28,7 @@ type Meta struct {
 	Expiration time.Time
 	Size       int64
 	Checksum   string
+	SHA256     []byte
 }
 
 // ListItem is a single item in a listing
@@ -155,6 +156,8 @@ func convertMeta(m streams.Meta) Meta {
 		Modified:         m.Modified,
 		Expiration:       m.Expiration,
 		Size:             m.Size,
+		Checksum:         string(m.Checksum),
+		SHA256:           m.SHA256,
 		SerializableMeta: ser,
 	}
 }
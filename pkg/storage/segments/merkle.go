@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package segments
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// merkleRoot returns the root of the merkle tree over the hashes of pieces,
+// ordered by piece number. It returns nil if any of the pieces has no hash.
+func merkleRoot(pieces []*pb.RemotePiece) []byte {
+	if len(pieces) == 0 {
+		return nil
+	}
+
+	sorted := append([]*pb.RemotePiece(nil), pieces...)
+	sort.Slice(sorted, func(i, k int) bool {
+		return sorted[i].GetPieceNum() < sorted[k].GetPieceNum()
+	})
+
+	level := make([][]byte, 0, len(sorted))
+	for _, piece := range sorted {
+		if len(piece.GetHash()) == 0 {
+			return nil
+		}
+		var num [4]byte
+		binary.BigEndian.PutUint32(num[:], uint32(piece.GetPieceNum()))
+		level = append(level, merkleHash(num[:], piece.GetHash()))
+	}
+
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				// the odd node is promoted to the next level
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, merkleHash([]byte{1}, level[i], level[i+1]))
+		}
+		level = next
+	}
+
+	return level[0]
+}
+
+func merkleHash(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// pieceHashes returns the hashes of the pieces of seg indexed by piece
+// number, once they're verified against the merkle root of seg. The hashes
+// signed by the storage nodes are returned for the pieces which have them. It
+// returns nil if seg has no merkle root.
+func pieceHashes(seg *pb.RemoteSegment) ([]*pb.PieceHash, error) {
+	if len(seg.GetMerkleRoot()) == 0 {
+		return nil, nil
+	}
+
+	if !bytes.Equal(merkleRoot(seg.GetRemotePieces()), seg.GetMerkleRoot()) {
+		return nil, Error.New("piece hashes don't match the merkle root")
+	}
+
+	hashes := make([]*pb.PieceHash, seg.GetRedundancy().GetTotal())
+	for _, piece := range seg.GetRemotePieces() {
+		if num := int(piece.GetPieceNum()); num >= 0 && num < len(hashes) {
+			hashes[num] = remotePieceHash(piece)
+		}
+	}
+	return hashes, nil
+}
+
+// remotePieceHash returns the hash signed by the storage node of piece, or
+// only the hash of its content for the pieces stored before the signed hashes
+// were kept
+func remotePieceHash(piece *pb.RemotePiece) *pb.PieceHash {
+	signed := piece.GetSignedHash()
+	if signed != nil && bytes.Equal(signed.GetHash(), piece.GetHash()) {
+		return signed
+	}
+	return &pb.PieceHash{Hash: piece.GetHash()}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestMerkleRoot(t *testing.T) {
+	pieces := []*pb.RemotePiece{
+		{PieceNum: 3, Hash: []byte("hash3")},
+		{PieceNum: 0, Hash: []byte("hash0")},
+		{PieceNum: 1, Hash: []byte("hash1")},
+	}
+
+	root := merkleRoot(pieces)
+	assert.Len(t, root, 32)
+
+	// the order of the pieces doesn't matter
+	reordered := []*pb.RemotePiece{pieces[1], pieces[2], pieces[0]}
+	assert.Equal(t, root, merkleRoot(reordered))
+
+	// any other hash or piece number changes the root
+	changed := []*pb.RemotePiece{pieces[0], pieces[1], {PieceNum: 2, Hash: []byte("hash3")}}
+	assert.NotEqual(t, root, merkleRoot(changed))
+	changed = []*pb.RemotePiece{pieces[0], pieces[1], {PieceNum: 3, Hash: []byte("hash2")}}
+	assert.NotEqual(t, root, merkleRoot(changed))
+
+	// without all of the hashes there is no root
+	assert.Nil(t, merkleRoot(nil))
+	assert.Nil(t, merkleRoot([]*pb.RemotePiece{pieces[0], {PieceNum: 2}}))
+}
+
+func TestPieceHashes(t *testing.T) {
+	signed := &pb.PieceHash{Hash: []byte("hash2"), BlockSize: 1024, Signature: []byte("signature")}
+	pieces := []*pb.RemotePiece{
+		{PieceNum: 0, Hash: []byte("hash0")},
+		{PieceNum: 2, Hash: []byte("hash2"), SignedHash: signed},
+	}
+	seg := &pb.RemoteSegment{
+		Redundancy:   &pb.RedundancyScheme{Total: 3},
+		RemotePieces: pieces,
+		MerkleRoot:   merkleRoot(pieces),
+	}
+
+	hashes, err := pieceHashes(seg)
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.PieceHash{{Hash: []byte("hash0")}, nil, signed}, hashes)
+
+	seg.RemotePieces[1].Hash = []byte("corrupted")
+	_, err = pieceHashes(seg)
+	assert.Error(t, err)
+
+	seg.MerkleRoot = nil
+	hashes, err = pieceHashes(seg)
+	assert.NoError(t, err)
+	assert.Nil(t, hashes)
+}
@@ -12,6 +12,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	eestream "storj.io/storj/pkg/eestream"
 	ranger "storj.io/storj/pkg/ranger"
 	storj "storj.io/storj/pkg/storj"
 )
@@ -91,6 +92,19 @@ func (mr *MockStoreMockRecorder) Put(ctx, data, expiration, segmentInfo interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expiration, segmentInfo)
 }
 
+// PutDeduplicated mocks base method
+func (m *MockStore) PutDeduplicated(ctx context.Context, dedupID []byte, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (Meta, error) {
+	ret := m.ctrl.Call(m, "PutDeduplicated", ctx, dedupID, data, expiration, segmentInfo)
+	ret0, _ := ret[0].(Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutDeduplicated indicates an expected call of PutDeduplicated
+func (mr *MockStoreMockRecorder) PutDeduplicated(ctx, dedupID, data, expiration, segmentInfo interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDeduplicated", reflect.TypeOf((*MockStore)(nil).PutDeduplicated), ctx, dedupID, data, expiration, segmentInfo)
+}
+
 // Delete mocks base method
 func (m *MockStore) Delete(ctx context.Context, path storj.Path) error {
 	ret := m.ctrl.Call(m, "Delete", ctx, path)
@@ -103,6 +117,32 @@ func (mr *MockStoreMockRecorder) Delete(ctx, path interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), ctx, path)
 }
 
+// Copy mocks base method
+func (m *MockStore) Copy(ctx context.Context, source, destination storj.Path, metadata []byte) (Meta, error) {
+	ret := m.ctrl.Call(m, "Copy", ctx, source, destination, metadata)
+	ret0, _ := ret[0].(Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy
+func (mr *MockStoreMockRecorder) Copy(ctx, source, destination, metadata interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockStore)(nil).Copy), ctx, source, destination, metadata)
+}
+
+// UpdateMeta mocks base method
+func (m *MockStore) UpdateMeta(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) (Meta, error) {
+	ret := m.ctrl.Call(m, "UpdateMeta", ctx, path, metadata, expiration)
+	ret0, _ := ret[0].(Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMeta indicates an expected call of UpdateMeta
+func (mr *MockStoreMockRecorder) UpdateMeta(ctx, path, metadata, expiration interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeta", reflect.TypeOf((*MockStore)(nil).UpdateMeta), ctx, path, metadata, expiration)
+}
+
 // List mocks base method
 func (m *MockStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) ([]ListItem, bool, error) {
 	ret := m.ctrl.Call(m, "List", ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags)
@@ -116,3 +156,15 @@ func (m *MockStore) List(ctx context.Context, prefix, startAfter, endBefore stor
 func (mr *MockStoreMockRecorder) List(ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStore)(nil).List), ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags)
 }
+
+// WithRedundancy mocks base method
+func (m *MockStore) WithRedundancy(rs eestream.RedundancyStrategy) Store {
+	ret := m.ctrl.Call(m, "WithRedundancy", rs)
+	ret0, _ := ret[0].(Store)
+	return ret0
+}
+
+// WithRedundancy indicates an expected call of WithRedundancy
+func (mr *MockStoreMockRecorder) WithRedundancy(rs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithRedundancy", reflect.TypeOf((*MockStore)(nil).WithRedundancy), rs)
+}
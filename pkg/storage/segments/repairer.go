@@ -46,6 +46,12 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	seg := pr.GetRemote()
 	pid := psclient.PieceID(seg.GetPieceId())
 
+	// the pieces of a deduplicated or copied segment are repaired with the
+	// entry of the segment, which the pointer under path only references
+	if len(seg.GetDedupId()) > 0 {
+		return Error.New("cannot repair a reference to the segment %x, repair its entry instead", seg.GetDedupId())
+	}
+
 	originalNodes, err = lookupAndAlignNodes(ctx, s.oc, originalNodes, seg)
 	if err != nil {
 		return Error.Wrap(err)
@@ -123,8 +129,17 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	hashes, err := pieceHashes(seg)
+	if err != nil {
+		return err
+	}
+	var verified *ecclient.PieceHashes
+	if hashes != nil {
+		verified = &ecclient.PieceHashes{Hashes: hashes}
+	}
+
 	// Download the segment using just the healthyNodes
-	rr, err := s.ec.Get(ctx, healthyNodes, rs, pid, pr.GetSegmentSize(), pbaGet, signedMessage)
+	rr, err := s.ec.Get(ctx, healthyNodes, rs, pid, pr.GetSegmentSize(), pbaGet, signedMessage, verified)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -140,11 +155,19 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 		return Error.Wrap(err)
 	}
 	// Upload the repaired pieces to the repairNodes
-	successfulNodes, err := s.ec.Put(ctx, repairNodes, rs, pid, r, convertTime(pr.GetExpirationDate()), pbaPut, signedMessage)
+	successfulNodes, successfulHashes, err := s.ec.Put(ctx, repairNodes, rs, pid, r, convertTime(pr.GetExpirationDate()), pbaPut, signedMessage)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	// keep the hashes of the healthy pieces
+	for _, piece := range seg.GetRemotePieces() {
+		i := piece.GetPieceNum()
+		if i >= 0 && int(i) < len(healthyNodes) && healthyNodes[i] != nil && int(i) < len(successfulHashes) {
+			successfulHashes[i] = remotePieceHash(piece)
+		}
+	}
+
 	// Merge the successful nodes list into the healthy nodes list
 	for i, v := range healthyNodes {
 		if v == nil {
@@ -154,10 +177,12 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	}
 
 	metadata := pr.GetMetadata()
-	pointer, err := makeRemotePointer(healthyNodes, rs, pid, rr.Size(), pr.GetExpirationDate(), metadata)
+	pointer, err := makeRemotePointer(healthyNodes, successfulHashes, rs, pid, rr.Size(), pr.GetExpirationDate(), metadata)
 	if err != nil {
 		return err
 	}
+	// the healthy pieces may still be referenced by other pointers
+	pointer.Remote.References = pr.GetRemote().GetReferences()
 
 	// update the segment info in the pointerDB
 	return s.pdb.Put(ctx, path, pointer)
@@ -104,12 +104,12 @@ func TestSegmentStoreRepairRemote(t *testing.T) {
 			mockPDB.EXPECT().SignedMessage(),
 			mockPDB.EXPECT().PayerBandwidthAllocation(gomock.Any(), gomock.Any()),
 			mockEC.EXPECT().Get(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(ranger.ByteRanger([]byte(tt.data)), nil),
 			mockPDB.EXPECT().PayerBandwidthAllocation(gomock.Any(), gomock.Any()),
 			mockEC.EXPECT().Put(
 				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
-			).Return(tt.newNodes, nil),
+			).Return(tt.newNodes, nil, nil),
 			mockPDB.EXPECT().Put(
 				gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(nil),
@@ -120,3 +120,25 @@ func TestSegmentStoreRepairRemote(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestSegmentStoreRepairReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOC := mock_overlay.NewMockClient(ctrl)
+	mockEC := mock_ecclient.NewMockClient(ctrl)
+	mockPDB := mock_pointerdb.NewMockClient(ctrl)
+
+	// the reference is resolved by pointerdb, but only its entry is repaired
+	mockPDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			DedupId:      []byte{1, 2, 3},
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 0}},
+		},
+	}, nil, nil, nil)
+
+	sr := NewSegmentRepairer(mockOC, mockEC, mockPDB)
+	err := sr.Repair(ctx, "s0/project/bucket/a", []int32{0})
+	assert.Error(t, err)
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/vivint/infectious"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/eestream"
@@ -23,6 +25,7 @@ import (
 	"storj.io/storj/pkg/ranger"
 	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -49,8 +52,12 @@ type Store interface {
 	Meta(ctx context.Context, path storj.Path) (meta Meta, err error)
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
 	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
+	PutDeduplicated(ctx context.Context, dedupID []byte, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	Copy(ctx context.Context, source, destination storj.Path, metadata []byte) (meta Meta, err error)
+	UpdateMeta(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) (meta Meta, err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+	WithRedundancy(rs eestream.RedundancyStrategy) Store
 }
 
 type segmentStore struct {
@@ -66,6 +73,14 @@ func NewSegmentStore(oc overlay.Client, ec ecclient.Client, pdb pdbclient.Client
 	return &segmentStore{oc: oc, ec: ec, pdb: pdb, rs: rs, thresholdSize: threshold}
 }
 
+// WithRedundancy returns a copy of the store which uploads the remote segments
+// with the redundancy strategy rs
+func (s *segmentStore) WithRedundancy(rs eestream.RedundancyStrategy) Store {
+	store := *s
+	store.rs = rs
+	return &store
+}
+
 // Meta retrieves the metadata of the segment
 func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -82,6 +97,20 @@ func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, er
 func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return s.put(ctx, nil, data, expiration, segmentInfo)
+}
+
+// PutDeduplicated is like Put, but a remote segment references the existing
+// segment with the same dedupID in the project instead of being uploaded
+// again. dedupID must be derived from the content of data, and segmentInfo
+// may be called before data is read.
+func (s *segmentStore) PutDeduplicated(ctx context.Context, dedupID []byte, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.put(ctx, dedupID, data, expiration, segmentInfo)
+}
+
+func (s *segmentStore) put(ctx context.Context, dedupID []byte, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
 	exp, err := ptypes.TimestampProto(expiration)
 	if err != nil {
 		return Meta{}, Error.Wrap(err)
@@ -110,6 +139,13 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 			Metadata:       metadata,
 		}
 	} else {
+		if len(dedupID) > 0 {
+			m, err := s.putReference(ctx, dedupID, exp, segmentInfo)
+			if err == nil || !storage.ErrKeyNotFound.Has(err) {
+				return m, err
+			}
+		}
+
 		sizedReader := SizeReader(peekReader)
 
 		// uses overlay client to request a list of nodes according to configured standards
@@ -135,7 +171,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 			return Meta{}, Error.Wrap(err)
 		}
 
-		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
+		successfulNodes, successfulHashes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
@@ -146,10 +182,11 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		}
 		path = p
 
-		pointer, err = makeRemotePointer(successfulNodes, s.rs, pieceID, sizedReader.Size(), exp, metadata)
+		pointer, err = makeRemotePointer(successfulNodes, successfulHashes, s.rs, pieceID, sizedReader.Size(), exp, metadata)
 		if err != nil {
 			return Meta{}, err
 		}
+		pointer.Remote.DedupId = dedupID
 	}
 
 	// puts pointer to pointerDB
@@ -166,6 +203,33 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 	return m, nil
 }
 
+// putReference puts a pointer referencing the pieces of the existing segment
+// with dedupID. It returns a not found error if the project has no such
+// segment.
+func (s *segmentStore) putReference(ctx context.Context, dedupID []byte, exp *timestamp.Timestamp, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path, metadata, err := segmentInfo()
+	if err != nil {
+		return Meta{}, Error.Wrap(err)
+	}
+
+	err = s.pdb.Put(ctx, path, &pb.Pointer{
+		Type:           pb.Pointer_REMOTE,
+		Remote:         &pb.RemoteSegment{DedupId: dedupID},
+		ExpirationDate: exp,
+		Metadata:       metadata,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return Meta{}, storage.ErrKeyNotFound.Wrap(err)
+		}
+		return Meta{}, Error.Wrap(err)
+	}
+
+	return s.Meta(ctx, path)
+}
+
 // Get retrieves a segment using erasure code, overlay, and pointerdb clients
 func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -210,8 +274,25 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 			node.Type.DPanicOnInvalid("ss get")
 		}
 
+		hashes, err := pieceHashes(seg)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		var verified *ecclient.PieceHashes
+		if hashes != nil {
+			verified = &ecclient.PieceHashes{
+				Hashes: hashes,
+				Corrupted: func(pieceNum int, node *pb.Node) {
+					err := s.pdb.ReportCorruption(ctx, path, int32(pieceNum), node.Id)
+					if err != nil {
+						zap.S().Warnf("Failed reporting corrupted piece %d of %s from node %s: %v", pieceNum, path, node.Id, err)
+					}
+				},
+			}
+		}
+
 		authorization := s.pdb.SignedMessage()
-		rr, err = s.ec.Get(ctx, selected, rs, pid, pr.GetSegmentSize(), pba, authorization)
+		rr, err = s.ec.Get(ctx, selected, rs, pid, pr.GetSegmentSize(), pba, authorization, verified)
 		if err != nil {
 			return nil, Meta{}, Error.Wrap(err)
 		}
@@ -222,18 +303,24 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 	return rr, convertMeta(pr), nil
 }
 
-// makeRemotePointer creates a pointer of type remote
-func makeRemotePointer(nodes []*pb.Node, rs eestream.RedundancyStrategy, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
+// makeRemotePointer creates a pointer of type remote, hashes are the hashes
+// of the pieces stored on nodes
+func makeRemotePointer(nodes []*pb.Node, hashes []*pb.PieceHash, rs eestream.RedundancyStrategy, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
 	var remotePieces []*pb.RemotePiece
 	for i := range nodes {
 		if nodes[i] == nil {
 			continue
 		}
 		nodes[i].Type.DPanicOnInvalid("makeremotepointer")
-		remotePieces = append(remotePieces, &pb.RemotePiece{
+		piece := &pb.RemotePiece{
 			PieceNum: int32(i),
 			NodeId:   nodes[i].Id,
-		})
+		}
+		if i < len(hashes) && hashes[i] != nil {
+			piece.Hash = hashes[i].GetHash()
+			piece.SignedHash = hashes[i]
+		}
+		remotePieces = append(remotePieces, piece)
 	}
 
 	pointer = &pb.Pointer{
@@ -249,6 +336,7 @@ func makeRemotePointer(nodes []*pb.Node, rs eestream.RedundancyStrategy, pieceID
 			},
 			PieceId:      string(pieceID),
 			RemotePieces: remotePieces,
+			MerkleRoot:   merkleRoot(remotePieces),
 		},
 		SegmentSize:    readerSize,
 		ExpirationDate: exp,
@@ -266,10 +354,21 @@ func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error)
 		return Error.Wrap(err)
 	}
 
-	if pr.GetType() == pb.Pointer_REMOTE {
-		seg := pr.GetRemote()
+	// deletes pointer from pointerdb, the pieces still referenced by other
+	// pointers are kept
+	released, err := s.pdb.Delete(ctx, path)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if released.GetType() == pb.Pointer_REMOTE {
+		seg := released.GetRemote()
 		pid := psclient.PieceID(seg.PieceId)
 
+		// the nodes were looked up for the pieces of the deleted pointer
+		if seg.GetPieceId() != pr.GetRemote().GetPieceId() {
+			nodes = nil
+		}
 		nodes, err = lookupAndAlignNodes(ctx, s.oc, nodes, seg)
 		if err != nil {
 			return Error.Wrap(err)
@@ -288,8 +387,33 @@ func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error)
 		}
 	}
 
-	// deletes pointer from pointerdb
-	return s.pdb.Delete(ctx, path)
+	return nil
+}
+
+// Copy duplicates a segment under a new path without copying the data of
+// the segment. The metadata of the source is kept if metadata is empty.
+func (s *segmentStore) Copy(ctx context.Context, source, destination storj.Path, metadata []byte) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.pdb.Copy(ctx, source, destination, metadata)
+	if err != nil {
+		return Meta{}, Error.Wrap(err)
+	}
+
+	return s.Meta(ctx, destination)
+}
+
+// UpdateMeta replaces the metadata, unless metadata is empty, and the
+// expiration of the segment at path without transferring its data
+func (s *segmentStore) UpdateMeta(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.pdb.UpdateMetadata(ctx, path, metadata, expiration)
+	if err != nil {
+		return Meta{}, Error.Wrap(err)
+	}
+
+	return s.Meta(ctx, path)
 }
 
 // List retrieves paths to segments and their metadata stored in the pointerdb
@@ -284,7 +284,7 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
 			mockPDB.EXPECT().SignedMessage(),
 			mockEC.EXPECT().Get(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
 		}
 		gomock.InOrder(calls...)
@@ -359,8 +359,11 @@ func TestSegmentStoreDeleteRemote(t *testing.T) {
 		pointerType   pb.Pointer_DataType
 		size          int64
 		metadata      []byte
+		released      bool
 	}{
-		{"path/1/2/3", 10, pb.Pointer_REMOTE, int64(3), []byte("metadata")},
+		{"path/1/2/3", 10, pb.Pointer_REMOTE, int64(3), []byte("metadata"), true},
+		// the pieces are still referenced by a copy
+		{"path/1/2/3", 10, pb.Pointer_REMOTE, int64(3), []byte("metadata"), false},
 	} {
 		mockOC := mock_overlay.NewMockClient(ctrl)
 		mockEC := mock_ecclient.NewMockClient(ctrl)
@@ -373,35 +376,46 @@ func TestSegmentStoreDeleteRemote(t *testing.T) {
 		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
 		assert.NotNil(t, ss)
 
+		pointer := &pb.Pointer{
+			Type: tt.pointerType,
+			Remote: &pb.RemoteSegment{
+				Redundancy: &pb.RedundancyScheme{
+					Type:             pb.RedundancyScheme_RS,
+					MinReq:           1,
+					Total:            2,
+					RepairThreshold:  1,
+					SuccessThreshold: 2,
+				},
+				PieceId:      "here's my piece id",
+				RemotePieces: []*pb.RemotePiece{},
+			},
+			CreationDate:   someTime,
+			ExpirationDate: someTime,
+			SegmentSize:    tt.size,
+			Metadata:       tt.metadata,
+		}
+
+		var released *pb.Pointer
+		if tt.released {
+			released = pointer
+		}
+
 		calls := []*gomock.Call{
 			mockPDB.EXPECT().Get(
 				gomock.Any(), gomock.Any(),
-			).Return(&pb.Pointer{
-				Type: tt.pointerType,
-				Remote: &pb.RemoteSegment{
-					Redundancy: &pb.RedundancyScheme{
-						Type:             pb.RedundancyScheme_RS,
-						MinReq:           1,
-						Total:            2,
-						RepairThreshold:  1,
-						SuccessThreshold: 2,
-					},
-					PieceId:      "here's my piece id",
-					RemotePieces: []*pb.RemotePiece{},
-				},
-				CreationDate:   someTime,
-				ExpirationDate: someTime,
-				SegmentSize:    tt.size,
-				Metadata:       tt.metadata,
-			}, nil, nil, nil),
-			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
-			mockPDB.EXPECT().SignedMessage(),
-			mockEC.EXPECT().Delete(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
-			),
+			).Return(pointer, nil, nil, nil),
 			mockPDB.EXPECT().Delete(
 				gomock.Any(), gomock.Any(),
-			),
+			).Return(released, nil),
+		}
+		if tt.released {
+			calls = append(calls,
+				mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
+				mockPDB.EXPECT().SignedMessage(),
+				mockEC.EXPECT().Delete(
+					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				),
+			)
 		}
 		gomock.InOrder(calls...)
 
@@ -0,0 +1,243 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding"
+	"hash"
+	"io"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+// ErrChecksumMismatch is the error class of downloads not matching the
+// checksum of the stream
+var ErrChecksumMismatch = errs.Class("checksum mismatch")
+
+// checksums are the MD5 and SHA-256 checksums of a content, or the states of
+// their hashes, nil when they aren't computed or unknown
+type checksums struct {
+	md5    []byte
+	sha256 []byte
+}
+
+// hashes are the hashes computing checksums, nil for the checksums that
+// aren't computed
+type hashes struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+}
+
+// newHashes returns the hashes computing the checksums of checksumType
+func newHashes(checksumType storj.ChecksumType) (h hashes, err error) {
+	if checksumType&^storj.MD5AndSHA256 != 0 {
+		return hashes{}, errs.New("unsupported checksum type %d", checksumType)
+	}
+	if checksumType.Has(storj.MD5) {
+		h.md5 = md5.New()
+	}
+	if checksumType.Has(storj.SHA256) {
+		h.sha256 = sha256.New()
+	}
+	return h, nil
+}
+
+// writer returns a writer adding the written content to all the hashes, nil
+// if there is none
+func (h hashes) writer() io.Writer {
+	var writers []io.Writer
+	for _, hash := range []hash.Hash{h.md5, h.sha256} {
+		if hash != nil {
+			writers = append(writers, hash)
+		}
+	}
+	if len(writers) == 0 {
+		return nil
+	}
+	return io.MultiWriter(writers...)
+}
+
+// sums returns the checksums computed by the hashes
+func (h hashes) sums() (sums checksums) {
+	if h.md5 != nil {
+		sums.md5 = h.md5.Sum(nil)
+	}
+	if h.sha256 != nil {
+		sums.sha256 = h.sha256.Sum(nil)
+	}
+	return sums
+}
+
+// states returns the states of the hashes
+func (h hashes) states() (states checksums, err error) {
+	if h.md5 != nil {
+		states.md5, err = h.md5.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return checksums{}, err
+		}
+	}
+	if h.sha256 != nil {
+		states.sha256, err = h.sha256.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return checksums{}, err
+		}
+	}
+	return states, nil
+}
+
+// keep forgets the hashes of the checksums missing from known
+func (h *hashes) keep(known checksums) {
+	if len(known.md5) == 0 {
+		h.md5 = nil
+	}
+	if len(known.sha256) == 0 {
+		h.sha256 = nil
+	}
+}
+
+// restore restores the states of the hashes, forgetting the hashes without
+// state as their checksums are unknown
+func (h *hashes) restore(states checksums) error {
+	h.keep(states)
+	if h.md5 != nil {
+		if err := h.md5.(encoding.BinaryUnmarshaler).UnmarshalBinary(states.md5); err != nil {
+			return err
+		}
+	}
+	if h.sha256 != nil {
+		if err := h.sha256.(encoding.BinaryUnmarshaler).UnmarshalBinary(states.sha256); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamChecksum computes the checksums of the content of a stream while it's
+// uploaded. The state of the checksums after each read segment is kept until
+// the segment is committed, so that an interrupted upload can be resumed.
+type streamChecksum struct {
+	hashes hashes
+	states map[int64]checksums
+}
+
+// newStreamChecksum creates the checksums of checksumType of a stream with
+// segments already committed, restoring the given states of their checksums.
+// The checksums of a resumed stream without states are unknown.
+func newStreamChecksum(checksumType storj.ChecksumType, segments int64, states checksums) (*streamChecksum, error) {
+	h, err := newHashes(checksumType)
+	if err != nil {
+		return nil, err
+	}
+
+	if segments > 0 {
+		if err := h.restore(states); err != nil {
+			return nil, err
+		}
+	}
+
+	return &streamChecksum{hashes: h, states: make(map[int64]checksums)}, nil
+}
+
+// reader returns a reader of data adding the read content to the checksums
+func (c *streamChecksum) reader(data io.Reader) io.Reader {
+	w := c.hashes.writer()
+	if w == nil {
+		return data
+	}
+	return io.TeeReader(data, w)
+}
+
+// read saves the states of the checksums after the given number of segments
+// were read
+func (c *streamChecksum) read(segments int64) error {
+	states, err := c.hashes.states()
+	if err != nil {
+		return err
+	}
+	c.states[segments] = states
+	return nil
+}
+
+// committed returns the states of the checksums after the given number of
+// committed segments, forgetting the states of the previous ones
+func (c *streamChecksum) committed(segments int64) checksums {
+	for read := range c.states {
+		if read < segments {
+			delete(c.states, read)
+		}
+	}
+	return c.states[segments]
+}
+
+// sums returns the checksums of the read content, nil for the unknown ones
+func (c *streamChecksum) sums() checksums {
+	return c.hashes.sums()
+}
+
+// verifiedRanger is a ranger verifying that the whole content matches the
+// checksums
+type verifiedRanger struct {
+	ranger.Ranger
+	checksumType storj.ChecksumType
+	sums         checksums
+}
+
+// verifyRanger returns a ranger verifying reads of the whole content of rr
+// against the known checksums of checksumType. Reads of partial ranges are
+// not verified.
+func verifyRanger(rr ranger.Ranger, checksumType storj.ChecksumType, sums checksums) ranger.Ranger {
+	if len(sums.md5) == 0 && len(sums.sha256) == 0 {
+		return rr
+	}
+	return &verifiedRanger{Ranger: rr, checksumType: checksumType, sums: sums}
+}
+
+// Range implements Ranger.Range
+func (vr *verifiedRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	r, err := vr.Ranger.Range(ctx, offset, length)
+	if err != nil || offset != 0 || length != vr.Size() {
+		return r, err
+	}
+
+	h, err := newHashes(vr.checksumType)
+	if err != nil {
+		return nil, errs.Combine(err, r.Close())
+	}
+	h.keep(vr.sums)
+
+	w := h.writer()
+	if w == nil {
+		return r, nil
+	}
+	return &verifiedReader{ReadCloser: r, hashes: h, writer: w, sums: vr.sums}, nil
+}
+
+// verifiedReader returns an error at the end of the content if it doesn't
+// match the checksums
+type verifiedReader struct {
+	io.ReadCloser
+	hashes hashes
+	writer io.Writer
+	sums   checksums
+}
+
+// Read implements io.Reader
+func (vr *verifiedReader) Read(p []byte) (n int, err error) {
+	n, err = vr.ReadCloser.Read(p)
+	_, _ = vr.writer.Write(p[:n])
+	if err == io.EOF {
+		sums := vr.hashes.sums()
+		if !bytes.Equal(sums.md5, vr.sums.md5) || !bytes.Equal(sums.sha256, vr.sums.sha256) {
+			return n, ErrChecksumMismatch.New("%x %x", vr.sums.md5, vr.sums.sha256)
+		}
+	}
+	return n, err
+}
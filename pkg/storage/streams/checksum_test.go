@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestStreamChecksum(t *testing.T) {
+	expectedMD5 := md5.Sum([]byte("firstsecond"))
+	expectedSHA256 := sha256.Sum256([]byte("firstsecond"))
+
+	checksum, err := newStreamChecksum(storj.MD5AndSHA256, 0, checksums{})
+	require.NoError(t, err)
+
+	_, err = ioutil.ReadAll(checksum.reader(strings.NewReader("first")))
+	require.NoError(t, err)
+	require.NoError(t, checksum.read(1))
+
+	// the upload is resumed from the states of the committed segment
+	resumed, err := newStreamChecksum(storj.MD5AndSHA256, 1, checksum.committed(1))
+	require.NoError(t, err)
+
+	_, err = ioutil.ReadAll(resumed.reader(strings.NewReader("second")))
+	require.NoError(t, err)
+	sums := resumed.sums()
+	assert.Equal(t, expectedMD5[:], sums.md5)
+	assert.Equal(t, expectedSHA256[:], sums.sha256)
+
+	// only the checksums of the type are computed
+	onlySHA256, err := newStreamChecksum(storj.SHA256, 0, checksums{})
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(onlySHA256.reader(strings.NewReader("firstsecond")))
+	require.NoError(t, err)
+	sums = onlySHA256.sums()
+	assert.Nil(t, sums.md5)
+	assert.Equal(t, expectedSHA256[:], sums.sha256)
+
+	// without states the checksums of a resumed upload are unknown
+	unknown, err := newStreamChecksum(storj.MD5AndSHA256, 1, checksums{})
+	require.NoError(t, err)
+	assert.Equal(t, checksums{}, unknown.sums())
+
+	_, err = newStreamChecksum(storj.ChecksumType(100), 0, checksums{})
+	assert.Error(t, err)
+}
+
+func TestVerifyRanger(t *testing.T) {
+	content := "content"
+	md5Sum := md5.Sum([]byte(content))
+	sha256Sum := sha256.Sum256([]byte(content))
+	sums := checksums{md5: md5Sum[:], sha256: sha256Sum[:]}
+
+	rr := verifyRanger(ranger.ByteRanger([]byte(content)), storj.MD5AndSHA256, sums)
+	reader, err := rr.Range(ctx, 0, rr.Size())
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	rr = verifyRanger(ranger.ByteRanger([]byte("corrupted")), storj.MD5AndSHA256, sums)
+	reader, err = rr.Range(ctx, 0, rr.Size())
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	assert.True(t, ErrChecksumMismatch.Has(err))
+
+	// partial ranges are not verified
+	reader, err = rr.Range(ctx, 1, 3)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+
+	// a single known checksum is verified
+	rr = verifyRanger(ranger.ByteRanger([]byte("corrupted")), storj.MD5AndSHA256, checksums{sha256: sha256Sum[:]})
+	reader, err = rr.Range(ctx, 0, rr.Size())
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	assert.True(t, ErrChecksumMismatch.Has(err))
+}
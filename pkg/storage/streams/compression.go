@@ -0,0 +1,217 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+// checkCompression returns an error if compression is not supported
+func checkCompression(compression storj.Compression) error {
+	switch compression {
+	case storj.NoCompression, storj.Gzip:
+		return nil
+	default:
+		return errs.New("unsupported compression type %d", compression)
+	}
+}
+
+// compressedBlockSize is the size of the blocks of the content of a segment
+// which are compressed on their own
+const compressedBlockSize = 1 << 20
+
+// compressedReader is a reader of compressed content, which keeps track of
+// the offsets of the compressed blocks
+type compressedReader struct {
+	io.ReadCloser
+	blockSize int64
+
+	mu      sync.Mutex
+	done    bool
+	offsets []int64
+}
+
+// compressReader returns a reader of data compressed with compression. Each
+// segment is compressed on its own, so that its content can be read without
+// the previous segments, and so is each block of blockSize bytes of it, so
+// that a range of the content is decompressed from the block it starts in.
+// The returned reader must be closed to release the compressing goroutine if
+// it's not read until EOF.
+func compressReader(data io.Reader, compression storj.Compression, blockSize int64) *compressedReader {
+	if compression == storj.NoCompression {
+		return &compressedReader{ReadCloser: ioutil.NopCloser(data), done: true}
+	}
+
+	pr, pw := io.Pipe()
+	reader := &compressedReader{ReadCloser: pr, blockSize: blockSize}
+	go func() {
+		counter := &countingWriter{writer: pw}
+		var offsets []int64
+		var err error
+		for {
+			offset := counter.written
+			gz := gzip.NewWriter(counter)
+			var n int64
+			n, err = io.CopyN(gz, data, blockSize)
+			if err == io.EOF {
+				err = nil
+			}
+			// the empty block after content of a multiple of the block size
+			// is left out
+			if n == 0 && len(offsets) > 0 && err == nil {
+				break
+			}
+			offsets = append(offsets, offset)
+			err = errs.Combine(err, gz.Close())
+			if err != nil || n < blockSize {
+				break
+			}
+		}
+
+		if err == nil {
+			reader.mu.Lock()
+			reader.offsets = append(offsets, counter.written)
+			reader.done = true
+			reader.mu.Unlock()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return reader
+}
+
+// BlockOffsets returns the size of the compressed blocks and their offsets
+// followed by the size of the compressed content. It must be called once
+// the whole content is read. It returns nil offsets without compression.
+func (reader *compressedReader) BlockOffsets() (blockSize int64, offsets []int64, err error) {
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+	if !reader.done {
+		return 0, nil, errs.New("compressed content isn't read completely")
+	}
+	return reader.blockSize, reader.offsets, nil
+}
+
+// countingWriter counts the bytes written to writer
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+// Write implements io.Writer
+func (w *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// decompressedRanger is a ranger of the decompressed content of a segment.
+// Ranges are decompressed from the beginning of the compressed block they
+// start in, or from the beginning of the segment if the offsets of the
+// blocks aren't known.
+type decompressedRanger struct {
+	rr        ranger.Ranger
+	size      int64
+	blockSize int64
+	offsets   []int64
+}
+
+// decompressRanger returns a ranger of size bytes, decompressing rr with
+// compression. The content is compressed in blocks of blockSize at offsets,
+// which may be nil for content compressed as a whole. Any data after the end
+// of the compressed content in rr, like the encryption padding, is ignored.
+func decompressRanger(rr ranger.Ranger, compression storj.Compression, size int64, blockSize int64, offsets []int64) (ranger.Ranger, error) {
+	switch compression {
+	case storj.NoCompression:
+		return rr, nil
+	case storj.Gzip:
+		if offsets != nil && (blockSize <= 0 || int64(len(offsets)) != compressedBlocks(size, blockSize)+1) {
+			return nil, errs.New("invalid compressed blocks")
+		}
+		return &decompressedRanger{rr: rr, size: size, blockSize: blockSize, offsets: offsets}, nil
+	default:
+		return nil, checkCompression(compression)
+	}
+}
+
+// compressedBlocks returns the number of compressed blocks of blockSize of
+// content of size, which is at least one
+func compressedBlocks(size, blockSize int64) int64 {
+	if size <= blockSize {
+		return 1
+	}
+	return (size + blockSize - 1) / blockSize
+}
+
+// Size implements Ranger.Size
+func (dr *decompressedRanger) Size() int64 {
+	return dr.size
+}
+
+// Range implements Ranger.Range
+func (dr *decompressedRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, errs.New("negative offset")
+	}
+	if length < 0 {
+		return nil, errs.New("negative length")
+	}
+	if offset+length > dr.size {
+		return nil, errs.New("range beyond end")
+	}
+	if length == 0 {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	// the range of the compressed blocks of the range of the content
+	first, start, end := int64(0), int64(0), dr.rr.Size()
+	if dr.offsets != nil {
+		first = offset / dr.blockSize
+		last := (offset + length - 1) / dr.blockSize
+		start, end = dr.offsets[first], dr.offsets[last+1]
+	}
+
+	r, err := dr.rr.Range(ctx, start, end-start)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errs.Combine(err, r.Close())
+	}
+	if dr.offsets == nil {
+		// stop at the end of the compressed stream, before the padding
+		gz.Multistream(false)
+	}
+
+	_, err = io.CopyN(ioutil.Discard, gz, offset-first*dr.blockSize)
+	if err != nil {
+		return nil, errs.Combine(err, r.Close())
+	}
+
+	return &decompressedReader{Reader: io.LimitReader(gz, length), gz: gz, r: r}, nil
+}
+
+// decompressedReader reads a range of the decompressed content and closes
+// both the decompressor and the underlying reader
+type decompressedReader struct {
+	io.Reader
+	gz *gzip.Reader
+	r  io.ReadCloser
+}
+
+// Close implements io.Closer
+func (dr *decompressedReader) Close() error {
+	return errs.Combine(dr.gz.Close(), dr.r.Close())
+}
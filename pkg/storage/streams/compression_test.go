@@ -0,0 +1,117 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestCompression(t *testing.T) {
+	expected := strings.Repeat("compressible ", 100)
+
+	for _, blockSize := range []int64{int64(len(expected)), 100, 13} {
+		for _, compression := range []storj.Compression{storj.NoCompression, storj.Gzip} {
+			errTag := func(args ...interface{}) []interface{} {
+				return append([]interface{}{"compression %d, block size %d", compression, blockSize}, args...)
+			}
+
+			reader := compressReader(strings.NewReader(expected), compression, blockSize)
+			compressed, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+
+			size, offsets, err := reader.BlockOffsets()
+			require.NoError(t, err)
+			if compression == storj.NoCompression {
+				assert.Nil(t, offsets, errTag()...)
+			} else {
+				assert.Equal(t, blockSize, size, errTag()...)
+				if assert.Len(t, offsets, (len(expected)+int(blockSize)-1)/int(blockSize)+1, errTag()...) {
+					assert.Equal(t, int64(0), offsets[0], errTag()...)
+					assert.Equal(t, int64(len(compressed)), offsets[len(offsets)-1], errTag()...)
+				}
+				if blockSize == int64(len(expected)) {
+					assert.True(t, len(compressed) < len(expected), errTag()...)
+				}
+			}
+
+			// the padding of the encryption is ignored
+			padded := compressed
+			if compression != storj.NoCompression {
+				padded = append(padded, make([]byte, 16)...)
+			}
+
+			recorder := &rangeRecorder{Ranger: ranger.ByteRanger(padded)}
+			rr, err := decompressRanger(recorder, compression, int64(len(expected)), size, offsets)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(expected)), rr.Size())
+
+			for _, r := range []struct{ offset, length int }{
+				{0, len(expected)},
+				{0, 0},
+				{13, 26},
+				{len(expected) - 1, 1},
+			} {
+				recorder.length = 0
+
+				reader, err := rr.Range(ctx, int64(r.offset), int64(r.length))
+				require.NoError(t, err)
+
+				data, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+
+				assert.Equal(t, expected[r.offset:r.offset+r.length], string(data), errTag("offset %d, length %d", r.offset, r.length)...)
+
+				// only the compressed blocks of the range are read
+				if compression != storj.NoCompression && r.length > 0 && blockSize < int64(len(expected)) {
+					first := int64(r.offset) / blockSize
+					last := int64(r.offset+r.length-1) / blockSize
+					assert.Equal(t, offsets[last+1]-offsets[first], recorder.length, errTag("offset %d, length %d", r.offset, r.length)...)
+				}
+			}
+
+			// the content of segments uploaded without the offsets of their
+			// blocks is compressed as a whole
+			if blockSize == int64(len(expected)) {
+				rr, err = decompressRanger(ranger.ByteRanger(padded), compression, int64(len(expected)), 0, nil)
+				require.NoError(t, err)
+				reader, err := rr.Range(ctx, 13, 26)
+				require.NoError(t, err)
+				data, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+				assert.Equal(t, expected[13:39], string(data), errTag()...)
+			}
+		}
+	}
+
+	_, err := decompressRanger(ranger.ByteRanger(nil), storj.Compression(100), 0, 0, nil)
+	assert.Error(t, err)
+
+	// the offsets must match the size of the content
+	_, err = decompressRanger(ranger.ByteRanger(nil), storj.Gzip, 100, 10, []int64{0, 5})
+	assert.Error(t, err)
+}
+
+// rangeRecorder records the length of the last range read from Ranger
+type rangeRecorder struct {
+	ranger.Ranger
+	length int64
+}
+
+// Range implements Ranger.Range
+func (rr *rangeRecorder) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	rr.length = length
+	return rr.Ranger.Range(ctx, offset, length)
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/ranger"
+)
+
+// segmentUploads uploads the segments of a stream in parallel and keeps
+// track of which ones are committed
+type segmentUploads struct {
+	limiter *sync2.Limiter
+	first   int64
+
+	mu       sync.Mutex
+	uploaded map[int64]bool
+	err      error
+}
+
+// newSegmentUploads creates a segmentUploads running up to inFlight uploads
+// at the same time, for a stream with first segments already committed
+func newSegmentUploads(inFlight int, first int64) *segmentUploads {
+	return &segmentUploads{
+		limiter:  sync2.NewLimiter(inFlight),
+		first:    first,
+		uploaded: make(map[int64]bool),
+	}
+}
+
+// Go starts upload of the segment at index, waiting when too many segments
+// are in flight
+func (uploads *segmentUploads) Go(ctx context.Context, index int64, upload func() error) {
+	started := uploads.limiter.Go(ctx, func() {
+		err := upload()
+
+		uploads.mu.Lock()
+		defer uploads.mu.Unlock()
+		if err != nil {
+			uploads.err = errs.Combine(uploads.err, err)
+			return
+		}
+		uploads.uploaded[index] = true
+	})
+	if !started {
+		uploads.mu.Lock()
+		defer uploads.mu.Unlock()
+		uploads.err = errs.Combine(uploads.err, ctx.Err())
+	}
+}
+
+// Wait waits for all the started uploads to finish
+func (uploads *segmentUploads) Wait() {
+	uploads.limiter.Wait()
+}
+
+// Err returns the errors of the failed uploads so far
+func (uploads *segmentUploads) Err() error {
+	uploads.mu.Lock()
+	defer uploads.mu.Unlock()
+	return uploads.err
+}
+
+// Committed returns the number of committed segments of the stream, which
+// are the ones before the first segment not uploaded yet
+func (uploads *segmentUploads) Committed() int64 {
+	uploads.mu.Lock()
+	defer uploads.mu.Unlock()
+
+	committed := uploads.first
+	for uploads.uploaded[committed] {
+		committed++
+	}
+	return committed
+}
+
+// concatSegments concatenates the rangers of the segments of a stream. With
+// more than a segment in flight, reading a range opens the segments ahead of
+// the one being read, so that they are downloaded in parallel.
+func concatSegments(inFlight int, segments ...ranger.Ranger) ranger.Ranger {
+	if inFlight <= 1 || len(segments) <= 1 {
+		return ranger.Concat(segments...)
+	}
+
+	var size int64
+	for _, segment := range segments {
+		size += segment.Size()
+	}
+	return &prefetchRanger{segments: segments, inFlight: inFlight, size: size}
+}
+
+// prefetchRanger is a concatenation of segment rangers which opens up to
+// inFlight segments at the same time
+type prefetchRanger struct {
+	segments []ranger.Ranger
+	inFlight int
+	size     int64
+}
+
+// Size implements Ranger.Size
+func (rr *prefetchRanger) Size() int64 { return rr.size }
+
+// Range implements Ranger.Range
+func (rr *prefetchRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, errs.New("negative offset")
+	}
+	if length < 0 {
+		return nil, errs.New("negative length")
+	}
+	if offset+length > rr.size {
+		return nil, errs.New("range beyond end")
+	}
+
+	reader := &prefetchReader{ctx: ctx, inFlight: rr.inFlight}
+	for _, segment := range rr.segments {
+		if length == 0 {
+			break
+		}
+
+		size := segment.Size()
+		if offset >= size {
+			offset -= size
+			continue
+		}
+
+		partLength := size - offset
+		if partLength > length {
+			partLength = length
+		}
+		reader.parts = append(reader.parts, &prefetchPart{
+			segment: segment,
+			offset:  offset,
+			length:  partLength,
+		})
+
+		offset = 0
+		length -= partLength
+	}
+
+	return reader, nil
+}
+
+// prefetchPart is the range of a segment read by a prefetchReader
+type prefetchPart struct {
+	segment        ranger.Ranger
+	offset, length int64
+
+	started bool
+	done    chan struct{}
+	reader  io.ReadCloser
+	err     error
+}
+
+// open starts opening the range of the segment
+func (part *prefetchPart) open(ctx context.Context) {
+	if part.started {
+		return
+	}
+	part.started = true
+	part.done = make(chan struct{})
+
+	go func() {
+		defer close(part.done)
+		part.reader, part.err = part.segment.Range(ctx, part.offset, part.length)
+	}()
+}
+
+// prefetchReader reads the parts in order while opening the next ones
+type prefetchReader struct {
+	ctx      context.Context
+	inFlight int
+	parts    []*prefetchPart
+	current  int
+}
+
+// Read implements io.Reader
+func (reader *prefetchReader) Read(p []byte) (n int, err error) {
+	for reader.current < len(reader.parts) {
+		for i := reader.current; i < len(reader.parts) && i < reader.current+reader.inFlight; i++ {
+			reader.parts[i].open(reader.ctx)
+		}
+
+		part := reader.parts[reader.current]
+		<-part.done
+		if part.err != nil {
+			return 0, part.err
+		}
+
+		n, err = part.reader.Read(p)
+		if err == io.EOF {
+			err = part.reader.Close()
+			part.reader = nil
+			reader.current++
+			if err != nil || n > 0 {
+				return n, err
+			}
+			continue
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+// Close implements io.Closer
+func (reader *prefetchReader) Close() error {
+	var group errs.Group
+	for _, part := range reader.parts {
+		if !part.started {
+			continue
+		}
+		<-part.done
+		if part.reader != nil {
+			group.Add(part.reader.Close())
+		}
+	}
+	return group.Err()
+}
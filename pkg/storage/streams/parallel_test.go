@@ -0,0 +1,76 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/ranger"
+)
+
+func TestConcatSegments(t *testing.T) {
+	segments := []ranger.Ranger{
+		ranger.ByteRanger("abcd"),
+		ranger.ByteRanger("efgh"),
+		ranger.ByteRanger("ij"),
+		ranger.ByteRanger(""),
+		ranger.ByteRanger("klm"),
+	}
+	expected := "abcdefghijklm"
+
+	for _, inFlight := range []int{1, 2, 3, 10} {
+		rr := concatSegments(inFlight, segments...)
+		require.Equal(t, int64(len(expected)), rr.Size())
+
+		for offset := 0; offset <= len(expected); offset++ {
+			for length := 0; offset+length <= len(expected); length++ {
+				reader, err := rr.Range(ctx, int64(offset), int64(length))
+				require.NoError(t, err)
+
+				data, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+
+				assert.Equal(t, expected[offset:offset+length], string(data), "inFlight %d, offset %d, length %d", inFlight, offset, length)
+			}
+		}
+
+		_, err := rr.Range(ctx, 1, int64(len(expected)))
+		assert.Error(t, err)
+	}
+}
+
+func TestSegmentUploads(t *testing.T) {
+	uploads := newSegmentUploads(2, 3)
+	assert.Equal(t, int64(3), uploads.Committed())
+
+	release := make(chan struct{})
+	uploads.Go(ctx, 3, func() error {
+		<-release
+		return nil
+	})
+	uploads.Go(ctx, 4, func() error { return nil })
+	// waits for the upload of segment 4, as segment 3 is still in flight
+	uploads.Go(ctx, 6, func() error { return nil })
+	assert.Equal(t, int64(3), uploads.Committed())
+
+	close(release)
+	uploads.Wait()
+	assert.Equal(t, int64(5), uploads.Committed())
+
+	uploads.Go(ctx, 5, func() error { return nil })
+	uploads.Wait()
+	assert.Equal(t, int64(7), uploads.Committed())
+	assert.NoError(t, uploads.Err())
+
+	uploads.Go(ctx, 8, func() error { return errors.New("failed") })
+	uploads.Wait()
+	assert.Error(t, uploads.Err())
+	assert.Equal(t, int64(7), uploads.Committed())
+}
@@ -6,14 +6,19 @@ package streams
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
@@ -36,6 +41,10 @@ type Meta struct {
 	Expiration time.Time
 	Size       int64
 	Data       []byte
+	// Checksum is the MD5 checksum of the content, nil if unknown
+	Checksum []byte
+	// SHA256 is the SHA-256 checksum of the content, nil if unknown
+	SHA256 []byte
 }
 
 // convertMeta converts segment metadata to stream metadata
@@ -49,7 +58,58 @@ func convertMeta(lastSegmentMeta segments.Meta) (Meta, error) {
 	return Meta{
 		Modified:   lastSegmentMeta.Modified,
 		Expiration: lastSegmentMeta.Expiration,
-		Size:       ((stream.NumberOfSegments - 1) * stream.SegmentsSize) + stream.LastSegmentSize,
+		Size:       StreamSize(&stream),
+		Data:       stream.Metadata,
+		Checksum:   stream.Checksum,
+		SHA256:     stream.Sha256Checksum,
+	}, nil
+}
+
+// StreamSize returns the size of the content of a stream
+func StreamSize(stream *pb.StreamInfo) int64 {
+	if len(stream.SegmentSizes) == 0 {
+		return (stream.NumberOfSegments-1)*stream.SegmentsSize + stream.LastSegmentSize
+	}
+
+	size := stream.LastSegmentSize
+	for _, segmentSize := range stream.SegmentSizes {
+		size += segmentSize
+	}
+	return size
+}
+
+// segmentSize returns the size of the content of the segment at index, which
+// isn't the last segment of the stream
+func segmentSize(stream *pb.StreamInfo, index int64) int64 {
+	if index < int64(len(stream.SegmentSizes)) {
+		return stream.SegmentSizes[index]
+	}
+	return stream.SegmentsSize
+}
+
+// contentNonce returns the nonce of the content of the segment at index with
+// metadata m, which is given by m for the segments of concatenated streams
+func contentNonce(m *pb.SegmentMeta, index int64, deduplicated bool) (nonce storj.Nonce, err error) {
+	if len(m.GetContentNonce()) > 0 {
+		copy(nonce[:], m.GetContentNonce())
+		return nonce, nil
+	}
+	return segmentNonce(index, deduplicated)
+}
+
+// convertPendingMeta converts the metadata of a pending stream marker to
+// stream metadata. Size is the amount of data in the committed segments.
+func convertPendingMeta(pendingMeta segments.Meta) (Meta, error) {
+	stream := pb.StreamInfo{}
+	err := proto.Unmarshal(pendingMeta.Data, &stream)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{
+		Modified:   pendingMeta.Modified,
+		Expiration: pendingMeta.Expiration,
+		Size:       stream.NumberOfSegments * stream.SegmentsSize,
 		Data:       stream.Metadata,
 	}, nil
 }
@@ -59,8 +119,23 @@ type Store interface {
 	Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
 	Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (ranger.Ranger, Meta, error)
 	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	WithSchemes(rs storj.RedundancyScheme, es storj.EncryptionScheme) (Store, error)
 	Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	Copy(ctx context.Context, source storj.Path, sourceCipher storj.Cipher, destination storj.Path, destinationCipher storj.Cipher) (Meta, error)
+	Concat(ctx context.Context, path storj.Path, pathCipher storj.Cipher, parts []storj.Path, partsCipher storj.Cipher, metadata []byte) (Meta, error)
+	UpdateMetadata(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte, expiration time.Time) (Meta, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+
+	Pending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
+	Continue(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader) (Meta, error)
+	DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+
+	Archive(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (version string, err error)
+	MetaVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (Meta, error)
+	GetVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (ranger.Ranger, Meta, error)
+	DeleteVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) error
+	ListVersions(ctx context.Context, path storj.Path, pathCipher storj.Cipher, startAfter, endBefore string, limit int) (items []ListItem, more bool, err error)
 }
 
 // streamStore is a store for streams
@@ -70,10 +145,41 @@ type streamStore struct {
 	rootKey      *storj.Key
 	encBlockSize int
 	cipher       storj.Cipher
+	compression  storj.Compression
+	checksum     storj.ChecksumType
+	dedup        bool
+
+	segmentsInFlight int
+	uploadBuffers    int
+}
+
+// Options are the options of the streams uploaded and downloaded by a stream
+// store, the zero options upload and download the streams as they are, one
+// segment at a time
+type Options struct {
+	// Compression is the compression of the content of each segment, before
+	// it's encrypted
+	Compression storj.Compression
+	// Checksum are the types of the checksums of the content, which are
+	// computed while it's uploaded and verified when the whole content is
+	// downloaded
+	Checksum storj.ChecksumType
+	// Dedup encrypts each segment with a convergent key derived from its
+	// content, so that identical remote segments within a project share their
+	// pieces. Uploading with dedup buffers the segment being uploaded.
+	Dedup bool
+
+	// SegmentsInFlight is the number of segments of a stream uploaded or
+	// downloaded at the same time, one when zero
+	SegmentsInFlight int
+	// MaxBufferMem is the memory buffering the segments uploaded in parallel.
+	// Only as many segments as fit in it are uploaded at the same time, and
+	// they are uploaded one after the other if fewer than two fit.
+	MaxBufferMem int
 }
 
 // NewStreamStore stuff
-func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
+func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher, opts Options) (Store, error) {
 	if segmentSize <= 0 {
 		return nil, errs.New("segment size must be larger than 0")
 	}
@@ -83,6 +189,25 @@ func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.K
 	if encBlockSize <= 0 {
 		return nil, errs.New("encryption block size must be larger than 0")
 	}
+	if err := checkCompression(opts.Compression); err != nil {
+		return nil, err
+	}
+	if _, err := newHashes(opts.Checksum); err != nil {
+		return nil, err
+	}
+
+	segmentsInFlight := opts.SegmentsInFlight
+	if segmentsInFlight == 0 {
+		segmentsInFlight = 1
+	}
+	if segmentsInFlight < 0 {
+		return nil, errs.New("segments in flight must be larger than 0")
+	}
+
+	uploadBuffers := segmentsInFlight
+	if fit := int64(opts.MaxBufferMem) / segmentSize; fit < int64(uploadBuffers) {
+		uploadBuffers = int(fit)
+	}
 
 	return &streamStore{
 		segments:     segments,
@@ -90,356 +215,1479 @@ func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.K
 		rootKey:      rootKey,
 		encBlockSize: encBlockSize,
 		cipher:       cipher,
+		compression:  opts.Compression,
+		checksum:     opts.Checksum,
+		dedup:        opts.Dedup,
+
+		segmentsInFlight: segmentsInFlight,
+		uploadBuffers:    uploadBuffers,
 	}, nil
 }
 
+// WithSchemes returns a copy of the store which uploads new streams with the
+// redundancy scheme rs and the encryption scheme es. The zero schemes keep
+// the ones of the store.
+func (s *streamStore) WithSchemes(rs storj.RedundancyScheme, es storj.EncryptionScheme) (Store, error) {
+	store := *s
+
+	if !es.IsZero() {
+		if es.BlockSize <= 0 {
+			return nil, errs.New("encryption block size must be larger than 0")
+		}
+		store.cipher = es.Cipher
+		store.encBlockSize = int(es.BlockSize)
+	}
+
+	if !rs.IsZero() {
+		strategy, err := redundancyStrategy(rs)
+		if err != nil {
+			return nil, err
+		}
+		if strategy.StripeSize()%store.encBlockSize != 0 {
+			return nil, errs.New("stripe size %d is not a multiple of the encryption block size %d", strategy.StripeSize(), store.encBlockSize)
+		}
+		store.segments = s.segments.WithRedundancy(strategy)
+	}
+
+	return &store, nil
+}
+
+// redundancyStrategy returns the erasure coding strategy of rs
+func redundancyStrategy(rs storj.RedundancyScheme) (eestream.RedundancyStrategy, error) {
+	if rs.Algorithm != storj.ReedSolomon {
+		return eestream.RedundancyStrategy{}, errs.New("redundancy algorithm %d is not supported", rs.Algorithm)
+	}
+	if rs.ShareSize <= 0 {
+		return eestream.RedundancyStrategy{}, errs.New("share size must be larger than 0")
+	}
+
+	fc, err := infectious.NewFEC(int(rs.RequiredShares), int(rs.TotalShares))
+	if err != nil {
+		return eestream.RedundancyStrategy{}, errs.Wrap(err)
+	}
+
+	return eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, int(rs.ShareSize)), int(rs.RepairShares), int(rs.OptimalShares))
+}
+
 // Put breaks up data as it comes in into s.segmentSize length pieces, then
 // store the first piece at s0/<path>, second piece at s1/<path>, and the
 // *last* piece at l/<path>. Store the given metadata, along with the number
 // of segments, in a new protobuf, in the metadata of l/<path>.
+//
+// While the upload is in progress, the number of committed segments is kept
+// in a pending marker at p/<path>, which is updated every pendingInterval
+// segments. If the upload is interrupted, the marker and the segments it
+// records are kept, so that the upload can be resumed with Continue. The
+// segments committed after the last update of the marker are deleted.
 func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// previously file uploaded?
 	err = s.Delete(ctx, path, pathCipher)
 	if err != nil && !storage.ErrKeyNotFound.Has(err) {
-		//something wrong happened checking for an existing
-		//file with the same name
+		//something wrong happened checking for an existing
+		//file with the same name
+		return Meta{}, err
+	}
+
+	// previously interrupted upload?
+	err = s.DeletePending(ctx, path, pathCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return Meta{}, err
+	}
+
+	return s.upload(ctx, path, pathCipher, data, metadata, expiration, s.segmentSize, 0, checksums{})
+}
+
+// Continue resumes an interrupted upload from the first segment that was not
+// committed. The data must start at the offset given by the Size returned
+// from Pending. The metadata, expiration and encryption settings of the
+// original upload are used.
+func (s *streamStore) Continue(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader) (m Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	pendingMeta, stream, streamMeta, err := s.getPending(ctx, path, encPath)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	if storj.Compression(stream.CompressionType) != s.compression {
+		return Meta{}, errs.New("compression differs from the interrupted upload")
+	}
+
+	if stream.Deduplicated != s.dedup {
+		return Meta{}, errs.New("deduplication differs from the interrupted upload")
+	}
+
+	if storj.ChecksumType(stream.ChecksumType) != s.checksum {
+		return Meta{}, errs.New("checksum type differs from the interrupted upload")
+	}
+
+	committed, err := s.isCommitted(ctx, encPath)
+	if err != nil {
+		return Meta{}, err
+	}
+	if committed {
+		return Meta{}, errs.New("upload is already committed")
+	}
+
+	// the segments committed after the last update of the marker may be
+	// left over if the upload wasn't interrupted cleanly
+	err = s.deleteSegments(ctx, encPath, stream.NumberOfSegments, stream.NumberOfSegments+s.staleSegments())
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// the remaining segments are encrypted like the committed ones
+	store := *s
+	store.cipher = storj.Cipher(streamMeta.EncryptionType)
+	store.encBlockSize = int(streamMeta.EncryptionBlockSize)
+
+	return store.upload(ctx, path, pathCipher, data, stream.Metadata, pendingMeta.Expiration, stream.SegmentsSize, stream.NumberOfSegments, checksums{md5: stream.Checksum, sha256: stream.Sha256Checksum})
+}
+
+// upload stores the segments of data starting from currentSegment. The
+// checksums of a resumed upload continue from checksumStates, the states of
+// the checksums of the committed segments.
+func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time, segmentSize int64, currentSegment int64, checksumStates checksums) (m Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	hasPending := currentSegment > 0
+	streamSize := currentSegment * segmentSize
+	var putMeta segments.Meta
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	checksum, err := newStreamChecksum(s.checksum, currentSegment, checksumStates)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// committed is the number of segments recorded by the pending marker
+	committed := currentSegment
+	defer func() {
+		if err != nil {
+			s.cancelHandler(context.Background(), encPath, committed, currentSegment)
+		}
+	}()
+
+	eofReader := NewEOFReader(data)
+
+	// with buffers for more than a segment, every segment but the last one is
+	// buffered and uploaded in parallel with the following ones. The segment
+	// being read takes one of the buffers, the uploads in flight the others.
+	parallel := s.uploadBuffers > 1
+	uploadsInFlight := s.uploadBuffers - 1
+	if uploadsInFlight < 1 {
+		uploadsInFlight = 1
+	}
+	uploads := newSegmentUploads(uploadsInFlight, currentSegment)
+	defer uploads.Wait()
+
+	for !eofReader.isEOF() && !eofReader.hasError() {
+		if err := uploads.Err(); err != nil {
+			return Meta{}, err
+		}
+
+		sizeReader := NewSizeReader(eofReader)
+		segmentReader := checksum.reader(io.LimitReader(sizeReader, segmentSize))
+
+		// the convergent key of a deduplicated segment is derived from
+		// its whole content
+		var buffer []byte
+		if parallel || s.dedup {
+			buffer, err = ioutil.ReadAll(segmentReader)
+			if err != nil {
+				return Meta{}, err
+			}
+			segmentReader = bytes.NewReader(buffer)
+
+			err = checksum.read(currentSegment + 1)
+			if err != nil {
+				return Meta{}, err
+			}
+		}
+
+		enc, err := s.newSegmentEncryption(derivedKey, currentSegment, buffer)
+		if err != nil {
+			return Meta{}, err
+		}
+
+		if parallel {
+			if !eofReader.isEOF() {
+				segmentIndex := currentSegment
+				uploads.Go(ctx, segmentIndex, func() error {
+					_, err := s.putSegment(ctx, enc, bytes.NewReader(buffer), expiration, func(blockSize int64, offsets []int64) (storj.Path, []byte, error) {
+						return s.segmentPathAndMeta(encPath, segmentIndex, enc, blockSize, offsets)
+					})
+					return err
+				})
+
+				currentSegment++
+				streamSize += sizeReader.Size()
+
+				// keep track of the committed segments in case the upload is interrupted
+				if uploaded := uploads.Committed(); uploaded-committed >= pendingInterval {
+					err = s.putPending(ctx, encPath, derivedKey, uploaded, segmentSize, metadata, expiration, checksum.committed(uploaded))
+					if err != nil {
+						return Meta{}, err
+					}
+					committed, hasPending = uploaded, true
+				}
+				continue
+			}
+
+			// the last segment commits the stream, so it's uploaded only once
+			// all the previous segments are
+			uploads.Wait()
+			if err := uploads.Err(); err != nil {
+				return Meta{}, err
+			}
+		}
+
+		putMeta, err = s.putSegment(ctx, enc, segmentReader, expiration, func(blockSize int64, offsets []int64) (storj.Path, []byte, error) {
+			if !eofReader.isEOF() {
+				return s.segmentPathAndMeta(encPath, currentSegment, enc, blockSize, offsets)
+			}
+
+			lastSegmentPath := storj.JoinPaths("l", encPath)
+
+			sums := checksum.sums()
+			lastSegmentMeta, err := s.marshalStreamMeta(&pb.StreamInfo{
+				NumberOfSegments: currentSegment + 1,
+				SegmentsSize:     segmentSize,
+				LastSegmentSize:  sizeReader.Size(),
+				Metadata:         metadata,
+				CompressionType:  int32(s.compression),
+				Deduplicated:     s.dedup,
+				Checksum:         sums.md5,
+				ChecksumType:     int32(s.checksum),
+				Sha256Checksum:   sums.sha256,
+			}, &enc.contentKey, enc.encryptedKey, &enc.keyNonce, blockSize, offsets)
+			if err != nil {
+				return "", nil, err
+			}
+
+			return lastSegmentPath, lastSegmentMeta, nil
+		})
+		if err != nil {
+			return Meta{}, err
+		}
+
+		currentSegment++
+		streamSize += sizeReader.Size()
+
+		// keep track of the committed segments in case the upload is interrupted
+		if !eofReader.isEOF() && currentSegment-committed >= pendingInterval {
+			if buffer == nil {
+				err = checksum.read(currentSegment)
+				if err != nil {
+					return Meta{}, err
+				}
+			}
+
+			err = s.putPending(ctx, encPath, derivedKey, currentSegment, segmentSize, metadata, expiration, checksum.committed(currentSegment))
+			if err != nil {
+				return Meta{}, err
+			}
+			committed, hasPending = currentSegment, true
+		}
+	}
+
+	if eofReader.hasError() {
+		return Meta{}, eofReader.err
+	}
+
+	if hasPending {
+		err = s.segments.Delete(ctx, storj.JoinPaths("p", encPath))
+		if err != nil {
+			zap.S().Warnf("Failed deleting pending upload marker %v: %v", path, err)
+		}
+	}
+
+	sums := checksum.sums()
+	resultMeta := Meta{
+		Modified:   putMeta.Modified,
+		Expiration: expiration,
+		Size:       streamSize,
+		Data:       metadata,
+		Checksum:   sums.md5,
+		SHA256:     sums.sha256,
+	}
+
+	return resultMeta, nil
+}
+
+// segmentEncryption holds the keys encrypting the content of a segment
+type segmentEncryption struct {
+	contentKey   storj.Key
+	contentNonce storj.Nonce
+	encryptedKey storj.EncryptedPrivateKey
+	keyNonce     storj.Nonce
+
+	// dedupID is the content address of a deduplicated segment
+	dedupID []byte
+}
+
+// newSegmentEncryption generates the keys for encrypting the content of the
+// segment at index. The content key of a deduplicated segment is derived from
+// content, the plain content of the segment, otherwise it's random.
+func (s *streamStore) newSegmentEncryption(derivedKey *storj.Key, index int64, content []byte) (enc *segmentEncryption, err error) {
+	enc = &segmentEncryption{}
+
+	if s.dedup {
+		enc.contentKey, enc.dedupID = s.convergentKey(content)
+	} else {
+		// generate random key for encrypting the segment's content
+		_, err = rand.Read(enc.contentKey[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enc.contentNonce, err = segmentNonce(index, s.dedup)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate random nonce for encrypting the content key
+	_, err = rand.Read(enc.keyNonce[:])
+	if err != nil {
+		return nil, err
+	}
+
+	enc.encryptedKey, err = encryption.EncryptKey(&enc.contentKey, s.cipher, derivedKey, &enc.keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return enc, nil
+}
+
+// convergentKey derives the content key and the dedup ID of a deduplicated
+// segment from its plain content. Identical segments are encrypted with the
+// same key, so that their encrypted pieces are identical too. The dedup ID
+// also covers the settings changing the encrypted content.
+func (s *streamStore) convergentKey(content []byte) (contentKey storj.Key, dedupID []byte) {
+	hash := sha256.Sum256(content)
+
+	mac := hmac.New(sha256.New, hash[:])
+	_, _ = mac.Write([]byte("content key"))
+	copy(contentKey[:], mac.Sum(nil))
+
+	mac = hmac.New(sha256.New, hash[:])
+	_, _ = mac.Write([]byte("dedup id"))
+	_, _ = mac.Write([]byte{byte(s.cipher), byte(s.compression)})
+	_ = binary.Write(mac, binary.BigEndian, int32(s.encBlockSize))
+	return contentKey, mac.Sum(nil)
+}
+
+// segmentNonce returns the nonce encrypting the content of the segment at
+// index. It's the segment's index incremented by 1, to avoid nonce reuse with
+// the metadata encryption, which is encrypted with the zero nonce. The
+// segments of a deduplicated stream use the nonce of the first segment
+// regardless of their index, as each of them has its own convergent key.
+func segmentNonce(index int64, deduplicated bool) (nonce storj.Nonce, err error) {
+	if deduplicated {
+		index = 0
+	}
+	_, err = encryption.Increment(&nonce, index+1)
+	return nonce, err
+}
+
+// putSegment compresses, encrypts and stores the content of a segment read
+// from data. segmentInfo is called with the offsets of the compressed blocks
+// of the content.
+func (s *streamStore) putSegment(ctx context.Context, enc *segmentEncryption, data io.Reader, expiration time.Time, segmentInfo func(blockSize int64, offsets []int64) (storj.Path, []byte, error)) (meta segments.Meta, err error) {
+	compressedReader := compressReader(data, s.compression, compressedBlockSize)
+	defer func() { _ = compressedReader.Close() }()
+
+	transformedReader, err := s.encryptSegment(enc, compressedReader)
+	if err != nil {
+		return segments.Meta{}, err
+	}
+
+	info := func() (storj.Path, []byte, error) {
+		blockSize, offsets, err := compressedReader.BlockOffsets()
+		if err != nil {
+			return "", nil, err
+		}
+		return segmentInfo(blockSize, offsets)
+	}
+
+	if enc.dedupID != nil {
+		return s.segments.PutDeduplicated(ctx, enc.dedupID, transformedReader, expiration, info)
+	}
+	return s.segments.Put(ctx, transformedReader, expiration, info)
+}
+
+// encryptSegment returns a reader of the encrypted content of a segment
+func (s *streamStore) encryptSegment(enc *segmentEncryption, data io.Reader) (io.Reader, error) {
+	encrypter, err := encryption.NewEncrypter(s.cipher, &enc.contentKey, &enc.contentNonce, s.encBlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	peekReader := segments.NewPeekThresholdReader(data)
+	largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
+	if err != nil {
+		return nil, err
+	}
+	if largeData {
+		paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
+		return encryption.TransformReader(paddedReader, encrypter, 0), nil
+	}
+
+	plainData, err := ioutil.ReadAll(peekReader)
+	if err != nil {
+		return nil, err
+	}
+	cipherData, err := encryption.Encrypt(plainData, s.cipher, &enc.contentKey, &enc.contentNonce)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(cipherData), nil
+}
+
+// segmentPathAndMeta returns the path and the metadata of the segment at
+// index, which isn't the last segment of the stream
+func (s *streamStore) segmentPathAndMeta(encPath storj.Path, index int64, enc *segmentEncryption, blockSize int64, offsets []int64) (storj.Path, []byte, error) {
+	segmentPath := getSegmentPath(encPath, index)
+
+	if s.cipher == storj.Unencrypted && offsets == nil {
+		return segmentPath, nil, nil
+	}
+
+	segmentMeta, err := proto.Marshal(s.segmentMeta(enc.encryptedKey, &enc.keyNonce, blockSize, offsets))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return segmentPath, segmentMeta, nil
+}
+
+// getSegmentPath returns the unique path for a particular segment
+func getSegmentPath(path storj.Path, segNum int64) storj.Path {
+	return storj.JoinPaths(fmt.Sprintf("s%d", segNum), path)
+}
+
+// getLastSegmentPath returns the path of the last segment of the stream, or
+// of its archived version if version is not empty
+func getLastSegmentPath(path storj.Path, version string) storj.Path {
+	if version == "" {
+		return storj.JoinPaths("l", path)
+	}
+	return storj.JoinPaths("v", path, version)
+}
+
+// getVersionSegmentPath returns the path of a segment of the stream, or of
+// its archived version if version is not empty
+func getVersionSegmentPath(path storj.Path, version string, segNum int64) storj.Path {
+	if version == "" {
+		return getSegmentPath(path, segNum)
+	}
+	return storj.JoinPaths(fmt.Sprintf("v%d", segNum), path, version)
+}
+
+// VersionID returns the identifier of the stream version last modified at
+// the given time. The identifiers sort in the order the versions were written.
+func VersionID(modified time.Time) string {
+	return fmt.Sprintf("%016x", modified.UnixNano())
+}
+
+// VersionTime returns the time the stream version was last modified
+func VersionTime(version string) (time.Time, error) {
+	nanos, err := strconv.ParseInt(version, 16, 64)
+	if err != nil || len(version) != 16 {
+		return time.Time{}, storage.ErrKeyNotFound.New("invalid version %q", version)
+	}
+	return time.Unix(0, nanos).UTC(), nil
+}
+
+// marshalStreamMeta encrypts the stream info with the content key and zero
+// nonce, and marshals it together with the encrypted content key. As the
+// convergent key of a deduplicated segment may encrypt the stream info of
+// other streams too, a random nonce is used for them instead.
+func (s *streamStore) marshalStreamMeta(stream *pb.StreamInfo, contentKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, keyNonce *storj.Nonce, blockSize int64, offsets []int64) ([]byte, error) {
+	streamInfo, err := proto.Marshal(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	var streamInfoNonce storj.Nonce
+	if s.dedup {
+		_, err = rand.Read(streamInfoNonce[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// encrypt metadata with the content encryption key
+	encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, contentKey, &streamInfoNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	streamMeta := pb.StreamMeta{
+		EncryptedStreamInfo: encryptedStreamInfo,
+		EncryptionType:      int32(s.cipher),
+		EncryptionBlockSize: int32(s.encBlockSize),
+	}
+
+	if s.dedup {
+		streamMeta.StreamInfoNonce = streamInfoNonce[:]
+	}
+
+	if s.cipher != storj.Unencrypted || offsets != nil {
+		streamMeta.LastSegmentMeta = s.segmentMeta(encryptedKey, keyNonce, blockSize, offsets)
+	}
+
+	return proto.Marshal(&streamMeta)
+}
+
+// segmentMeta returns the metadata of a segment with the encrypted content
+// key and the offsets of the compressed blocks of the content
+func (s *streamStore) segmentMeta(encryptedKey storj.EncryptedPrivateKey, keyNonce *storj.Nonce, blockSize int64, offsets []int64) *pb.SegmentMeta {
+	segmentMeta := &pb.SegmentMeta{
+		CompressedBlockSize: blockSize,
+		CompressedOffsets:   offsets,
+	}
+	if s.cipher != storj.Unencrypted {
+		segmentMeta.EncryptedKey = encryptedKey
+		segmentMeta.KeyNonce = keyNonce[:]
+	}
+	return segmentMeta
+}
+
+// pendingInterval is the number of segments committed between two updates of
+// the pending marker of an upload
+const pendingInterval = 8
+
+// staleSegments returns the number of segments which may be committed after
+// the last update of the pending marker of an upload
+func (s *streamStore) staleSegments() int64 {
+	return pendingInterval + int64(s.segmentsInFlight)
+}
+
+// cancelHandler cleans up the segments of a failed upload from first up to
+// last, which aren't recorded by its pending marker
+func (s *streamStore) cancelHandler(ctx context.Context, encPath storj.Path, first, last int64) {
+	// a failed upload may be committed already, if only getting the
+	// metadata of the last segment failed
+	committed, err := s.isCommitted(ctx, encPath)
+	if err != nil {
+		zap.S().Warnf("Failed checking whether the upload of %v is committed: %v", encPath, err)
+		return
+	}
+	if committed {
+		return
+	}
+
+	for i := first; i <= last; i++ {
+		currentPath := getSegmentPath(encPath, i)
+		err := s.segments.Delete(ctx, currentPath)
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			zap.S().Warnf("Failed deleting a segment %v %v", currentPath, err)
+		}
+	}
+}
+
+// deleteSegments deletes the segments of an upload from first up to, but not
+// including, last, skipping the ones which don't exist
+func (s *streamStore) deleteSegments(ctx context.Context, encPath storj.Path, first, last int64) (err error) {
+	for i := first; i < last; i++ {
+		err = s.segments.Delete(ctx, getSegmentPath(encPath, i))
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// putPending stores the pending marker of an upload at p/<path>. The marker
+// has the same format as the metadata of l/<path>, where the number of
+// segments is the number of committed segments, and the checksums are the
+// states of the checksums of their content.
+func (s *streamStore) putPending(ctx context.Context, encPath storj.Path, derivedKey *storj.Key, committedSegments, segmentSize int64, metadata []byte, expiration time.Time, checksumStates checksums) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var contentKey storj.Key
+	_, err = rand.Read(contentKey[:])
+	if err != nil {
+		return err
+	}
+
+	var keyNonce storj.Nonce
+	_, err = rand.Read(keyNonce[:])
+	if err != nil {
+		return err
+	}
+
+	encryptedKey, err := encryption.EncryptKey(&contentKey, s.cipher, derivedKey, &keyNonce)
+	if err != nil {
+		return err
+	}
+
+	pendingMeta, err := s.marshalStreamMeta(&pb.StreamInfo{
+		NumberOfSegments: committedSegments,
+		SegmentsSize:     segmentSize,
+		Metadata:         metadata,
+		CompressionType:  int32(s.compression),
+		Deduplicated:     s.dedup,
+		Checksum:         checksumStates.md5,
+		ChecksumType:     int32(s.checksum),
+		Sha256Checksum:   checksumStates.sha256,
+	}, &contentKey, encryptedKey, &keyNonce, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.segments.Put(ctx, bytes.NewReader(nil), expiration, func() (storj.Path, []byte, error) {
+		return storj.JoinPaths("p", encPath), pendingMeta, nil
+	})
+	return err
+}
+
+// getPending returns the pending marker of an upload
+func (s *streamStore) getPending(ctx context.Context, path, encPath storj.Path) (pendingMeta segments.Meta, stream pb.StreamInfo, streamMeta pb.StreamMeta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pendingMeta, err = s.segments.Meta(ctx, storj.JoinPaths("p", encPath))
+	if err != nil {
+		return segments.Meta{}, pb.StreamInfo{}, pb.StreamMeta{}, err
+	}
+
+	err = proto.Unmarshal(pendingMeta.Data, &streamMeta)
+	if err != nil {
+		return segments.Meta{}, pb.StreamInfo{}, pb.StreamMeta{}, err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, pendingMeta, path, s.rootKey)
+	if err != nil {
+		return segments.Meta{}, pb.StreamInfo{}, pb.StreamMeta{}, err
+	}
+
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return segments.Meta{}, pb.StreamInfo{}, pb.StreamMeta{}, err
+	}
+
+	return pendingMeta, stream, streamMeta, nil
+}
+
+// isCommitted checks whether l/<path> exists
+func (s *streamStore) isCommitted(ctx context.Context, encPath storj.Path) (bool, error) {
+	_, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns a ranger that knows what the overall size is (from l/<path>)
+// and then returns the appropriate data from segments s0/<path>, s1/<path>,
+// ..., l/<path>.
+func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (rr ranger.Ranger, meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.get(ctx, path, pathCipher, "")
+}
+
+// GetVersion returns a ranger that knows what the overall size is (from
+// v/<path>/<version>) and then returns the appropriate data from segments
+// v0/<path>/<version>, v1/<path>/<version>, ..., v/<path>/<version>.
+func (s *streamStore) GetVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (rr ranger.Ranger, meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if version == "" {
+		return nil, Meta{}, storage.ErrKeyNotFound.New("no version specified")
+	}
+
+	return s.get(ctx, path, pathCipher, version)
+}
+
+func (s *streamStore) get(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (rr ranger.Ranger, meta Meta, err error) {
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return getEncrypted(ctx, s.segments, encPath, derivedKey, version, s.segmentsInFlight)
+}
+
+// GetWithKey returns a ranger of the object at encPath, the encrypted path
+// of the object after its bucket, decrypting it with derivedKey, the content
+// key derived for the object. It allows reading a shared object without the
+// root key or the unencrypted path.
+func GetWithKey(ctx context.Context, segmentStore segments.Store, encPath storj.Path, derivedKey *storj.Key) (rr ranger.Ranger, meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return getEncrypted(ctx, segmentStore, encPath, derivedKey, "", 1)
+}
+
+func getEncrypted(ctx context.Context, segmentStore segments.Store, encPath storj.Path, derivedKey *storj.Key, version string, segmentsInFlight int) (rr ranger.Ranger, meta Meta, err error) {
+	lastSegmentRanger, lastSegmentMeta, err := segmentStore.Get(ctx, getLastSegmentPath(encPath, version))
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	if version != "" {
+		lastSegmentMeta.Modified, err = VersionTime(version)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+	}
+
+	streamInfo, err := decryptStreamInfo(lastSegmentMeta, derivedKey)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(lastSegmentMeta.Data, &streamMeta)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	compression := storj.Compression(stream.CompressionType)
+	err = checkCompression(compression)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	var rangers []ranger.Ranger
+	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
+		currentPath := getVersionSegmentPath(encPath, version, i)
+		rr := &lazySegmentRanger{
+			segments:     segmentStore,
+			path:         currentPath,
+			index:        i,
+			deduplicated: stream.Deduplicated,
+			size:         segmentSize(&stream, i),
+			derivedKey:   derivedKey,
+			encBlockSize: int(streamMeta.EncryptionBlockSize),
+			cipher:       storj.Cipher(streamMeta.EncryptionType),
+			compression:  compression,
+		}
+		rangers = append(rangers, rr)
+	}
+
+	lastSegmentNonce, err := contentNonce(streamMeta.LastSegmentMeta, stream.NumberOfSegments-1, stream.Deduplicated)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
+	decryptedLastSegmentRanger, err := decryptRanger(
+		ctx,
+		lastSegmentRanger,
+		stream.LastSegmentSize,
+		compression,
+		streamMeta.LastSegmentMeta,
+		storj.Cipher(streamMeta.EncryptionType),
+		derivedKey,
+		encryptedKey,
+		keyNonce,
+		&lastSegmentNonce,
+		int(streamMeta.EncryptionBlockSize),
+	)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	rangers = append(rangers, decryptedLastSegmentRanger)
+
+	catRangers := concatSegments(segmentsInFlight, rangers...)
+	catRangers = verifyRanger(catRangers, storj.ChecksumType(stream.ChecksumType), checksums{md5: stream.Checksum, sha256: stream.Sha256Checksum})
+
+	lastSegmentMeta.Data = streamInfo
+	meta, err = convertMeta(lastSegmentMeta)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return catRangers, meta, nil
+}
+
+// Meta implements Store.Meta
+func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.meta(ctx, path, pathCipher, "")
+}
+
+// MetaVersion returns information about an archived version of a stream
+func (s *streamStore) MetaVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if version == "" {
+		return Meta{}, storage.ErrKeyNotFound.New("no version specified")
+	}
+
+	return s.meta(ctx, path, pathCipher, version)
+}
+
+func (s *streamStore) meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (meta Meta, err error) {
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	lastSegmentMeta, err := s.segments.Meta(ctx, getLastSegmentPath(encPath, version))
+	if err != nil {
+		return Meta{}, err
+	}
+
+	if version != "" {
+		lastSegmentMeta.Modified, err = VersionTime(version)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	lastSegmentMeta.Data = streamInfo
+	newStreamMeta, err := convertMeta(lastSegmentMeta)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return newStreamMeta, nil
+}
+
+// Delete all the segments, with the last one last
+func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.delete(ctx, path, pathCipher, "")
+}
+
+// DeleteVersion deletes all the segments of an archived version of a stream,
+// with the last one last
+func (s *streamStore) DeleteVersion(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if version == "" {
+		return storage.ErrKeyNotFound.New("no version specified")
+	}
+
+	return s.delete(ctx, path, pathCipher, version)
+}
+
+func (s *streamStore) delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher, version string) (err error) {
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return err
+	}
+	lastSegmentMeta, err := s.segments.Meta(ctx, getLastSegmentPath(encPath, version))
+	if err != nil {
+		return err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	if err != nil {
+		return err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
+		encPath, err = EncryptAfterBucket(path, pathCipher, s.rootKey)
+		if err != nil {
+			return err
+		}
+		currentPath := getVersionSegmentPath(encPath, version, int64(i))
+		err := s.segments.Delete(ctx, currentPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.segments.Delete(ctx, getLastSegmentPath(encPath, version))
+}
+
+// Archive moves the stream at path to v/<path>/<version> and
+// v0/<path>/<version>, v1/<path>/<version>, ... where it is kept as a prior
+// version of the stream. The data of the segments is not copied.
+func (s *streamStore) Archive(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (version string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return "", err
+	}
+
+	lastSegmentMeta, err := s.segments.Meta(ctx, getLastSegmentPath(encPath, ""))
+	if err != nil {
+		return "", err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	if err != nil {
+		return "", err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return "", err
+	}
+
+	version = VersionID(lastSegmentMeta.Modified)
+
+	// the segment keys are derived from the path only, so the pointers can
+	// be copied as they are
+	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
+		_, err = s.segments.Copy(ctx, getSegmentPath(encPath, i), getVersionSegmentPath(encPath, version, i), nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	_, err = s.segments.Copy(ctx, getLastSegmentPath(encPath, ""), getLastSegmentPath(encPath, version), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return version, s.Delete(ctx, path, pathCipher)
+}
+
+// Copy duplicates the stream at source under destination without copying
+// its data. Only the content keys of the segments are re-encrypted with the
+// key derived from the destination path.
+func (s *streamStore) Copy(ctx context.Context, source storj.Path, sourceCipher storj.Cipher, destination storj.Path, destinationCipher storj.Cipher) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encSource, err := EncryptAfterBucket(source, sourceCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	encDestination, err := EncryptAfterBucket(destination, destinationCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	lastSegmentMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encSource))
+	if err != nil {
+		return Meta{}, err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, source, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(lastSegmentMeta.Data, &streamMeta)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	sourceKey, err := encryption.DeriveContentKey(source, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	destinationKey, err := encryption.DeriveContentKey(destination, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	cipher := storj.Cipher(streamMeta.EncryptionType)
+
+	// previously file uploaded?
+	err = s.Delete(ctx, destination, destinationCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return Meta{}, err
+	}
+
+	err = s.DeletePending(ctx, destination, destinationCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return Meta{}, err
+	}
+
+	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
+		var metadata []byte
+		if cipher != storj.Unencrypted {
+			segmentMeta, err := s.segments.Meta(ctx, getSegmentPath(encSource, i))
+			if err != nil {
+				return Meta{}, err
+			}
+
+			meta := pb.SegmentMeta{}
+			err = proto.Unmarshal(segmentMeta.Data, &meta)
+			if err != nil {
+				return Meta{}, err
+			}
+
+			rewrapped, err := rewrapKey(&meta, cipher, sourceKey, destinationKey)
+			if err != nil {
+				return Meta{}, err
+			}
+
+			metadata, err = proto.Marshal(rewrapped)
+			if err != nil {
+				return Meta{}, err
+			}
+		}
+
+		_, err = s.segments.Copy(ctx, getSegmentPath(encSource, i), getSegmentPath(encDestination, i), metadata)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+
+	if cipher != storj.Unencrypted && streamMeta.LastSegmentMeta != nil {
+		streamMeta.LastSegmentMeta, err = rewrapKey(streamMeta.LastSegmentMeta, cipher, sourceKey, destinationKey)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+
+	metadata, err := proto.Marshal(&streamMeta)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// the last segment is copied last, as it commits the copy
+	copiedMeta, err := s.segments.Copy(ctx, storj.JoinPaths("l", encSource), storj.JoinPaths("l", encDestination), metadata)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	copiedMeta.Data = streamInfo
+	return convertMeta(copiedMeta)
+}
+
+// rewrapKey decrypts the content key of a segment with the source key and
+// encrypts it with the destination key
+func rewrapKey(m *pb.SegmentMeta, cipher storj.Cipher, sourceKey, destinationKey *storj.Key) (*pb.SegmentMeta, error) {
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(m)
+	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, sourceKey, keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var newKeyNonce storj.Nonce
+	_, err = rand.Read(newKeyNonce[:])
+	if err != nil {
+		return nil, err
+	}
+
+	newEncryptedKey, err := encryption.EncryptKey(contentKey, cipher, destinationKey, &newKeyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SegmentMeta{
+		EncryptedKey:        newEncryptedKey,
+		KeyNonce:            newKeyNonce[:],
+		ContentNonce:        m.GetContentNonce(),
+		CompressedBlockSize: m.GetCompressedBlockSize(),
+		CompressedOffsets:   m.GetCompressedOffsets(),
+	}, nil
+}
+
+// Concat stores the concatenation of the streams at parts under path with
+// the given metadata. The pointers of the segments of the parts are copied
+// to the concatenated stream without copying their data, and only their
+// content keys are re-encrypted with the key derived from path. The parts
+// must have the same encryption and compression, and they are left as they
+// are. The concatenated stream has no checksum unless there is a single part.
+func (s *streamStore) Concat(ctx context.Context, path storj.Path, pathCipher storj.Cipher, parts []storj.Path, partsCipher storj.Cipher, metadata []byte) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(parts) == 0 {
+		return Meta{}, errs.New("no streams to concatenate")
+	}
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// previously file uploaded?
+	err = s.Delete(ctx, path, pathCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
 		return Meta{}, err
 	}
 
-	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration)
-	if err != nil {
-		s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
+	err = s.DeletePending(ctx, path, pathCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return Meta{}, err
 	}
 
-	return m, err
-}
-
-func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	var currentSegment int64
-	var streamSize int64
-	var putMeta segments.Meta
+	concatenated := pb.StreamInfo{Metadata: metadata}
+	concatenatedMeta := pb.StreamMeta{}
+	var lastSegment storj.Path
+	var lastSegmentKey *storj.Key
 
-	defer func() {
-		select {
-		case <-ctx.Done():
-			s.cancelHandler(context.Background(), currentSegment, path, pathCipher)
-		default:
+	for i, part := range parts {
+		encPart, err := EncryptAfterBucket(part, partsCipher, s.rootKey)
+		if err != nil {
+			return Meta{}, err
 		}
-	}()
-
-	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
-	if err != nil {
-		return Meta{}, currentSegment, err
-	}
-
-	eofReader := NewEOFReader(data)
 
-	for !eofReader.isEOF() && !eofReader.hasError() {
-		// generate random key for encrypting the segment's content
-		var contentKey storj.Key
-		_, err = rand.Read(contentKey[:])
+		partKey, err := encryption.DeriveContentKey(part, s.rootKey)
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		// Initialize the content nonce with the segment's index incremented by 1.
-		// The increment by 1 is to avoid nonce reuse with the metadata encryption,
-		// which is encrypted with the zero nonce.
-		var contentNonce storj.Nonce
-		_, err := encryption.Increment(&contentNonce, currentSegment+1)
+		partMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPart))
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		encrypter, err := encryption.NewEncrypter(s.cipher, &contentKey, &contentNonce, s.encBlockSize)
+		streamInfo, err := decryptStreamInfo(partMeta, partKey)
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		// generate random nonce for encrypting the content key
-		var keyNonce storj.Nonce
-		_, err = rand.Read(keyNonce[:])
+		stream := pb.StreamInfo{}
+		err = proto.Unmarshal(streamInfo, &stream)
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		encryptedKey, err := encryption.EncryptKey(&contentKey, s.cipher, derivedKey, &keyNonce)
+		streamMeta := pb.StreamMeta{}
+		err = proto.Unmarshal(partMeta.Data, &streamMeta)
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		sizeReader := NewSizeReader(eofReader)
-		segmentReader := io.LimitReader(sizeReader, s.segmentSize)
-		peekReader := segments.NewPeekThresholdReader(segmentReader)
-		largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-		var transformedReader io.Reader
-		if largeData {
-			paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
-			transformedReader = encryption.TransformReader(paddedReader, encrypter, 0)
-		} else {
-			data, err := ioutil.ReadAll(peekReader)
-			if err != nil {
-				return Meta{}, currentSegment, err
-			}
-			cipherData, err := encryption.Encrypt(data, s.cipher, &contentKey, &contentNonce)
-			if err != nil {
-				return Meta{}, currentSegment, err
-			}
-			transformedReader = bytes.NewReader(cipherData)
+		if len(parts) == 1 {
+			concatenated.Checksum = stream.Checksum
+			concatenated.ChecksumType = stream.ChecksumType
+			concatenated.Sha256Checksum = stream.Sha256Checksum
 		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
-			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
-			if err != nil {
-				return "", nil, err
-			}
-
-			if !eofReader.isEOF() {
-				segmentPath := getSegmentPath(encPath, currentSegment)
+		if i == 0 {
+			concatenated.CompressionType = stream.CompressionType
+			concatenatedMeta.EncryptionType = streamMeta.EncryptionType
+			concatenatedMeta.EncryptionBlockSize = streamMeta.EncryptionBlockSize
+		} else if stream.CompressionType != concatenated.CompressionType ||
+			streamMeta.EncryptionType != concatenatedMeta.EncryptionType ||
+			streamMeta.EncryptionBlockSize != concatenatedMeta.EncryptionBlockSize {
+			return Meta{}, errs.New("streams with different encryption or compression can't be concatenated")
+		}
 
-				if s.cipher == storj.Unencrypted {
-					return segmentPath, nil, nil
+		cipher := storj.Cipher(streamMeta.EncryptionType)
+		for k := int64(0); k < stream.NumberOfSegments; k++ {
+			isLast := k == stream.NumberOfSegments-1
+
+			source := getSegmentPath(encPart, k)
+			size := segmentSize(&stream, k)
+			segmentMeta := streamMeta.LastSegmentMeta
+			if isLast {
+				source = storj.JoinPaths("l", encPart)
+				size = stream.LastSegmentSize
+			} else {
+				sourceMeta, err := s.segments.Meta(ctx, source)
+				if err != nil {
+					return Meta{}, err
 				}
 
-				segmentMeta, err := proto.Marshal(&pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
-				})
+				segmentMeta = &pb.SegmentMeta{}
+				err = proto.Unmarshal(sourceMeta.Data, segmentMeta)
 				if err != nil {
-					return "", nil, err
+					return Meta{}, err
 				}
-
-				return segmentPath, segmentMeta, nil
 			}
 
-			lastSegmentPath := storj.JoinPaths("l", encPath)
-
-			streamInfo, err := proto.Marshal(&pb.StreamInfo{
-				NumberOfSegments: currentSegment + 1,
-				SegmentsSize:     s.segmentSize,
-				LastSegmentSize:  sizeReader.Size(),
-				Metadata:         metadata,
-			})
-			if err != nil {
-				return "", nil, err
+			moved := &pb.SegmentMeta{
+				CompressedBlockSize: segmentMeta.GetCompressedBlockSize(),
+				CompressedOffsets:   segmentMeta.GetCompressedOffsets(),
+			}
+			if cipher != storj.Unencrypted {
+				moved, err = rewrapKey(segmentMeta, cipher, partKey, derivedKey)
+				if err != nil {
+					return Meta{}, err
+				}
 			}
 
-			// encrypt metadata with the content encryption key and zero nonce
-			encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, &contentKey, &storj.Nonce{})
+			// the content of the segment is decrypted with the nonce
+			// of its index in the part
+			nonce, err := contentNonce(segmentMeta, k, stream.Deduplicated)
 			if err != nil {
-				return "", nil, err
+				return Meta{}, err
 			}
+			moved.ContentNonce = nonce[:]
 
-			streamMeta := pb.StreamMeta{
-				EncryptedStreamInfo: encryptedStreamInfo,
-				EncryptionType:      int32(s.cipher),
-				EncryptionBlockSize: int32(s.encBlockSize),
+			if isLast && i == len(parts)-1 {
+				concatenated.LastSegmentSize = size
+				concatenatedMeta.LastSegmentMeta = moved
+				lastSegment, lastSegmentKey = source, partKey
+				break
 			}
 
-			if s.cipher != storj.Unencrypted {
-				streamMeta.LastSegmentMeta = &pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
-				}
+			movedData, err := proto.Marshal(moved)
+			if err != nil {
+				return Meta{}, err
 			}
 
-			lastSegmentMeta, err := proto.Marshal(&streamMeta)
+			_, err = s.segments.Copy(ctx, source, getSegmentPath(encPath, concatenated.NumberOfSegments), movedData)
 			if err != nil {
-				return "", nil, err
+				return Meta{}, err
 			}
 
-			return lastSegmentPath, lastSegmentMeta, nil
-		})
+			concatenated.NumberOfSegments++
+			concatenated.SegmentSizes = append(concatenated.SegmentSizes, size)
+		}
+	}
+	concatenated.NumberOfSegments++
+
+	// the content key of the last segment encrypted the stream info of the
+	// last part already, so the new one is encrypted with a random nonce
+	cipher := storj.Cipher(concatenatedMeta.EncryptionType)
+	var contentKey storj.Key
+	var streamInfoNonce storj.Nonce
+	if cipher != storj.Unencrypted {
+		partSegmentMeta, err := s.segments.Meta(ctx, lastSegment)
 		if err != nil {
-			return Meta{}, currentSegment, err
+			return Meta{}, err
 		}
 
-		currentSegment++
-		streamSize += sizeReader.Size()
+		partMeta := pb.StreamMeta{}
+		err = proto.Unmarshal(partSegmentMeta.Data, &partMeta)
+		if err != nil {
+			return Meta{}, err
+		}
+
+		encryptedKey, keyNonce := getEncryptedKeyAndNonce(partMeta.LastSegmentMeta)
+		key, err := encryption.DecryptKey(encryptedKey, cipher, lastSegmentKey, keyNonce)
+		if err != nil {
+			return Meta{}, err
+		}
+		contentKey = *key
+
+		_, err = rand.Read(streamInfoNonce[:])
+		if err != nil {
+			return Meta{}, err
+		}
+		concatenatedMeta.StreamInfoNonce = streamInfoNonce[:]
 	}
 
-	if eofReader.hasError() {
-		return Meta{}, currentSegment, eofReader.err
+	streamInfo, err := proto.Marshal(&concatenated)
+	if err != nil {
+		return Meta{}, err
 	}
 
-	resultMeta := Meta{
-		Modified:   putMeta.Modified,
-		Expiration: expiration,
-		Size:       streamSize,
-		Data:       metadata,
+	concatenatedMeta.EncryptedStreamInfo, err = encryption.Encrypt(streamInfo, cipher, &contentKey, &streamInfoNonce)
+	if err != nil {
+		return Meta{}, err
 	}
 
-	return resultMeta, currentSegment, nil
-}
+	lastSegmentData, err := proto.Marshal(&concatenatedMeta)
+	if err != nil {
+		return Meta{}, err
+	}
 
-// getSegmentPath returns the unique path for a particular segment
-func getSegmentPath(path storj.Path, segNum int64) storj.Path {
-	return storj.JoinPaths(fmt.Sprintf("s%d", segNum), path)
+	// the last segment is copied last, as it commits the concatenation
+	concatenatedSegmentMeta, err := s.segments.Copy(ctx, lastSegment, storj.JoinPaths("l", encPath), lastSegmentData)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	concatenatedSegmentMeta.Data = streamInfo
+	return convertMeta(concatenatedSegmentMeta)
 }
 
-// Get returns a ranger that knows what the overall size is (from l/<path>)
-// and then returns the appropriate data from segments s0/<path>, s1/<path>,
-// ..., l/<path>.
-func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (rr ranger.Ranger, meta Meta, err error) {
+// UpdateMetadata replaces the metadata and the expiration of the stream at
+// path without transferring its data. Only the stream info in l/<path> is
+// re-encrypted, the other segments are updated only if the expiration changes.
+// The expiration can be set or brought forward, but not extended or cleared.
+func (s *streamStore) UpdateMetadata(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte, expiration time.Time) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 	if err != nil {
-		return nil, Meta{}, err
-	}
-
-	lastSegmentRanger, lastSegmentMeta, err := s.segments.Get(ctx, storj.JoinPaths("l", encPath))
-	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	lastSegmentMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
 
-	stream := pb.StreamInfo{}
-	err = proto.Unmarshal(streamInfo, &stream)
-	if err != nil {
-		return nil, Meta{}, err
+	// the storage nodes delete the pieces at the expiration they were stored
+	// with, so it's checked before any segment is updated
+	current := lastSegmentMeta.Expiration
+	if !current.IsZero() && (expiration.IsZero() || expiration.After(current)) {
+		return Meta{}, storj.ErrExpiration.New("%q expires at %s", path, current)
 	}
 
 	streamMeta := pb.StreamMeta{}
 	err = proto.Unmarshal(lastSegmentMeta.Data, &streamMeta)
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
 
 	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
 
-	var rangers []ranger.Ranger
-	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
-		currentPath := getSegmentPath(encPath, i)
-		size := stream.SegmentsSize
-		var contentNonce storj.Nonce
-		_, err := encryption.Increment(&contentNonce, i+1)
-		if err != nil {
-			return nil, Meta{}, err
-		}
-		rr := &lazySegmentRanger{
-			segments:      s.segments,
-			path:          currentPath,
-			size:          size,
-			derivedKey:    derivedKey,
-			startingNonce: &contentNonce,
-			encBlockSize:  int(streamMeta.EncryptionBlockSize),
-			cipher:        storj.Cipher(streamMeta.EncryptionType),
-		}
-		rangers = append(rangers, rr)
+	cipher := storj.Cipher(streamMeta.EncryptionType)
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
+	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, keyNonce)
+	if err != nil {
+		return Meta{}, err
 	}
 
-	var contentNonce storj.Nonce
-	_, err = encryption.Increment(&contentNonce, stream.NumberOfSegments)
+	var streamInfoNonce storj.Nonce
+	copy(streamInfoNonce[:], streamMeta.StreamInfoNonce)
+	streamInfo, err := encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &streamInfoNonce)
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
-	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
-	decryptedLastSegmentRanger, err := decryptRanger(
-		ctx,
-		lastSegmentRanger,
-		stream.LastSegmentSize,
-		storj.Cipher(streamMeta.EncryptionType),
-		derivedKey,
-		encryptedKey,
-		keyNonce,
-		&contentNonce,
-		int(streamMeta.EncryptionBlockSize),
-	)
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
-	rangers = append(rangers, decryptedLastSegmentRanger)
 
-	catRangers := ranger.Concat(rangers...)
-
-	lastSegmentMeta.Data = streamInfo
-	meta, err = convertMeta(lastSegmentMeta)
+	stream.Metadata = metadata
+	streamInfo, err = proto.Marshal(&stream)
 	if err != nil {
-		return nil, Meta{}, err
+		return Meta{}, err
 	}
 
-	return catRangers, meta, nil
-}
-
-// Meta implements Store.Meta
-func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (meta Meta, err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	// the content key already encrypted the previous stream info, so the new
+	// one is encrypted with a random nonce
+	_, err = rand.Read(streamInfoNonce[:])
 	if err != nil {
 		return Meta{}, err
 	}
 
-	lastSegmentMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
+	streamMeta.EncryptedStreamInfo, err = encryption.Encrypt(streamInfo, cipher, contentKey, &streamInfoNonce)
 	if err != nil {
 		return Meta{}, err
 	}
+	streamMeta.StreamInfoNonce = streamInfoNonce[:]
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	lastSegmentData, err := proto.Marshal(&streamMeta)
 	if err != nil {
 		return Meta{}, err
 	}
 
-	lastSegmentMeta.Data = streamInfo
-	newStreamMeta, err := convertMeta(lastSegmentMeta)
+	if !expiration.Equal(lastSegmentMeta.Expiration) {
+		for i := int64(0); i < stream.NumberOfSegments-1; i++ {
+			_, err = s.segments.UpdateMeta(ctx, getSegmentPath(encPath, i), nil, expiration)
+			if err != nil {
+				return Meta{}, err
+			}
+		}
+	}
+
+	// the last segment is updated last, as it commits the update
+	updatedMeta, err := s.segments.UpdateMeta(ctx, storj.JoinPaths("l", encPath), lastSegmentData, expiration)
 	if err != nil {
 		return Meta{}, err
 	}
 
-	return newStreamMeta, nil
+	updatedMeta.Data = streamInfo
+	return convertMeta(updatedMeta)
 }
 
-// Delete all the segments, with the last one last
-func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+// Pending returns information about an interrupted upload from p/<path>.
+// The returned Size is the amount of data that is already committed.
+func (s *streamStore) Pending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 	if err != nil {
-		return err
+		return Meta{}, err
 	}
-	lastSegmentMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
+
+	pendingMeta, stream, _, err := s.getPending(ctx, path, encPath)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{
+		Modified:   pendingMeta.Modified,
+		Expiration: pendingMeta.Expiration,
+		Size:       stream.NumberOfSegments * stream.SegmentsSize,
+		Data:       stream.Metadata,
+	}, nil
+}
+
+// DeletePending aborts an interrupted upload by deleting its committed
+// segments and the pending marker
+func (s *streamStore) DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 	if err != nil {
 		return err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	_, stream, _, err := s.getPending(ctx, path, encPath)
 	if err != nil {
 		return err
 	}
 
-	stream := pb.StreamInfo{}
-	err = proto.Unmarshal(streamInfo, &stream)
+	// a stale marker of a committed upload must not delete its segments
+	committed, err := s.isCommitted(ctx, encPath)
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
-		encPath, err = EncryptAfterBucket(path, pathCipher, s.rootKey)
-		if err != nil {
-			return err
-		}
-		currentPath := getSegmentPath(encPath, int64(i))
-		err := s.segments.Delete(ctx, currentPath)
+	if !committed {
+		// the marker is updated every pendingInterval segments,
+		// so there may be more segments than recorded
+		err = s.deleteSegments(ctx, encPath, 0, stream.NumberOfSegments+s.staleSegments())
 		if err != nil {
 			return err
 		}
 	}
 
-	return s.segments.Delete(ctx, storj.JoinPaths("l", encPath))
+	return s.segments.Delete(ctx, storj.JoinPaths("p", encPath))
 }
 
 // ListItem is a single item in a listing
@@ -453,6 +1701,63 @@ type ListItem struct {
 func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return s.list(ctx, "l", convertMeta, prefix, startAfter, endBefore, pathCipher, recursive, limit, metaFlags)
+}
+
+// ListPending lists the interrupted uploads inside p/, stripping off the p/ prefix
+func (s *streamStore) ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.list(ctx, "p", convertPendingMeta, prefix, startAfter, endBefore, pathCipher, recursive, limit, metaFlags)
+}
+
+// ListVersions lists the archived versions of the stream at path. The path of
+// each item is the version.
+func (s *streamStore) ListVersions(ctx context.Context, path storj.Path, pathCipher storj.Cipher, startAfter, endBefore string, limit int) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	segments, more, err := s.segments.List(ctx, storj.JoinPaths("v", encPath), startAfter, endBefore, false, limit, meta.All)
+	if err != nil {
+		return nil, false, err
+	}
+
+	items = make([]ListItem, 0, len(segments))
+	for _, item := range segments {
+		// prefixes are the versions of the streams nested under path
+		if item.IsPrefix {
+			continue
+		}
+
+		item.Meta.Modified, err = VersionTime(item.Path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		streamInfo, err := DecryptStreamInfo(ctx, item.Meta, path, s.rootKey)
+		if err != nil {
+			return nil, false, err
+		}
+
+		item.Meta.Data = streamInfo
+		newMeta, err := convertMeta(item.Meta)
+		if err != nil {
+			return nil, false, err
+		}
+
+		items = append(items, ListItem{Path: item.Path, Meta: newMeta})
+	}
+
+	return items, more, nil
+}
+
+func (s *streamStore) list(ctx context.Context, root storj.Path, convert func(segments.Meta) (Meta, error), prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	if metaFlags&meta.Size != 0 {
 		// Calculating the stream's size require also the user-defined metadata,
 		// where stream store keeps info about the number of segments and their size.
@@ -481,7 +1786,7 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 		return nil, false, err
 	}
 
-	segments, more, err := s.segments.List(ctx, storj.JoinPaths("l", encPrefix), encStartAfter, encEndBefore, recursive, limit, metaFlags)
+	segments, more, err := s.segments.List(ctx, storj.JoinPaths(root, encPrefix), encStartAfter, encEndBefore, recursive, limit, metaFlags)
 	if err != nil {
 		return nil, false, err
 	}
@@ -499,7 +1804,7 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 		}
 
 		item.Meta.Data = streamInfo
-		newMeta, err := convertMeta(item.Meta)
+		newMeta, err := convert(item.Meta)
 		if err != nil {
 			return nil, false, err
 		}
@@ -527,14 +1832,16 @@ func (s *streamStore) decryptMarker(marker storj.Path, pathCipher storj.Cipher,
 }
 
 type lazySegmentRanger struct {
-	ranger        ranger.Ranger
-	segments      segments.Store
-	path          storj.Path
-	size          int64
-	derivedKey    *storj.Key
-	startingNonce *storj.Nonce
-	encBlockSize  int
-	cipher        storj.Cipher
+	ranger       ranger.Ranger
+	segments     segments.Store
+	path         storj.Path
+	index        int64
+	deduplicated bool
+	size         int64
+	derivedKey   *storj.Key
+	encBlockSize int
+	cipher       storj.Cipher
+	compression  storj.Compression
 }
 
 // Size implements Ranger.Size
@@ -555,7 +1862,11 @@ func (lr *lazySegmentRanger) Range(ctx context.Context, offset, length int64) (i
 			return nil, err
 		}
 		encryptedKey, keyNonce := getEncryptedKeyAndNonce(&segmentMeta)
-		lr.ranger, err = decryptRanger(ctx, rr, lr.size, lr.cipher, lr.derivedKey, encryptedKey, keyNonce, lr.startingNonce, lr.encBlockSize)
+		startingNonce, err := contentNonce(&segmentMeta, lr.index, lr.deduplicated)
+		if err != nil {
+			return nil, err
+		}
+		lr.ranger, err = decryptRanger(ctx, rr, lr.size, lr.compression, &segmentMeta, lr.cipher, lr.derivedKey, encryptedKey, keyNonce, &startingNonce, lr.encBlockSize)
 		if err != nil {
 			return nil, err
 		}
@@ -563,8 +1874,10 @@ func (lr *lazySegmentRanger) Range(ctx context.Context, offset, length int64) (i
 	return lr.ranger.Range(ctx, offset, length)
 }
 
-// decryptRanger returns a decrypted ranger of the given rr ranger
-func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, cipher storj.Cipher, derivedKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, encryptedKeyNonce, startingNonce *storj.Nonce, encBlockSize int) (ranger.Ranger, error) {
+// decryptRanger returns a decrypted and decompressed ranger of the given rr
+// ranger, where decryptedSize is the size of the uncompressed content. The
+// offsets of its compressed blocks are in segmentMeta, which may be nil.
+func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, compression storj.Compression, segmentMeta *pb.SegmentMeta, cipher storj.Cipher, derivedKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, encryptedKeyNonce, startingNonce *storj.Nonce, encBlockSize int) (ranger.Ranger, error) {
 	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, encryptedKeyNonce)
 	if err != nil {
 		return nil, err
@@ -589,13 +1902,17 @@ func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, c
 		if err != nil {
 			return nil, err
 		}
-		return ranger.ByteRanger(data), nil
+		return decompressRanger(ranger.ByteRanger(data), compression, decryptedSize, segmentMeta.GetCompressedBlockSize(), segmentMeta.GetCompressedOffsets())
 	}
 
 	rd, err = encryption.Transform(rr, decrypter)
 	if err != nil {
 		return nil, err
 	}
+	if compression != storj.NoCompression {
+		// the decompression stops before the padding
+		return decompressRanger(rd, compression, decryptedSize, segmentMeta.GetCompressedBlockSize(), segmentMeta.GetCompressedOffsets())
+	}
 	return eestream.Unpad(rd, int(rd.Size()-decryptedSize))
 }
 
@@ -638,22 +1955,6 @@ func DecryptAfterBucket(path storj.Path, cipher storj.Cipher, key *storj.Key) (d
 	return storj.JoinPaths(bucket, decPath), nil
 }
 
-// CancelHandler handles clean up of segments on receiving CTRL+C
-func (s *streamStore) cancelHandler(ctx context.Context, totalSegments int64, path storj.Path, pathCipher storj.Cipher) {
-	for i := int64(0); i < totalSegments; i++ {
-		encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
-		if err != nil {
-			zap.S().Warnf("Failed deleting a segment due to encryption path %v %v", i, err)
-		}
-
-		currentPath := getSegmentPath(encPath, i)
-		err = s.segments.Delete(ctx, currentPath)
-		if err != nil {
-			zap.S().Warnf("Failed deleting a segment %v %v", currentPath, err)
-		}
-	}
-}
-
 func getEncryptedKeyAndNonce(m *pb.SegmentMeta) (storj.EncryptedPrivateKey, *storj.Nonce) {
 	if m == nil {
 		return nil, nil
@@ -667,13 +1968,18 @@ func getEncryptedKeyAndNonce(m *pb.SegmentMeta) (storj.EncryptedPrivateKey, *sto
 
 // DecryptStreamInfo decrypts stream info
 func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path, rootKey *storj.Key) (streamInfo []byte, err error) {
-	streamMeta := pb.StreamMeta{}
-	err = proto.Unmarshal(item.Data, &streamMeta)
+	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
 	if err != nil {
 		return nil, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
+	return decryptStreamInfo(item, derivedKey)
+}
+
+// decryptStreamInfo decrypts stream info with the content key derived for the object
+func decryptStreamInfo(item segments.Meta, derivedKey *storj.Key) (streamInfo []byte, err error) {
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(item.Data, &streamMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -685,6 +1991,9 @@ func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path,
 		return nil, err
 	}
 
-	// decrypt metadata with the content encryption key and zero nonce
-	return encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &storj.Nonce{})
+	// decrypt metadata with the content encryption key and the zero nonce,
+	// unless another one is given
+	var streamInfoNonce storj.Nonce
+	copy(streamInfoNonce[:], streamMeta.StreamInfoNonce)
+	return encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &streamInfoNonce)
 }
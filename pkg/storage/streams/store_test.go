@@ -4,21 +4,31 @@
 package streams
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -91,7 +101,7 @@ func TestStreamStoreMeta(t *testing.T) {
 			Meta(gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, storj.AESGCM)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, storj.AESGCM, Options{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -156,6 +166,9 @@ func TestStreamStorePut(t *testing.T) {
 				}
 			})
 
+		mockSegmentStore.EXPECT().
+			Meta(gomock.Any(), "p/"+test.path).
+			Return(segments.Meta{}, storage.ErrKeyNotFound.New(test.path))
 		mockSegmentStore.EXPECT().
 			Meta(gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError)
@@ -163,7 +176,7 @@ func TestStreamStorePut(t *testing.T) {
 			Delete(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0, Options{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -177,6 +190,57 @@ func TestStreamStorePut(t *testing.T) {
 	}
 }
 
+func TestStreamStorePending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	stream, err := proto.Marshal(&pb.StreamInfo{
+		NumberOfSegments: 3,
+		SegmentsSize:     10,
+		Metadata:         []byte("metadata"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingMetadata, err := proto.Marshal(&pb.StreamMeta{
+		EncryptedStreamInfo: stream,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staticTime := time.Now()
+	pendingMeta := segments.Meta{
+		Modified:   staticTime,
+		Expiration: staticTime,
+		Data:       pendingMetadata,
+	}
+
+	mockSegmentStore.EXPECT().
+		Meta(gomock.Any(), "p/bucket/object").
+		Return(pendingMeta, nil)
+
+	streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := streamStore.Pending(ctx, "bucket/object", storj.Unencrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Meta{
+		Modified:   staticTime,
+		Expiration: staticTime,
+		Size:       30,
+		Data:       []byte("metadata"),
+	}, meta)
+}
+
 type stubRanger struct {
 	len    int64
 	closer io.ReadCloser
@@ -263,7 +327,7 @@ func TestStreamStoreGet(t *testing.T) {
 
 		gomock.InOrder(calls...)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0, Options{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -312,7 +376,7 @@ func TestStreamStoreDelete(t *testing.T) {
 			Delete(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0, Options{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -356,7 +420,7 @@ func TestStreamStoreList(t *testing.T) {
 			List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(test.segments, test.segmentMore, test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0, Options{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -370,3 +434,347 @@ func TestStreamStoreList(t *testing.T) {
 		assert.Equal(t, test.streamMore, more, errTag)
 	}
 }
+
+func TestStreamStoreResume(t *testing.T) {
+	data := make([]byte, 10*pendingInterval*2+5)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for _, inFlight := range []int{1, 3} {
+		segmentStore := newMemorySegments()
+		streamStore, err := NewStreamStore(segmentStore, 10, new(storj.Key), 1024, storj.AESGCM, Options{Checksum: storj.MD5AndSHA256, SegmentsInFlight: inFlight, MaxBufferMem: 10 * inFlight})
+		require.NoError(t, err)
+
+		// the upload is interrupted in the middle of the second half
+		interrupted := &failingReader{reader: bytes.NewReader(data), failAfter: int64(len(data)) - 50}
+		_, err = streamStore.Put(ctx, "bucket/object", storj.Unencrypted, interrupted, []byte("metadata"), time.Time{})
+		require.Error(t, err, "inFlight %d", inFlight)
+
+		offset := int64(0)
+		pending, err := streamStore.Pending(ctx, "bucket/object", storj.Unencrypted)
+		if inFlight == 1 {
+			require.NoError(t, err)
+			assert.Equal(t, int64(10*pendingInterval), pending.Size)
+		}
+		if err == nil {
+			offset = pending.Size
+			// only the segments recorded by the pending marker are kept
+			assert.Equal(t, int(offset/10)+1, segmentStore.Len(), "inFlight %d", inFlight)
+		} else {
+			require.True(t, storage.ErrKeyNotFound.Has(err))
+			assert.Equal(t, 0, segmentStore.Len(), "inFlight %d", inFlight)
+		}
+
+		var meta Meta
+		if offset > 0 {
+			meta, err = streamStore.Continue(ctx, "bucket/object", storj.Unencrypted, bytes.NewReader(data[offset:]))
+		} else {
+			meta, err = streamStore.Put(ctx, "bucket/object", storj.Unencrypted, bytes.NewReader(data), []byte("metadata"), time.Time{})
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), meta.Size)
+		assert.Equal(t, []byte("metadata"), meta.Data)
+		if offset > 0 {
+			// the checksums continue from the states of the pending marker
+			md5Sum, sha256Sum := md5.Sum(data), sha256.Sum256(data)
+			assert.Equal(t, md5Sum[:], meta.Checksum)
+			assert.Equal(t, sha256Sum[:], meta.SHA256)
+		}
+
+		// the pending marker is deleted once the upload is committed
+		_, err = streamStore.Pending(ctx, "bucket/object", storj.Unencrypted)
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+		assert.Equal(t, (len(data)+9)/10, segmentStore.Len())
+
+		rr, _, err := streamStore.Get(ctx, "bucket/object", storj.Unencrypted)
+		require.NoError(t, err)
+		reader, err := rr.Range(ctx, 0, rr.Size())
+		require.NoError(t, err)
+		downloaded, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		assert.Equal(t, data, downloaded)
+	}
+}
+
+func TestStreamStoreParallelPut(t *testing.T) {
+	// fewer segments than the pending interval, so that only segments are put
+	data := make([]byte, 10*(pendingInterval-2)+5)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		inFlight     int
+		maxBufferMem int
+		maxPuts      int
+	}{
+		{inFlight: 1, maxBufferMem: 100, maxPuts: 1},
+		// one of the buffers holds the segment being read
+		{inFlight: 3, maxBufferMem: 100, maxPuts: 2},
+		{inFlight: 4, maxBufferMem: 30, maxPuts: 2},
+		// without buffers for two segments, they are put one after the other
+		{inFlight: 3, maxBufferMem: 15, maxPuts: 1},
+	} {
+		var mu sync.Mutex
+		maxPuts := 0
+
+		segmentStore := newMemorySegments()
+		segmentStore.putting = func(puts int) {
+			mu.Lock()
+			if puts > maxPuts {
+				maxPuts = puts
+			}
+			mu.Unlock()
+
+			// give the following segments the time to start uploading
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		streamStore, err := NewStreamStore(segmentStore, 10, new(storj.Key), 1024, storj.AESGCM, Options{Checksum: storj.SHA256, SegmentsInFlight: tt.inFlight, MaxBufferMem: tt.maxBufferMem})
+		require.NoError(t, err)
+
+		meta, err := streamStore.Put(ctx, "bucket/object", storj.Unencrypted, bytes.NewReader(data), nil, time.Time{})
+		require.NoError(t, err, i)
+		assert.Equal(t, int64(len(data)), meta.Size, i)
+		assert.Equal(t, (len(data)+9)/10, segmentStore.Len(), i)
+
+		mu.Lock()
+		assert.Equal(t, tt.maxPuts, maxPuts, i)
+		mu.Unlock()
+
+		rr, _, err := streamStore.Get(ctx, "bucket/object", storj.Unencrypted)
+		require.NoError(t, err, i)
+		reader, err := rr.Range(ctx, 0, rr.Size())
+		require.NoError(t, err, i)
+		downloaded, err := ioutil.ReadAll(reader)
+		require.NoError(t, err, i)
+		require.NoError(t, reader.Close(), i)
+		assert.Equal(t, data, downloaded, i)
+	}
+}
+
+func TestStreamStorePutCleanup(t *testing.T) {
+	data := make([]byte, 10*pendingInterval*2)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	segmentStore := newMemorySegments()
+	segmentStore.failPut = func(path storj.Path) error {
+		if strings.HasPrefix(path, "p/") {
+			return errors.New("pending marker failed")
+		}
+		return nil
+	}
+
+	streamStore, err := NewStreamStore(segmentStore, 10, new(storj.Key), 1024, storj.AESGCM, Options{})
+	require.NoError(t, err)
+
+	_, err = streamStore.Put(ctx, "bucket/object", storj.Unencrypted, bytes.NewReader(data), nil, time.Time{})
+	require.Error(t, err)
+
+	// the committed segments aren't recorded anywhere, so they are deleted
+	assert.Equal(t, 0, segmentStore.Len())
+}
+
+func TestStreamStoreConcat(t *testing.T) {
+	for _, tt := range []struct {
+		cipher      storj.Cipher
+		compression storj.Compression
+	}{
+		{storj.Unencrypted, storj.NoCompression},
+		{storj.AESGCM, storj.NoCompression},
+		// the offsets of the compressed blocks are kept in the segments
+		{storj.Unencrypted, storj.Gzip},
+		{storj.AESGCM, storj.Gzip},
+	} {
+		errTag := fmt.Sprintf("cipher %d, compression %d", tt.cipher, tt.compression)
+
+		segmentStore := newMemorySegments()
+		streamStore, err := NewStreamStore(segmentStore, 10, new(storj.Key), 1024, tt.cipher, Options{Compression: tt.compression})
+		require.NoError(t, err)
+
+		// parts of several segments, a single segment and a partial last segment
+		var data []byte
+		var parts []storj.Path
+		for i, size := range []int{25, 7, 13} {
+			part := make([]byte, size)
+			_, err := rand.Read(part)
+			require.NoError(t, err)
+
+			path := fmt.Sprintf("bucket/parts/%d", i)
+			_, err = streamStore.Put(ctx, path, storj.Unencrypted, bytes.NewReader(part), nil, time.Time{})
+			require.NoError(t, err, errTag)
+
+			data = append(data, part...)
+			parts = append(parts, path)
+		}
+
+		meta, err := streamStore.Concat(ctx, "bucket/object", storj.AESGCM, parts, storj.Unencrypted, []byte("metadata"))
+		require.NoError(t, err, errTag)
+		assert.Equal(t, int64(len(data)), meta.Size, errTag)
+		assert.Equal(t, []byte("metadata"), meta.Data, errTag)
+
+		// the parts are left for the caller to delete
+		for _, part := range parts {
+			require.NoError(t, streamStore.Delete(ctx, part, storj.Unencrypted), errTag)
+		}
+
+		rr, meta, err := streamStore.Get(ctx, "bucket/object", storj.AESGCM)
+		require.NoError(t, err, errTag)
+		assert.Equal(t, int64(len(data)), meta.Size, errTag)
+		assert.Equal(t, []byte("metadata"), meta.Data, errTag)
+
+		reader, err := rr.Range(ctx, 12, 20)
+		require.NoError(t, err, errTag)
+		downloaded, err := ioutil.ReadAll(reader)
+		require.NoError(t, err, errTag)
+		require.NoError(t, reader.Close())
+		assert.Equal(t, data[12:32], downloaded, errTag)
+	}
+}
+
+// failingReader fails reading after failAfter bytes
+type failingReader struct {
+	reader    io.Reader
+	failAfter int64
+}
+
+// Read implements io.Reader
+func (r *failingReader) Read(p []byte) (n int, err error) {
+	if r.failAfter <= 0 {
+		return 0, errors.New("interrupted")
+	}
+	if int64(len(p)) > r.failAfter {
+		p = p[:r.failAfter]
+	}
+	n, err = r.reader.Read(p)
+	r.failAfter -= int64(n)
+	return n, err
+}
+
+// memorySegments is a segment store keeping the segments in memory
+type memorySegments struct {
+	mu       sync.Mutex
+	segments map[storj.Path]memorySegment
+	failPut  func(path storj.Path) error
+	// putting is called at the start of each put with the number of puts in
+	// progress
+	putting func(puts int)
+	puts    int
+}
+
+type memorySegment struct {
+	data []byte
+	meta segments.Meta
+}
+
+func newMemorySegments() *memorySegments {
+	return &memorySegments{segments: make(map[storj.Path]memorySegment)}
+}
+
+// Len returns the number of stored segments
+func (m *memorySegments) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.segments)
+}
+
+func (m *memorySegments) Meta(ctx context.Context, path storj.Path) (segments.Meta, error) {
+	_, meta, err := m.Get(ctx, path)
+	return meta, err
+}
+
+func (m *memorySegments) Get(ctx context.Context, path storj.Path) (ranger.Ranger, segments.Meta, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	segment, ok := m.segments[path]
+	if !ok {
+		return nil, segments.Meta{}, storage.ErrKeyNotFound.New(path)
+	}
+	return ranger.ByteRanger(segment.data), segment.meta, nil
+}
+
+func (m *memorySegments) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (segments.Meta, error) {
+	m.mu.Lock()
+	m.puts++
+	puts := m.puts
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.puts--
+		m.mu.Unlock()
+	}()
+	if m.putting != nil {
+		m.putting(puts)
+	}
+
+	content, err := ioutil.ReadAll(data)
+	if err != nil {
+		return segments.Meta{}, err
+	}
+
+	path, metadata, err := segmentInfo()
+	if err != nil {
+		return segments.Meta{}, err
+	}
+	if m.failPut != nil {
+		if err := m.failPut(path); err != nil {
+			return segments.Meta{}, err
+		}
+	}
+
+	meta := segments.Meta{
+		Modified:   time.Now(),
+		Expiration: expiration,
+		Size:       int64(len(content)),
+		Data:       metadata,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.segments[path] = memorySegment{data: content, meta: meta}
+	return meta, nil
+}
+
+func (m *memorySegments) PutDeduplicated(ctx context.Context, dedupID []byte, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (segments.Meta, error) {
+	return m.Put(ctx, data, expiration, segmentInfo)
+}
+
+func (m *memorySegments) Delete(ctx context.Context, path storj.Path) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.segments[path]; !ok {
+		return storage.ErrKeyNotFound.New(path)
+	}
+	delete(m.segments, path)
+	return nil
+}
+
+func (m *memorySegments) Copy(ctx context.Context, source, destination storj.Path, metadata []byte) (segments.Meta, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	segment, ok := m.segments[source]
+	if !ok {
+		return segments.Meta{}, storage.ErrKeyNotFound.New(source)
+	}
+	if len(metadata) > 0 {
+		segment.meta.Data = metadata
+	}
+	m.segments[destination] = segment
+	return segment.meta, nil
+}
+
+func (m *memorySegments) UpdateMeta(ctx context.Context, path storj.Path, metadata []byte, expiration time.Time) (segments.Meta, error) {
+	return segments.Meta{}, errors.New("not implemented")
+}
+
+func (m *memorySegments) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) ([]segments.ListItem, bool, error) {
+	return nil, false, errors.New("not implemented")
+}
+
+func (m *memorySegments) WithRedundancy(rs eestream.RedundancyStrategy) segments.Store {
+	return m
+}
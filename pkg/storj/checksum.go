@@ -0,0 +1,24 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+// ChecksumType specifies the checksum algorithms of the content of objects,
+// which are combined to compute several checksums
+type ChecksumType byte
+
+// List of supported checksum algorithms
+const (
+	NoChecksum = ChecksumType(0)
+	MD5        = ChecksumType(1 << 0)
+	SHA256     = ChecksumType(1 << 1)
+
+	// MD5AndSHA256 computes the MD5 checksum for S3 compatibility and the
+	// SHA-256 checksum
+	MD5AndSHA256 = MD5 | SHA256
+)
+
+// Has returns whether the checksums of checksumType include the ones of other
+func (checksumType ChecksumType) Has(other ChecksumType) bool {
+	return checksumType&other == other
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+// Compression specifies a compression algorithm
+type Compression byte
+
+// List of supported compression algorithms
+const (
+	NoCompression = Compression(iota)
+	Gzip
+)
@@ -0,0 +1,22 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+// LifecycleRule deletes the objects under a prefix of a bucket after a number
+// of days. The rules are applied by the satellite.
+type LifecycleRule struct {
+	ID      string
+	Enabled bool
+
+	// Prefix is the directory of the bucket the rule applies to,
+	// the rule applies to the whole bucket if it is empty
+	Prefix Path
+
+	// ExpirationDays is the number of days after the upload when
+	// objects are deleted, objects are kept if it is zero
+	ExpirationDays int
+	// AbortPendingDays is the number of days without progress when
+	// pending uploads are aborted, uploads are kept if it is zero
+	AbortPendingDays int
+}
@@ -22,6 +22,10 @@ type Metainfo interface {
 	GetBucket(ctx context.Context, bucket string) (Bucket, error)
 	// ListBuckets lists buckets starting from first
 	ListBuckets(ctx context.Context, options BucketListOptions) (BucketList, error)
+	// SetBucketLifecycle replaces the lifecycle rules of a bucket
+	SetBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) error
+	// GetBucketLifecycle returns the lifecycle rules of a bucket
+	GetBucketLifecycle(ctx context.Context, bucket string) ([]LifecycleRule, error)
 
 	// GetObject returns information about an object
 	GetObject(ctx context.Context, bucket string, path Path) (Object, error)
@@ -30,10 +34,14 @@ type Metainfo interface {
 
 	// CreateObject creates a mutable object for uploading stream info
 	CreateObject(ctx context.Context, bucket string, path Path, info *CreateObject) (MutableObject, error)
-	// ModifyObject creates a mutable object for updating a partially uploaded object
+	// ModifyObject creates a mutable object for updating a committed object
 	ModifyObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
 	// DeleteObject deletes an object from database
 	DeleteObject(ctx context.Context, bucket string, path Path) error
+	// CopyObject copies an object without transferring its data
+	CopyObject(ctx context.Context, srcBucket string, srcPath Path, dstBucket string, dstPath Path) (Object, error)
+	// UpdateObjectMetadata replaces the metadata of a committed object without transferring its data
+	UpdateObjectMetadata(ctx context.Context, bucket string, path Path, info *UpdateObject) (Object, error)
 	// ListObjects lists objects in bucket based on the ListOptions
 	ListObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
 
@@ -41,6 +49,15 @@ type Metainfo interface {
 	ModifyPendingObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
 	// ListPendingObjects lists pending objects in bucket based on the ListOptions
 	ListPendingObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
+
+	// GetObjectVersion returns information about a version of an object
+	GetObjectVersion(ctx context.Context, bucket string, path Path, version string) (Object, error)
+	// GetObjectVersionStream returns interface for reading the stream of a version of an object
+	GetObjectVersionStream(ctx context.Context, bucket string, path Path, version string) (ReadOnlyStream, error)
+	// DeleteObjectVersion permanently deletes a version of an object
+	DeleteObjectVersion(ctx context.Context, bucket string, path Path, version string) error
+	// ListObjectVersions lists prior versions of an object, using versions as cursor
+	ListObjectVersions(ctx context.Context, bucket string, path Path, options ListOptions) (ObjectList, error)
 }
 
 // CreateObject has optional parameters that can be set
@@ -53,6 +70,13 @@ type CreateObject struct {
 	EncryptionScheme
 }
 
+// UpdateObject has the information which replaces the metadata of an object
+type UpdateObject struct {
+	Metadata    map[string]string
+	ContentType string
+	Expires     time.Time
+}
+
 // Object converts the CreateObject to an object with unitialized values
 func (create CreateObject) Object(bucket Bucket, path Path) Object {
 	return Object{
@@ -64,6 +88,7 @@ func (create CreateObject) Object(bucket Bucket, path Path) Object {
 		Stream: Stream{
 			Size:             -1,  // unknown
 			Checksum:         nil, // unknown
+			SHA256:           nil, // unknown
 			SegmentCount:     -1,  // unknown
 			FixedSegmentSize: -1,  // unknown
 
@@ -212,9 +237,12 @@ type MutableObject interface {
 
 // MutableStream is an interface for manipulating stream information
 type MutableStream interface {
-	// TODO: methods for finding partially uploaded segments
-
+	// Info gets the current information about the stream. For a continued
+	// stream Size is the amount of data in the already committed segments.
 	Info() Object
+	// Continued returns true, when the stream continues a partially uploaded
+	// stream. The uploaded data must then start at Info().Size.
+	Continued() bool
 	// AddSegments adds segments to the stream.
 	AddSegments(ctx context.Context, segments ...Segment) error
 	// UpdateSegments updates information about segments.
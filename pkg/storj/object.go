@@ -16,11 +16,19 @@ var (
 	// ErrNoPath is an error class for using empty path
 	ErrNoPath = errs.Class("no path specified")
 
+	// ErrBucketNameReserved is an error class for creating a bucket with a
+	// name reserved for the multipart uploads
+	ErrBucketNameReserved = errs.Class("bucket name is reserved")
+
 	// ErrBucketNotFound is an error class for non-existing bucket
 	ErrBucketNotFound = errs.Class("bucket not found")
 
 	// ErrObjectNotFound is an error class for non-existing object
 	ErrObjectNotFound = errs.Class("object not found")
+
+	// ErrExpiration is an error class for extending or clearing the
+	// expiration of a stored object
+	ErrExpiration = errs.Class("expiration can't be extended")
 )
 
 // Bucket contains information about a specific bucket
@@ -28,6 +36,13 @@ type Bucket struct {
 	Name       string
 	Created    time.Time
 	PathCipher Cipher
+	// Versioning keeps overwritten and deleted objects as prior versions
+	Versioning bool
+
+	// RedundancyScheme and EncryptionScheme are the defaults for the objects
+	// uploaded to the bucket, the zero schemes leave them to the uplink
+	RedundancyScheme RedundancyScheme
+	EncryptionScheme EncryptionScheme
 }
 
 // Object contains information about a specific object
@@ -37,6 +52,11 @@ type Object struct {
 	Path     Path
 	IsPrefix bool
 
+	// VersionID identifies the version of the object
+	VersionID string
+	// Archived is set for prior versions of an object in a versioned bucket
+	Archived bool
+
 	Metadata map[string]string
 
 	ContentType string
@@ -51,8 +71,10 @@ type Object struct {
 type Stream struct {
 	// Size is the total size of the stream in bytes
 	Size int64
-	// Checksum is the checksum of the segment checksums
+	// Checksum is the MD5 checksum of the content, nil if unknown
 	Checksum []byte
+	// SHA256 is the SHA-256 checksum of the content, nil if unknown
+	SHA256 []byte
 
 	// SegmentCount is the number of segments
 	SegmentCount int64
@@ -19,3 +19,33 @@ func SplitPath(path Path) []string {
 func JoinPaths(paths ...Path) Path {
 	return strings.Join(paths, "/")
 }
+
+// The state of the multipart uploads of a bucket is kept in pseudo-buckets
+// named after the bucket with these prefixes. The prefixes aren't valid in
+// bucket names and buckets can't be created with them, hence the
+// pseudo-buckets don't collide with any bucket.
+const (
+	// MultipartUploadsPrefix is the prefix of the pseudo-bucket keeping the
+	// info about the multipart uploads of a bucket
+	MultipartUploadsPrefix = ".uploads."
+	// MultipartPartsPrefix is the prefix of the pseudo-bucket keeping the
+	// uploaded parts of the multipart uploads of a bucket
+	MultipartPartsPrefix = ".parts."
+)
+
+// IsMultipartBucket returns whether name is the name of a pseudo-bucket, which
+// is reserved for the multipart uploads
+func IsMultipartBucket(name string) bool {
+	return MultipartBucket(name) != name
+}
+
+// MultipartBucket returns the bucket whose multipart uploads are kept in the
+// pseudo-bucket name, or name itself if it's not a pseudo-bucket
+func MultipartBucket(name string) string {
+	for _, prefix := range []string{MultipartUploadsPrefix, MultipartPartsPrefix} {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
@@ -52,3 +52,19 @@ func TestJoinPaths(t *testing.T) {
 		assert.Equal(t, tt.path, JoinPaths(tt.comps...), errTag)
 	}
 }
+
+func TestMultipartBucket(t *testing.T) {
+	for i, tt := range []struct {
+		name   string
+		bucket string
+	}{
+		{"", ""},
+		{"bucket", "bucket"},
+		{MultipartUploadsPrefix + "bucket", "bucket"},
+		{MultipartPartsPrefix + "bucket", "bucket"},
+		{"bucket" + MultipartPartsPrefix, "bucket" + MultipartPartsPrefix},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+		assert.Equal(t, tt.bucket, MultipartBucket(tt.name), errTag)
+	}
+}
@@ -5,6 +5,8 @@ package stream
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"io"
 
 	"github.com/gogo/protobuf/proto"
@@ -14,6 +16,7 @@ import (
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 // Upload implements Writer and Closer for writing to stream.
@@ -49,7 +52,27 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
 
-		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		path := storj.JoinPaths(obj.Bucket.Name, obj.Path)
+		rs, es := obj.RedundancyScheme, obj.EncryptionScheme
+		if stream.Continued() {
+			// the remaining segments are encrypted like the committed ones
+			rs, es = obj.Bucket.RedundancyScheme, storj.EncryptionScheme{}
+		}
+
+		store, err := streams.WithSchemes(rs, es)
+		if err != nil {
+			return utils.CombineErrors(err, reader.CloseWithError(err))
+		}
+
+		switch {
+		case stream.Continued():
+			// the written data starts after the already committed segments
+			_, err = store.Continue(ctx, path, obj.Bucket.PathCipher, reader)
+		case obj.Bucket.Versioning:
+			err = putVersioned(ctx, store, obj, reader, metadata)
+		default:
+			_, err = store.Put(ctx, path, obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		}
 		if err != nil {
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
@@ -60,6 +83,36 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 	return &upload
 }
 
+// putVersioned uploads an object to a versioned bucket. The object is staged
+// in the parts pseudo-bucket of the bucket first, and the current object is
+// archived as a prior version only once the new one is uploaded completely.
+// The staged segments are then moved to the object without copying their data.
+func putVersioned(ctx context.Context, store streams.Store, obj storj.Object, data io.Reader, metadata []byte) (err error) {
+	var id [16]byte
+	if _, err = rand.Read(id[:]); err != nil {
+		return Error.Wrap(err)
+	}
+
+	path := storj.JoinPaths(obj.Bucket.Name, obj.Path)
+	staged := storj.JoinPaths(storj.MultipartPartsPrefix+obj.Bucket.Name, obj.Path, "staged-"+hex.EncodeToString(id[:]))
+
+	_, err = store.Put(ctx, staged, obj.Bucket.PathCipher, data, nil, obj.Expires)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = utils.CombineErrors(err, store.Delete(ctx, staged, obj.Bucket.PathCipher))
+	}()
+
+	_, err = store.Archive(ctx, path, obj.Bucket.PathCipher)
+	if err != nil && !storage.ErrKeyNotFound.Has(err) {
+		return err
+	}
+
+	_, err = store.Concat(ctx, path, obj.Bucket.PathCipher, []storj.Path{staged}, obj.Bucket.PathCipher, metadata)
+	return err
+}
+
 // Write writes len(data) bytes from data to the underlying data stream.
 //
 // See io.Writer for more details.
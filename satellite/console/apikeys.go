@@ -23,6 +23,8 @@ type APIKeys interface {
 	Get(ctx context.Context, id uuid.UUID) (*APIKeyInfo, error)
 	//GetByKey retrieves APIKeyInfo for given key
 	GetByKey(ctx context.Context, key APIKey) (*APIKeyInfo, error)
+	// GetSecret retrieves the root secret of the macaroon of the api key with given ID and the project of the key
+	GetSecret(ctx context.Context, id uuid.UUID) (secret []byte, projectID uuid.UUID, err error)
 	// Create creates and stores new APIKeyInfo
 	Create(ctx context.Context, key APIKey, info APIKeyInfo) (*APIKeyInfo, error)
 	// Update updates APIKeyInfo in store
@@ -43,7 +45,8 @@ type APIKeyInfo struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
-// APIKey is an api key type
+// APIKey is an api key type, it is the root secret of the macaroon
+// handed out to the users, whose head is the id of the APIKeyInfo
 type APIKey [24]byte
 
 // String implements Stringer
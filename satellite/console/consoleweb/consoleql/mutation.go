@@ -30,6 +30,8 @@ const (
 	DeleteProjectMutation = "deleteProject"
 	// UpdateProjectDescriptionMutation is a mutation name for project updating
 	UpdateProjectDescriptionMutation = "updateProjectDescription"
+	// UpdateProjectLimitsMutation is a mutation name for project limits updating
+	UpdateProjectLimitsMutation = "updateProjectLimits"
 
 	// AddProjectMembersMutation is a mutation name for adding new project members
 	AddProjectMembersMutation = "addProjectMembers"
@@ -231,6 +233,33 @@ func rootMutation(service *console.Service, types Types) *graphql.Object {
 					return service.UpdateProject(p.Context, *projectID, description)
 				},
 			},
+			// updates project usage limits
+			UpdateProjectLimitsMutation: &graphql.Field{
+				Type: types.Project(),
+				Args: graphql.FieldConfigArgument{
+					FieldID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					FieldStorageLimit: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+					FieldEgressLimit: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					storageLimit := p.Args[FieldStorageLimit].(int)
+					egressLimit := p.Args[FieldEgressLimit].(int)
+
+					inputID := p.Args[FieldID].(string)
+					projectID, err := uuid.Parse(inputID)
+					if err != nil {
+						return nil, err
+					}
+
+					return service.UpdateProjectLimits(p.Context, *projectID, int64(storageLimit), int64(egressLimit))
+				},
+			},
 			// add user as member of given project
 			AddProjectMembersMutation: &graphql.Field{
 				Type: types.Project(),
@@ -322,8 +351,13 @@ func rootMutation(service *console.Service, types Types) *graphql.Object {
 						return nil, err
 					}
 
+					serialized, err := key.Serialize()
+					if err != nil {
+						return nil, err
+					}
+
 					return createAPIKey{
-						Key:     key,
+						Key:     serialized,
 						KeyInfo: info,
 					}, nil
 				},
@@ -12,6 +12,7 @@ import (
 	"github.com/graphql-go/graphql"
 	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/testcontext"
@@ -34,6 +35,8 @@ func TestGrapqhlMutation(t *testing.T) {
 			log,
 			&consoleauth.Hmac{Secret: []byte("my-suppa-secret-key")},
 			db.Console(),
+			// the root user is a satellite administrator
+			[]string{"test@email.com"},
 		)
 
 		if err != nil {
@@ -363,6 +366,24 @@ func TestGrapqhlMutation(t *testing.T) {
 			assert.Equal(t, "", proj[consoleql.FieldDescription])
 		})
 
+		t.Run("Update project limits mutation", func(t *testing.T) {
+			query := fmt.Sprintf(
+				"mutation {updateProjectLimits(id:\"%s\",storageLimit:%d,egressLimit:%d){id,storageLimit,egressLimit}}",
+				project.ID.String(),
+				1000,
+				2000,
+			)
+
+			result := testQuery(t, query)
+
+			data := result.(map[string]interface{})
+			proj := data[consoleql.UpdateProjectLimitsMutation].(map[string]interface{})
+
+			assert.Equal(t, project.ID.String(), proj[consoleql.FieldID])
+			assert.Equal(t, 1000, proj[consoleql.FieldStorageLimit])
+			assert.Equal(t, 2000, proj[consoleql.FieldEgressLimit])
+		})
+
 		user1, err := service.CreateUser(authCtx, console.CreateUser{
 			UserInfo: console.UserInfo{
 				FirstName: "User1",
@@ -432,6 +453,38 @@ func TestGrapqhlMutation(t *testing.T) {
 			assert.Equal(t, 3, len(proj[consoleql.FieldMembers].([]interface{})))
 		})
 
+		t.Run("Update project limits by member", func(t *testing.T) {
+			token, err := service.Token(ctx, user1.Email, "123a123")
+			require.NoError(t, err)
+
+			memberAuth, err := service.Authorize(auth.WithAPIKey(ctx, []byte(token)))
+			require.NoError(t, err)
+
+			_, err = service.UpdateProjectLimits(console.WithAuth(ctx, memberAuth), project.ID, 1, 1)
+			assert.True(t, console.ErrUnauthorized.Has(err), fmt.Sprint(err))
+
+			query := fmt.Sprintf(
+				"mutation {updateProjectLimits(id:\"%s\",storageLimit:%d,egressLimit:%d){id}}",
+				project.ID.String(),
+				1,
+				1,
+			)
+
+			result := graphql.Do(graphql.Params{
+				Schema:        schema,
+				Context:       console.WithAuth(ctx, memberAuth),
+				RequestString: query,
+				RootObject:    make(map[string]interface{}),
+			})
+			assert.True(t, result.HasErrors())
+
+			// the limits are kept
+			limited, err := service.GetProject(authCtx, project.ID)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1000, limited.StorageLimit)
+			assert.EqualValues(t, 2000, limited.EgressLimit)
+		})
+
 		t.Run("Delete project members mutation", func(t *testing.T) {
 			query := fmt.Sprintf(
 				"mutation {deleteProjectMembers(projectID:\"%s\",email:[\"%s\",\"%s\"]){id,name,members(limit:50,offset:0){user{id}}}}",
@@ -18,6 +18,10 @@ const (
 	FieldName = "name"
 	// FieldDescription is a field name for description
 	FieldDescription = "description"
+	// FieldStorageLimit is a field name for the storage limit
+	FieldStorageLimit = "storageLimit"
+	// FieldEgressLimit is a field name for the egress limit
+	FieldEgressLimit = "egressLimit"
 	// FieldMembers is field name for members
 	FieldMembers = "members"
 	// FieldAPIKeys is a field name for api keys
@@ -47,6 +51,12 @@ func graphqlProject(service *console.Service, types Types) *graphql.Object {
 			FieldDescription: &graphql.Field{
 				Type: graphql.String,
 			},
+			FieldStorageLimit: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldEgressLimit: &graphql.Field{
+				Type: graphql.Int,
+			},
 			FieldCreatedAt: &graphql.Field{
 				Type: graphql.DateTime,
 			},
@@ -4,6 +4,8 @@
 package consoleql
 
 import (
+	"time"
+
 	"github.com/graphql-go/graphql"
 	"github.com/skyrings/skyring-common/tools/uuid"
 
@@ -19,6 +21,8 @@ const (
 	ProjectQuery = "project"
 	// MyProjectsQuery is a query name for projects related to account
 	MyProjectsQuery = "myProjects"
+	// ProjectUsageQuery is a query name for the usage of a project
+	ProjectUsageQuery = "projectUsage"
 	// TokenQuery is a query name for token
 	TokenQuery = "token"
 )
@@ -68,6 +72,32 @@ func rootQuery(service *console.Service, types Types) *graphql.Object {
 					return service.GetUsersProjects(p.Context)
 				},
 			},
+			ProjectUsageQuery: &graphql.Field{
+				Type: types.ProjectUsage(),
+				Args: graphql.FieldConfigArgument{
+					FieldProjectID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					FieldSince: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.DateTime),
+					},
+					FieldBefore: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.DateTime),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					inputID, _ := p.Args[FieldProjectID].(string)
+					since, _ := p.Args[FieldSince].(time.Time)
+					before, _ := p.Args[FieldBefore].(time.Time)
+
+					id, err := uuid.Parse(inputID)
+					if err != nil {
+						return nil, err
+					}
+
+					return service.GetProjectUsage(p.Context, *id, since, before)
+				},
+			},
 			TokenQuery: &graphql.Field{
 				Type: types.Token(),
 				Args: graphql.FieldConfigArgument{
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
@@ -32,6 +33,7 @@ func TestGraphqlQuery(t *testing.T) {
 			log,
 			&consoleauth.Hmac{Secret: []byte("my-suppa-secret-key")},
 			db.Console(),
+			nil,
 		)
 
 		if err != nil {
@@ -400,6 +402,43 @@ func TestGraphqlQuery(t *testing.T) {
 			assert.True(t, foundProj2)
 		})
 
+		t.Run("Project usage query", func(t *testing.T) {
+			intervalEnd := time.Now().UTC().Truncate(time.Second)
+			bucket := accounting.BucketID{ProjectID: createdProject.ID, BucketName: "bucket"}
+
+			err := db.Accounting().SaveBucketRaw(ctx, intervalEnd, accounting.AtRest, map[accounting.BucketID]float64{bucket: 1024})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = db.Accounting().SaveBucketRaw(ctx, intervalEnd, accounting.BandwidthGet, map[accounting.BucketID]float64{bucket: 512})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			query := fmt.Sprintf(
+				"query {projectUsage(projectID: \"%s\", since: \"%s\", before: \"%s\"){storage,egress,buckets{bucketName,storage,egress}}}",
+				createdProject.ID.String(),
+				intervalEnd.Add(-time.Hour).Format(time.RFC3339),
+				intervalEnd.Add(time.Hour).Format(time.RFC3339),
+			)
+
+			result := testQuery(t, query)
+
+			data := result.(map[string]interface{})
+			usage := data[consoleql.ProjectUsageQuery].(map[string]interface{})
+
+			assert.Equal(t, float64(1024), usage[consoleql.FieldStorage])
+			assert.Equal(t, 512, usage[consoleql.FieldEgress])
+
+			buckets := usage[consoleql.FieldBuckets].([]interface{})
+			if assert.Equal(t, 1, len(buckets)) {
+				bucketUsage := buckets[0].(map[string]interface{})
+				assert.Equal(t, "bucket", bucketUsage[consoleql.FieldBucketName])
+				assert.Equal(t, float64(1024), bucketUsage[consoleql.FieldStorage])
+				assert.Equal(t, 512, bucketUsage[consoleql.FieldEgress])
+			}
+		})
+
 		t.Run("Token query", func(t *testing.T) {
 			query := fmt.Sprintf(
 				"query {token(email: \"%s\", password: \"%s\"){token,user{id,email,firstName,lastName,createdAt}}}",
@@ -21,6 +21,8 @@ type Types interface {
 	ProjectMember() *graphql.Object
 	APIKeyInfo() *graphql.Object
 	CreateAPIKey() *graphql.Object
+	ProjectUsage() *graphql.Object
+	BucketUsage() *graphql.Object
 
 	UserInput() *graphql.InputObject
 	ProjectInput() *graphql.InputObject
@@ -38,6 +40,8 @@ type TypeCreator struct {
 	projectMember *graphql.Object
 	apiKeyInfo    *graphql.Object
 	createAPIKey  *graphql.Object
+	projectUsage  *graphql.Object
+	bucketUsage   *graphql.Object
 
 	userInput    *graphql.InputObject
 	projectInput *graphql.InputObject
@@ -82,6 +86,16 @@ func (c *TypeCreator) Create(service *console.Service) error {
 		return err
 	}
 
+	c.bucketUsage = graphqlBucketUsage()
+	if err := c.bucketUsage.Error(); err != nil {
+		return err
+	}
+
+	c.projectUsage = graphqlProjectUsage(c)
+	if err := c.projectUsage.Error(); err != nil {
+		return err
+	}
+
 	c.token = graphqlToken(service, c)
 	if err := c.user.Error(); err != nil {
 		return err
@@ -142,6 +156,16 @@ func (c *TypeCreator) ProjectMember() *graphql.Object {
 	return c.projectMember
 }
 
+// ProjectUsage returns instance of satellite.ProjectUsage *graphql.Object
+func (c *TypeCreator) ProjectUsage() *graphql.Object {
+	return c.projectUsage
+}
+
+// BucketUsage returns instance of satellite.BucketUsage *graphql.Object
+func (c *TypeCreator) BucketUsage() *graphql.Object {
+	return c.bucketUsage
+}
+
 // UserInput returns instance of UserInput *graphql.Object
 func (c *TypeCreator) UserInput() *graphql.InputObject {
 	return c.userInput
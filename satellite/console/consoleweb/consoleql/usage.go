@@ -0,0 +1,69 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	// ProjectUsageType is a graphql type name for project usage
+	ProjectUsageType = "projectUsage"
+	// BucketUsageType is a graphql type name for bucket usage
+	BucketUsageType = "bucketUsage"
+	// FieldSince is a field name for the start of a usage period
+	FieldSince = "since"
+	// FieldBefore is a field name for the end of a usage period
+	FieldBefore = "before"
+	// FieldStorage is a field name for the data at rest in byte-hours
+	FieldStorage = "storage"
+	// FieldEgress is a field name for the downloaded data in bytes
+	FieldEgress = "egress"
+	// FieldBuckets is a field name for buckets
+	FieldBuckets = "buckets"
+	// FieldBucketName is a field name for bucket name
+	FieldBucketName = "bucketName"
+)
+
+// graphqlProjectUsage creates *graphql.Object type representation of satellite.ProjectUsage
+func graphqlProjectUsage(types Types) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: ProjectUsageType,
+		Fields: graphql.Fields{
+			FieldSince: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldBefore: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldStorage: &graphql.Field{
+				Type: graphql.Float,
+			},
+			FieldEgress: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldBuckets: &graphql.Field{
+				Type: graphql.NewList(types.BucketUsage()),
+			},
+		},
+	})
+}
+
+// graphqlBucketUsage creates *graphql.Object type representation of satellite.BucketUsage
+func graphqlBucketUsage() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: BucketUsageType,
+		Fields: graphql.Fields{
+			FieldBucketName: &graphql.Field{
+				Type: graphql.String,
+			},
+			FieldStorage: &graphql.Field{
+				Type: graphql.Float,
+			},
+			FieldEgress: &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	})
+}
@@ -37,6 +37,7 @@ var Error = errs.Class("satellite console error")
 type Config struct {
 	Address   string `help:"server address of the graphql api gateway and frontend app" default:"127.0.0.1:8081"`
 	StaticDir string `help:"path to static resources" default:""`
+	Admins    string `help:"comma separated emails of the satellite administrators, who may change the limits of projects" default:""`
 }
 
 // Server represents console web server
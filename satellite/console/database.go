@@ -17,6 +17,8 @@ type DB interface {
 	APIKeys() APIKeys
 	// Buckets is a getter for Buckets repository
 	Buckets() Buckets
+	// UsageRollups is a getter for UsageRollups repository
+	UsageRollups() UsageRollups
 
 	// CreateTables is a method for creating all tables for satellitedb
 	CreateTables() error
@@ -24,6 +24,8 @@ type Projects interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	// Update is a method for updating project entity.
 	Update(ctx context.Context, project *Project) error
+	// GetLimits is a method for querying the storage and egress limits of project by id.
+	GetLimits(ctx context.Context, id uuid.UUID) (storage, egress int64, err error)
 }
 
 // Project is a database object that describes Project entity
@@ -33,6 +35,11 @@ type Project struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 
+	// StorageLimit and EgressLimit are the usage limits of the project in bytes,
+	// zero means unlimited
+	StorageLimit int64 `json:"storageLimit"`
+	EgressLimit  int64 `json:"egressLimit"`
+
 	CreatedAt time.Time `json:"createdAt"`
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/subtle"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/skyrings/skyring-common/tools/uuid"
@@ -16,6 +17,7 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/macaroon"
 	"storj.io/storj/satellite/console/consoleauth"
 )
 
@@ -35,10 +37,14 @@ type Service struct {
 
 	store DB
 	log   *zap.Logger
+
+	// admins are the normalized emails of the satellite administrators
+	admins map[string]bool
 }
 
-// NewService returns new instance of Service
-func NewService(log *zap.Logger, signer Signer, store DB) (*Service, error) {
+// NewService returns new instance of Service. The admins are the emails of
+// the satellite administrators, who may change the limits of projects.
+func NewService(log *zap.Logger, signer Signer, store DB, admins []string) (*Service, error) {
 	if signer == nil {
 		return nil, errs.New("signer can't be nil")
 	}
@@ -51,7 +57,12 @@ func NewService(log *zap.Logger, signer Signer, store DB) (*Service, error) {
 		return nil, errs.New("log can't be nil")
 	}
 
-	return &Service{Signer: signer, store: store, log: log}, nil
+	service := &Service{Signer: signer, store: store, log: log, admins: map[string]bool{}}
+	for _, email := range admins {
+		service.admins[normalizeEmail(strings.TrimSpace(email))] = true
+	}
+
+	return service, nil
 }
 
 // CreateUser gets password hash value and creates new inactive User
@@ -354,6 +365,60 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, descri
 	return project, nil
 }
 
+// UpdateProjectLimits is a method for updating the storage and egress limits
+// of a project, zero means unlimited. Only the satellite administrators may
+// change the limits.
+func (s *Service) UpdateProjectLimits(ctx context.Context, projectID uuid.UUID, storageLimit, egressLimit int64) (p *Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := GetAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.admins[normalizeEmail(auth.User.Email)] {
+		return nil, ErrUnauthorized.New("only satellite administrators may change the limits of projects")
+	}
+
+	if storageLimit < 0 || egressLimit < 0 {
+		return nil, errs.New("limits can't be negative")
+	}
+
+	project, err := s.store.Projects().Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	project.StorageLimit = storageLimit
+	project.EgressLimit = egressLimit
+
+	err = s.store.Projects().Update(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// GetProjectUsage retrieves the usage of a project and its buckets in the [since, before) period
+func (s *Service) GetProjectUsage(ctx context.Context, projectID uuid.UUID, since, before time.Time) (usage *ProjectUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := GetAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !since.Before(before) {
+		return nil, errs.New("since should be earlier than before")
+	}
+
+	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.UsageRollups().GetProjectUsage(ctx, projectID, since, before)
+}
+
 // AddProjectMembers adds users by email to given project
 func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, emails []string) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -483,8 +548,9 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, pa
 	return s.store.ProjectMembers().GetByProjectID(ctx, projectID, pagination)
 }
 
-// CreateAPIKey creates new api key
-func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (*APIKeyInfo, *APIKey, error) {
+// CreateAPIKey creates new api key, the returned macaroon is the only
+// place the users can get the key from
+func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (*APIKeyInfo, *macaroon.APIKey, error) {
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
@@ -507,7 +573,11 @@ func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name st
 		Name:      name,
 		ProjectID: projectID,
 	})
-	return info, key, err
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, macaroon.NewAPIKey(info.ID[:], key[:]), nil
 }
 
 // GetAPIKeyInfo retrieves api key by id
@@ -0,0 +1,40 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+)
+
+// UsageRollups is interface for working with the usage of projects and buckets as accounted by tally
+type UsageRollups interface {
+	// GetProjectUsage retrieves the usage of a project and its buckets in the [since, before) period
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID, since, before time.Time) (*ProjectUsage, error)
+}
+
+// ProjectUsage represents the usage of a project in a period
+type ProjectUsage struct {
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
+
+	// Storage is the data at rest in byte-hours
+	Storage float64 `json:"storage"`
+	// Egress is the downloaded data in bytes
+	Egress int64 `json:"egress"`
+
+	Buckets []BucketUsage `json:"buckets"`
+}
+
+// BucketUsage represents the usage of a bucket in a period
+type BucketUsage struct {
+	BucketName string `json:"bucketName"`
+
+	// Storage is the data at rest in byte-hours
+	Storage float64 `json:"storage"`
+	// Egress is the downloaded data in bytes
+	Egress int64 `json:"egress"`
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -27,14 +28,21 @@ import (
 	"storj.io/storj/pkg/datarepair/queue"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/gc"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/lifecycle"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/statdb"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/satellite/console"
@@ -64,6 +72,8 @@ type DB interface {
 	RepairQueue() queue.RepairQueue
 	// Irreparable returns database for failed repairs
 	Irreparable() irreparable.DB
+	// GracefulExit returns database for the progress of exiting nodes
+	GracefulExit() gracefulexit.DB
 	// Console returns database for satellite console
 	Console() console.DB
 }
@@ -86,6 +96,9 @@ type Config struct {
 	Repairer repairer.Config
 	Audit    audit.Config
 
+	Lifecycle lifecycle.Config
+	GC        gc.Config
+
 	Tally  tally.Config
 	Rollup rollup.Config
 
@@ -140,6 +153,10 @@ type Peer struct {
 		Endpoint *bwagreement.Server
 	}
 
+	GracefulExit struct {
+		Endpoint *gracefulexit.Endpoint
+	}
+
 	Repair struct {
 		Checker  checker.Checker // TODO: convert to actual struct
 		Repairer *repairer.Service
@@ -148,6 +165,14 @@ type Peer struct {
 		Service *audit.Service
 	}
 
+	Lifecycle struct {
+		Service *lifecycle.Service
+	}
+
+	GC struct {
+		Service *gc.Service
+	}
+
 	Accounting struct {
 		Tally  *tally.Tally
 		Rollup *rollup.Rollup
@@ -285,8 +310,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 
 		peer.Metainfo.Database = storelogger.New(peer.Log.Named("pdb"), db)
 		peer.Metainfo.Service = pointerdb.NewService(peer.Log.Named("pointerdb"), peer.Metainfo.Database)
+		// without api keys the paths aren't namespaced by project
+		peer.Metainfo.Service.SingleProject = !config.PointerDB.Auth
 		peer.Metainfo.Allocation = pointerdb.NewAllocationSigner(peer.Identity, config.PointerDB.BwExpiration)
-		peer.Metainfo.Endpoint = pointerdb.NewServer(peer.Log.Named("pointerdb:endpoint"), peer.Metainfo.Service, peer.Metainfo.Allocation, peer.Overlay.Service, config.PointerDB, peer.Identity)
+		peer.Metainfo.Endpoint = pointerdb.NewServer(peer.Log.Named("pointerdb:endpoint"), peer.Metainfo.Service, peer.Metainfo.Allocation, peer.Overlay.Service, config.PointerDB, peer.Identity, peer.DB.Console().APIKeys(),
+			pointerdb.NewLimiter(peer.DB.Console().Projects(), peer.DB.Accounting()), peer.DB.RepairQueue())
 		pb.RegisterPointerDBServer(peer.Public.Server.GRPC(), peer.Metainfo.Endpoint)
 	}
 
@@ -296,6 +324,13 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 		pb.RegisterBandwidthServer(peer.Public.Server.GRPC(), peer.Agreements.Endpoint)
 	}
 
+	{ // setup graceful exit
+		peer.GracefulExit.Endpoint = gracefulexit.NewEndpoint(peer.Log.Named("gracefulexit"), peer.DB.GracefulExit(),
+			peer.Metainfo.Service, peer.Metainfo.Allocation, peer.Overlay.Endpoint,
+			pointerdb.NewPieceVerifier(peer.Overlay.Service, peer.Metainfo.Allocation, peer.Identity), peer.Identity)
+		pb.RegisterGracefulExitServer(peer.Public.Server.GRPC(), peer.GracefulExit.Endpoint)
+	}
+
 	{ // setup datarepair
 		// TODO: simplify argument list somehow
 		peer.Repair.Checker = checker.NewChecker(
@@ -332,6 +367,33 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 		}
 	}
 
+	{ // setup lifecycle
+		// the segments are deleted through the endpoints of the satellite
+		// itself, like the segments of the repairer
+		// TODO: close the clients, currently this leaks connections
+		overlayClient, err := overlay.NewClient(peer.Identity, peer.Addr())
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		pointerdbClient, err := pdbclient.NewClient(peer.Identity, peer.Addr(), "")
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		segmentStore := segments.NewSegmentStore(overlayClient, ecclient.NewClient(peer.Identity, 0), pointerdbClient, eestream.RedundancyStrategy{}, 0)
+
+		peer.Lifecycle.Service = lifecycle.NewService(peer.Log.Named("lifecycle"), peer.Metainfo.Service, segmentStore, config.Lifecycle.Interval)
+	}
+
+	{ // setup garbage collection
+		// TODO: use common transport Client and close to avoid leak
+		transportClient := transport.NewClient(peer.Identity)
+
+		peer.GC.Service = gc.NewService(peer.Log.Named("gc"), config.GC, peer.Identity.ID,
+			peer.Metainfo.Service, peer.Overlay.Service, transportClient)
+	}
+
 	{ // setup accounting
 		peer.Accounting.Tally = tally.New(peer.Log.Named("tally"), peer.DB.Accounting(), peer.DB.BandwidthAgreement(), peer.Metainfo.Service, peer.Overlay.Endpoint, 0, config.Tally.Interval)
 		peer.Accounting.Rollup = rollup.New(peer.Log.Named("rollup"), peer.DB.Accounting(), config.Rollup.Interval)
@@ -345,10 +407,15 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		var admins []string
+		if config.Admins != "" {
+			admins = strings.Split(config.Admins, ",")
+		}
+
 		peer.Console.Service, err = console.NewService(peer.Log.Named("console:service"),
 			// TODO: use satellite key
 			&consoleauth.Hmac{Secret: []byte("my-suppa-secret-key")},
-			peer.DB.Console())
+			peer.DB.Console(), admins)
 
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -400,6 +467,12 @@ func (peer *Peer) Run(ctx context.Context) error {
 	group.Go(func() error {
 		return ignoreCancel(peer.Audit.Service.Run(ctx))
 	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Lifecycle.Service.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.GC.Service.Run(ctx))
+	})
 	group.Go(func() error {
 		// TODO: move the message into Server instead
 		peer.Log.Sugar().Infof("Node %s started on %s", peer.Identity.ID, peer.Public.Server.Addr().String())
@@ -437,6 +510,12 @@ func (peer *Peer) Close() error {
 	}
 
 	// close services in reverse initialization order
+	if peer.GC.Service != nil {
+		errlist.Add(peer.GC.Service.Close())
+	}
+	if peer.Lifecycle.Service != nil {
+		errlist.Add(peer.Lifecycle.Service.Close())
+	}
 	if peer.Repair.Repairer != nil {
 		errlist.Add(peer.Repair.Repairer.Close())
 	}
@@ -444,6 +523,10 @@ func (peer *Peer) Close() error {
 		errlist.Add(peer.Repair.Checker.Close())
 	}
 
+	if peer.GracefulExit.Endpoint != nil {
+		errlist.Add(peer.GracefulExit.Endpoint.Close())
+	}
+
 	if peer.Agreements.Endpoint != nil {
 		errlist.Add(peer.Agreements.Endpoint.Close())
 	}
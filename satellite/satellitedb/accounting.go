@@ -5,8 +5,12 @@ package satellitedb
 
 import (
 	"context"
+	"database/sql"
+	"sort"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
+
 	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
@@ -223,3 +227,160 @@ func (db *accountingDB) QueryPaymentInfo(ctx context.Context, start time.Time, e
 	}
 	return rows, nil
 }
+
+// GetProjectUsage retrieves the last tallied usage of a project, which is zero for projects that weren't tallied yet
+func (db *accountingDB) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*accounting.ProjectUsage, error) {
+	usage, err := db.db.Get_ProjectUsage_By_ProjectId(ctx, dbx.ProjectUsage_ProjectId(projectID[:]))
+	if err == sql.ErrNoRows {
+		return &accounting.ProjectUsage{ProjectID: projectID}, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return projectUsageFromDBX(usage)
+}
+
+// GetProjectUsages retrieves the last tallied usage of all projects
+func (db *accountingDB) GetProjectUsages(ctx context.Context) ([]*accounting.ProjectUsage, error) {
+	usages, err := db.db.All_ProjectUsage(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	out := make([]*accounting.ProjectUsage, len(usages))
+	for i, usage := range usages {
+		out[i], err = projectUsageFromDBX(usage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// SaveProjectUsage records the tallied usage of a project
+func (db *accountingDB) SaveProjectUsage(ctx context.Context, usage *accounting.ProjectUsage) error {
+	projectID := dbx.ProjectUsage_ProjectId(usage.ProjectID[:])
+	atRest := dbx.ProjectUsage_AtRestTotal(usage.AtRestTotal)
+	egress := dbx.ProjectUsage_EgressTotal(usage.EgressTotal)
+	egressStart := dbx.ProjectUsage_EgressStart(usage.EgressStart)
+	talliedAt := dbx.ProjectUsage_TalliedAt(usage.TalliedAt)
+
+	_, err := db.db.Get_ProjectUsage_By_ProjectId(ctx, projectID)
+	if err == sql.ErrNoRows {
+		_, err = db.db.Create_ProjectUsage(ctx, projectID, atRest, egress, egressStart, talliedAt)
+		return Error.Wrap(err)
+	}
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, err = db.db.Update_ProjectUsage_By_ProjectId(ctx, projectID, dbx.ProjectUsage_Update_Fields{
+		AtRestTotal: atRest,
+		EgressTotal: egress,
+		EgressStart: egressStart,
+		TalliedAt:   talliedAt,
+	})
+	return Error.Wrap(err)
+}
+
+// projectUsageFromDBX converts dbx.ProjectUsage to accounting.ProjectUsage
+func projectUsageFromDBX(usage *dbx.ProjectUsage) (*accounting.ProjectUsage, error) {
+	projectID, err := bytesToUUID(usage.ProjectId)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &accounting.ProjectUsage{
+		ProjectID:   projectID,
+		AtRestTotal: usage.AtRestTotal,
+		EgressTotal: usage.EgressTotal,
+		EgressStart: usage.EgressStart,
+		TalliedAt:   usage.TalliedAt,
+	}, nil
+}
+
+// SaveBucketRaw records raw tallies of the data of dataType of the buckets of the projects
+func (db *accountingDB) SaveBucketRaw(ctx context.Context, intervalEnd time.Time, dataType int, bucketData map[accounting.BucketID]float64) (err error) {
+	if len(bucketData) == 0 {
+		return nil
+	}
+	tx, err := db.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+	for bucket, total := range bucketData {
+		_, err = tx.Create_BucketUsage(ctx,
+			dbx.BucketUsage_ProjectId(bucket.ProjectID[:]),
+			dbx.BucketUsage_BucketName(bucket.BucketName),
+			dbx.BucketUsage_IntervalEndTime(intervalEnd),
+			dbx.BucketUsage_DataTotal(total),
+			dbx.BucketUsage_DataType(dataType))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// QueryBucketUsage sums the raw tallies of the buckets of all projects in the [start, end) period
+func (db *accountingDB) QueryBucketUsage(ctx context.Context, start time.Time, end time.Time) ([]*accounting.BucketUsage, error) {
+	raws, err := db.db.All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx,
+		dbx.BucketUsage_IntervalEndTime(start),
+		dbx.BucketUsage_IntervalEndTime(end))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return sumBucketUsage(raws)
+}
+
+// QueryProjectUsage sums the raw tallies of the buckets of a project in the [start, end) period
+func (db *accountingDB) QueryProjectUsage(ctx context.Context, projectID uuid.UUID, start time.Time, end time.Time) ([]*accounting.BucketUsage, error) {
+	raws, err := db.db.All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx,
+		dbx.BucketUsage_ProjectId(projectID[:]),
+		dbx.BucketUsage_IntervalEndTime(start),
+		dbx.BucketUsage_IntervalEndTime(end))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return sumBucketUsage(raws)
+}
+
+// sumBucketUsage sums raw bucket tallies by bucket, sorted by project and bucket name
+func sumBucketUsage(raws []*dbx.BucketUsage) ([]*accounting.BucketUsage, error) {
+	sums := make(map[accounting.BucketID]*accounting.BucketUsage)
+	var usages []*accounting.BucketUsage
+	for _, raw := range raws {
+		projectID, err := bytesToUUID(raw.ProjectId)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		bucket := accounting.BucketID{ProjectID: projectID, BucketName: raw.BucketName}
+		usage, ok := sums[bucket]
+		if !ok {
+			usage = &accounting.BucketUsage{ProjectID: projectID, BucketName: raw.BucketName}
+			sums[bucket] = usage
+			usages = append(usages, usage)
+		}
+
+		switch raw.DataType {
+		case accounting.AtRest:
+			usage.AtRestTotal += raw.DataTotal
+		case accounting.BandwidthGet:
+			usage.GetTotal += int64(raw.DataTotal)
+		}
+	}
+
+	sort.Slice(usages, func(i, k int) bool {
+		if usages[i].ProjectID != usages[k].ProjectID {
+			return usages[i].ProjectID.String() < usages[k].ProjectID.String()
+		}
+		return usages[i].BucketName < usages[k].BucketName
+	})
+	return usages, nil
+}
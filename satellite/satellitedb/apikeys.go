@@ -65,6 +65,21 @@ func (keys *apikeys) GetByKey(ctx context.Context, key console.APIKey) (*console
 	return fromDBXAPIKey(dbKey)
 }
 
+// GetSecret implements satellite.APIKeys
+func (keys *apikeys) GetSecret(ctx context.Context, id uuid.UUID) (secret []byte, projectID uuid.UUID, err error) {
+	dbKey, err := keys.db.Get_ApiKey_By_Id(ctx, dbx.ApiKey_Id(id[:]))
+	if err != nil {
+		return nil, uuid.UUID{}, err
+	}
+
+	project, err := bytesToUUID(dbKey.ProjectId)
+	if err != nil {
+		return nil, uuid.UUID{}, err
+	}
+
+	return dbKey.Key, project, nil
+}
+
 // Create implements satellite.APIKeys
 func (keys *apikeys) Create(ctx context.Context, key console.APIKey, info console.APIKeyInfo) (*console.APIKeyInfo, error) {
 	id, err := uuid.New()
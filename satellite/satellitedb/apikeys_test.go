@@ -75,6 +75,25 @@ func TestApiKeysRepository(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("GetSecret success", func(t *testing.T) {
+		key, err := console.CreateAPIKey()
+		assert.NoError(t, err)
+
+		info, err := apikeys.Create(ctx, *key, console.APIKeyInfo{
+			Name:      "secret key",
+			ProjectID: project.ID,
+		})
+		assert.NoError(t, err)
+
+		secret, projectID, err := apikeys.GetSecret(ctx, info.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, key[:], secret)
+		assert.Equal(t, project.ID, projectID)
+
+		err = apikeys.Delete(ctx, info.ID)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Update success", func(t *testing.T) {
 		keys, err := apikeys.GetByProjectID(ctx, project.ID)
 		assert.NotNil(t, keys)
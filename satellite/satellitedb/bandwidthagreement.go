@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
 )
 
@@ -33,9 +35,24 @@ func (b *bandwidthagreement) CreateAgreement(ctx context.Context, rba *pb.Renter
 		dbx.Bwagreement_Total(rba.Total),
 		dbx.Bwagreement_ExpiresAt(expiration),
 	)
+	if dbxErr, ok := errs.Unwrap(err).(*dbx.Error); ok && dbxErr.Code == dbx.ErrorCode_ConstraintViolation {
+		return bwagreement.ErrDuplicate.Wrap(err)
+	}
 	return err
 }
 
+func (b *bandwidthagreement) GetAgreement(ctx context.Context, serialNumber string, storageNodeID storj.NodeID) (*bwagreement.Agreement, error) {
+	entry, err := b.db.Get_Bwagreement_By_Serialnum(ctx, dbx.Bwagreement_Serialnum(serialNumber+storageNodeID.String()))
+	if err != nil {
+		return nil, err
+	}
+	rba := pb.RenterBandwidthAllocation{}
+	if err := proto.Unmarshal(entry.Data, &rba); err != nil {
+		return nil, err
+	}
+	return &bwagreement.Agreement{Agreement: rba, CreatedAt: entry.CreatedAt}, nil
+}
+
 func (b *bandwidthagreement) GetAgreements(ctx context.Context) ([]bwagreement.Agreement, error) {
 	rows, err := b.db.All_Bwagreement(ctx)
 	if err != nil {
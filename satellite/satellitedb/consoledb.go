@@ -62,6 +62,11 @@ func (db *ConsoleDB) Buckets() console.Buckets {
 	return &buckets{db.methods}
 }
 
+// UsageRollups is a getter for UsageRollups repository
+func (db *ConsoleDB) UsageRollups() console.UsageRollups {
+	return &usagerollups{db.methods}
+}
+
 // CreateTables is a method for creating all tables for satellitedb
 func (db *ConsoleDB) CreateTables() error {
 	if db.db == nil {
@@ -11,6 +11,7 @@ import (
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/utils"
@@ -91,6 +92,11 @@ func (db *DB) Irreparable() irreparable.DB {
 	return &irreparableDB{db: db.db}
 }
 
+// GracefulExit returns database for the progress of exiting nodes
+func (db *DB) GracefulExit() gracefulexit.DB {
+	return &gracefulExitDB{db: db.db}
+}
+
 // Console returns database for storing users, projects and api keys
 func (db *DB) Console() console.DB {
 	return &ConsoleDB{
@@ -299,6 +299,16 @@ CREATE TABLE accounting_timestamps (
 	value timestamp with time zone NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE bucket_usages (
+	id bigserial NOT NULL,
+	project_id bytea NOT NULL,
+	bucket_name text NOT NULL,
+	interval_end_time timestamp with time zone NOT NULL,
+	data_total double precision NOT NULL,
+	data_type integer NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bwagreements (
 	serialnum text NOT NULL,
 	data bytea NOT NULL,
@@ -309,6 +319,27 @@ CREATE TABLE bwagreements (
 	expires_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( serialnum )
 );
+CREATE TABLE graceful_exit_transfers (
+	node_id bytea NOT NULL,
+	path bytea NOT NULL,
+	piece_num integer NOT NULL,
+	receiver_id bytea,
+	serialnum text,
+	failed boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, path, piece_num )
+);
+CREATE TABLE graceful_exits (
+	node_id bytea NOT NULL,
+	pieces_transferred bigint NOT NULL,
+	pieces_failed bigint NOT NULL,
+	bytes_transferred bigint NOT NULL,
+	started_at timestamp with time zone NOT NULL,
+	finished_at timestamp with time zone,
+	scan_cursor bytea,
+	scanned_at timestamp with time zone,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE injuredsegments (
 	id bigserial NOT NULL,
 	info bytea NOT NULL,
@@ -353,10 +384,20 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
+CREATE TABLE project_usages (
+	project_id bytea NOT NULL,
+	at_rest_total bigint NOT NULL,
+	egress_total bigint NOT NULL,
+	egress_start timestamp with time zone NOT NULL,
+	tallied_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE projects (
 	id bytea NOT NULL,
 	name text NOT NULL,
 	description text NOT NULL,
+	storage_limit bigint NOT NULL,
+	egress_limit bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -481,6 +522,16 @@ CREATE TABLE accounting_timestamps (
 	value TIMESTAMP NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE bucket_usages (
+	id INTEGER NOT NULL,
+	project_id BLOB NOT NULL,
+	bucket_name TEXT NOT NULL,
+	interval_end_time TIMESTAMP NOT NULL,
+	data_total REAL NOT NULL,
+	data_type INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bwagreements (
 	serialnum TEXT NOT NULL,
 	data BLOB NOT NULL,
@@ -491,6 +542,27 @@ CREATE TABLE bwagreements (
 	expires_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( serialnum )
 );
+CREATE TABLE graceful_exit_transfers (
+	node_id BLOB NOT NULL,
+	path BLOB NOT NULL,
+	piece_num INTEGER NOT NULL,
+	receiver_id BLOB,
+	serialnum TEXT,
+	failed INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, path, piece_num )
+);
+CREATE TABLE graceful_exits (
+	node_id BLOB NOT NULL,
+	pieces_transferred INTEGER NOT NULL,
+	pieces_failed INTEGER NOT NULL,
+	bytes_transferred INTEGER NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP,
+	scan_cursor BLOB,
+	scanned_at TIMESTAMP,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE injuredsegments (
 	id INTEGER NOT NULL,
 	info BLOB NOT NULL,
@@ -535,10 +607,20 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
+CREATE TABLE project_usages (
+	project_id BLOB NOT NULL,
+	at_rest_total INTEGER NOT NULL,
+	egress_total INTEGER NOT NULL,
+	egress_start TIMESTAMP NOT NULL,
+	tallied_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE projects (
 	id BLOB NOT NULL,
 	name TEXT NOT NULL,
 	description TEXT NOT NULL,
+	storage_limit INTEGER NOT NULL,
+	egress_limit INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -1001,6 +1083,154 @@ func (f AccountingTimestamps_Value_Field) value() interface{} {
 
 func (AccountingTimestamps_Value_Field) _Column() string { return "value" }
 
+type BucketUsage struct {
+	Id              int64
+	ProjectId       []byte
+	BucketName      string
+	IntervalEndTime time.Time
+	DataTotal       float64
+	DataType        int
+	CreatedAt       time.Time
+}
+
+func (BucketUsage) _Table() string { return "bucket_usages" }
+
+type BucketUsage_Update_Fields struct {
+}
+
+type BucketUsage_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketUsage_Id(v int64) BucketUsage_Id_Field {
+	return BucketUsage_Id_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_Id_Field) _Column() string { return "id" }
+
+type BucketUsage_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketUsage_ProjectId(v []byte) BucketUsage_ProjectId_Field {
+	return BucketUsage_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_ProjectId_Field) _Column() string { return "project_id" }
+
+type BucketUsage_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketUsage_BucketName(v string) BucketUsage_BucketName_Field {
+	return BucketUsage_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_BucketName_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_BucketName_Field) _Column() string { return "bucket_name" }
+
+type BucketUsage_IntervalEndTime_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketUsage_IntervalEndTime(v time.Time) BucketUsage_IntervalEndTime_Field {
+	return BucketUsage_IntervalEndTime_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_IntervalEndTime_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_IntervalEndTime_Field) _Column() string { return "interval_end_time" }
+
+type BucketUsage_DataTotal_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func BucketUsage_DataTotal(v float64) BucketUsage_DataTotal_Field {
+	return BucketUsage_DataTotal_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_DataTotal_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_DataTotal_Field) _Column() string { return "data_total" }
+
+type BucketUsage_DataType_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func BucketUsage_DataType(v int) BucketUsage_DataType_Field {
+	return BucketUsage_DataType_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_DataType_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_DataType_Field) _Column() string { return "data_type" }
+
+type BucketUsage_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketUsage_CreatedAt(v time.Time) BucketUsage_CreatedAt_Field {
+	return BucketUsage_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_CreatedAt_Field) _Column() string { return "created_at" }
+
 type Bwagreement struct {
 	Serialnum   string
 	Data        []byte
@@ -1121,33 +1351,435 @@ func Bwagreement_CreatedAt(v time.Time) Bwagreement_CreatedAt_Field {
 	return Bwagreement_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Bwagreement_CreatedAt_Field) value() interface{} {
+func (f Bwagreement_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Bwagreement_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Bwagreement_ExpiresAt(v time.Time) Bwagreement_ExpiresAt_Field {
+	return Bwagreement_ExpiresAt_Field{_set: true, _value: v}
+}
+
+func (f Bwagreement_ExpiresAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_ExpiresAt_Field) _Column() string { return "expires_at" }
+
+type GracefulExitTransfer struct {
+	NodeId     []byte
+	Path       []byte
+	PieceNum   int
+	ReceiverId []byte
+	Serialnum  *string
+	Failed     bool
+	CreatedAt  time.Time
+}
+
+func (GracefulExitTransfer) _Table() string { return "graceful_exit_transfers" }
+
+type GracefulExitTransfer_Create_Fields struct {
+	ReceiverId GracefulExitTransfer_ReceiverId_Field
+	Serialnum  GracefulExitTransfer_Serialnum_Field
+}
+
+type GracefulExitTransfer_Update_Fields struct {
+}
+
+type GracefulExitTransfer_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func GracefulExitTransfer_NodeId(v []byte) GracefulExitTransfer_NodeId_Field {
+	return GracefulExitTransfer_NodeId_Field{_set: true, _value: v}
+}
+
+func (f GracefulExitTransfer_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_NodeId_Field) _Column() string { return "node_id" }
+
+type GracefulExitTransfer_Path_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func GracefulExitTransfer_Path(v []byte) GracefulExitTransfer_Path_Field {
+	return GracefulExitTransfer_Path_Field{_set: true, _value: v}
+}
+
+func (f GracefulExitTransfer_Path_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_Path_Field) _Column() string { return "path" }
+
+type GracefulExitTransfer_PieceNum_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func GracefulExitTransfer_PieceNum(v int) GracefulExitTransfer_PieceNum_Field {
+	return GracefulExitTransfer_PieceNum_Field{_set: true, _value: v}
+}
+
+func (f GracefulExitTransfer_PieceNum_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_PieceNum_Field) _Column() string { return "piece_num" }
+
+type GracefulExitTransfer_ReceiverId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func GracefulExitTransfer_ReceiverId(v []byte) GracefulExitTransfer_ReceiverId_Field {
+	return GracefulExitTransfer_ReceiverId_Field{_set: true, _value: v}
+}
+
+func GracefulExitTransfer_ReceiverId_Raw(v []byte) GracefulExitTransfer_ReceiverId_Field {
+	if v == nil {
+		return GracefulExitTransfer_ReceiverId_Null()
+	}
+	return GracefulExitTransfer_ReceiverId(v)
+}
+
+func GracefulExitTransfer_ReceiverId_Null() GracefulExitTransfer_ReceiverId_Field {
+	return GracefulExitTransfer_ReceiverId_Field{_set: true, _null: true}
+}
+
+func (f GracefulExitTransfer_ReceiverId_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f GracefulExitTransfer_ReceiverId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_ReceiverId_Field) _Column() string { return "receiver_id" }
+
+type GracefulExitTransfer_Serialnum_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func GracefulExitTransfer_Serialnum(v string) GracefulExitTransfer_Serialnum_Field {
+	return GracefulExitTransfer_Serialnum_Field{_set: true, _value: &v}
+}
+
+func GracefulExitTransfer_Serialnum_Raw(v *string) GracefulExitTransfer_Serialnum_Field {
+	if v == nil {
+		return GracefulExitTransfer_Serialnum_Null()
+	}
+	return GracefulExitTransfer_Serialnum(*v)
+}
+
+func GracefulExitTransfer_Serialnum_Null() GracefulExitTransfer_Serialnum_Field {
+	return GracefulExitTransfer_Serialnum_Field{_set: true, _null: true}
+}
+
+func (f GracefulExitTransfer_Serialnum_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f GracefulExitTransfer_Serialnum_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_Serialnum_Field) _Column() string { return "serialnum" }
+
+type GracefulExitTransfer_Failed_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func GracefulExitTransfer_Failed(v bool) GracefulExitTransfer_Failed_Field {
+	return GracefulExitTransfer_Failed_Field{_set: true, _value: v}
+}
+
+func (f GracefulExitTransfer_Failed_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_Failed_Field) _Column() string { return "failed" }
+
+type GracefulExitTransfer_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func GracefulExitTransfer_CreatedAt(v time.Time) GracefulExitTransfer_CreatedAt_Field {
+	return GracefulExitTransfer_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f GracefulExitTransfer_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExitTransfer_CreatedAt_Field) _Column() string { return "created_at" }
+
+type GracefulExit struct {
+	NodeId            []byte
+	PiecesTransferred int64
+	PiecesFailed      int64
+	BytesTransferred  int64
+	StartedAt         time.Time
+	FinishedAt        *time.Time
+	ScanCursor        []byte
+	ScannedAt         *time.Time
+}
+
+func (GracefulExit) _Table() string { return "graceful_exits" }
+
+type GracefulExit_Create_Fields struct {
+	FinishedAt GracefulExit_FinishedAt_Field
+	ScanCursor GracefulExit_ScanCursor_Field
+	ScannedAt  GracefulExit_ScannedAt_Field
+}
+
+type GracefulExit_Update_Fields struct {
+	PiecesTransferred GracefulExit_PiecesTransferred_Field
+	PiecesFailed      GracefulExit_PiecesFailed_Field
+	BytesTransferred  GracefulExit_BytesTransferred_Field
+	FinishedAt        GracefulExit_FinishedAt_Field
+	ScanCursor        GracefulExit_ScanCursor_Field
+	ScannedAt         GracefulExit_ScannedAt_Field
+}
+
+type GracefulExit_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func GracefulExit_NodeId(v []byte) GracefulExit_NodeId_Field {
+	return GracefulExit_NodeId_Field{_set: true, _value: v}
+}
+
+func (f GracefulExit_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_NodeId_Field) _Column() string { return "node_id" }
+
+type GracefulExit_PiecesTransferred_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func GracefulExit_PiecesTransferred(v int64) GracefulExit_PiecesTransferred_Field {
+	return GracefulExit_PiecesTransferred_Field{_set: true, _value: v}
+}
+
+func (f GracefulExit_PiecesTransferred_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_PiecesTransferred_Field) _Column() string { return "pieces_transferred" }
+
+type GracefulExit_PiecesFailed_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func GracefulExit_PiecesFailed(v int64) GracefulExit_PiecesFailed_Field {
+	return GracefulExit_PiecesFailed_Field{_set: true, _value: v}
+}
+
+func (f GracefulExit_PiecesFailed_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_PiecesFailed_Field) _Column() string { return "pieces_failed" }
+
+type GracefulExit_BytesTransferred_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func GracefulExit_BytesTransferred(v int64) GracefulExit_BytesTransferred_Field {
+	return GracefulExit_BytesTransferred_Field{_set: true, _value: v}
+}
+
+func (f GracefulExit_BytesTransferred_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_BytesTransferred_Field) _Column() string { return "bytes_transferred" }
+
+type GracefulExit_StartedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func GracefulExit_StartedAt(v time.Time) GracefulExit_StartedAt_Field {
+	return GracefulExit_StartedAt_Field{_set: true, _value: v}
+}
+
+func (f GracefulExit_StartedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_StartedAt_Field) _Column() string { return "started_at" }
+
+type GracefulExit_FinishedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func GracefulExit_FinishedAt(v time.Time) GracefulExit_FinishedAt_Field {
+	return GracefulExit_FinishedAt_Field{_set: true, _value: &v}
+}
+
+func GracefulExit_FinishedAt_Raw(v *time.Time) GracefulExit_FinishedAt_Field {
+	if v == nil {
+		return GracefulExit_FinishedAt_Null()
+	}
+	return GracefulExit_FinishedAt(*v)
+}
+
+func GracefulExit_FinishedAt_Null() GracefulExit_FinishedAt_Field {
+	return GracefulExit_FinishedAt_Field{_set: true, _null: true}
+}
+
+func (f GracefulExit_FinishedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f GracefulExit_FinishedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (GracefulExit_FinishedAt_Field) _Column() string { return "finished_at" }
+
+type GracefulExit_ScanCursor_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func GracefulExit_ScanCursor(v []byte) GracefulExit_ScanCursor_Field {
+	return GracefulExit_ScanCursor_Field{_set: true, _value: v}
+}
+
+func GracefulExit_ScanCursor_Raw(v []byte) GracefulExit_ScanCursor_Field {
+	if v == nil {
+		return GracefulExit_ScanCursor_Null()
+	}
+	return GracefulExit_ScanCursor(v)
+}
+
+func GracefulExit_ScanCursor_Null() GracefulExit_ScanCursor_Field {
+	return GracefulExit_ScanCursor_Field{_set: true, _null: true}
+}
+
+func (f GracefulExit_ScanCursor_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f GracefulExit_ScanCursor_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Bwagreement_CreatedAt_Field) _Column() string { return "created_at" }
+func (GracefulExit_ScanCursor_Field) _Column() string { return "scan_cursor" }
 
-type Bwagreement_ExpiresAt_Field struct {
+type GracefulExit_ScannedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value *time.Time
 }
 
-func Bwagreement_ExpiresAt(v time.Time) Bwagreement_ExpiresAt_Field {
-	return Bwagreement_ExpiresAt_Field{_set: true, _value: v}
+func GracefulExit_ScannedAt(v time.Time) GracefulExit_ScannedAt_Field {
+	return GracefulExit_ScannedAt_Field{_set: true, _value: &v}
 }
 
-func (f Bwagreement_ExpiresAt_Field) value() interface{} {
+func GracefulExit_ScannedAt_Raw(v *time.Time) GracefulExit_ScannedAt_Field {
+	if v == nil {
+		return GracefulExit_ScannedAt_Null()
+	}
+	return GracefulExit_ScannedAt(*v)
+}
+
+func GracefulExit_ScannedAt_Null() GracefulExit_ScannedAt_Field {
+	return GracefulExit_ScannedAt_Field{_set: true, _null: true}
+}
+
+func (f GracefulExit_ScannedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f GracefulExit_ScannedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Bwagreement_ExpiresAt_Field) _Column() string { return "expires_at" }
+func (GracefulExit_ScannedAt_Field) _Column() string { return "scanned_at" }
 
 type Injuredsegment struct {
 	Id   int64
@@ -1824,17 +2456,133 @@ func (f OverlayCacheNode_UptimeSuccessCount_Field) value() interface{} {
 
 func (OverlayCacheNode_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
 
+type ProjectUsage struct {
+	ProjectId   []byte
+	AtRestTotal int64
+	EgressTotal int64
+	EgressStart time.Time
+	TalliedAt   time.Time
+}
+
+func (ProjectUsage) _Table() string { return "project_usages" }
+
+type ProjectUsage_Update_Fields struct {
+	AtRestTotal ProjectUsage_AtRestTotal_Field
+	EgressTotal ProjectUsage_EgressTotal_Field
+	EgressStart ProjectUsage_EgressStart_Field
+	TalliedAt   ProjectUsage_TalliedAt_Field
+}
+
+type ProjectUsage_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsage_ProjectId(v []byte) ProjectUsage_ProjectId_Field {
+	return ProjectUsage_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsage_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsage_ProjectId_Field) _Column() string { return "project_id" }
+
+type ProjectUsage_AtRestTotal_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func ProjectUsage_AtRestTotal(v int64) ProjectUsage_AtRestTotal_Field {
+	return ProjectUsage_AtRestTotal_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsage_AtRestTotal_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsage_AtRestTotal_Field) _Column() string { return "at_rest_total" }
+
+type ProjectUsage_EgressTotal_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func ProjectUsage_EgressTotal(v int64) ProjectUsage_EgressTotal_Field {
+	return ProjectUsage_EgressTotal_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsage_EgressTotal_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsage_EgressTotal_Field) _Column() string { return "egress_total" }
+
+type ProjectUsage_EgressStart_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectUsage_EgressStart(v time.Time) ProjectUsage_EgressStart_Field {
+	return ProjectUsage_EgressStart_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsage_EgressStart_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsage_EgressStart_Field) _Column() string { return "egress_start" }
+
+type ProjectUsage_TalliedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectUsage_TalliedAt(v time.Time) ProjectUsage_TalliedAt_Field {
+	return ProjectUsage_TalliedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsage_TalliedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsage_TalliedAt_Field) _Column() string { return "tallied_at" }
+
 type Project struct {
-	Id          []byte
-	Name        string
-	Description string
-	CreatedAt   time.Time
+	Id           []byte
+	Name         string
+	Description  string
+	StorageLimit int64
+	EgressLimit  int64
+	CreatedAt    time.Time
 }
 
 func (Project) _Table() string { return "projects" }
 
 type Project_Update_Fields struct {
-	Description Project_Description_Field
+	Description  Project_Description_Field
+	StorageLimit Project_StorageLimit_Field
+	EgressLimit  Project_EgressLimit_Field
 }
 
 type Project_Id_Field struct {
@@ -1894,6 +2642,44 @@ func (f Project_Description_Field) value() interface{} {
 
 func (Project_Description_Field) _Column() string { return "description" }
 
+type Project_StorageLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Project_StorageLimit(v int64) Project_StorageLimit_Field {
+	return Project_StorageLimit_Field{_set: true, _value: v}
+}
+
+func (f Project_StorageLimit_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_StorageLimit_Field) _Column() string { return "storage_limit" }
+
+type Project_EgressLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Project_EgressLimit(v int64) Project_EgressLimit_Field {
+	return Project_EgressLimit_Field{_set: true, _value: v}
+}
+
+func (f Project_EgressLimit_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_EgressLimit_Field) _Column() string { return "egress_limit" }
+
 type Project_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -2641,6 +3427,63 @@ func (obj *postgresImpl) Create_AccountingRaw(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_ProjectUsage(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	project_usage_at_rest_total ProjectUsage_AtRestTotal_Field,
+	project_usage_egress_total ProjectUsage_EgressTotal_Field,
+	project_usage_egress_start ProjectUsage_EgressStart_Field,
+	project_usage_tallied_at ProjectUsage_TalliedAt_Field) (
+	project_usage *ProjectUsage, err error) {
+	__project_id_val := project_usage_project_id.value()
+	__at_rest_total_val := project_usage_at_rest_total.value()
+	__egress_total_val := project_usage_egress_total.value()
+	__egress_start_val := project_usage_egress_start.value()
+	__tallied_at_val := project_usage_tallied_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_usages ( project_id, at_rest_total, egress_total, egress_start, tallied_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __project_id_val, __at_rest_total_val, __egress_total_val, __egress_start_val, __tallied_at_val)
+
+	project_usage = &ProjectUsage{}
+	err = obj.driver.QueryRow(__stmt, __project_id_val, __at_rest_total_val, __egress_total_val, __egress_start_val, __tallied_at_val).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+
+}
+
+func (obj *postgresImpl) Create_BucketUsage(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_interval_end_time BucketUsage_IntervalEndTime_Field,
+	bucket_usage_data_total BucketUsage_DataTotal_Field,
+	bucket_usage_data_type BucketUsage_DataType_Field) (
+	bucket_usage *BucketUsage, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := bucket_usage_project_id.value()
+	__bucket_name_val := bucket_usage_bucket_name.value()
+	__interval_end_time_val := bucket_usage_interval_end_time.value()
+	__data_total_val := bucket_usage_data_total.value()
+	__data_type_val := bucket_usage_data_type.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_usages ( project_id, bucket_name, interval_end_time, data_total, data_type, created_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __project_id_val, __bucket_name_val, __interval_end_time_val, __data_total_val, __data_type_val, __created_at_val)
+
+	bucket_usage = &BucketUsage{}
+	err = obj.driver.QueryRow(__stmt, __project_id_val, __bucket_name_val, __interval_end_time_val, __data_total_val, __data_type_val, __created_at_val).Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_usage, nil
+
+}
+
 func (obj *postgresImpl) Create_Node(ctx context.Context,
 	node_id Node_Id_Field,
 	node_audit_success_count Node_AuditSuccessCount_Field,
@@ -2723,6 +3566,38 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_GracefulExit(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	graceful_exit_pieces_transferred GracefulExit_PiecesTransferred_Field,
+	graceful_exit_pieces_failed GracefulExit_PiecesFailed_Field,
+	graceful_exit_bytes_transferred GracefulExit_BytesTransferred_Field,
+	optional GracefulExit_Create_Fields) (
+	graceful_exit *GracefulExit, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := graceful_exit_node_id.value()
+	__pieces_transferred_val := graceful_exit_pieces_transferred.value()
+	__pieces_failed_val := graceful_exit_pieces_failed.value()
+	__bytes_transferred_val := graceful_exit_bytes_transferred.value()
+	__started_at_val := __now
+	__finished_at_val := optional.FinishedAt.value()
+	__scan_cursor_val := optional.ScanCursor.value()
+	__scanned_at_val := optional.ScannedAt.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO graceful_exits ( node_id, pieces_transferred, pieces_failed, bytes_transferred, started_at, finished_at, scan_cursor, scanned_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __pieces_transferred_val, __pieces_failed_val, __bytes_transferred_val, __started_at_val, __finished_at_val, __scan_cursor_val, __scanned_at_val)
+
+	graceful_exit = &GracefulExit{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __pieces_transferred_val, __pieces_failed_val, __bytes_transferred_val, __started_at_val, __finished_at_val, __scan_cursor_val, __scanned_at_val).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
+
+}
+
 func (obj *postgresImpl) Create_Injuredsegment(ctx context.Context,
 	injuredsegment_info Injuredsegment_Info_Field) (
 	injuredsegment *Injuredsegment, err error) {
@@ -2775,22 +3650,26 @@ func (obj *postgresImpl) Create_User(ctx context.Context,
 func (obj *postgresImpl) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
-	project_description Project_Description_Field) (
+	project_description Project_Description_Field,
+	project_storage_limit Project_StorageLimit_Field,
+	project_egress_limit Project_EgressLimit_Field) (
 	project *Project, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__id_val := project_id.value()
 	__name_val := project_name.value()
 	__description_val := project_description.value()
+	__storage_limit_val := project_storage_limit.value()
+	__egress_limit_val := project_egress_limit.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, name, description, created_at ) VALUES ( ?, ?, ?, ? ) RETURNING projects.id, projects.name, projects.description, projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, name, description, storage_limit, egress_limit, created_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __name_val, __description_val, __created_at_val)
+	obj.logStmt(__stmt, __id_val, __name_val, __description_val, __storage_limit_val, __egress_limit_val, __created_at_val)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, __id_val, __name_val, __description_val, __created_at_val).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __id_val, __name_val, __description_val, __storage_limit_val, __egress_limit_val, __created_at_val).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3180,6 +4059,128 @@ func (obj *postgresImpl) All_AccountingRaw_By_IntervalEndTime_GreaterOrEqual(ctx
 
 }
 
+func (obj *postgresImpl) Get_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field) (
+	project_usage *ProjectUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages WHERE project_usages.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_usage_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage = &ProjectUsage{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+
+}
+
+func (obj *postgresImpl) All_ProjectUsage(ctx context.Context) (
+	rows []*ProjectUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages")
+
+	var __values []interface{}
+	__values = append(__values)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		project_usage := &ProjectUsage{}
+		err = __rows.Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, project_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at FROM bucket_usages WHERE bucket_usages.interval_end_time >= ? AND bucket_usages.interval_end_time < ?")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_interval_end_time_greater_or_equal.value(), bucket_usage_interval_end_time_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bucket_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at FROM bucket_usages WHERE bucket_usages.project_id = ? AND bucket_usages.interval_end_time >= ? AND bucket_usages.interval_end_time < ?")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_project_id.value(), bucket_usage_interval_end_time_greater_or_equal.value(), bucket_usage_interval_end_time_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bucket_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
@@ -3341,7 +4342,28 @@ func (obj *postgresImpl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx c
 	if err := __rows.Err(); err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return rows, nil
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) Get_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field) (
+	graceful_exit *GracefulExit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at FROM graceful_exits WHERE graceful_exits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, graceful_exit_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	graceful_exit = &GracefulExit{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
 
 }
 
@@ -3466,7 +4488,7 @@ func (obj *postgresImpl) Get_User_By_Id(ctx context.Context,
 func (obj *postgresImpl) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -3482,7 +4504,7 @@ func (obj *postgresImpl) All_Project(ctx context.Context) (
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3499,7 +4521,7 @@ func (obj *postgresImpl) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -3508,7 +4530,7 @@ func (obj *postgresImpl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3520,7 +4542,7 @@ func (obj *postgresImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Proje
 	project_member_member_id ProjectMember_MemberId_Field) (
 	rows []*Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
 
 	var __values []interface{}
 	__values = append(__values, project_member_member_id.value())
@@ -3536,7 +4558,7 @@ func (obj *postgresImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Proje
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3842,6 +4864,61 @@ func (obj *postgresImpl) Update_AccountingTimestamps_By_Name(ctx context.Context
 	return accounting_timestamps, nil
 }
 
+func (obj *postgresImpl) Update_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	update ProjectUsage_Update_Fields) (
+	project_usage *ProjectUsage, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_usages SET "), __sets, __sqlbundle_Literal(" WHERE project_usages.project_id = ? RETURNING project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.AtRestTotal._set {
+		__values = append(__values, update.AtRestTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("at_rest_total = ?"))
+	}
+
+	if update.EgressTotal._set {
+		__values = append(__values, update.EgressTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_total = ?"))
+	}
+
+	if update.EgressStart._set {
+		__values = append(__values, update.EgressStart.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_start = ?"))
+	}
+
+	if update.TalliedAt._set {
+		__values = append(__values, update.TalliedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("tallied_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, project_usage_project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage = &ProjectUsage{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+}
+
 func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -4008,6 +5085,71 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 	return overlay_cache_node, nil
 }
 
+func (obj *postgresImpl) Update_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	update GracefulExit_Update_Fields) (
+	graceful_exit *GracefulExit, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE graceful_exits SET "), __sets, __sqlbundle_Literal(" WHERE graceful_exits.node_id = ? RETURNING graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.PiecesTransferred._set {
+		__values = append(__values, update.PiecesTransferred.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pieces_transferred = ?"))
+	}
+
+	if update.PiecesFailed._set {
+		__values = append(__values, update.PiecesFailed.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pieces_failed = ?"))
+	}
+
+	if update.BytesTransferred._set {
+		__values = append(__values, update.BytesTransferred.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bytes_transferred = ?"))
+	}
+
+	if update.FinishedAt._set {
+		__values = append(__values, update.FinishedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("finished_at = ?"))
+	}
+
+	if update.ScanCursor._set {
+		__values = append(__values, update.ScanCursor.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("scan_cursor = ?"))
+	}
+
+	if update.ScannedAt._set {
+		__values = append(__values, update.ScannedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("scanned_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, graceful_exit_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	graceful_exit = &GracefulExit{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
+}
+
 func (obj *postgresImpl) Update_User_By_Id(ctx context.Context,
 	user_id User_Id_Field,
 	update User_Update_Fields) (
@@ -4069,7 +5211,7 @@ func (obj *postgresImpl) Update_Project_By_Id(ctx context.Context,
 	project *Project, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -4080,6 +5222,16 @@ func (obj *postgresImpl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("description = ?"))
 	}
 
+	if update.StorageLimit._set {
+		__values = append(__values, update.StorageLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("storage_limit = ?"))
+	}
+
+	if update.EgressLimit._set {
+		__values = append(__values, update.EgressLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_limit = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -4093,7 +5245,7 @@ func (obj *postgresImpl) Update_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -4540,6 +5692,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM project_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4580,6 +5742,26 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM graceful_exits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM graceful_exit_transfers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4590,6 +5772,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4786,6 +5978,69 @@ func (obj *sqlite3Impl) Create_AccountingRaw(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_ProjectUsage(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	project_usage_at_rest_total ProjectUsage_AtRestTotal_Field,
+	project_usage_egress_total ProjectUsage_EgressTotal_Field,
+	project_usage_egress_start ProjectUsage_EgressStart_Field,
+	project_usage_tallied_at ProjectUsage_TalliedAt_Field) (
+	project_usage *ProjectUsage, err error) {
+	__project_id_val := project_usage_project_id.value()
+	__at_rest_total_val := project_usage_at_rest_total.value()
+	__egress_total_val := project_usage_egress_total.value()
+	__egress_start_val := project_usage_egress_start.value()
+	__tallied_at_val := project_usage_tallied_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_usages ( project_id, at_rest_total, egress_total, egress_start, tallied_at ) VALUES ( ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __project_id_val, __at_rest_total_val, __egress_total_val, __egress_start_val, __tallied_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __project_id_val, __at_rest_total_val, __egress_total_val, __egress_start_val, __tallied_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastProjectUsage(ctx, __pk)
+
+}
+
+func (obj *sqlite3Impl) Create_BucketUsage(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_interval_end_time BucketUsage_IntervalEndTime_Field,
+	bucket_usage_data_total BucketUsage_DataTotal_Field,
+	bucket_usage_data_type BucketUsage_DataType_Field) (
+	bucket_usage *BucketUsage, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := bucket_usage_project_id.value()
+	__bucket_name_val := bucket_usage_bucket_name.value()
+	__interval_end_time_val := bucket_usage_interval_end_time.value()
+	__data_total_val := bucket_usage_data_total.value()
+	__data_type_val := bucket_usage_data_type.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_usages ( project_id, bucket_name, interval_end_time, data_total, data_type, created_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __project_id_val, __bucket_name_val, __interval_end_time_val, __data_total_val, __data_type_val, __created_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __project_id_val, __bucket_name_val, __interval_end_time_val, __data_total_val, __data_type_val, __created_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastBucketUsage(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	node_id Node_Id_Field,
 	node_audit_success_count Node_AuditSuccessCount_Field,
@@ -4874,6 +6129,41 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_GracefulExit(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	graceful_exit_pieces_transferred GracefulExit_PiecesTransferred_Field,
+	graceful_exit_pieces_failed GracefulExit_PiecesFailed_Field,
+	graceful_exit_bytes_transferred GracefulExit_BytesTransferred_Field,
+	optional GracefulExit_Create_Fields) (
+	graceful_exit *GracefulExit, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := graceful_exit_node_id.value()
+	__pieces_transferred_val := graceful_exit_pieces_transferred.value()
+	__pieces_failed_val := graceful_exit_pieces_failed.value()
+	__bytes_transferred_val := graceful_exit_bytes_transferred.value()
+	__started_at_val := __now
+	__finished_at_val := optional.FinishedAt.value()
+	__scan_cursor_val := optional.ScanCursor.value()
+	__scanned_at_val := optional.ScannedAt.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO graceful_exits ( node_id, pieces_transferred, pieces_failed, bytes_transferred, started_at, finished_at, scan_cursor, scanned_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __pieces_transferred_val, __pieces_failed_val, __bytes_transferred_val, __started_at_val, __finished_at_val, __scan_cursor_val, __scanned_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __pieces_transferred_val, __pieces_failed_val, __bytes_transferred_val, __started_at_val, __finished_at_val, __scan_cursor_val, __scanned_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastGracefulExit(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Create_Injuredsegment(ctx context.Context,
 	injuredsegment_info Injuredsegment_Info_Field) (
 	injuredsegment *Injuredsegment, err error) {
@@ -4932,21 +6222,25 @@ func (obj *sqlite3Impl) Create_User(ctx context.Context,
 func (obj *sqlite3Impl) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
-	project_description Project_Description_Field) (
+	project_description Project_Description_Field,
+	project_storage_limit Project_StorageLimit_Field,
+	project_egress_limit Project_EgressLimit_Field) (
 	project *Project, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__id_val := project_id.value()
 	__name_val := project_name.value()
 	__description_val := project_description.value()
+	__storage_limit_val := project_storage_limit.value()
+	__egress_limit_val := project_egress_limit.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, name, description, created_at ) VALUES ( ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, name, description, storage_limit, egress_limit, created_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __name_val, __description_val, __created_at_val)
+	obj.logStmt(__stmt, __id_val, __name_val, __description_val, __storage_limit_val, __egress_limit_val, __created_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __id_val, __name_val, __description_val, __created_at_val)
+	__res, err := obj.driver.Exec(__stmt, __id_val, __name_val, __description_val, __storage_limit_val, __egress_limit_val, __created_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -5323,7 +6617,129 @@ func (obj *sqlite3Impl) All_AccountingRaw_By_IntervalEndTime_GreaterOrEqual(ctx
 	var __embed_stmt = __sqlbundle_Literal("SELECT accounting_raws.id, accounting_raws.node_id, accounting_raws.interval_end_time, accounting_raws.data_total, accounting_raws.data_type, accounting_raws.created_at FROM accounting_raws WHERE accounting_raws.interval_end_time >= ?")
 
 	var __values []interface{}
-	__values = append(__values, accounting_raw_interval_end_time_greater_or_equal.value())
+	__values = append(__values, accounting_raw_interval_end_time_greater_or_equal.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		accounting_raw := &AccountingRaw{}
+		err = __rows.Scan(&accounting_raw.Id, &accounting_raw.NodeId, &accounting_raw.IntervalEndTime, &accounting_raw.DataTotal, &accounting_raw.DataType, &accounting_raw.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, accounting_raw)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) Get_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field) (
+	project_usage *ProjectUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages WHERE project_usages.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_usage_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage = &ProjectUsage{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+
+}
+
+func (obj *sqlite3Impl) All_ProjectUsage(ctx context.Context) (
+	rows []*ProjectUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages")
+
+	var __values []interface{}
+	__values = append(__values)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		project_usage := &ProjectUsage{}
+		err = __rows.Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, project_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at FROM bucket_usages WHERE bucket_usages.interval_end_time >= ? AND bucket_usages.interval_end_time < ?")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_interval_end_time_greater_or_equal.value(), bucket_usage_interval_end_time_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bucket_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at FROM bucket_usages WHERE bucket_usages.project_id = ? AND bucket_usages.interval_end_time >= ? AND bucket_usages.interval_end_time < ?")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_project_id.value(), bucket_usage_interval_end_time_greater_or_equal.value(), bucket_usage_interval_end_time_less.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -5335,12 +6751,12 @@ func (obj *sqlite3Impl) All_AccountingRaw_By_IntervalEndTime_GreaterOrEqual(ctx
 	defer __rows.Close()
 
 	for __rows.Next() {
-		accounting_raw := &AccountingRaw{}
-		err = __rows.Scan(&accounting_raw.Id, &accounting_raw.NodeId, &accounting_raw.IntervalEndTime, &accounting_raw.DataTotal, &accounting_raw.DataType, &accounting_raw.CreatedAt)
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
-		rows = append(rows, accounting_raw)
+		rows = append(rows, bucket_usage)
 	}
 	if err := __rows.Err(); err != nil {
 		return nil, obj.makeErr(err)
@@ -5514,6 +6930,27 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx co
 
 }
 
+func (obj *sqlite3Impl) Get_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field) (
+	graceful_exit *GracefulExit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at FROM graceful_exits WHERE graceful_exits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, graceful_exit_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	graceful_exit = &GracefulExit{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
+
+}
+
 func (obj *sqlite3Impl) First_Injuredsegment(ctx context.Context) (
 	injuredsegment *Injuredsegment, err error) {
 
@@ -5635,7 +7072,7 @@ func (obj *sqlite3Impl) Get_User_By_Id(ctx context.Context,
 func (obj *sqlite3Impl) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -5651,7 +7088,7 @@ func (obj *sqlite3Impl) All_Project(ctx context.Context) (
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -5668,7 +7105,7 @@ func (obj *sqlite3Impl) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -5677,7 +7114,7 @@ func (obj *sqlite3Impl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -5689,7 +7126,7 @@ func (obj *sqlite3Impl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Projec
 	project_member_member_id ProjectMember_MemberId_Field) (
 	rows []*Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
 
 	var __values []interface{}
 	__values = append(__values, project_member_member_id.value())
@@ -5705,7 +7142,7 @@ func (obj *sqlite3Impl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Projec
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -6031,6 +7468,71 @@ func (obj *sqlite3Impl) Update_AccountingTimestamps_By_Name(ctx context.Context,
 	return accounting_timestamps, nil
 }
 
+func (obj *sqlite3Impl) Update_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	update ProjectUsage_Update_Fields) (
+	project_usage *ProjectUsage, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_usages SET "), __sets, __sqlbundle_Literal(" WHERE project_usages.project_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.AtRestTotal._set {
+		__values = append(__values, update.AtRestTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("at_rest_total = ?"))
+	}
+
+	if update.EgressTotal._set {
+		__values = append(__values, update.EgressTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_total = ?"))
+	}
+
+	if update.EgressStart._set {
+		__values = append(__values, update.EgressStart.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_start = ?"))
+	}
+
+	if update.TalliedAt._set {
+		__values = append(__values, update.TalliedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("tallied_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, project_usage_project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage = &ProjectUsage{}
+	_, err = obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages WHERE project_usages.project_id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+}
+
 func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -6217,6 +7719,81 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 	return overlay_cache_node, nil
 }
 
+func (obj *sqlite3Impl) Update_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	update GracefulExit_Update_Fields) (
+	graceful_exit *GracefulExit, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE graceful_exits SET "), __sets, __sqlbundle_Literal(" WHERE graceful_exits.node_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.PiecesTransferred._set {
+		__values = append(__values, update.PiecesTransferred.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pieces_transferred = ?"))
+	}
+
+	if update.PiecesFailed._set {
+		__values = append(__values, update.PiecesFailed.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pieces_failed = ?"))
+	}
+
+	if update.BytesTransferred._set {
+		__values = append(__values, update.BytesTransferred.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bytes_transferred = ?"))
+	}
+
+	if update.FinishedAt._set {
+		__values = append(__values, update.FinishedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("finished_at = ?"))
+	}
+
+	if update.ScanCursor._set {
+		__values = append(__values, update.ScanCursor.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("scan_cursor = ?"))
+	}
+
+	if update.ScannedAt._set {
+		__values = append(__values, update.ScannedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("scanned_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, graceful_exit_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	graceful_exit = &GracefulExit{}
+	_, err = obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at FROM graceful_exits WHERE graceful_exits.node_id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
+}
+
 func (obj *sqlite3Impl) Update_User_By_Id(ctx context.Context,
 	user_id User_Id_Field,
 	update User_Update_Fields) (
@@ -6299,6 +7876,16 @@ func (obj *sqlite3Impl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("description = ?"))
 	}
 
+	if update.StorageLimit._set {
+		__values = append(__values, update.StorageLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("storage_limit = ?"))
+	}
+
+	if update.EgressLimit._set {
+		__values = append(__values, update.EgressLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_limit = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -6317,12 +7904,12 @@ func (obj *sqlite3Impl) Update_Project_By_Id(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -6811,6 +8398,42 @@ func (obj *sqlite3Impl) getLastAccountingRaw(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastProjectUsage(ctx context.Context,
+	pk int64) (
+	project_usage *ProjectUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usages.project_id, project_usages.at_rest_total, project_usages.egress_total, project_usages.egress_start, project_usages.tallied_at FROM project_usages WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	project_usage = &ProjectUsage{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&project_usage.ProjectId, &project_usage.AtRestTotal, &project_usage.EgressTotal, &project_usage.EgressStart, &project_usage.TalliedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_usage, nil
+
+}
+
+func (obj *sqlite3Impl) getLastBucketUsage(ctx context.Context,
+	pk int64) (
+	bucket_usage *BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.project_id, bucket_usages.bucket_name, bucket_usages.interval_end_time, bucket_usages.data_total, bucket_usages.data_type, bucket_usages.created_at FROM bucket_usages WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	bucket_usage = &BucketUsage{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&bucket_usage.Id, &bucket_usage.ProjectId, &bucket_usage.BucketName, &bucket_usage.IntervalEndTime, &bucket_usage.DataTotal, &bucket_usage.DataType, &bucket_usage.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_usage, nil
+
+}
+
 func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 	pk int64) (
 	node *Node, err error) {
@@ -6847,6 +8470,24 @@ func (obj *sqlite3Impl) getLastOverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastGracefulExit(ctx context.Context,
+	pk int64) (
+	graceful_exit *GracefulExit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT graceful_exits.node_id, graceful_exits.pieces_transferred, graceful_exits.pieces_failed, graceful_exits.bytes_transferred, graceful_exits.started_at, graceful_exits.finished_at, graceful_exits.scan_cursor, graceful_exits.scanned_at FROM graceful_exits WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	graceful_exit = &GracefulExit{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&graceful_exit.NodeId, &graceful_exit.PiecesTransferred, &graceful_exit.PiecesFailed, &graceful_exit.BytesTransferred, &graceful_exit.StartedAt, &graceful_exit.FinishedAt, &graceful_exit.ScanCursor, &graceful_exit.ScannedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return graceful_exit, nil
+
+}
+
 func (obj *sqlite3Impl) getLastInjuredsegment(ctx context.Context,
 	pk int64) (
 	injuredsegment *Injuredsegment, err error) {
@@ -6887,13 +8528,13 @@ func (obj *sqlite3Impl) getLastProject(ctx context.Context,
 	pk int64) (
 	project *Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.created_at FROM projects WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.storage_limit, projects.egress_limit, projects.created_at FROM projects WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&project.Id, &project.Name, &project.Description, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&project.Id, &project.Name, &project.Description, &project.StorageLimit, &project.EgressLimit, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -7018,6 +8659,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM project_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7058,6 +8709,26 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM graceful_exits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM graceful_exit_transfers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7068,6 +8739,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7199,6 +8880,29 @@ func (rx *Rx) All_BucketInfo_By_ProjectId_OrderBy_Asc_Name(ctx context.Context,
 	return tx.All_BucketInfo_By_ProjectId_OrderBy_Asc_Name(ctx, bucket_info_project_id)
 }
 
+func (rx *Rx) All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx, bucket_usage_interval_end_time_greater_or_equal, bucket_usage_interval_end_time_less)
+}
+
+func (rx *Rx) All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+	bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+	rows []*BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx, bucket_usage_project_id, bucket_usage_interval_end_time_greater_or_equal, bucket_usage_interval_end_time_less)
+}
+
 func (rx *Rx) All_Bwagreement(ctx context.Context) (
 	rows []*Bwagreement, err error) {
 	var tx *Tx
@@ -7257,6 +8961,15 @@ func (rx *Rx) All_ProjectMember_By_MemberId(ctx context.Context,
 	return tx.All_ProjectMember_By_MemberId(ctx, project_member_member_id)
 }
 
+func (rx *Rx) All_ProjectUsage(ctx context.Context) (
+	rows []*ProjectUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_ProjectUsage(ctx)
+}
+
 func (rx *Rx) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
 	project_member_member_id ProjectMember_MemberId_Field) (
 	rows []*Project, err error) {
@@ -7337,6 +9050,21 @@ func (rx *Rx) Create_BucketInfo(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_BucketUsage(ctx context.Context,
+	bucket_usage_project_id BucketUsage_ProjectId_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_interval_end_time BucketUsage_IntervalEndTime_Field,
+	bucket_usage_data_total BucketUsage_DataTotal_Field,
+	bucket_usage_data_type BucketUsage_DataType_Field) (
+	bucket_usage *BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_BucketUsage(ctx, bucket_usage_project_id, bucket_usage_bucket_name, bucket_usage_interval_end_time, bucket_usage_data_total, bucket_usage_data_type)
+
+}
+
 func (rx *Rx) Create_Bwagreement(ctx context.Context,
 	bwagreement_serialnum Bwagreement_Serialnum_Field,
 	bwagreement_data Bwagreement_Data_Field,
@@ -7353,6 +9081,21 @@ func (rx *Rx) Create_Bwagreement(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_GracefulExit(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	graceful_exit_pieces_transferred GracefulExit_PiecesTransferred_Field,
+	graceful_exit_pieces_failed GracefulExit_PiecesFailed_Field,
+	graceful_exit_bytes_transferred GracefulExit_BytesTransferred_Field,
+	optional GracefulExit_Create_Fields) (
+	graceful_exit *GracefulExit, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_GracefulExit(ctx, graceful_exit_node_id, graceful_exit_pieces_transferred, graceful_exit_pieces_failed, graceful_exit_bytes_transferred, optional)
+
+}
+
 func (rx *Rx) Create_Injuredsegment(ctx context.Context,
 	injuredsegment_info Injuredsegment_Info_Field) (
 	injuredsegment *Injuredsegment, err error) {
@@ -7424,13 +9167,15 @@ func (rx *Rx) Create_OverlayCacheNode(ctx context.Context,
 func (rx *Rx) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
-	project_description Project_Description_Field) (
+	project_description Project_Description_Field,
+	project_storage_limit Project_StorageLimit_Field,
+	project_egress_limit Project_EgressLimit_Field) (
 	project *Project, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Project(ctx, project_id, project_name, project_description)
+	return tx.Create_Project(ctx, project_id, project_name, project_description, project_storage_limit, project_egress_limit)
 
 }
 
@@ -7446,6 +9191,21 @@ func (rx *Rx) Create_ProjectMember(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_ProjectUsage(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	project_usage_at_rest_total ProjectUsage_AtRestTotal_Field,
+	project_usage_egress_total ProjectUsage_EgressTotal_Field,
+	project_usage_egress_start ProjectUsage_EgressStart_Field,
+	project_usage_tallied_at ProjectUsage_TalliedAt_Field) (
+	project_usage *ProjectUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_ProjectUsage(ctx, project_usage_project_id, project_usage_at_rest_total, project_usage_egress_total, project_usage_egress_start, project_usage_tallied_at)
+
+}
+
 func (rx *Rx) Create_User(ctx context.Context,
 	user_id User_Id_Field,
 	user_first_name User_FirstName_Field,
@@ -7672,6 +9432,16 @@ func (rx *Rx) Get_Bwagreement_By_Serialnum(ctx context.Context,
 	return tx.Get_Bwagreement_By_Serialnum(ctx, bwagreement_serialnum)
 }
 
+func (rx *Rx) Get_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field) (
+	graceful_exit *GracefulExit, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_GracefulExit_By_NodeId(ctx, graceful_exit_node_id)
+}
+
 func (rx *Rx) Get_Irreparabledb_By_Segmentpath(ctx context.Context,
 	irreparabledb_segmentpath Irreparabledb_Segmentpath_Field) (
 	irreparabledb *Irreparabledb, err error) {
@@ -7712,6 +9482,16 @@ func (rx *Rx) Get_OverlayCacheNode_OperatorWallet_By_NodeId(ctx context.Context,
 	return tx.Get_OverlayCacheNode_OperatorWallet_By_NodeId(ctx, overlay_cache_node_node_id)
 }
 
+func (rx *Rx) Get_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field) (
+	project_usage *ProjectUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_ProjectUsage_By_ProjectId(ctx, project_usage_project_id)
+}
+
 func (rx *Rx) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
@@ -7806,6 +9586,17 @@ func (rx *Rx) Update_ApiKey_By_Id(ctx context.Context,
 	return tx.Update_ApiKey_By_Id(ctx, api_key_id, update)
 }
 
+func (rx *Rx) Update_GracefulExit_By_NodeId(ctx context.Context,
+	graceful_exit_node_id GracefulExit_NodeId_Field,
+	update GracefulExit_Update_Fields) (
+	graceful_exit *GracefulExit, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_GracefulExit_By_NodeId(ctx, graceful_exit_node_id, update)
+}
+
 func (rx *Rx) Update_Irreparabledb_By_Segmentpath(ctx context.Context,
 	irreparabledb_segmentpath Irreparabledb_Segmentpath_Field,
 	update Irreparabledb_Update_Fields) (
@@ -7839,6 +9630,17 @@ func (rx *Rx) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 	return tx.Update_OverlayCacheNode_By_NodeId(ctx, overlay_cache_node_node_id, update)
 }
 
+func (rx *Rx) Update_ProjectUsage_By_ProjectId(ctx context.Context,
+	project_usage_project_id ProjectUsage_ProjectId_Field,
+	update ProjectUsage_Update_Fields) (
+	project_usage *ProjectUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_ProjectUsage_By_ProjectId(ctx, project_usage_project_id, update)
+}
+
 func (rx *Rx) Update_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field,
 	update Project_Update_Fields) (
@@ -7881,6 +9683,17 @@ type Methods interface {
 		bucket_info_project_id BucketInfo_ProjectId_Field) (
 		rows []*BucketInfo, err error)
 
+	All_BucketUsage_By_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+		bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+		bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+		rows []*BucketUsage, err error)
+
+	All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx context.Context,
+		bucket_usage_project_id BucketUsage_ProjectId_Field,
+		bucket_usage_interval_end_time_greater_or_equal BucketUsage_IntervalEndTime_Field,
+		bucket_usage_interval_end_time_less BucketUsage_IntervalEndTime_Field) (
+		rows []*BucketUsage, err error)
+
 	All_Bwagreement(ctx context.Context) (
 		rows []*Bwagreement, err error)
 
@@ -7903,6 +9716,9 @@ type Methods interface {
 		project_member_member_id ProjectMember_MemberId_Field) (
 		rows []*ProjectMember, err error)
 
+	All_ProjectUsage(ctx context.Context) (
+		rows []*ProjectUsage, err error)
+
 	All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
 		project_member_member_id ProjectMember_MemberId_Field) (
 		rows []*Project, err error)
@@ -7942,6 +9758,14 @@ type Methods interface {
 		bucket_info_name BucketInfo_Name_Field) (
 		bucket_info *BucketInfo, err error)
 
+	Create_BucketUsage(ctx context.Context,
+		bucket_usage_project_id BucketUsage_ProjectId_Field,
+		bucket_usage_bucket_name BucketUsage_BucketName_Field,
+		bucket_usage_interval_end_time BucketUsage_IntervalEndTime_Field,
+		bucket_usage_data_total BucketUsage_DataTotal_Field,
+		bucket_usage_data_type BucketUsage_DataType_Field) (
+		bucket_usage *BucketUsage, err error)
+
 	Create_Bwagreement(ctx context.Context,
 		bwagreement_serialnum Bwagreement_Serialnum_Field,
 		bwagreement_data Bwagreement_Data_Field,
@@ -7951,6 +9775,14 @@ type Methods interface {
 		bwagreement_expires_at Bwagreement_ExpiresAt_Field) (
 		bwagreement *Bwagreement, err error)
 
+	Create_GracefulExit(ctx context.Context,
+		graceful_exit_node_id GracefulExit_NodeId_Field,
+		graceful_exit_pieces_transferred GracefulExit_PiecesTransferred_Field,
+		graceful_exit_pieces_failed GracefulExit_PiecesFailed_Field,
+		graceful_exit_bytes_transferred GracefulExit_BytesTransferred_Field,
+		optional GracefulExit_Create_Fields) (
+		graceful_exit *GracefulExit, err error)
+
 	Create_Injuredsegment(ctx context.Context,
 		injuredsegment_info Injuredsegment_Info_Field) (
 		injuredsegment *Injuredsegment, err error)
@@ -7994,7 +9826,9 @@ type Methods interface {
 	Create_Project(ctx context.Context,
 		project_id Project_Id_Field,
 		project_name Project_Name_Field,
-		project_description Project_Description_Field) (
+		project_description Project_Description_Field,
+		project_storage_limit Project_StorageLimit_Field,
+		project_egress_limit Project_EgressLimit_Field) (
 		project *Project, err error)
 
 	Create_ProjectMember(ctx context.Context,
@@ -8002,6 +9836,14 @@ type Methods interface {
 		project_member_project_id ProjectMember_ProjectId_Field) (
 		project_member *ProjectMember, err error)
 
+	Create_ProjectUsage(ctx context.Context,
+		project_usage_project_id ProjectUsage_ProjectId_Field,
+		project_usage_at_rest_total ProjectUsage_AtRestTotal_Field,
+		project_usage_egress_total ProjectUsage_EgressTotal_Field,
+		project_usage_egress_start ProjectUsage_EgressStart_Field,
+		project_usage_tallied_at ProjectUsage_TalliedAt_Field) (
+		project_usage *ProjectUsage, err error)
+
 	Create_User(ctx context.Context,
 		user_id User_Id_Field,
 		user_first_name User_FirstName_Field,
@@ -8094,6 +9936,10 @@ type Methods interface {
 		bwagreement_serialnum Bwagreement_Serialnum_Field) (
 		bwagreement *Bwagreement, err error)
 
+	Get_GracefulExit_By_NodeId(ctx context.Context,
+		graceful_exit_node_id GracefulExit_NodeId_Field) (
+		graceful_exit *GracefulExit, err error)
+
 	Get_Irreparabledb_By_Segmentpath(ctx context.Context,
 		irreparabledb_segmentpath Irreparabledb_Segmentpath_Field) (
 		irreparabledb *Irreparabledb, err error)
@@ -8110,6 +9956,10 @@ type Methods interface {
 		overlay_cache_node_node_id OverlayCacheNode_NodeId_Field) (
 		row *OperatorWallet_Row, err error)
 
+	Get_ProjectUsage_By_ProjectId(ctx context.Context,
+		project_usage_project_id ProjectUsage_ProjectId_Field) (
+		project_usage *ProjectUsage, err error)
+
 	Get_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
 		project *Project, err error)
@@ -8150,6 +10000,11 @@ type Methods interface {
 		update ApiKey_Update_Fields) (
 		api_key *ApiKey, err error)
 
+	Update_GracefulExit_By_NodeId(ctx context.Context,
+		graceful_exit_node_id GracefulExit_NodeId_Field,
+		update GracefulExit_Update_Fields) (
+		graceful_exit *GracefulExit, err error)
+
 	Update_Irreparabledb_By_Segmentpath(ctx context.Context,
 		irreparabledb_segmentpath Irreparabledb_Segmentpath_Field,
 		update Irreparabledb_Update_Fields) (
@@ -8165,6 +10020,11 @@ type Methods interface {
 		update OverlayCacheNode_Update_Fields) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
+	Update_ProjectUsage_By_ProjectId(ctx context.Context,
+		project_usage_project_id ProjectUsage_ProjectId_Field,
+		update ProjectUsage_Update_Fields) (
+		project_usage *ProjectUsage, err error)
+
 	Update_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field,
 		update Project_Update_Fields) (
@@ -26,6 +26,16 @@ CREATE TABLE accounting_timestamps (
 	value timestamp with time zone NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE bucket_usages (
+	id bigserial NOT NULL,
+	project_id bytea NOT NULL,
+	bucket_name text NOT NULL,
+	interval_end_time timestamp with time zone NOT NULL,
+	data_total double precision NOT NULL,
+	data_type integer NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bwagreements (
 	serialnum text NOT NULL,
 	data bytea NOT NULL,
@@ -36,6 +46,27 @@ CREATE TABLE bwagreements (
 	expires_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( serialnum )
 );
+CREATE TABLE graceful_exit_transfers (
+	node_id bytea NOT NULL,
+	path bytea NOT NULL,
+	piece_num integer NOT NULL,
+	receiver_id bytea,
+	serialnum text,
+	failed boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, path, piece_num )
+);
+CREATE TABLE graceful_exits (
+	node_id bytea NOT NULL,
+	pieces_transferred bigint NOT NULL,
+	pieces_failed bigint NOT NULL,
+	bytes_transferred bigint NOT NULL,
+	started_at timestamp with time zone NOT NULL,
+	finished_at timestamp with time zone,
+	scan_cursor bytea,
+	scanned_at timestamp with time zone,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE injuredsegments (
 	id bigserial NOT NULL,
 	info bytea NOT NULL,
@@ -80,10 +111,20 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
+CREATE TABLE project_usages (
+	project_id bytea NOT NULL,
+	at_rest_total bigint NOT NULL,
+	egress_total bigint NOT NULL,
+	egress_start timestamp with time zone NOT NULL,
+	tallied_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE projects (
 	id bytea NOT NULL,
 	name text NOT NULL,
 	description text NOT NULL,
+	storage_limit bigint NOT NULL,
+	egress_limit bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -26,6 +26,16 @@ CREATE TABLE accounting_timestamps (
 	value TIMESTAMP NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE bucket_usages (
+	id INTEGER NOT NULL,
+	project_id BLOB NOT NULL,
+	bucket_name TEXT NOT NULL,
+	interval_end_time TIMESTAMP NOT NULL,
+	data_total REAL NOT NULL,
+	data_type INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bwagreements (
 	serialnum TEXT NOT NULL,
 	data BLOB NOT NULL,
@@ -36,6 +46,27 @@ CREATE TABLE bwagreements (
 	expires_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( serialnum )
 );
+CREATE TABLE graceful_exit_transfers (
+	node_id BLOB NOT NULL,
+	path BLOB NOT NULL,
+	piece_num INTEGER NOT NULL,
+	receiver_id BLOB,
+	serialnum TEXT,
+	failed INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, path, piece_num )
+);
+CREATE TABLE graceful_exits (
+	node_id BLOB NOT NULL,
+	pieces_transferred INTEGER NOT NULL,
+	pieces_failed INTEGER NOT NULL,
+	bytes_transferred INTEGER NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP,
+	scan_cursor BLOB,
+	scanned_at TIMESTAMP,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE injuredsegments (
 	id INTEGER NOT NULL,
 	info BLOB NOT NULL,
@@ -80,10 +111,20 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
+CREATE TABLE project_usages (
+	project_id BLOB NOT NULL,
+	at_rest_total INTEGER NOT NULL,
+	egress_total INTEGER NOT NULL,
+	egress_start TIMESTAMP NOT NULL,
+	tallied_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE projects (
 	id BLOB NOT NULL,
 	name TEXT NOT NULL,
 	description TEXT NOT NULL,
+	storage_limit INTEGER NOT NULL,
+	egress_limit INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
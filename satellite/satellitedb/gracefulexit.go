@@ -0,0 +1,266 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"storj.io/storj/pkg/gracefulexit"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+var _ gracefulexit.DB = (*gracefulExitDB)(nil)
+
+type gracefulExitDB struct {
+	db *dbx.DB
+}
+
+// Start starts the exit of the node, keeping the progress of an exit which
+// was already started
+func (db *gracefulExitDB) Start(ctx context.Context, nodeID storj.NodeID) (*gracefulexit.Progress, error) {
+	dbxExit, err := db.db.Get_GracefulExit_By_NodeId(ctx, dbx.GracefulExit_NodeId(nodeID.Bytes()))
+	if err == sql.ErrNoRows {
+		dbxExit, err = db.db.Create_GracefulExit(ctx,
+			dbx.GracefulExit_NodeId(nodeID.Bytes()),
+			dbx.GracefulExit_PiecesTransferred(0),
+			dbx.GracefulExit_PiecesFailed(0),
+			dbx.GracefulExit_BytesTransferred(0),
+			dbx.GracefulExit_Create_Fields{},
+		)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return progressFromDBX(dbxExit)
+}
+
+// Get returns the progress of the exit of the node
+func (db *gracefulExitDB) Get(ctx context.Context, nodeID storj.NodeID) (*gracefulexit.Progress, error) {
+	dbxExit, err := db.db.Get_GracefulExit_By_NodeId(ctx, dbx.GracefulExit_NodeId(nodeID.Bytes()))
+	if err == sql.ErrNoRows {
+		return nil, gracefulexit.ErrNotStarted.New("%s", nodeID)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return progressFromDBX(dbxExit)
+}
+
+// Increment adds to the counters of the exit of the node
+func (db *gracefulExitDB) Increment(ctx context.Context, nodeID storj.NodeID, piecesTransferred, piecesFailed, bytesTransferred int64) error {
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(`UPDATE graceful_exits SET
+		pieces_transferred = pieces_transferred + ?,
+		pieces_failed = pieces_failed + ?,
+		bytes_transferred = bytes_transferred + ?
+		WHERE node_id = ?`), piecesTransferred, piecesFailed, bytesTransferred, nodeID.Bytes())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if updated == 0 {
+		return gracefulexit.ErrNotStarted.New("%s", nodeID)
+	}
+	return nil
+}
+
+// Finish marks the exit of the node as finished
+func (db *gracefulExitDB) Finish(ctx context.Context, nodeID storj.NodeID, finishedAt time.Time) (*gracefulexit.Progress, error) {
+	dbxExit, err := db.db.Update_GracefulExit_By_NodeId(ctx, dbx.GracefulExit_NodeId(nodeID.Bytes()),
+		dbx.GracefulExit_Update_Fields{
+			FinishedAt: dbx.GracefulExit_FinishedAt(finishedAt.UTC()),
+		})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if dbxExit == nil {
+		return nil, gracefulexit.ErrNotStarted.New("%s", nodeID)
+	}
+	return progressFromDBX(dbxExit)
+}
+
+// UpdateScan stores the path of the last segment scanned for the pieces of the
+// node, and whether every segment was scanned
+func (db *gracefulExitDB) UpdateScan(ctx context.Context, nodeID storj.NodeID, cursor string, scanned bool) error {
+	update := dbx.GracefulExit_Update_Fields{
+		ScanCursor: dbx.GracefulExit_ScanCursor([]byte(cursor)),
+	}
+	if scanned {
+		update.ScannedAt = dbx.GracefulExit_ScannedAt(time.Now().UTC())
+	}
+
+	dbxExit, err := db.db.Update_GracefulExit_By_NodeId(ctx, dbx.GracefulExit_NodeId(nodeID.Bytes()), update)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if dbxExit == nil {
+		return gracefulexit.ErrNotStarted.New("%s", nodeID)
+	}
+	return nil
+}
+
+// PutTransfer stores the transfer of a piece, replacing the previous transfer
+// of the piece
+func (db *gracefulExitDB) PutTransfer(ctx context.Context, transfer *gracefulexit.Transfer) (err error) {
+	tx, err := db.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+
+	_, err = tx.Tx.ExecContext(ctx, db.db.Rebind(`DELETE FROM graceful_exit_transfers
+		WHERE node_id = ? AND path = ? AND piece_num = ?`),
+		transfer.NodeID.Bytes(), []byte(transfer.Path), transfer.PieceNum)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// the receiver and the serial number are unknown when no node could
+	// receive the piece
+	var receiverID, serialNumber interface{}
+	if !transfer.ReceiverID.IsZero() {
+		receiverID = transfer.ReceiverID.Bytes()
+	}
+	if transfer.SerialNumber != "" {
+		serialNumber = transfer.SerialNumber
+	}
+
+	_, err = tx.Tx.ExecContext(ctx, db.db.Rebind(`INSERT INTO graceful_exit_transfers
+		( node_id, path, piece_num, receiver_id, serialnum, failed, created_at )
+		VALUES ( ?, ?, ?, ?, ?, ?, ? )`),
+		transfer.NodeID.Bytes(), []byte(transfer.Path), transfer.PieceNum,
+		receiverID, serialNumber, transfer.Failed, time.Now().UTC())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
+// GetTransfer returns the transfer of piece pieceNum of the segment at path
+// from the node
+func (db *gracefulExitDB) GetTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) (*gracefulexit.Transfer, error) {
+	transfer := &gracefulexit.Transfer{
+		NodeID:   nodeID,
+		Path:     path,
+		PieceNum: pieceNum,
+	}
+
+	var receiverID []byte
+	var serialNumber sql.NullString
+	err := db.db.QueryRowContext(ctx, db.db.Rebind(`SELECT receiver_id, serialnum, failed, created_at
+		FROM graceful_exit_transfers
+		WHERE node_id = ? AND path = ? AND piece_num = ?`),
+		nodeID.Bytes(), []byte(path), pieceNum,
+	).Scan(&receiverID, &serialNumber, &transfer.Failed, &transfer.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, gracefulexit.ErrTransferNotFound.New("piece %d of %q on node %s", pieceNum, path, nodeID)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return transfer, setReceiver(transfer, receiverID, serialNumber)
+}
+
+// DeleteTransfer deletes the transfer of piece pieceNum of the segment at
+// path from the node
+func (db *gracefulExitDB) DeleteTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) error {
+	_, err := db.db.ExecContext(ctx, db.db.Rebind(`DELETE FROM graceful_exit_transfers
+		WHERE node_id = ? AND path = ? AND piece_num = ?`),
+		nodeID.Bytes(), []byte(path), pieceNum)
+	return Error.Wrap(err)
+}
+
+// GetOpenTransfers returns up to limit transfers from the node which didn't
+// fail and either have no receiver or were given one before assignedBefore,
+// in the order of their paths
+func (db *gracefulExitDB) GetOpenTransfers(ctx context.Context, nodeID storj.NodeID, assignedBefore time.Time, limit int) (transfers []*gracefulexit.Transfer, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`SELECT path, piece_num, receiver_id, serialnum, created_at
+		FROM graceful_exit_transfers
+		WHERE node_id = ? AND failed = ? AND ( receiver_id IS NULL OR created_at < ? )
+		ORDER BY path, piece_num
+		LIMIT ?`),
+		nodeID.Bytes(), false, assignedBefore.UTC(), limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		transfer := &gracefulexit.Transfer{NodeID: nodeID}
+
+		var path, receiverID []byte
+		var serialNumber sql.NullString
+		err := rows.Scan(&path, &transfer.PieceNum, &receiverID, &serialNumber, &transfer.CreatedAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		transfer.Path = string(path)
+		if err := setReceiver(transfer, receiverID, serialNumber); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, Error.Wrap(rows.Err())
+}
+
+// CountOpenTransfers returns the number of transfers from the node which
+// didn't fail
+func (db *gracefulExitDB) CountOpenTransfers(ctx context.Context, nodeID storj.NodeID) (count int64, err error) {
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(`SELECT COUNT(*) FROM graceful_exit_transfers
+		WHERE node_id = ? AND failed = ?`),
+		nodeID.Bytes(), false,
+	).Scan(&count)
+	return count, Error.Wrap(err)
+}
+
+// setReceiver sets the nullable receiver and serial number of transfer
+func setReceiver(transfer *gracefulexit.Transfer, receiverID []byte, serialNumber sql.NullString) (err error) {
+	if receiverID != nil {
+		transfer.ReceiverID, err = storj.NodeIDFromBytes(receiverID)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	transfer.SerialNumber = serialNumber.String
+	return nil
+}
+
+// progressFromDBX converts the dbx graceful exit to the progress of the exit
+func progressFromDBX(dbxExit *dbx.GracefulExit) (*gracefulexit.Progress, error) {
+	nodeID, err := storj.NodeIDFromBytes(dbxExit.NodeId)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	progress := &gracefulexit.Progress{
+		NodeID:            nodeID,
+		PiecesTransferred: dbxExit.PiecesTransferred,
+		PiecesFailed:      dbxExit.PiecesFailed,
+		BytesTransferred:  dbxExit.BytesTransferred,
+		StartedAt:         dbxExit.StartedAt,
+		ScanCursor:        string(dbxExit.ScanCursor),
+	}
+	if dbxExit.FinishedAt != nil {
+		progress.FinishedAt = *dbxExit.FinishedAt
+	}
+	if dbxExit.ScannedAt != nil {
+		progress.ScannedAt = *dbxExit.ScannedAt
+	}
+	return progress, nil
+}
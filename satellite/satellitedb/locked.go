@@ -16,6 +16,7 @@ import (
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/statdb"
@@ -48,6 +49,20 @@ type lockedAccounting struct {
 	db accounting.DB
 }
 
+// GetProjectUsage retrieves the last tallied usage of a project, which is zero for projects that weren't tallied yet
+func (m *lockedAccounting) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*accounting.ProjectUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetProjectUsage(ctx, projectID)
+}
+
+// GetProjectUsages retrieves the last tallied usage of all projects
+func (m *lockedAccounting) GetProjectUsages(ctx context.Context) ([]*accounting.ProjectUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetProjectUsages(ctx)
+}
+
 // GetRaw retrieves all raw tallies
 func (m *lockedAccounting) GetRaw(ctx context.Context) ([]*accounting.Raw, error) {
 	m.Lock()
@@ -69,6 +84,13 @@ func (m *lockedAccounting) LastRawTime(ctx context.Context, timestampType string
 	return m.db.LastRawTime(ctx, timestampType)
 }
 
+// QueryBucketUsage sums the raw tallies of the buckets of all projects in the [start, end) period
+func (m *lockedAccounting) QueryBucketUsage(ctx context.Context, start time.Time, end time.Time) ([]*accounting.BucketUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.QueryBucketUsage(ctx, start, end)
+}
+
 // QueryPaymentInfo queries StatDB, Accounting Rollup on nodeID
 func (m *lockedAccounting) QueryPaymentInfo(ctx context.Context, start time.Time, end time.Time) ([]*accounting.CSVRow, error) {
 	m.Lock()
@@ -76,6 +98,13 @@ func (m *lockedAccounting) QueryPaymentInfo(ctx context.Context, start time.Time
 	return m.db.QueryPaymentInfo(ctx, start, end)
 }
 
+// QueryProjectUsage sums the raw tallies of the buckets of a project in the [start, end) period
+func (m *lockedAccounting) QueryProjectUsage(ctx context.Context, projectID uuid.UUID, start time.Time, end time.Time) ([]*accounting.BucketUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.QueryProjectUsage(ctx, projectID, start, end)
+}
+
 // SaveAtRestRaw records raw tallies of at-rest-data.
 func (m *lockedAccounting) SaveAtRestRaw(ctx context.Context, latestTally time.Time, isNew bool, nodeData map[storj.NodeID]float64) error {
 	m.Lock()
@@ -83,6 +112,13 @@ func (m *lockedAccounting) SaveAtRestRaw(ctx context.Context, latestTally time.T
 	return m.db.SaveAtRestRaw(ctx, latestTally, isNew, nodeData)
 }
 
+// SaveBucketRaw records raw tallies of the data of dataType of the buckets of the projects
+func (m *lockedAccounting) SaveBucketRaw(ctx context.Context, intervalEnd time.Time, dataType int, bucketData map[accounting.BucketID]float64) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.SaveBucketRaw(ctx, intervalEnd, dataType, bucketData)
+}
+
 // SaveBWRaw records raw sums of agreement values to the database and updates the LastRawTime.
 func (m *lockedAccounting) SaveBWRaw(ctx context.Context, latestBwa time.Time, isNew bool, bwTotals accounting.BWTally) error {
 	m.Lock()
@@ -90,6 +126,13 @@ func (m *lockedAccounting) SaveBWRaw(ctx context.Context, latestBwa time.Time, i
 	return m.db.SaveBWRaw(ctx, latestBwa, isNew, bwTotals)
 }
 
+// SaveProjectUsage records the tallied usage of a project
+func (m *lockedAccounting) SaveProjectUsage(ctx context.Context, usage *accounting.ProjectUsage) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.SaveProjectUsage(ctx, usage)
+}
+
 // SaveRollup records raw tallies of at rest data to the database
 func (m *lockedAccounting) SaveRollup(ctx context.Context, latestTally time.Time, isNew bool, stats accounting.RollupStats) error {
 	m.Lock()
@@ -110,13 +153,22 @@ type lockedBandwidthAgreement struct {
 	db bwagreement.DB
 }
 
-// CreateAgreement adds a new bandwidth agreement.
+// CreateAgreement adds a new bandwidth agreement, failing with ErrDuplicate
+// when it was already added.
 func (m *lockedBandwidthAgreement) CreateAgreement(ctx context.Context, a1 *pb.RenterBandwidthAllocation) error {
 	m.Lock()
 	defer m.Unlock()
 	return m.db.CreateAgreement(ctx, a1)
 }
 
+// GetAgreement gets the bandwidth agreement of the storage node with the
+// serial number.
+func (m *lockedBandwidthAgreement) GetAgreement(ctx context.Context, serialNumber string, storageNodeID storj.NodeID) (*bwagreement.Agreement, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetAgreement(ctx, serialNumber, storageNodeID)
+}
+
 // GetAgreements gets all bandwidth agreements.
 func (m *lockedBandwidthAgreement) GetAgreements(ctx context.Context) ([]bwagreement.Agreement, error) {
 	m.Lock()
@@ -199,6 +251,13 @@ func (m *lockedAPIKeys) GetByProjectID(ctx context.Context, projectID uuid.UUID)
 	return m.db.GetByProjectID(ctx, projectID)
 }
 
+// GetSecret retrieves the root secret of the macaroon of the api key with given ID and the project of the key
+func (m *lockedAPIKeys) GetSecret(ctx context.Context, id uuid.UUID) (secret []byte, projectID uuid.UUID, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetSecret(ctx, id)
+}
+
 // Update updates APIKeyInfo in store
 func (m *lockedAPIKeys) Update(ctx context.Context, key console.APIKeyInfo) error {
 	m.Lock()
@@ -343,6 +402,13 @@ func (m *lockedProjects) GetByUserID(ctx context.Context, userID uuid.UUID) ([]c
 	return m.db.GetByUserID(ctx, userID)
 }
 
+// GetLimits is a method for querying the storage and egress limits of project by id.
+func (m *lockedProjects) GetLimits(ctx context.Context, id uuid.UUID) (storage int64, egress int64, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetLimits(ctx, id)
+}
+
 // Insert is a method for inserting project into the database.
 func (m *lockedProjects) Insert(ctx context.Context, project *console.Project) (*console.Project, error) {
 	m.Lock()
@@ -357,6 +423,26 @@ func (m *lockedProjects) Update(ctx context.Context, project *console.Project) e
 	return m.db.Update(ctx, project)
 }
 
+// UsageRollups is a getter for UsageRollups repository
+func (m *lockedConsole) UsageRollups() console.UsageRollups {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedUsageRollups{m.Locker, m.db.UsageRollups()}
+}
+
+// lockedUsageRollups implements locking wrapper for console.UsageRollups
+type lockedUsageRollups struct {
+	sync.Locker
+	db console.UsageRollups
+}
+
+// GetProjectUsage retrieves the usage of a project and its buckets in the [since, before) period
+func (m *lockedUsageRollups) GetProjectUsage(ctx context.Context, projectID uuid.UUID, since time.Time, before time.Time) (*console.ProjectUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetProjectUsage(ctx, projectID, since, before)
+}
+
 // Users is a getter for Users repository
 func (m *lockedConsole) Users() console.Users {
 	m.Lock()
@@ -412,6 +498,97 @@ func (m *locked) CreateTables() error {
 	return m.db.CreateTables()
 }
 
+// GracefulExit returns database for the progress of exiting nodes
+func (m *locked) GracefulExit() gracefulexit.DB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedGracefulExit{m.Locker, m.db.GracefulExit()}
+}
+
+// lockedGracefulExit implements locking wrapper for gracefulexit.DB
+type lockedGracefulExit struct {
+	sync.Locker
+	db gracefulexit.DB
+}
+
+// CountOpenTransfers returns the number of transfers from the node which
+// didn't fail.
+func (m *lockedGracefulExit) CountOpenTransfers(ctx context.Context, nodeID storj.NodeID) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CountOpenTransfers(ctx, nodeID)
+}
+
+// DeleteTransfer deletes the transfer of piece pieceNum of the segment at
+// path from the node.
+func (m *lockedGracefulExit) DeleteTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.DeleteTransfer(ctx, nodeID, path, pieceNum)
+}
+
+// Finish marks the exit of the node as finished.
+func (m *lockedGracefulExit) Finish(ctx context.Context, nodeID storj.NodeID, finishedAt time.Time) (*gracefulexit.Progress, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Finish(ctx, nodeID, finishedAt)
+}
+
+// Get returns the progress of the exit of the node.
+func (m *lockedGracefulExit) Get(ctx context.Context, nodeID storj.NodeID) (*gracefulexit.Progress, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Get(ctx, nodeID)
+}
+
+// GetOpenTransfers returns up to limit transfers from the node which
+// didn't fail and either have no receiver or were given one before
+// assignedBefore, in the order of their paths.
+func (m *lockedGracefulExit) GetOpenTransfers(ctx context.Context, nodeID storj.NodeID, assignedBefore time.Time, limit int) ([]*gracefulexit.Transfer, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetOpenTransfers(ctx, nodeID, assignedBefore, limit)
+}
+
+// GetTransfer returns the transfer of piece pieceNum of the segment at
+// path from the node.
+func (m *lockedGracefulExit) GetTransfer(ctx context.Context, nodeID storj.NodeID, path string, pieceNum int32) (*gracefulexit.Transfer, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetTransfer(ctx, nodeID, path, pieceNum)
+}
+
+// Increment adds to the counters of the exit of the node.
+func (m *lockedGracefulExit) Increment(ctx context.Context, nodeID storj.NodeID, piecesTransferred int64, piecesFailed int64, bytesTransferred int64) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Increment(ctx, nodeID, piecesTransferred, piecesFailed, bytesTransferred)
+}
+
+// PutTransfer stores the transfer of a piece, replacing the previous
+// transfer of the piece.
+func (m *lockedGracefulExit) PutTransfer(ctx context.Context, transfer *gracefulexit.Transfer) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.PutTransfer(ctx, transfer)
+}
+
+// Start starts the exit of the node, keeping the progress of an exit
+// which was already started.
+func (m *lockedGracefulExit) Start(ctx context.Context, nodeID storj.NodeID) (*gracefulexit.Progress, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Start(ctx, nodeID)
+}
+
+// UpdateScan stores the path of the last segment scanned for the pieces of
+// the node, and whether every segment was scanned.
+func (m *lockedGracefulExit) UpdateScan(ctx context.Context, nodeID storj.NodeID, cursor string, scanned bool) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateScan(ctx, nodeID, cursor, scanned)
+}
+
 // Irreparable returns database for failed repairs
 func (m *locked) Irreparable() irreparable.DB {
 	m.Lock()
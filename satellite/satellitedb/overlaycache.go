@@ -165,12 +165,14 @@ func (cache *overlaycache) findReputableNodesQuery(ctx context.Context, req *get
 
 	// This queries for nodes whose audit counts are greater than or equal to
 	// the new node audit threshold and the minimum reputation audit count.
+	// Nodes which are gracefully exiting don't get new pieces.
 	rows, err = cache.db.Query(`SELECT node_id,
 	node_type, address, free_bandwidth, free_disk, audit_success_ratio,
 	audit_uptime_ratio, audit_count, audit_success_count, uptime_count,
 	uptime_success_count
 	FROM overlay_cache_nodes
 	WHERE node_id NOT IN (`+strings.Join(sliceOfCopies("?", len(req.excluded)), ", ")+`)
+	AND node_id NOT IN (SELECT node_id FROM graceful_exits)
 	AND audit_count >= ?
 	AND audit_success_ratio >= ?
 	AND uptime_count >= ?
@@ -216,6 +218,7 @@ func (cache *overlaycache) findNewNodesQuery(ctx context.Context, req *getNodesR
 		uptime_success_count
 		FROM overlay_cache_nodes
 		WHERE node_id NOT IN (`+strings.Join(sliceOfCopies("?", len(req.excluded)), ", ")+`)	
+		AND node_id NOT IN (SELECT node_id FROM graceful_exits)
 		AND audit_count < ?
 		AND free_bandwidth >= ?
 		AND free_disk >= ?
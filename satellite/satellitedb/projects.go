@@ -49,6 +49,16 @@ func (projects *projects) Get(ctx context.Context, id uuid.UUID) (*console.Proje
 	return projectFromDBX(project)
 }
 
+// GetLimits is a method for querying the storage and egress limits of project by id.
+func (projects *projects) GetLimits(ctx context.Context, id uuid.UUID) (storage, egress int64, err error) {
+	project, err := projects.db.Get_Project_By_Id(ctx, dbx.Project_Id(id[:]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return project.StorageLimit, project.EgressLimit, nil
+}
+
 // Insert is a method for inserting project into the database.
 func (projects *projects) Insert(ctx context.Context, project *console.Project) (*console.Project, error) {
 	projectID, err := uuid.New()
@@ -59,7 +69,9 @@ func (projects *projects) Insert(ctx context.Context, project *console.Project)
 	createdProject, err := projects.db.Create_Project(ctx,
 		dbx.Project_Id(projectID[:]),
 		dbx.Project_Name(project.Name),
-		dbx.Project_Description(project.Description))
+		dbx.Project_Description(project.Description),
+		dbx.Project_StorageLimit(project.StorageLimit),
+		dbx.Project_EgressLimit(project.EgressLimit))
 
 	if err != nil {
 		return nil, err
@@ -78,7 +90,9 @@ func (projects *projects) Delete(ctx context.Context, id uuid.UUID) error {
 // Update is a method for updating project entity
 func (projects *projects) Update(ctx context.Context, project *console.Project) error {
 	updateFields := dbx.Project_Update_Fields{
-		Description: dbx.Project_Description(project.Description),
+		Description:  dbx.Project_Description(project.Description),
+		StorageLimit: dbx.Project_StorageLimit(project.StorageLimit),
+		EgressLimit:  dbx.Project_EgressLimit(project.EgressLimit),
 	}
 
 	_, err := projects.db.Update_Project_By_Id(ctx,
@@ -100,10 +114,12 @@ func projectFromDBX(project *dbx.Project) (*console.Project, error) {
 	}
 
 	u := &console.Project{
-		ID:          id,
-		Name:        project.Name,
-		Description: project.Description,
-		CreatedAt:   project.CreatedAt,
+		ID:           id,
+		Name:         project.Name,
+		Description:  project.Description,
+		StorageLimit: project.StorageLimit,
+		EgressLimit:  project.EgressLimit,
+		CreatedAt:    project.CreatedAt,
 	}
 
 	return u, nil
@@ -110,6 +110,23 @@ func TestProjectsRepository(t *testing.T) {
 		assert.Equal(t, newProject.Description, newDescription)
 	})
 
+	t.Run("Update project limits success", func(t *testing.T) {
+		oldProject, err := projects.Get(ctx, project.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, oldProject)
+
+		oldProject.StorageLimit = 1000
+		oldProject.EgressLimit = 2000
+
+		err = projects.Update(ctx, oldProject)
+		assert.NoError(t, err)
+
+		storage, egress, err := projects.GetLimits(ctx, oldProject.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), storage)
+		assert.Equal(t, int64(2000), egress)
+	})
+
 	t.Run("Delete project success", func(t *testing.T) {
 		oldProject, err := projects.Get(ctx, project.ID)
 		assert.NoError(t, err)
@@ -101,7 +101,8 @@ func (s *statDB) Get(ctx context.Context, nodeID storj.NodeID) (stats *statdb.No
 	return nodeStats, nil
 }
 
-// FindInvalidNodes finds a subset of storagenodes that fail to meet minimum reputation requirements
+// FindInvalidNodes finds a subset of storagenodes that fail to meet minimum reputation requirements,
+// or which have gracefully exited the network
 func (s *statDB) FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList, maxStats *statdb.NodeStats) (invalidIDs storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -147,12 +148,16 @@ func (s *statDB) findInvalidNodesQuery(nodeIds storj.NodeIDList, auditSuccess, u
 		nodes.uptime_ratio
 		FROM nodes
 		WHERE nodes.id IN (?`+strings.Repeat(", ?", len(nodeIds)-1)+`)
-		AND nodes.total_audit_count > 0
-		AND nodes.total_uptime_count > 0
-		AND (
-			nodes.audit_success_ratio < ?
-			OR nodes.uptime_ratio < ?
-		)`), args...)
+		AND ((
+			nodes.total_audit_count > 0
+			AND nodes.total_uptime_count > 0
+			AND (
+				nodes.audit_success_ratio < ?
+				OR nodes.uptime_ratio < ?
+			)
+		) OR nodes.id IN (
+			SELECT node_id FROM graceful_exits WHERE finished_at IS NOT NULL
+		))`), args...)
 
 	return rows, err
 }
@@ -0,0 +1,50 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+
+	"storj.io/storj/satellite/console"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type usagerollups struct {
+	db dbx.Methods
+}
+
+// GetProjectUsage retrieves the usage of a project and its buckets in the [since, before) period
+func (rollups *usagerollups) GetProjectUsage(ctx context.Context, projectID uuid.UUID, since, before time.Time) (*console.ProjectUsage, error) {
+	raws, err := rollups.db.All_BucketUsage_By_ProjectId_And_IntervalEndTime_GreaterOrEqual_And_IntervalEndTime_Less(ctx,
+		dbx.BucketUsage_ProjectId(projectID[:]),
+		dbx.BucketUsage_IntervalEndTime(since),
+		dbx.BucketUsage_IntervalEndTime(before))
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := sumBucketUsage(raws)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &console.ProjectUsage{
+		Since:  since,
+		Before: before,
+	}
+	for _, bucket := range buckets {
+		usage.Storage += bucket.AtRestTotal
+		usage.Egress += bucket.GetTotal
+		usage.Buckets = append(usage.Buckets, console.BucketUsage{
+			BucketName: bucket.BucketName,
+			Storage:    bucket.AtRestTotal,
+			Egress:     bucket.GetTotal,
+		})
+	}
+
+	return usage, nil
+}
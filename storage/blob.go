@@ -9,7 +9,13 @@ import (
 )
 
 // BlobRef is an unique reference to a blob
-type BlobRef [32]byte
+type BlobRef struct {
+	Namespace []byte
+	Key       []byte
+}
+
+// IsValid returns whether the reference has a key
+func (ref BlobRef) IsValid() bool { return len(ref.Key) > 0 }
 
 // ReadSeekCloser is an interface that groups Read, ReadAt, Seek and Close.
 type ReadSeekCloser interface {
@@ -20,13 +26,35 @@ type ReadSeekCloser interface {
 	Size() int64
 }
 
+// BlobWriter is a writer of a blob, which becomes visible once committed
+type BlobWriter interface {
+	io.Writer
+	// Cancel discards the written data
+	Cancel() error
+	// Commit makes the blob visible under its reference
+	Commit() error
+}
+
 // Blobs is a blob storage interface
+//
+// Load, Trash and RestoreTrash return an error satisfying os.IsNotExist when
+// the blob doesn't exist.
 type Blobs interface {
+	// Create starts writing the blob with the specified reference
+	// optionally takes a size argument for improvements, -1 is unknown size
+	Create(ctx context.Context, ref BlobRef, size int64) (BlobWriter, error)
 	// Load loads blob with the specified reference
-	Load(context.Context, BlobRef) (ReadSeekCloser, error)
+	Load(ctx context.Context, ref BlobRef) (ReadSeekCloser, error)
 	// Delete deletes the blob with the specified reference
-	Delete(context.Context, BlobRef) error
-	// Store stores blob from reader
-	// optionally takes a size argument for improvements, -1 is unknown size
-	Store(ctx context.Context, r io.Reader, size int64) (BlobRef, error)
+	Delete(ctx context.Context, ref BlobRef) error
+
+	// Trash moves the blob with the specified reference to the trash
+	Trash(ctx context.Context, ref BlobRef) error
+	// RestoreTrash moves the blob with the specified reference back from the trash
+	RestoreTrash(ctx context.Context, ref BlobRef) error
+	// DeleteTrash permanently deletes the blob with the specified reference from the trash
+	DeleteTrash(ctx context.Context, ref BlobRef) error
+
+	// FreeSpace returns how much space is left on the disk of the blobs
+	FreeSpace() (int64, error)
 }
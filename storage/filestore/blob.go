@@ -0,0 +1,66 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// blobReader implements reading blobs
+type blobReader struct {
+	*os.File
+}
+
+func newBlobReader(file *os.File) *blobReader {
+	return &blobReader{file}
+}
+
+// Size returns the size of the blob
+func (blob *blobReader) Size() int64 {
+	stat, err := blob.Stat()
+	if err != nil {
+		return 0
+	}
+	return stat.Size()
+}
+
+// blobWriter implements writing blobs
+type blobWriter struct {
+	ref    storage.BlobRef
+	store  *Store
+	file   *os.File
+	buffer *bufio.Writer
+}
+
+func newBlobWriter(ref storage.BlobRef, store *Store, file *os.File) *blobWriter {
+	return &blobWriter{
+		ref:    ref,
+		store:  store,
+		file:   file,
+		buffer: bufio.NewWriterSize(file, writeBufferSize),
+	}
+}
+
+// Write writes data to the blob
+func (blob *blobWriter) Write(data []byte) (int, error) {
+	return blob.buffer.Write(data)
+}
+
+// Cancel discards the blob
+func (blob *blobWriter) Cancel() error {
+	return Error.Wrap(blob.store.dir.DeleteTemporary(blob.file))
+}
+
+// Commit moves the blob to its final location
+func (blob *blobWriter) Commit() error {
+	if err := blob.buffer.Flush(); err != nil {
+		return Error.Wrap(errs.Combine(err, blob.store.dir.DeleteTemporary(blob.file)))
+	}
+	return Error.Wrap(blob.store.dir.Commit(blob.file, blob.ref))
+}
@@ -4,7 +4,7 @@
 package filestore
 
 import (
-	"encoding/hex"
+	"encoding/base32"
 	"io"
 	"io/ioutil"
 	"math"
@@ -22,6 +22,9 @@ const (
 	dirPermission  = 0700
 )
 
+// pathEncoding encodes namespaces and keys for case insensitive file systems
+var pathEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
 // Dir represents single folder for storing blobs
 type Dir struct {
 	path string
@@ -37,8 +40,9 @@ func NewDir(path string) (*Dir, error) {
 	}
 
 	return dir, errs.Combine(
-		os.MkdirAll(dir.blobdir(), dirPermission),
+		os.MkdirAll(dir.blobsdir(), dirPermission),
 		os.MkdirAll(dir.tempdir(), dirPermission),
+		os.MkdirAll(dir.garbagedir(), dirPermission),
 		os.MkdirAll(dir.trashdir(), dirPermission),
 	)
 }
@@ -46,9 +50,10 @@ func NewDir(path string) (*Dir, error) {
 // Path returns the directory path
 func (dir *Dir) Path() string { return dir.path }
 
-func (dir *Dir) blobdir() string  { return filepath.Join(dir.path) }
-func (dir *Dir) tempdir() string  { return filepath.Join(dir.path, "tmp") }
-func (dir *Dir) trashdir() string { return filepath.Join(dir.path, "trash") }
+func (dir *Dir) blobsdir() string   { return filepath.Join(dir.path, "blobs") }
+func (dir *Dir) tempdir() string    { return filepath.Join(dir.path, "temp") }
+func (dir *Dir) garbagedir() string { return filepath.Join(dir.path, "garbage") }
+func (dir *Dir) trashdir() string   { return filepath.Join(dir.path, "trash") }
 
 // CreateTemporaryFile creates a preallocated temporary file in the temp directory
 // prealloc preallocates file to make writing faster
@@ -77,10 +82,15 @@ func (dir *Dir) DeleteTemporary(file *os.File) error {
 	return errs.Combine(closeErr, os.Remove(file.Name()))
 }
 
-// refToPath converts blob reference to a filepath
-func (dir *Dir) refToPath(ref storage.BlobRef) string {
-	hex := hex.EncodeToString(ref[:])
-	return filepath.Join(dir.blobdir(), hex[0:2], hex[2:])
+// refToPath converts blob reference to a filepath under root, which is either
+// the blobs or the trash directory
+func (dir *Dir) refToPath(root string, ref storage.BlobRef) (string, error) {
+	key := pathEncoding.EncodeToString(ref.Key)
+	if len(key) < 3 {
+		return "", Error.New("invalid blob key %x", ref.Key)
+	}
+	namespace := pathEncoding.EncodeToString(ref.Namespace)
+	return filepath.Join(root, namespace, key[0:2], key[2:]), nil
 }
 
 // Commit commits temporary file to the permanent storage
@@ -96,7 +106,12 @@ func (dir *Dir) Commit(file *os.File, ref storage.BlobRef) error {
 		return errs.Combine(seekErr, truncErr, syncErr, chmodErr, closeErr, removeErr)
 	}
 
-	path := dir.refToPath(ref)
+	path, refErr := dir.refToPath(dir.blobsdir(), ref)
+	if refErr != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(refErr, removeErr)
+	}
+
 	mkdirErr := os.MkdirAll(filepath.Dir(path), dirPermission)
 	if os.IsExist(mkdirErr) {
 		mkdirErr = nil
@@ -117,28 +132,35 @@ func (dir *Dir) Commit(file *os.File, ref storage.BlobRef) error {
 
 // Open opens the file with the specified ref
 func (dir *Dir) Open(ref storage.BlobRef) (*os.File, error) {
-	path := dir.refToPath(ref)
+	path, err := dir.refToPath(dir.blobsdir(), ref)
+	if err != nil {
+		return nil, err
+	}
 	return os.OpenFile(path, os.O_RDONLY, blobPermission)
 }
 
 // Delete deletes file with the specified ref
 func (dir *Dir) Delete(ref storage.BlobRef) error {
-	path := dir.refToPath(ref)
+	path, err := dir.refToPath(dir.blobsdir(), ref)
+	if err != nil {
+		return err
+	}
 
-	// move to trash folder, this is allowed for some OS-es
-	trashPath := filepath.Join(dir.trashdir(), hex.EncodeToString(ref[:]))
-	moveErr := os.Rename(path, trashPath)
+	// move to garbage folder, this is allowed for some OS-es
+	garbagePath := filepath.Join(dir.garbagedir(),
+		pathEncoding.EncodeToString(ref.Namespace)+"-"+pathEncoding.EncodeToString(ref.Key))
+	moveErr := os.Rename(path, garbagePath)
 
 	// ignore concurrent delete
 	if os.IsNotExist(moveErr) {
 		return nil
 	}
 	if moveErr != nil {
-		trashPath = path
+		garbagePath = path
 	}
 
 	// try removing the file
-	err := os.Remove(trashPath)
+	err = os.Remove(garbagePath)
 
 	// ignore concurrent deletes
 	if os.IsNotExist(err) {
@@ -148,7 +170,7 @@ func (dir *Dir) Delete(ref storage.BlobRef) error {
 	// this may fail, because someone might be still reading it
 	if err != nil {
 		dir.mu.Lock()
-		dir.deleteQueue = append(dir.deleteQueue, trashPath)
+		dir.deleteQueue = append(dir.deleteQueue, garbagePath)
 		dir.mu.Unlock()
 	}
 
@@ -187,11 +209,59 @@ func (dir *Dir) GarbageCollect() error {
 		dir.mu.Unlock()
 	}
 
-	// remove anything left in the trashdir
-	_ = removeAllContent(dir.trashdir())
+	// remove anything left in the garbagedir
+	_ = removeAllContent(dir.garbagedir())
 	return nil
 }
 
+// Trash moves the file with the specified ref to the trash
+func (dir *Dir) Trash(ref storage.BlobRef) error {
+	return dir.move(ref, dir.blobsdir(), dir.trashdir())
+}
+
+// RestoreTrash moves the file with the specified ref back from the trash
+func (dir *Dir) RestoreTrash(ref storage.BlobRef) error {
+	return dir.move(ref, dir.trashdir(), dir.blobsdir())
+}
+
+// DeleteTrash deletes the file with the specified ref from the trash
+func (dir *Dir) DeleteTrash(ref storage.BlobRef) error {
+	path, err := dir.refToPath(dir.trashdir(), ref)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// move moves the file with the specified ref from the root directory from
+// to the root directory to
+func (dir *Dir) move(ref storage.BlobRef, from, to string) error {
+	fromPath, err := dir.refToPath(from, ref)
+	if err != nil {
+		return err
+	}
+	toPath, err := dir.refToPath(to, ref)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(fromPath); err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(toPath), dirPermission)
+	if err != nil && !os.IsExist(err) {
+		return err
+	}
+
+	return os.Rename(fromPath, toPath)
+}
+
 // removeAllContent deletes everything in the folder
 func removeAllContent(path string) error {
 	dir, err := os.Open(path)
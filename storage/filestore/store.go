@@ -4,11 +4,7 @@
 package filestore
 
 import (
-	"bufio"
 	"context"
-	"crypto/rand"
-	"crypto/sha256"
-	"io"
 	"os"
 
 	"github.com/zeebo/errs"
@@ -20,8 +16,6 @@ import (
 var Error = errs.Class("filestore error")
 
 const (
-	headerSize = 32
-
 	// TODO: implement readBufferSize  = 64 << 10 // 64 KB
 	writeBufferSize = 64 << 10 // 64 KB
 )
@@ -47,87 +41,67 @@ func NewAt(path string) (*Store, error) {
 	return &Store{dir}, nil
 }
 
-// Load loads blob with the specified hash
-func (store *Store) Load(ctx context.Context, hash storage.BlobRef) (storage.ReadSeekCloser, error) {
-	file, openErr := store.dir.Open(hash)
-	if openErr != nil {
-		if os.IsNotExist(openErr) {
-			return nil, openErr
-		}
-		return nil, Error.Wrap(openErr)
-	}
+// Close closes the store
+func (store *Store) Close() error { return nil }
 
-	bodyReader, err := newBlobBodyReader(file)
+// Create creates a new blob that can be written
+// optionally takes a size argument for performance improvements, -1 is unknown size
+func (store *Store) Create(ctx context.Context, ref storage.BlobRef, size int64) (storage.BlobWriter, error) {
+	file, err := store.dir.CreateTemporaryFile(size)
 	if err != nil {
-		return nil, Error.Wrap(errs.Combine(err, file.Close()))
+		return nil, Error.Wrap(err)
 	}
-
-	return bodyReader, nil
+	return newBlobWriter(ref, store, file), nil
 }
 
-// Delete deletes blobs with the specified hash
-func (store *Store) Delete(ctx context.Context, hash storage.BlobRef) error {
-	err := store.dir.Delete(hash)
+// Load loads blob with the specified reference
+func (store *Store) Load(ctx context.Context, ref storage.BlobRef) (storage.ReadSeekCloser, error) {
+	file, err := store.dir.Open(ref)
 	if err != nil {
-		return Error.Wrap(err)
+		return nil, wrapExceptNotExist(err)
 	}
-	return nil
+	return newBlobReader(file), nil
 }
 
-// GarbageCollect tries to delete any files that haven't yet been deleted
-func (store *Store) GarbageCollect(ctx context.Context) error {
-	err := store.dir.GarbageCollect()
-	if err != nil {
-		return Error.Wrap(err)
-	}
-	return nil
+// Delete deletes blobs with the specified reference
+func (store *Store) Delete(ctx context.Context, ref storage.BlobRef) error {
+	return Error.Wrap(store.dir.Delete(ref))
 }
 
-// Store stores r to disk, optionally takes a size argument, -1 is unknown size
-func (store *Store) Store(ctx context.Context, r io.Reader, size int64) (storage.BlobRef, error) {
-	file, err := store.dir.CreateTemporaryFile(size)
-	if err != nil {
-		return storage.BlobRef{}, Error.Wrap(err)
-	}
-
-	hasher := sha256.New()
-	bufferedFile := bufio.NewWriterSize(file, writeBufferSize)
-
-	// write to both hasher and file
-	writer := io.MultiWriter(hasher, bufferedFile)
-
-	// seed file with random data
-	_, err = io.CopyN(writer, rand.Reader, headerSize)
-	if err != nil {
-		return storage.BlobRef{}, Error.Wrap(err)
-	}
+// Trash moves the blob with the specified reference to the trash
+func (store *Store) Trash(ctx context.Context, ref storage.BlobRef) error {
+	return wrapExceptNotExist(store.dir.Trash(ref))
+}
 
-	// copy data to disk
-	if size >= 0 {
-		if _, err = io.CopyN(writer, r, size); err != nil && err != io.EOF {
-			return storage.BlobRef{}, Error.Wrap(errs.Combine(err, store.dir.DeleteTemporary(file)))
-		}
-	} else {
-		if _, err = io.Copy(writer, r); err != nil && err != io.EOF {
-			return storage.BlobRef{}, Error.Wrap(errs.Combine(err, store.dir.DeleteTemporary(file)))
-		}
-	}
+// RestoreTrash moves the blob with the specified reference back from the trash
+func (store *Store) RestoreTrash(ctx context.Context, ref storage.BlobRef) error {
+	return wrapExceptNotExist(store.dir.RestoreTrash(ref))
+}
 
-	// flush any pending data
-	if err = bufferedFile.Flush(); err != nil {
-		return storage.BlobRef{}, Error.Wrap(err)
-	}
+// DeleteTrash permanently deletes the blob with the specified reference from the trash
+func (store *Store) DeleteTrash(ctx context.Context, ref storage.BlobRef) error {
+	return Error.Wrap(store.dir.DeleteTrash(ref))
+}
 
-	// figure out the hash
-	var blobref storage.BlobRef
-	copy(blobref[:], hasher.Sum(nil))
+// GarbageCollect tries to delete any files that haven't yet been deleted
+func (store *Store) GarbageCollect(ctx context.Context) error {
+	return Error.Wrap(store.dir.GarbageCollect())
+}
 
-	// commit file to blob folder
-	err = store.dir.Commit(file, blobref)
+// FreeSpace returns how much space is left on the disk of the store
+func (store *Store) FreeSpace() (int64, error) {
+	info, err := store.dir.Info()
 	if err != nil {
-		return storage.BlobRef{}, Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
+	return info.AvailableSpace, nil
+}
 
-	// return the reference
-	return blobref, nil
+// wrapExceptNotExist wraps err, unless it reports a missing file, so that it
+// can be still checked with os.IsNotExist
+func wrapExceptNotExist(err error) error {
+	if os.IsNotExist(err) {
+		return err
+	}
+	return Error.Wrap(err)
 }
@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -16,6 +15,7 @@ import (
 
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/testsuite"
 )
 
 func newTestStore(t testing.TB) (dir string, store *filestore.Store, cleanup func()) {
@@ -37,105 +37,59 @@ func newTestStore(t testing.TB) (dir string, store *filestore.Store, cleanup fun
 	}
 }
 
-func TestStoreLoad(t *testing.T) {
-	const blobSize = 8 << 10
-	const repeatCount = 16
+func TestBlobs(t *testing.T) {
+	_, store, cleanup := newTestStore(t)
+	defer cleanup()
 
+	testsuite.RunBlobTests(t, store)
+}
+
+func TestNamespaces(t *testing.T) {
 	ctx := context.Background()
 
 	_, store, cleanup := newTestStore(t)
 	defer cleanup()
 
-	data := make([]byte, blobSize)
-	temp := make([]byte, len(data))
-	_, _ = rand.Read(data)
-
-	refs := map[storage.BlobRef]bool{}
-
-	// store without size
-	for i := 0; i < repeatCount; i++ {
-		ref, err := store.Store(ctx, bytes.NewReader(data), -1)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if refs[ref] {
-			t.Fatal("duplicate ref received")
-		}
-		refs[ref] = true
+	key := []byte("key")
+	refs := []storage.BlobRef{
+		{Namespace: nil, Key: key},
+		{Namespace: []byte("first"), Key: key},
+		{Namespace: []byte("second"), Key: key},
 	}
 
-	// store with size
-	for i := 0; i < repeatCount; i++ {
-		ref, err := store.Store(ctx, bytes.NewReader(data), int64(len(data)))
+	for i, ref := range refs {
+		writer, err := store.Create(ctx, ref, -1)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if refs[ref] {
-			t.Fatal("duplicate ref received")
-		}
-		refs[ref] = true
-	}
-
-	// store with larger size
-	{
-		ref, err := store.Store(ctx, bytes.NewReader(data), int64(len(data))*2)
-		if err != nil {
+		if _, err := writer.Write([]byte{byte(i)}); err != nil {
 			t.Fatal(err)
 		}
-		if refs[ref] {
-			t.Fatal("duplicate ref received")
-		}
-		refs[ref] = true
-	}
-
-	// store with error
-	{
-		_, err := store.Store(ctx, &errorReader{}, int64(len(data)))
-		if err == nil {
-			t.Fatal("expected store error")
+		if err := writer.Commit(); err != nil {
+			t.Fatal(err)
 		}
 	}
 
-	// try reading all the blobs
-	for ref := range refs {
+	for i, ref := range refs {
 		reader, err := store.Load(ctx, ref)
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		if reader.Size() != int64(len(data)) {
-			t.Fatal(err)
-		}
-
-		_, err = io.ReadFull(reader, temp)
+		data, err := ioutil.ReadAll(reader)
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		err = reader.Close()
-		if err != nil {
+		if err := reader.Close(); err != nil {
 			t.Fatal(err)
 		}
-
-		if !bytes.Equal(data, temp) {
-			t.Fatal("data mismatch")
-		}
-	}
-
-	// delete the blobs
-	for ref := range refs {
-		err := store.Delete(ctx, ref)
-		if err != nil {
-			t.Fatal(err)
+		if !bytes.Equal([]byte{byte(i)}, data) {
+			t.Fatalf("data mismatch in namespace %q", ref.Namespace)
 		}
 	}
 
-	// try reading all the blobs
-	for ref := range refs {
-		_, err := store.Load(ctx, ref)
-		if err == nil {
-			t.Fatal("expected error when loading invalid ref")
-		}
+	// keys too short to be split into directories are invalid
+	if _, err := store.Load(ctx, storage.BlobRef{Key: []byte("k")}); !filestore.Error.Has(err) {
+		t.Fatalf("expected an invalid key error, got %v", err)
 	}
 }
 
@@ -150,10 +104,8 @@ func TestDeleteWhileReading(t *testing.T) {
 	data := make([]byte, blobSize)
 	_, _ = rand.Read(data)
 
-	ref, err := store.Store(ctx, bytes.NewReader(data), -1)
-	if err != nil {
-		t.Fatal(err)
-	}
+	ref := storage.BlobRef{Namespace: []byte("namespace"), Key: []byte("key")}
+	storeData(t, store, ref, data)
 
 	rd, loadErr := store.Load(ctx, ref)
 	if loadErr != nil {
@@ -186,7 +138,7 @@ func TestDeleteWhileReading(t *testing.T) {
 	}
 
 	// flaky test, for checking whether files have been actually deleted from disk
-	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if info.IsDir() {
 			return nil
 		}
@@ -197,10 +149,17 @@ func TestDeleteWhileReading(t *testing.T) {
 	}
 }
 
-type errorReader struct{}
-
-func (errorReader *errorReader) Read(data []byte) (n int, err error) {
-	return 0, errors.New("internal-error")
+func storeData(t testing.TB, store *filestore.Store, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(context.Background(), ref, int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func BenchmarkStoreDelete(b *testing.B) {
@@ -211,12 +170,11 @@ func BenchmarkStoreDelete(b *testing.B) {
 	_, store, cleanup := newTestStore(b)
 	defer cleanup()
 
+	ref := storage.BlobRef{Key: []byte("benchmark")}
+
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
-		ref, err := store.Store(ctx, bytes.NewReader(data[:]), int64(len(data)))
-		if err != nil {
-			b.Fatal(err)
-		}
+		storeData(b, store, ref, data[:])
 		if err := store.Delete(ctx, ref); err != nil {
 			b.Fatal(err)
 		}
@@ -0,0 +1,219 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+// RunBlobTests runs common storage.Blobs tests
+func RunBlobTests(t *testing.T, blobs storage.Blobs) {
+	t.Run("CreateLoad", func(t *testing.T) { testBlobCreateLoad(t, blobs) })
+	t.Run("Cancel", func(t *testing.T) { testBlobCancel(t, blobs) })
+	t.Run("Delete", func(t *testing.T) { testBlobDelete(t, blobs) })
+	t.Run("Trash", func(t *testing.T) { testBlobTrash(t, blobs) })
+	t.Run("FreeSpace", func(t *testing.T) { testBlobFreeSpace(t, blobs) })
+}
+
+// newBlobRef returns a random reference which is valid for every
+// implementation
+func newBlobRef() storage.BlobRef {
+	const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	namespace := make([]byte, 32)
+	_, _ = rand.Read(namespace)
+
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+
+	return storage.BlobRef{Namespace: namespace, Key: key}
+}
+
+// storeBlob creates and commits the blob ref with data
+func storeBlob(t *testing.T, blobs storage.Blobs, ref storage.BlobRef, data []byte, size int64) {
+	ctx := context.Background()
+
+	writer, err := blobs.Create(ctx, ref, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// loadBlob returns the content of the blob ref
+func loadBlob(t *testing.T, blobs storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := blobs.Load(context.Background(), ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(data)) != reader.Size() {
+		t.Fatalf("read %d bytes, but the size is %d", len(data), reader.Size())
+	}
+	return data
+}
+
+// assertBlobNotExist checks that the blob ref can't be loaded
+func assertBlobNotExist(t *testing.T, blobs storage.Blobs, ref storage.BlobRef) {
+	reader, err := blobs.Load(context.Background(), ref)
+	if err == nil {
+		_ = reader.Close()
+		t.Fatal("expected the blob to not exist")
+	}
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+}
+
+func testBlobCreateLoad(t *testing.T, blobs storage.Blobs) {
+	ctx := context.Background()
+
+	data := make([]byte, 8<<10)
+	_, _ = rand.Read(data)
+
+	for _, size := range []int64{-1, 0, int64(len(data)), 2 * int64(len(data))} {
+		ref := newBlobRef()
+		storeBlob(t, blobs, ref, data, size)
+
+		if loaded := loadBlob(t, blobs, ref); !bytes.Equal(data, loaded) {
+			t.Fatalf("data mismatch with size %d", size)
+		}
+
+		reader, err := blobs.Load(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		part := make([]byte, 100)
+		if _, err := reader.ReadAt(part, 1000); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data[1000:1100], part) {
+			t.Fatal("data mismatch when reading at an offset")
+		}
+
+		if _, err := reader.Seek(2000, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.ReadFull(reader, part); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data[2000:2100], part) {
+			t.Fatal("data mismatch when reading after seeking")
+		}
+
+		if err := reader.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := blobs.Delete(ctx, ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertBlobNotExist(t, blobs, newBlobRef())
+}
+
+func testBlobCancel(t *testing.T, blobs storage.Blobs) {
+	ctx := context.Background()
+	ref := newBlobRef()
+
+	writer, err := blobs.Create(ctx, ref, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write([]byte("canceled")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+
+	assertBlobNotExist(t, blobs, ref)
+}
+
+func testBlobDelete(t *testing.T, blobs storage.Blobs) {
+	ctx := context.Background()
+	ref := newBlobRef()
+
+	storeBlob(t, blobs, ref, []byte("deleted"), -1)
+	if err := blobs.Delete(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	assertBlobNotExist(t, blobs, ref)
+
+	// deleting a missing blob isn't an error
+	if err := blobs.Delete(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testBlobTrash(t *testing.T, blobs storage.Blobs) {
+	ctx := context.Background()
+	ref := newBlobRef()
+	data := []byte("trashed")
+
+	storeBlob(t, blobs, ref, data, -1)
+
+	if err := blobs.Trash(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	assertBlobNotExist(t, blobs, ref)
+
+	if err := blobs.RestoreTrash(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	if loaded := loadBlob(t, blobs, ref); !bytes.Equal(data, loaded) {
+		t.Fatal("data mismatch after restoring from the trash")
+	}
+
+	if err := blobs.Trash(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	if err := blobs.DeleteTrash(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	if err := blobs.RestoreTrash(ctx, ref); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error when restoring a deleted blob, got %v", err)
+	}
+	assertBlobNotExist(t, blobs, ref)
+
+	// missing blobs can't be trashed, but deleting them from the trash isn't an error
+	if err := blobs.Trash(ctx, ref); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error when trashing a missing blob, got %v", err)
+	}
+	if err := blobs.DeleteTrash(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testBlobFreeSpace(t *testing.T, blobs storage.Blobs) {
+	space, err := blobs.FreeSpace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if space <= 0 {
+		t.Fatal("expected to have some free space")
+	}
+}
@@ -33,7 +33,9 @@ type DB interface {
 	Close() error
 
 	// TODO: use better interfaces
+	// Storage returns the legacy piece storage, which is migrated to Pieces
 	Storage() *pstore.Storage
+	Pieces() storage.Blobs
 	PSDB() *psdb.DB
 	RoutingTable() (kdb, ndb storage.KeyValueStore)
 }
@@ -77,9 +79,11 @@ type Peer struct {
 	}
 
 	Storage struct {
+		Migration *pstore.Migration
 		Endpoint  *psserver.Server // TODO: separate into endpoint and service
 		Monitor   *psserver.Monitor
 		Collector *psserver.Collector
+		Exiter    *psserver.Exiter
 	}
 
 	Agreements struct {
@@ -164,8 +168,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 		// TODO: move this setup logic into psstore package
 		config := config.Storage
 
-		// TODO: psserver shouldn't need the private key
-		peer.Storage.Endpoint, err = psserver.NewEndpoint(peer.Log.Named("piecestore"), config, peer.DB.Storage(), peer.DB.PSDB(), peer.Identity.Key, peer.Kademlia.Service)
+		// the pieces of the legacy storage are moved while the node is running
+		peer.Storage.Migration = pstore.NewMigration(peer.Log.Named("piecestore:migration"),
+			peer.DB.Storage(), peer.DB.Pieces(), psserver.PieceNamespaces(peer.DB.PSDB()), config.MigrationInterval)
+
+		peer.Storage.Endpoint, err = psserver.NewEndpoint(peer.Log.Named("piecestore"), config, peer.Storage.Migration, peer.DB.PSDB(), peer.Identity, peer.Kademlia.Service)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -173,7 +180,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 
 		// TODO: organize better
 		peer.Storage.Monitor = psserver.NewMonitor(peer.Log.Named("piecestore:monitor"), config.KBucketRefreshInterval, peer.Kademlia.RoutingTable, peer.Storage.Endpoint)
-		peer.Storage.Collector = psserver.NewCollector(peer.Log.Named("piecestore:collector"), peer.DB.PSDB(), peer.DB.Storage(), config.CollectorInterval)
+		peer.Storage.Collector = psserver.NewCollector(peer.Log.Named("piecestore:collector"), peer.DB.PSDB(), peer.Storage.Migration, config.CollectorInterval, config.TrashExpiration)
+		peer.Storage.Exiter = psserver.NewExiter(peer.Log.Named("piecestore:exiter"), peer.DB.PSDB(), peer.Storage.Migration, peer.Identity, peer.Kademlia.Service, config.ExitInterval)
 	}
 
 	{ // agreements
@@ -181,7 +189,12 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 		peer.Agreements.Sender = agreementsender.New(
 			peer.Log.Named("agreements"),
 			peer.DB.PSDB(), peer.Identity, peer.Kademlia.Service,
-			config.AgreementSenderCheckInterval,
+			agreementsender.Config{
+				CheckInterval: config.AgreementSenderCheckInterval,
+				BatchSize:     config.AgreementSenderBatchSize,
+				RetryDelay:    config.AgreementSenderRetryDelay,
+				MaxRetryDelay: config.AgreementSenderMaxRetryDelay,
+			},
 		)
 	}
 
@@ -209,6 +222,12 @@ func (peer *Peer) Run(ctx context.Context) error {
 	group.Go(func() error {
 		return ignoreCancel(peer.Storage.Collector.Run(ctx))
 	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Storage.Migration.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Storage.Exiter.Run(ctx))
+	})
 	group.Go(func() error {
 		// TODO: move the message into Server instead
 		peer.Log.Sugar().Infof("Node %s started on %s", peer.Identity.ID, peer.Public.Server.Addr().String())
@@ -4,6 +4,8 @@
 package storagenodedb
 
 import (
+	"path/filepath"
+
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/kademlia"
@@ -11,6 +13,7 @@ import (
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
+	"storj.io/storj/storage/filestore"
 	"storj.io/storj/storage/teststore"
 	"storj.io/storj/storagenode"
 )
@@ -21,6 +24,7 @@ var _ storagenode.DB = (*DB)(nil)
 type Config struct {
 	// TODO: figure out better names
 	Storage  string
+	Pieces   string
 	Info     string
 	Kademlia string
 }
@@ -28,6 +32,7 @@ type Config struct {
 // DB contains access to different database tables
 type DB struct {
 	storage  *pstore.Storage
+	pieces   *filestore.Store
 	psdb     *psdb.DB
 	kdb, ndb storage.KeyValueStore
 }
@@ -36,6 +41,11 @@ type DB struct {
 func New(config Config) (*DB, error) {
 	storage := pstore.NewStorage(config.Storage)
 
+	pieces, err := filestore.NewAt(config.Pieces)
+	if err != nil {
+		return nil, err
+	}
+
 	psdb, err := psdb.Open(config.Info)
 	if err != nil {
 		return nil, err
@@ -48,6 +58,7 @@ func New(config Config) (*DB, error) {
 
 	return &DB{
 		storage: storage,
+		pieces:  pieces,
 		psdb:    psdb,
 		kdb:     dbs[0],
 		ndb:     dbs[1],
@@ -59,6 +70,11 @@ func New(config Config) (*DB, error) {
 func NewInMemory(storageDir string) (*DB, error) {
 	storage := pstore.NewStorage(storageDir)
 
+	pieces, err := filestore.NewAt(filepath.Join(storageDir, "blobs"))
+	if err != nil {
+		return nil, err
+	}
+
 	psdb, err := psdb.OpenInMemory()
 	if err != nil {
 		return nil, err
@@ -66,6 +82,7 @@ func NewInMemory(storageDir string) (*DB, error) {
 
 	return &DB{
 		storage: storage,
+		pieces:  pieces,
 		psdb:    psdb,
 		kdb:     teststore.New(),
 		ndb:     teststore.New(),
@@ -84,14 +101,20 @@ func (db *DB) Close() error {
 		db.kdb.Close(),
 		db.ndb.Close(),
 		db.storage.Close(),
+		db.pieces.Close(),
 	)
 }
 
-// Storage returns piecestore location
+// Storage returns the legacy piece storage
 func (db *DB) Storage() *pstore.Storage {
 	return db.storage
 }
 
+// Pieces returns the piece storage
+func (db *DB) Pieces() storage.Blobs {
+	return db.pieces
+}
+
 // PSDB returns piecestore database
 func (db *DB) PSDB() *psdb.DB {
 	return db.psdb